@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+)
+
+// newCaches creates the shared in-memory cache and the API key cache used
+// for authentication. metrics enables hit/miss counting on both.
+func newCaches(metrics bool) (*ristretto.Cache[string, any], *ristretto.Cache[string, *auth.CachedAPIKey], error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{
+		NumCounters: 1e7,
+		MaxCost:     1 << 30,
+		BufferItems: 64,
+		Metrics:     metrics,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiKeyCache, err := ristretto.NewCache(&ristretto.Config[string, *auth.CachedAPIKey]{
+		NumCounters: 1e5,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+		Metrics:     metrics,
+	})
+	if err != nil {
+		cache.Close()
+		return nil, nil, fmt.Errorf("API key cache: %w", err)
+	}
+	return cache, apiKeyCache, nil
+}
+
+// newTokenizer creates the token counter with the configured encodings and
+// message overheads. A failed self-check only warns, since /readyz reports it.
+func newTokenizer(cfg *config.Config) (*tokenizer.TiktokenTokenizer, error) {
+	tok, err := tokenizer.NewWithOverrides(cfg.TokenizerEncodings)
+	if err != nil {
+		return nil, err
+	}
+	if err := tok.SetMessageOverheads(cfg.TokenizerOverheads); err != nil {
+		return nil, err
+	}
+	if err := tokenizer.Probe(tok); err != nil {
+		slog.Warn("tokenizer self-check failed; /readyz reports not ready until it passes", "error", err)
+	}
+	return tok, nil
+}
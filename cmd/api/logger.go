@@ -30,3 +30,9 @@ func printStartupBanner(cfg *config.Config) {
 	fmt.Fprintln(os.Stderr, "════════════════════════════════════════════════")
 	fmt.Fprintf(os.Stderr, "\n")
 }
+
+func printVersion() {
+	fmt.Printf("goatway %s\n", version.Version)
+	fmt.Printf("  commit:  %s\n", version.Commit)
+	fmt.Printf("  built:   %s\n", version.BuildTime)
+}
@@ -1,22 +1,22 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/dgraph-io/ristretto/v2"
 	"github.com/mandalnilabja/goatway/internal/app"
 	"github.com/mandalnilabja/goatway/internal/config"
 	"github.com/mandalnilabja/goatway/internal/provider"
 	"github.com/mandalnilabja/goatway/internal/storage"
-	"github.com/mandalnilabja/goatway/internal/tokenizer"
 	"github.com/mandalnilabja/goatway/internal/transport/http/handler"
 	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
 	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/ratelimit"
-	"github.com/mandalnilabja/goatway/internal/version"
 )
 
 func main() {
@@ -41,6 +41,11 @@ func main() {
 
 	// 1. Load Configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	storage.SetKeyScheme(cfg.APIKeyPrefix, cfg.APIKeyLength)
+	storage.SetKeyExpiryGrace(cfg.APIKeyExpiryGrace)
 
 	// 2. Initialize Data Directory
 	if err := config.EnsureDataDir(); err != nil {
@@ -53,81 +58,90 @@ func main() {
 	}
 
 	// 4. Initialize Storage
-	store, err := storage.NewSQLiteStorage(config.DBPath())
+	store, err := storage.Open(cfg.StorageBackend, config.DBPath(), storage.Options{CompressBodies: cfg.LogBodyCompression})
 	if err != nil {
 		log.Fatal("Failed to initialize storage:", err)
 	}
-	defer store.Close()
+	store = storage.NewUsageBatcher(store, cfg.UsageFlushInterval)
 
 	// 4. First-run admin password setup
-	if err := ensureAdminPassword(store); err != nil {
+	if err := ensureAdminPassword(store, cfg.AdminPassword); err != nil {
 		log.Fatal("Failed to setup admin password:", err)
 	}
+	warnEncryptionMismatch(store)
 
-	// 5. Initialize Cache
-	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{
-		NumCounters: 1e7,
-		MaxCost:     1 << 30,
-		BufferItems: 64,
-	})
+	// 5. Initialize Caches (general and API keys for authentication)
+	cache, apiKeyCache, err := newCaches(cfg.CacheMetrics)
 	if err != nil {
 		log.Fatal("Failed to initialize cache:", err)
 	}
 
-	// 6. Initialize API Key Cache for authentication
-	apiKeyCache, err := ristretto.NewCache(&ristretto.Config[string, *auth.CachedAPIKey]{
-		NumCounters: 1e5,
-		MaxCost:     1 << 20,
-		BufferItems: 64,
-	})
-	if err != nil {
-		log.Fatal("Failed to initialize API key cache:", err)
-	}
-
 	// 7. Initialize Session Store for Web UI
 	sessionStore := auth.NewSessionStore(24 * time.Hour) // 24 hour session TTL
 
 	// 8. Initialize Rate Limiter for API key rate limiting
-	rateLimiter := ratelimit.New()
+	rateLimiter, err := ratelimit.Open(cfg.RateLimitBackend, cfg.RedisURL)
+	if err != nil {
+		log.Fatal("Failed to initialize rate limiter:", err)
+	}
 
 	// 8. Initialize Provider Router (routes models to appropriate providers)
-	providers := provider.NewProviders()
+	providers, err := provider.NewProviders(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize providers:", err)
+	}
 	llmProvider := provider.NewRouter(providers, cfg, store)
 
 	// 9. Initialize Tokenizer for token counting
-	tok := tokenizer.New()
+	tok, err := newTokenizer(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize tokenizer:", err)
+	}
 
 	// 10. Initialize Handler Repository with dependencies
-	repo := handler.NewRepo(cache, llmProvider, store, tok, apiKeyCache)
+	repo := handler.NewRepo(cfg, cache, llmProvider, store, tok, apiKeyCache)
 	repo.SetSessionStore(sessionStore)
 	repo.SetCredentialResolver(llmProvider.CredentialResolver())
+	repo.SetHealthTracker(llmProvider.Health())
+	repo.SetRouter(llmProvider)
+	repo.SetUserLimiter(rateLimiter)
 
 	// 11. Setup Logger for request logging
 	logger := setupLogger()
 
 	// 13. Setup Router with all routes
 	routerOpts := &app.RouterOptions{
-		EnableWebUI:  cfg.EnableWebUI,
-		Logger:       logger,
-		Storage:      store,
-		APIKeyCache:  apiKeyCache,
-		SessionStore: sessionStore,
-		RateLimiter:  rateLimiter,
+		EnableWebUI:      cfg.EnableWebUI,
+		Logger:           logger,
+		Storage:          store,
+		APIKeyCache:      apiKeyCache,
+		SessionStore:     sessionStore,
+		RateLimiter:      rateLimiter,
+		GlobalRPS:        cfg.GlobalRateLimit,
+		OpenProxy:        !cfg.RequireClientAuth,
+		AdminCORSOrigins: cfg.AdminCORSOrigins,
+		RequestIDHeader:  cfg.RequestIDHeader,
+		RequestIDFormat:  cfg.RequestIDFormat,
+		StreamErrors:     cfg.StreamErrorFormat,
+		DisabledRoutes:   cfg.DisabledEndpoints,
+		WebHeaders:       app.WebSecurityHeaders(cfg),
 	}
 	router := app.NewRouter(repo, routerOpts)
 
 	// 13. Print startup info
 	printStartupBanner(cfg)
 
-	// 14. Create and Start Server
+	// 14. Create and Start Server; SIGINT or SIGTERM shuts it down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	server := app.NewServer(cfg, router)
-	if err := server.Start(); err != nil {
+	if err := server.Start(ctx); err != nil {
 		log.Fatal("Server failed to start:", err)
 	}
-}
 
-func printVersion() {
-	fmt.Printf("goatway %s\n", version.Version)
-	fmt.Printf("  commit:  %s\n", version.Commit)
-	fmt.Printf("  built:   %s\n", version.BuildTime)
+	// 15. Finish background logging, then flush buffered usage to storage
+	repo.Close()
+	if err := store.Close(); err != nil {
+		slog.Error("failed to close storage", "error", err)
+	}
 }
@@ -3,22 +3,91 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 
 	"github.com/mandalnilabja/goatway/internal/storage"
 )
 
-func ensureAdminPassword(store storage.Storage) error {
+// ensureAdminPassword makes sure an admin password is stored before serving.
+// On first run it is taken from ADMIN_PASSWORD (envPassword), else prompted for
+// on an interactive terminal. Without either the server starts with the Web UI
+// and password-based admin access locked, and a warning is logged.
+func ensureAdminPassword(store storage.Storage, envPassword string) error {
 	hasPassword, err := store.HasAdminPassword()
 	if err != nil {
 		return fmt.Errorf("failed to check admin password: %w", err)
 	}
 
 	if hasPassword {
+		if envPassword != "" {
+			slog.Info("ADMIN_PASSWORD ignored: an admin password is already set (change it via PUT /api/admin/password)")
+		}
+		return nil
+	}
+
+	if envPassword != "" {
+		if err := bootstrapAdminPassword(store, envPassword); err != nil {
+			return err
+		}
+		slog.Info("admin password set from ADMIN_PASSWORD")
+		return nil
+	}
+
+	if !isTerminal(os.Stdin) {
+		slog.Warn("no admin password configured and stdin is not a terminal; set ADMIN_PASSWORD and restart to unlock the Web UI and admin API")
 		return nil
 	}
+	return promptAdminPassword(store)
+}
 
+// bootstrapAdminPassword validates password, then hashes and stores it.
+func bootstrapAdminPassword(store storage.Storage, password string) error {
+	if !isValidAdminPassword(password) {
+		return fmt.Errorf("ADMIN_PASSWORD must be alphanumeric with at least 8 characters")
+	}
+	return saveAdminPassword(store, password)
+}
+
+// saveAdminPassword hashes password and stores it as the admin password.
+func saveAdminPassword(store storage.Storage, password string) error {
+	hash, err := storage.HashPassword(password, storage.DefaultArgon2Params())
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := store.SetAdminPasswordHash(hash); err != nil {
+		return fmt.Errorf("failed to save password: %w", err)
+	}
+	return nil
+}
+
+// warnEncryptionMismatch logs a warning when the encryption key cannot read
+// the stored credentials, e.g. after the database moved to another machine.
+func warnEncryptionMismatch(store storage.Storage) {
+	health, err := store.CheckEncryption()
+	if err != nil {
+		slog.Warn("encryption health check failed", "error", err)
+		return
+	}
+	if !health.Healthy() {
+		slog.Warn("encryption key cannot decrypt stored credentials; restore the original key or re-enter them",
+			"key_source", health.KeySource,
+			"undecryptable", health.Undecryptable,
+			"credentials", health.Credentials,
+		)
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptAdminPassword asks for the admin password on stdin until a valid,
+// confirmed one is entered, then stores it.
+func promptAdminPassword(store storage.Storage) error {
 	fmt.Println()
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
 	fmt.Println("║              FIRST-TIME SETUP REQUIRED                     ║")
@@ -57,13 +126,8 @@ func ensureAdminPassword(store storage.Storage) error {
 			continue
 		}
 
-		hash, err := storage.HashPassword(password, storage.DefaultArgon2Params())
-		if err != nil {
-			return fmt.Errorf("failed to hash password: %w", err)
-		}
-
-		if err := store.SetAdminPasswordHash(hash); err != nil {
-			return fmt.Errorf("failed to save password: %w", err)
+		if err := saveAdminPassword(store, password); err != nil {
+			return err
 		}
 
 		fmt.Println()
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestEnsureAdminPassword_FromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		env      string
+		wantErr  bool
+		wantPass string
+	}{
+		{name: "env password stored", env: "bootstrap123", wantPass: "bootstrap123"},
+		{name: "existing password kept", existing: "original123", env: "bootstrap123", wantPass: "original123"},
+		{name: "invalid env password", env: "short", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			defer store.Close()
+
+			if tt.existing != "" {
+				if err := saveAdminPassword(store, tt.existing); err != nil {
+					t.Fatalf("saveAdminPassword: %v", err)
+				}
+			}
+
+			err := ensureAdminPassword(store, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ensureAdminPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if has, _ := store.HasAdminPassword(); has {
+					t.Error("invalid password should not be stored")
+				}
+				return
+			}
+
+			hash, err := store.GetAdminPasswordHash()
+			if err != nil {
+				t.Fatalf("GetAdminPasswordHash: %v", err)
+			}
+			ok, err := storage.VerifyPassword(tt.wantPass, hash)
+			if err != nil || !ok {
+				t.Errorf("stored password does not verify as %q (err %v)", tt.wantPass, err)
+			}
+		})
+	}
+}
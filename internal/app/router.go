@@ -14,12 +14,20 @@ import (
 
 // RouterOptions configures the HTTP router behavior.
 type RouterOptions struct {
-	EnableWebUI  bool
-	Logger       *slog.Logger
-	Storage      storage.Storage
-	APIKeyCache  *ristretto.Cache[string, *auth.CachedAPIKey]
-	SessionStore *auth.SessionStore
-	RateLimiter  *ratelimit.Limiter
+	EnableWebUI      bool
+	WebHeaders       *middleware.SecurityHeaders // Security headers on /web responses (nil sets none)
+	Logger           *slog.Logger
+	Storage          storage.Storage
+	APIKeyCache      *ristretto.Cache[string, *auth.CachedAPIKey]
+	SessionStore     *auth.SessionStore
+	RateLimiter      ratelimit.RateLimiter
+	GlobalRPS        int      // Server-wide proxy requests per second, any key (0 = unlimited)
+	OpenProxy        bool     // Allow /v1 requests without an API key (RequireClientAuth disabled)
+	AdminCORSOrigins []string // Origins allowed cross-origin on admin routes
+	RequestIDHeader  string   // Header carrying the request ID (default X-Request-ID)
+	RequestIDFormat  string   // Generated request ID format: "hex" or "uuid"
+	StreamErrors     string   // Error format for stream:true requests: "sse" (default) or "json"
+	DisabledRoutes   []string // Route groups answered with 404 (see RouteGroupChat etc.)
 }
 
 // NewRouter creates and configures the HTTP router with all application routes.
@@ -30,33 +38,65 @@ func NewRouter(repo *handler.Repo, opts *RouterOptions) http.Handler {
 
 	// Public routes (no auth)
 	mux.HandleFunc("GET /api/health", repo.Infra.HealthCheck)
+	mux.HandleFunc("GET /readyz", repo.Infra.Readiness)
 	mux.HandleFunc("GET /api/data", repo.Infra.GetCachedData)
 
-	// Create middleware chain for proxy routes: auth → rate limit
+	// Create middleware chain for proxy routes: CORS → auth → rate limit
 	apiKeyAuth := auth.APIKeyAuth(opts.Storage, opts.APIKeyCache)
 	rateLimitMw := ratelimit.Middleware(opts.RateLimiter)
 
-	// withProxy chains auth and rate limiting for proxy handlers
+	// Open mode still validates keys that are sent, so per-key limits keep working
+	proxyAuth := apiKeyAuth
+	if opts.OpenProxy {
+		proxyAuth = auth.OptionalAPIKeyAuth(opts.Storage, opts.APIKeyCache)
+	}
+
+	// withProxy chains permissive CORS, SSE errors for streaming requests, the
+	// global cap, auth and rate limiting for proxy handlers; the global cap runs
+	// before auth so overload sheds early
+	streamErrors := middleware.StreamErrors(opts.StreamErrors)
+	globalLimit := ratelimit.Global(opts.GlobalRPS)
+	cors := middleware.CORSWith(opts.RequestIDHeader)
 	withProxy := func(h http.HandlerFunc) http.Handler {
-		return apiKeyAuth(rateLimitMw(h))
+		return cors(streamErrors(globalLimit(proxyAuth(rateLimitMw(h)))))
+	}
+
+	// proxyRoute registers a proxy handler, or a 404 when its group is disabled
+	warnUnknownRouteGroups(opts.DisabledRoutes)
+	proxyRoute := func(group, pattern string, h http.HandlerFunc) {
+		if !opts.routeGroupEnabled(group) {
+			mux.Handle(pattern, disabledRoute)
+			return
+		}
+		mux.Handle(pattern, withProxy(h))
 	}
 
+	// Browser preflight for the public API (answered before auth)
+	mux.Handle("OPTIONS /v1/", cors(middleware.Preflight))
+
 	// Proxy routes (require API key auth + rate limiting)
-	mux.Handle("POST /v1/chat/completions", withProxy(repo.Proxy.ChatCompletions))
-	mux.Handle("GET /v1/models", withProxy(repo.Proxy.ListModels))
-	mux.Handle("GET /v1/models/{model}", withProxy(repo.Proxy.GetModel))
-	mux.Handle("POST /v1/embeddings", withProxy(repo.Proxy.Embeddings))
-	mux.Handle("POST /v1/audio/speech", withProxy(repo.Proxy.TextToSpeech))
-	mux.Handle("POST /v1/audio/transcriptions", withProxy(repo.Proxy.Transcription))
-	mux.Handle("POST /v1/audio/translations", withProxy(repo.Proxy.Translation))
-	mux.Handle("POST /v1/images/generations", withProxy(repo.Proxy.ImageGeneration))
-	mux.Handle("POST /v1/images/edits", withProxy(repo.Proxy.ImageEdit))
-	mux.Handle("POST /v1/images/variations", withProxy(repo.Proxy.ImageVariation))
-	mux.Handle("POST /v1/completions", withProxy(repo.Proxy.LegacyCompletion))
-	mux.Handle("POST /v1/moderations", withProxy(repo.Proxy.Moderation))
-
-	// Admin API routes (require admin auth)
-	registerAdminRoutes(mux, repo, opts)
+	proxyRoute(RouteGroupChat, "POST /v1/chat/completions", repo.Proxy.ChatCompletions)
+	proxyRoute(RouteGroupModels, "GET /v1/models", repo.Proxy.ListModels)
+	proxyRoute(RouteGroupModels, "GET /v1/models/{model}", repo.Proxy.GetModel)
+	proxyRoute(RouteGroupEmbeddings, "POST /v1/embeddings", repo.Proxy.Embeddings)
+	proxyRoute(RouteGroupAudio, "POST /v1/audio/speech", repo.Proxy.TextToSpeech)
+	proxyRoute(RouteGroupAudio, "POST /v1/audio/transcriptions", repo.Proxy.Transcription)
+	proxyRoute(RouteGroupAudio, "POST /v1/audio/translations", repo.Proxy.Translation)
+	proxyRoute(RouteGroupImages, "POST /v1/images/generations", repo.Proxy.ImageGeneration)
+	proxyRoute(RouteGroupImages, "POST /v1/images/edits", repo.Proxy.ImageEdit)
+	proxyRoute(RouteGroupImages, "POST /v1/images/variations", repo.Proxy.ImageVariation)
+	proxyRoute(RouteGroupCompletions, "POST /v1/completions", repo.Proxy.LegacyCompletion)
+	proxyRoute(RouteGroupModerations, "POST /v1/moderations", repo.Proxy.Moderation)
+
+	// Key validation is authenticated but not rate limited, so checks don't consume quota
+	mux.Handle("GET /v1/key/validate", cors(apiKeyAuth(http.HandlerFunc(repo.Proxy.ValidateKey))))
+
+	// Admin API routes (require admin auth); the whole prefix 404s when disabled
+	if opts.routeGroupEnabled(RouteGroupAdmin) {
+		registerAdminRoutes(mux, repo, opts)
+	} else {
+		disablePrefix(mux, "/api/admin/")
+	}
 
 	// Root returns JSON status (per PRD requirement)
 	mux.HandleFunc("GET /", repo.Infra.RootStatus)
@@ -75,72 +115,10 @@ func NewRouter(repo *handler.Repo, opts *RouterOptions) http.Handler {
 	}
 
 	// Request ID (always applied)
-	h = middleware.RequestID(h)
-
-	// CORS (always applied for Web UI compatibility)
-	h = middleware.CORS(h)
+	h = middleware.RequestIDWith(middleware.RequestIDOptions{
+		Header: opts.RequestIDHeader,
+		Format: opts.RequestIDFormat,
+	})(h)
 
 	return h
 }
-
-// registerAdminRoutes adds all admin API routes to the router.
-func registerAdminRoutes(mux *http.ServeMux, repo *handler.Repo, opts *RouterOptions) {
-	// Create admin auth middleware using session store (session-only, no Bearer fallback)
-	adminAuth := auth.AdminAuth(opts.SessionStore)
-
-	// Helper to wrap handler with admin auth
-	withAuth := func(h http.HandlerFunc) http.Handler {
-		return adminAuth(h)
-	}
-
-	// Credential management
-	mux.Handle("POST /api/admin/credentials", withAuth(repo.Admin.CreateCredential))
-	mux.Handle("GET /api/admin/credentials", withAuth(repo.Admin.ListCredentials))
-	mux.Handle("GET /api/admin/credentials/{id}", withAuth(repo.Admin.GetCredential))
-	mux.Handle("PUT /api/admin/credentials/{id}", withAuth(repo.Admin.UpdateCredential))
-	mux.Handle("DELETE /api/admin/credentials/{id}", withAuth(repo.Admin.DeleteCredential))
-
-	// API key management
-	mux.Handle("POST /api/admin/apikeys", withAuth(repo.Admin.CreateAPIKey))
-	mux.Handle("GET /api/admin/apikeys", withAuth(repo.Admin.ListAPIKeys))
-	mux.Handle("GET /api/admin/apikeys/{id}", withAuth(repo.Admin.GetAPIKeyByID))
-	mux.Handle("PUT /api/admin/apikeys/{id}", withAuth(repo.Admin.UpdateAPIKey))
-	mux.Handle("DELETE /api/admin/apikeys/{id}", withAuth(repo.Admin.DeleteAPIKey))
-	mux.Handle("POST /api/admin/apikeys/{id}/rotate", withAuth(repo.Admin.RotateAPIKey))
-
-	// Password management
-	mux.Handle("PUT /api/admin/password", withAuth(repo.Admin.ChangeAdminPassword))
-
-	// Usage and logs
-	mux.Handle("GET /api/admin/usage", withAuth(repo.Admin.GetUsageStats))
-	mux.Handle("GET /api/admin/usage/daily", withAuth(repo.Admin.GetDailyUsage))
-	mux.Handle("GET /api/admin/logs", withAuth(repo.Admin.GetRequestLogs))
-	mux.Handle("DELETE /api/admin/logs", withAuth(repo.Admin.DeleteRequestLogs))
-
-	// System info
-	mux.Handle("GET /api/admin/health", withAuth(repo.Admin.AdminHealth))
-	mux.Handle("GET /api/admin/info", withAuth(repo.Admin.AdminInfo))
-}
-
-// registerWebUIRoutes adds web UI routes with session auth support.
-func registerWebUIRoutes(mux *http.ServeMux, repo *handler.Repo, opts *RouterOptions) {
-	webUI := repo.WebUI.ServeWebUI()
-	sessionAuth := auth.SessionAuth(opts.SessionStore)
-
-	// Login routes (no auth required)
-	mux.HandleFunc("GET /web/login", repo.WebUI.LoginPage)
-	mux.HandleFunc("POST /web/login", repo.WebUI.Login)
-	mux.HandleFunc("POST /web/logout", repo.WebUI.Logout)
-
-	// Static files (no auth)
-	mux.Handle("GET /web/static/", webUI)
-
-	// Protected Web UI routes
-	mux.Handle("GET /web", sessionAuth(webUI))
-	mux.Handle("GET /web/", sessionAuth(webUI))
-	mux.Handle("GET /web/credentials", sessionAuth(webUI))
-	mux.Handle("GET /web/usage", sessionAuth(webUI))
-	mux.Handle("GET /web/logs", sessionAuth(webUI))
-	mux.Handle("GET /web/apikeys", sessionAuth(webUI))
-	mux.Handle("GET /web/settings", sessionAuth(webUI))
-}
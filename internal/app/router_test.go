@@ -0,0 +1,269 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/admin"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/proxy"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/webui"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/ratelimit"
+)
+
+func TestNewRouter_CORSPerRouteGroup(t *testing.T) {
+	router := NewRouter(&handler.Repo{}, &RouterOptions{
+		AdminCORSOrigins: []string{"https://admin.example.com"},
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		wantOrigin string
+		wantStatus int
+	}{
+		{"proxy preflight allows any origin", http.MethodOptions, "/v1/chat/completions", "https://app.example.com", "*", http.StatusNoContent},
+		{"proxy request allows any origin", http.MethodGet, "/v1/models", "https://app.example.com", "*", http.StatusUnauthorized},
+		{"admin preflight rejects unknown origin", http.MethodOptions, "/api/admin/credentials", "https://evil.example.com", "", http.StatusNoContent},
+		{"admin request rejects unknown origin", http.MethodGet, "/api/admin/credentials", "https://evil.example.com", "", http.StatusUnauthorized},
+		{"admin preflight allows configured origin", http.MethodOptions, "/api/admin/credentials", "https://admin.example.com", "https://admin.example.com", http.StatusNoContent},
+		{"admin request allows configured origin", http.MethodGet, "/api/admin/info", "https://admin.example.com", "https://admin.example.com", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+}
+
+func TestNewRouter_RequireClientAuth(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	repo := &handler.Repo{Proxy: proxy.New(nil, nil, store, nil, nil)}
+
+	tests := []struct {
+		name       string
+		open       bool
+		auth       string
+		path       string
+		wantStatus int
+	}{
+		// A malformed body returns 400 from the handler, proving auth let the request through
+		{"enforced rejects missing key", false, "", "/v1/chat/completions", http.StatusUnauthorized},
+		{"open allows missing key", true, "", "/v1/chat/completions", http.StatusBadRequest},
+		{"open still rejects invalid key", true, "Bearer gw_invalid", "/v1/chat/completions", http.StatusUnauthorized},
+		{"open keeps key validation authenticated", true, "", "/v1/key/validate", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(repo, &RouterOptions{Storage: store, RateLimiter: ratelimit.New(), OpenProxy: tt.open})
+
+			method := http.MethodPost
+			if strings.HasSuffix(tt.path, "/validate") {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader("{"))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewRouter_DisabledRoutes(t *testing.T) {
+	router := NewRouter(&handler.Repo{}, &RouterOptions{
+		DisabledRoutes: []string{RouteGroupImages, RouteGroupAdmin},
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"disabled proxy group", http.MethodPost, "/v1/images/generations", http.StatusNotFound},
+		{"disabled admin route", http.MethodGet, "/api/admin/credentials", http.StatusNotFound},
+		{"disabled admin prefix", http.MethodDelete, "/api/admin/apikeys/k1", http.StatusNotFound},
+		// Enabled routes reach auth, which rejects the missing key
+		{"enabled chat", http.MethodPost, "/v1/chat/completions", http.StatusUnauthorized},
+		{"enabled models", http.MethodGet, "/v1/models", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewRouter_WebSecurityHeaders(t *testing.T) {
+	repo := &handler.Repo{WebUI: webui.New(nil, nil)}
+	enabled := &config.Config{WebConfig: config.WebConfig{WebSecurityHeaders: true, WebCSP: config.DefaultWebCSP, WebFrameOptions: "DENY"}}
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		path    string
+		wantCSP string
+	}{
+		{"login page", enabled, "/web/login", config.DefaultWebCSP},
+		{"custom frontend override", &config.Config{WebConfig: config.WebConfig{WebSecurityHeaders: true, WebCSP: "default-src 'none'", WebFrameOptions: "SAMEORIGIN"}}, "/web/login", "default-src 'none'"},
+		{"disabled", &config.Config{WebConfig: config.WebConfig{WebSecurityHeaders: false, WebCSP: config.DefaultWebCSP}}, "/web/login", ""},
+		{"not on proxy API", enabled, "/v1/models", ""},
+		{"not on admin API", enabled, "/api/admin/info", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(repo, &RouterOptions{EnableWebUI: true, WebHeaders: WebSecurityHeaders(tt.cfg)})
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			header := rec.Header()
+			if got := header.Get("Content-Security-Policy"); got != tt.wantCSP {
+				t.Errorf("Content-Security-Policy = %q, want %q", got, tt.wantCSP)
+			}
+			want := map[string]string{"X-Content-Type-Options": "", "X-Frame-Options": "", "Referrer-Policy": ""}
+			if tt.wantCSP != "" {
+				want = map[string]string{"X-Content-Type-Options": "nosniff", "X-Frame-Options": tt.cfg.WebFrameOptions, "Referrer-Policy": "same-origin"}
+			}
+			for key, value := range want {
+				if got := header.Get(key); got != value {
+					t.Errorf("%s = %q, want %q", key, got, value)
+				}
+			}
+		})
+	}
+}
+
+// patternRecorder records the patterns registered on it.
+type patternRecorder struct {
+	patterns []string
+}
+
+func (p *patternRecorder) Handle(pattern string, _ http.Handler) {
+	p.patterns = append(p.patterns, pattern)
+}
+
+func TestAdminRoutesDocumentedInOpenAPI(t *testing.T) {
+	rec := &patternRecorder{}
+	registerAdminRoutes(rec, &handler.Repo{}, &RouterOptions{})
+
+	paths, _ := admin.OpenAPISpec()["paths"].(map[string]map[string]any)
+	if len(paths) == 0 {
+		t.Fatal("OpenAPI document has no paths")
+	}
+
+	for _, pattern := range rec.patterns {
+		method, path, _ := strings.Cut(pattern, " ")
+		if method == http.MethodOptions {
+			continue // CORS preflight, not an API operation
+		}
+		if _, ok := paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("route %q is not described in the OpenAPI document", pattern)
+		}
+	}
+}
+
+func TestNewRouter_GlobalRateLimit(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	repo := &handler.Repo{Proxy: proxy.New(nil, nil, store, nil, nil)}
+	router := NewRouter(repo, &RouterOptions{Storage: store, RateLimiter: ratelimit.New(), OpenProxy: true, GlobalRPS: 2})
+
+	// The cap is shared by every caller and runs before auth
+	steps := []struct {
+		name       string
+		auth       string
+		path       string
+		wantStatus int
+	}{
+		{"first request", "", "/v1/chat/completions", http.StatusBadRequest},
+		{"second request, other key", "Bearer gw_invalid", "/v1/chat/completions", http.StatusUnauthorized},
+		{"over the cap without key", "", "/v1/chat/completions", http.StatusServiceUnavailable},
+		{"over the cap with key", "Bearer gw_invalid", "/v1/chat/completions", http.StatusServiceUnavailable},
+		{"admin routes unaffected", "", "/api/admin/info", http.StatusUnauthorized},
+	}
+	for _, step := range steps {
+		method := http.MethodPost
+		if strings.HasPrefix(step.path, "/api/admin/") {
+			method = http.MethodGet
+		}
+		req := httptest.NewRequest(method, step.path, strings.NewReader("{"))
+		if step.auth != "" {
+			req.Header.Set("Authorization", step.auth)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != step.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", step.name, rec.Code, step.wantStatus, rec.Body.String())
+		}
+		if rec.Code == http.StatusServiceUnavailable && rec.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: 503 without Retry-After", step.name)
+		}
+	}
+}
+
+func TestNewRouter_StreamingAuthErrorAsSSE(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	repo := &handler.Repo{Proxy: proxy.New(nil, nil, store, nil, nil)}
+	router := NewRouter(repo, &RouterOptions{Storage: store, RateLimiter: ratelimit.New()})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"m","messages":[],"stream":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, `data: {"error":{`) || !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Errorf("body = %q, want one SSE error frame and [DONE]", body)
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+)
+
+// routeMux is the part of *http.ServeMux used to register routes, so tests
+// can record the registered patterns.
+type routeMux interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// registerAdminRoutes adds all admin API routes to the router.
+// New routes must also be described in the admin OpenAPI document.
+func registerAdminRoutes(mux routeMux, repo *handler.Repo, opts *RouterOptions) {
+	// Admin auth accepts a web session, the admin password, or an admin-scoped API key
+	adminAuth := auth.AdminAuth(opts.SessionStore, opts.Storage, opts.APIKeyCache)
+	adminCORS := middleware.AdminCORS(opts.AdminCORSOrigins, opts.RequestIDHeader)
+
+	// Helper to wrap handler with restrictive CORS and admin auth
+	withAuth := func(h http.HandlerFunc) http.Handler {
+		return adminCORS(adminAuth(h))
+	}
+
+	// Browser preflight for the admin API (answered before auth)
+	mux.Handle("OPTIONS /api/admin/", adminCORS(middleware.Preflight))
+
+	// Credential management
+	mux.Handle("POST /api/admin/credentials", withAuth(repo.Admin.CreateCredential))
+	mux.Handle("GET /api/admin/credentials", withAuth(repo.Admin.ListCredentials))
+	mux.Handle("GET /api/admin/credentials/{id}", withAuth(repo.Admin.GetCredential))
+	mux.Handle("PUT /api/admin/credentials/{id}", withAuth(repo.Admin.UpdateCredential))
+	mux.Handle("DELETE /api/admin/credentials/{id}", withAuth(repo.Admin.DeleteCredential))
+
+	// API key management
+	mux.Handle("POST /api/admin/apikeys", withAuth(repo.Admin.CreateAPIKey))
+	mux.Handle("GET /api/admin/apikeys", withAuth(repo.Admin.ListAPIKeys))
+	mux.Handle("GET /api/admin/apikeys/{id}", withAuth(repo.Admin.GetAPIKeyByID))
+	mux.Handle("PUT /api/admin/apikeys/{id}", withAuth(repo.Admin.UpdateAPIKey))
+	mux.Handle("DELETE /api/admin/apikeys/{id}", withAuth(repo.Admin.DeleteAPIKey))
+	mux.Handle("POST /api/admin/apikeys/{id}/rotate", withAuth(repo.Admin.RotateAPIKey))
+	mux.Handle("GET /api/admin/apikeys/{id}/usage", withAuth(repo.Admin.GetAPIKeyUsage))
+
+	// Password management
+	mux.Handle("PUT /api/admin/password", withAuth(repo.Admin.ChangeAdminPassword))
+
+	// Routing configuration (stored aliases override config ones; imports only suggest)
+	mux.Handle("GET /api/admin/aliases", withAuth(repo.Admin.ListAliases))
+	mux.Handle("POST /api/admin/aliases", withAuth(repo.Admin.CreateAlias))
+	mux.Handle("PUT /api/admin/aliases/{slug}", withAuth(repo.Admin.UpdateAlias))
+	mux.Handle("DELETE /api/admin/aliases/{slug}", withAuth(repo.Admin.DeleteAlias))
+	mux.Handle("POST /api/admin/aliases/import", withAuth(repo.Admin.ImportAliases))
+	mux.Handle("GET /api/admin/config", withAuth(repo.Admin.GetConfig))
+	mux.Handle("GET /api/admin/providers/status", withAuth(repo.Admin.ProvidersStatus))
+	mux.Handle("POST /api/admin/tokenize", withAuth(repo.Admin.Tokenize))
+
+	// Usage and logs
+	mux.Handle("GET /api/admin/usage", withAuth(repo.Admin.GetUsageStats))
+	mux.Handle("GET /api/admin/usage/daily", withAuth(repo.Admin.GetDailyUsage))
+	mux.Handle("GET /api/admin/usage/users", withAuth(repo.Admin.GetUserUsage))
+	mux.Handle("GET /api/admin/logs", withAuth(repo.Admin.GetRequestLogs))
+	mux.Handle("DELETE /api/admin/logs", withAuth(repo.Admin.DeleteRequestLogs))
+	mux.Handle("GET /api/admin/failures", withAuth(repo.Admin.GetFailures))
+	mux.Handle("GET /api/admin/trace/{request_id}", withAuth(repo.Admin.GetRequestTrace))
+
+	// System info
+	mux.Handle("GET /api/admin/health", withAuth(repo.Admin.AdminHealth))
+	mux.Handle("GET /api/admin/info", withAuth(repo.Admin.AdminInfo))
+	mux.Handle("GET /api/admin/encryption/health", withAuth(repo.Admin.EncryptionHealth))
+	mux.Handle("GET /api/admin/cache/metrics", withAuth(repo.Admin.CacheMetrics))
+	mux.Handle("GET /api/admin/openapi.json", withAuth(repo.Admin.GetOpenAPI))
+}
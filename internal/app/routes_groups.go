@@ -0,0 +1,55 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// Route groups that can be switched off with RouterOptions.DisabledRoutes.
+const (
+	RouteGroupChat        = "chat"        // /v1/chat/completions
+	RouteGroupCompletions = "completions" // /v1/completions
+	RouteGroupEmbeddings  = "embeddings"  // /v1/embeddings
+	RouteGroupAudio       = "audio"       // /v1/audio/*
+	RouteGroupImages      = "images"      // /v1/images/*
+	RouteGroupModerations = "moderations" // /v1/moderations
+	RouteGroupModels      = "models"      // /v1/models
+	RouteGroupAdmin       = "admin"       // /api/admin/*
+)
+
+var routeGroups = []string{
+	RouteGroupChat, RouteGroupCompletions, RouteGroupEmbeddings, RouteGroupAudio,
+	RouteGroupImages, RouteGroupModerations, RouteGroupModels, RouteGroupAdmin,
+}
+
+// routeGroupEnabled reports whether a route group is served.
+func (o *RouterOptions) routeGroupEnabled(group string) bool {
+	return !slices.Contains(o.DisabledRoutes, group)
+}
+
+// warnUnknownRouteGroups logs disabled route names that match no group.
+func warnUnknownRouteGroups(disabled []string) {
+	for _, name := range disabled {
+		if !slices.Contains(routeGroups, name) {
+			slog.Warn("unknown route group in disabled endpoints", "group", name, "known", routeGroups)
+		}
+	}
+}
+
+// disablePrefix answers every method under prefix with a 404. Methods are listed
+// one by one because a method-less prefix pattern conflicts with "GET /".
+func disablePrefix(mux routeMux, prefix string) {
+	for _, method := range []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	} {
+		mux.Handle(method+" "+prefix, disabledRoute)
+	}
+}
+
+// disabledRoute answers requests to a disabled route group as if it did not exist.
+var disabledRoute = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	types.WriteError(w, http.StatusNotFound, types.ErrNotFound("endpoint not available on this gateway"))
+})
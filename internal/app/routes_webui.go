@@ -0,0 +1,48 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+)
+
+// registerWebUIRoutes adds web UI routes with session auth support.
+// Every /web response carries opts.WebHeaders; proxy and admin API routes don't.
+func registerWebUIRoutes(mux *http.ServeMux, repo *handler.Repo, opts *RouterOptions) {
+	webUI := repo.WebUI.ServeWebUI()
+	sessionAuth := auth.SessionAuth(opts.SessionStore)
+	secure := middleware.Secure(opts.WebHeaders)
+
+	// Login routes (no auth required)
+	mux.Handle("GET /web/login", secure(http.HandlerFunc(repo.WebUI.LoginPage)))
+	mux.Handle("POST /web/login", secure(http.HandlerFunc(repo.WebUI.Login)))
+	mux.Handle("POST /web/logout", secure(http.HandlerFunc(repo.WebUI.Logout)))
+
+	// Static files (no auth)
+	mux.Handle("GET /web/static/", secure(webUI))
+
+	// Protected Web UI routes
+	mux.Handle("GET /web", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/credentials", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/usage", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/logs", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/apikeys", secure(sessionAuth(webUI)))
+	mux.Handle("GET /web/settings", secure(sessionAuth(webUI)))
+}
+
+// WebSecurityHeaders returns the headers configured for /web responses, or nil
+// when WebSecurityHeaders is off (e.g. a fronting proxy sets its own).
+func WebSecurityHeaders(cfg *config.Config) *middleware.SecurityHeaders {
+	if !cfg.WebSecurityHeaders {
+		return nil
+	}
+	return &middleware.SecurityHeaders{
+		ContentSecurityPolicy: cfg.WebCSP,
+		FrameOptions:          cfg.WebFrameOptions,
+		ReferrerPolicy:        "same-origin",
+	}
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -31,11 +33,27 @@ func NewServer(cfg *config.Config, handler http.Handler) *Server {
 	}
 }
 
-// Start begins listening and serving HTTP requests
-func (s *Server) Start() error {
+// shutdownTimeout bounds how long Start waits for in-flight requests (and
+// streams) to finish once ctx is cancelled.
+const shutdownTimeout = 30 * time.Second
+
+// Start listens and serves HTTP requests until ctx is cancelled, then stops
+// accepting connections and waits for in-flight requests before returning.
+func (s *Server) Start(ctx context.Context) error {
 	log.Printf("Goatway server starting on http://localhost%s", s.config.ServerPort)
 
-	if err := s.httpServer.ListenAndServe(); err != nil {
+	errc := make(chan error, 1)
+	go func() { errc <- s.httpServer.ListenAndServe() }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down; waiting up to %s for in-flight requests", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 	return nil
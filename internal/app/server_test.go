@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestServerStart_ShutsDownOnCancel(t *testing.T) {
+	server := NewServer(&config.Config{ServerPort: "127.0.0.1:0"}, http.NotFoundHandler())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- server.Start(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start = %v, want nil after a graceful shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// MinAPIKeyLength is the fewest random characters API_KEY_LENGTH may set.
+const MinAPIKeyLength = 32
+
+// AuthConfig holds client authentication, API key and rate limit settings.
+type AuthConfig struct {
+	// RequireClientAuth requires a valid client API key on /v1 routes; when
+	// disabled, requests without an Authorization header use stored credentials
+	RequireClientAuth bool
+
+	// APIKeyPrefix and APIKeyLength configure generated client API keys
+	APIKeyPrefix string
+	APIKeyLength int
+
+	// APIKeyExpiryGrace keeps expired API keys working for this long (0 disables)
+	APIKeyExpiryGrace time.Duration
+
+	// APIKeyDefaultTTL is the lifetime of keys created without expires_in (0 = never expire)
+	APIKeyDefaultTTL time.Duration
+
+	// RateLimitBackend selects where API key rate limits are tracked: "memory" or "redis"
+	RateLimitBackend string
+
+	// GlobalRateLimit caps proxy requests per second across the whole server,
+	// whatever key they use; beyond it requests get 503 (0 disables)
+	GlobalRateLimit int
+
+	// RedisURL is the redis://[:password@]host:port[/db] address for the Redis backend
+	RedisURL string
+
+	// AdminPassword bootstraps the admin password on first run (env only;
+	// ignored once a password is stored)
+	AdminPassword string
+}
+
+// loadAuthConfig reads the authentication settings from env and file.
+func loadAuthConfig(f *FileConfig) AuthConfig {
+	return AuthConfig{
+		RequireClientAuth: getEnvBoolOrFile("REQUIRE_CLIENT_AUTH", f.RequireClientAuth, false),
+		APIKeyPrefix:      getEnvOrFile("API_KEY_PREFIX", f.APIKeyPrefix, "gw_"),
+		APIKeyLength:      getEnvIntOrFile("API_KEY_LENGTH", f.APIKeyLength, 64),
+		APIKeyExpiryGrace: time.Duration(getEnvIntOrFile("API_KEY_EXPIRY_GRACE", f.APIKeyExpiryGrace, 0)) * time.Minute,
+		APIKeyDefaultTTL:  time.Duration(getEnvIntOrFile("API_KEY_DEFAULT_TTL", f.APIKeyDefaultTTL, 0)) * 24 * time.Hour,
+		RateLimitBackend:  getEnvOrFile("RATE_LIMIT_BACKEND", f.RateLimitBackend, "memory"),
+		GlobalRateLimit:   getEnvIntOrFile("GLOBAL_RATE_LIMIT", f.GlobalRateLimit, 0),
+		RedisURL:          getEnvOrFile("REDIS_URL", f.RedisURL, ""),
+		AdminPassword:     os.Getenv("ADMIN_PASSWORD"),
+	}
+}
@@ -1,21 +1,99 @@
 package config
 
-import "os"
+import "time"
+
+// Max tokens policies applied when a request exceeds an alias's max_output_tokens.
+const (
+	MaxTokensPolicyClamp  = "clamp"
+	MaxTokensPolicyReject = "reject"
+)
 
 // Config holds application configuration loaded from environment and file.
 // Priority: CLI flags → Env vars → config.toml → defaults
 type Config struct {
+	WebConfig
+	AuthConfig
+	LogConfig
+	UpstreamConfig
+	ResponseConfig
+
 	// ServerPort is the address to bind the server to (e.g., ":8080")
 	ServerPort string
 
-	// EnableWebUI enables the web dashboard at /web
-	EnableWebUI bool
-
 	// Default routing for unaliased models
 	Default *DefaultRoute
 
+	// EndpointDefaults overrides Default per endpoint group ("chat", "embeddings",
+	// "audio", ...), for providers that serve only some endpoints
+	EndpointDefaults map[string]DefaultRoute
+
+	// Tenants maps tenant names to their own routing config, selected by the
+	// tenant the client's API key is bound to
+	Tenants map[string]TenantRoutes
+
 	// Models contains model alias mappings
 	Models []ModelAlias
+
+	// Providers lists the provider instances to build (empty builds every built-in provider)
+	Providers []ProviderDef
+
+	// Shadow mirrors non-streaming chat requests to a secondary model (nil disables)
+	Shadow *ShadowRoute
+
+	// StrictAliases rejects unaliased models instead of passing them through the default route
+	StrictAliases bool
+
+	// ModelNotFoundHints adds the closest alias and a list of available aliases
+	// to "Model not found" errors; off by default since it reveals alias names
+	ModelNotFoundHints bool
+
+	// ClampSamplingParams clamps temperature to [0, 2] and top_p to [0, 1] before proxying
+	ClampSamplingParams bool
+
+	// DefaultChatModel fills chat requests that omit "model" (empty rejects them with 400)
+	DefaultChatModel string
+
+	// MaxTokensPolicy decides what happens when max_tokens exceeds an alias's
+	// max_output_tokens: "clamp" lowers it to the ceiling, "reject" returns 400
+	MaxTokensPolicy string
+
+	// TokenCountWorkers caps concurrent prompt token counters (requests queue beyond it)
+	TokenCountWorkers int
+
+	// TokenizerEncodings pins the tiktoken encoding for models the tokenizer does not recognize
+	TokenizerEncodings map[string]string
+
+	// TokenizerOverheads sets the per-message token overhead by model prefix
+	TokenizerOverheads map[string]int
+
+	// Pricing maps an alias slug or upstream model to its per-million-token price,
+	// used by the max_cost_usd ceiling on [[models]]
+	Pricing map[string]Price
+
+	// CredentialLimits caps concurrent requests per credential name, across
+	// every alias and the default route that use it
+	CredentialLimits map[string]CredentialLimit
+
+	// MaxRequestBodyBytes caps JSON request bodies on /v1 routes (larger bodies get 413)
+	MaxRequestBodyBytes int64
+
+	// MaxRequestTimeout caps the deadline a client may set with X-Goatway-Timeout (0 = no cap)
+	MaxRequestTimeout time.Duration
+
+	// CacheMetrics counts hits, misses and evictions in the in-memory caches
+	// (served at /api/admin/cache/metrics) at a small cost per cache operation
+	CacheMetrics bool
+
+	// ErrorAlertPercent warns when a provider's failure rate over ErrorAlertWindow
+	// reaches this percentage (0 disables)
+	ErrorAlertPercent int
+	ErrorAlertWindow  time.Duration
+
+	// StorageBackend selects where credentials, keys and logs live: "sqlite" or "memory"
+	StorageBackend string
+
+	// DisabledEndpoints lists route groups answered with 404 ("chat", "images", "admin", ...)
+	DisabledEndpoints []string
 }
 
 // Load reads configuration from file and environment variables.
@@ -24,31 +102,36 @@ func Load() *Config {
 	fileConfig, _ := LoadFile() // Ignore error, use defaults
 
 	return &Config{
-		ServerPort:  getEnvOrFile("SERVER_PORT", fileConfig.ServerPort, ":8080"),
-		EnableWebUI: getEnvBoolOrFile("ENABLE_WEB_UI", fileConfig.EnableWebUI, true),
-		Default:     fileConfig.Default,
-		Models:      fileConfig.Models,
-	}
-}
+		WebConfig:      loadWebConfig(fileConfig),
+		AuthConfig:     loadAuthConfig(fileConfig),
+		LogConfig:      loadLogConfig(fileConfig),
+		UpstreamConfig: loadUpstreamConfig(fileConfig),
+		ResponseConfig: loadResponseConfig(fileConfig),
 
-// getEnvOrFile returns env value, file value, or default (in priority order)
-func getEnvOrFile(key, fileValue, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	if fileValue != "" {
-		return fileValue
-	}
-	return defaultValue
-}
+		ServerPort:         getEnvOrFile("SERVER_PORT", fileConfig.ServerPort, ":8080"),
+		Default:            fileConfig.Default,
+		EndpointDefaults:   fileConfig.EndpointDefaults,
+		Tenants:            fileConfig.Tenants,
+		Models:             fileConfig.Models,
+		Providers:          fileConfig.Providers,
+		Shadow:             fileConfig.Shadow,
+		TokenizerEncodings: fileConfig.TokenizerEncodings,
+		TokenizerOverheads: fileConfig.TokenizerOverheads,
+		Pricing:            fileConfig.Pricing,
+		CredentialLimits:   fileConfig.CredentialLimits,
 
-// getEnvBoolOrFile returns env bool, file bool, or default (in priority order)
-func getEnvBoolOrFile(key string, fileValue *bool, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		return value == "true" || value == "1" || value == "yes"
-	}
-	if fileValue != nil {
-		return *fileValue
+		StrictAliases:       getEnvBoolOrFile("STRICT_ALIASES", fileConfig.StrictAliases, false),
+		ModelNotFoundHints:  getEnvBoolOrFile("MODEL_NOT_FOUND_HINTS", fileConfig.ModelNotFoundHints, false),
+		ClampSamplingParams: getEnvBoolOrFile("CLAMP_SAMPLING_PARAMS", fileConfig.ClampSamplingParams, false),
+		DefaultChatModel:    getEnvOrFile("DEFAULT_CHAT_MODEL", fileConfig.DefaultChatModel, ""),
+		MaxTokensPolicy:     getEnvOrFile("MAX_TOKENS_POLICY", fileConfig.MaxTokensPolicy, MaxTokensPolicyClamp),
+		TokenCountWorkers:   getEnvIntOrFile("TOKEN_COUNT_WORKERS", fileConfig.TokenCountWorkers, 8),
+		MaxRequestBodyBytes: int64(getEnvIntOrFile("MAX_REQUEST_BODY_MB", fileConfig.MaxRequestBodyMB, 32)) << 20,
+		MaxRequestTimeout:   time.Duration(getEnvIntOrFile("MAX_REQUEST_TIMEOUT", fileConfig.MaxRequestTimeout, 600)) * time.Second,
+		CacheMetrics:        getEnvBoolOrFile("CACHE_METRICS", fileConfig.CacheMetrics, true),
+		ErrorAlertPercent:   getEnvIntOrFile("ERROR_ALERT_PERCENT", fileConfig.ErrorAlertPercent, 0),
+		ErrorAlertWindow:    time.Duration(getEnvIntOrFile("ERROR_ALERT_WINDOW", fileConfig.ErrorAlertWindow, 300)) * time.Second,
+		StorageBackend:      getEnvOrFile("STORAGE_BACKEND", fileConfig.StorageBackend, "sqlite"),
+		DisabledEndpoints:   getEnvListOrFile("DISABLED_ENDPOINTS", fileConfig.DisabledEndpoints),
 	}
-	return defaultValue
 }
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// getEnvOrFile returns env value, file value, or default (in priority order)
+func getEnvOrFile(key, fileValue, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if fileValue != "" {
+		return fileValue
+	}
+	return defaultValue
+}
+
+// getEnvBoolOrFile returns env bool, file bool, or default (in priority order)
+func getEnvBoolOrFile(key string, fileValue *bool, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		return value == "true" || value == "1" || value == "yes"
+	}
+	if fileValue != nil {
+		return *fileValue
+	}
+	return defaultValue
+}
+
+// getEnvIntOrFile returns env int, file int, or default (in priority order)
+func getEnvIntOrFile(key string, fileValue *int, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	if fileValue != nil {
+		return *fileValue
+	}
+	return defaultValue
+}
+
+// getEnvListOrFile returns a comma-separated env list or the file list (in priority order)
+func getEnvListOrFile(key string, fileValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fileValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// listOrDefault returns list, or defaultValue when list is empty
+func listOrDefault(list, defaultValue []string) []string {
+	if len(list) == 0 {
+		return defaultValue
+	}
+	return list
+}
@@ -9,25 +9,68 @@ import (
 
 // FileConfig represents the TOML configuration file structure.
 type FileConfig struct {
-	ServerPort  string        `toml:"server_port"`
-	EnableWebUI *bool         `toml:"enable_web_ui"`
-	Default     *DefaultRoute `toml:"default"`
-	Models      []ModelAlias  `toml:"models"`
-}
-
-// DefaultRoute defines the fallback provider and model for unknown slugs.
-type DefaultRoute struct {
-	Provider       string `toml:"provider"`
-	Model          string `toml:"model"`
-	CredentialName string `toml:"credential_name"`
-}
-
-// ModelAlias maps a short slug to a provider and model combination.
-type ModelAlias struct {
-	Slug           string `toml:"slug"`
-	Provider       string `toml:"provider"`
-	Model          string `toml:"model"`
-	CredentialName string `toml:"credential_name"`
+	ServerPort          string            `toml:"server_port"`
+	EnableWebUI         *bool             `toml:"enable_web_ui"`
+	WebSecurityHeaders  *bool             `toml:"web_security_headers"`
+	WebCSP              string            `toml:"web_csp"`
+	WebFrameOptions     string            `toml:"web_frame_options"`
+	StreamIdleTimeout   *int              `toml:"stream_idle_timeout"` // seconds
+	FirstByteTimeout    *int              `toml:"first_byte_timeout"`  // seconds
+	MaxRequestTimeout   *int              `toml:"max_request_timeout"` // seconds
+	ErrorAlertPercent   *int              `toml:"error_alert_percent"`
+	ErrorAlertWindow    *int              `toml:"error_alert_window"` // seconds
+	NormalizeSSE        *bool             `toml:"normalize_sse"`
+	StreamRequestID     *bool             `toml:"stream_request_id"`
+	RewriteModel        *bool             `toml:"rewrite_response_model"`
+	CacheMetrics        *bool             `toml:"cache_metrics"`
+	MaxIdleConns        *int              `toml:"upstream_max_idle_conns"`
+	MaxConnsPerHost     *int              `toml:"upstream_max_conns_per_host"`
+	IdleConnTimeout     *int              `toml:"upstream_idle_conn_timeout"` // seconds
+	TokenCountWorkers   *int              `toml:"token_count_workers"`
+	MaxRequestBodyMB    *int              `toml:"max_request_body_mb"`
+	ResponseParseMB     *int              `toml:"response_parse_limit_mb"`
+	ModelsFetchTimeout  *int              `toml:"models_fetch_timeout"` // seconds
+	ModelsResponseMB    *int              `toml:"models_response_limit_mb"`
+	AdminCORSOrigins    []string          `toml:"admin_cors_origins"`
+	DisabledEndpoints   []string          `toml:"disabled_endpoints"`
+	StripHeaders        []string          `toml:"strip_headers"`
+	UpstreamHosts       []string          `toml:"upstream_allowed_hosts"`
+	StrictAliases       *bool             `toml:"strict_aliases"`
+	ModelNotFoundHints  *bool             `toml:"model_not_found_hints"`
+	RequireClientAuth   *bool             `toml:"require_client_auth"`
+	ClampSamplingParams *bool             `toml:"clamp_sampling_params"`
+	MaxTokensPolicy     string            `toml:"max_tokens_policy"`
+	DefaultChatModel    string            `toml:"default_chat_model"`
+	APIKeyPrefix        string            `toml:"api_key_prefix"`
+	APIKeyLength        *int              `toml:"api_key_length"`
+	APIKeyExpiryGrace   *int              `toml:"api_key_expiry_grace"` // minutes
+	APIKeyDefaultTTL    *int              `toml:"api_key_default_ttl"`  // days
+	RateLimitBackend    string            `toml:"rate_limit_backend"`
+	GlobalRateLimit     *int              `toml:"global_rate_limit"` // requests per second
+	RedisURL            string            `toml:"redis_url"`
+	StorageBackend      string            `toml:"storage_backend"`
+	UsageFlushInterval  *int              `toml:"usage_flush_interval"` // seconds
+	RequestIDHeader     string            `toml:"request_id_header"`
+	RequestIDFormat     string            `toml:"request_id_format"`
+	StreamErrorFormat   string            `toml:"stream_error_format"`
+	LogOmitFields       []string          `toml:"log_omit_fields"`
+	LogHashFields       []string          `toml:"log_hash_fields"`
+	LogSampleRate       *int              `toml:"log_sample_rate"`
+	FlagAnomalies       *bool             `toml:"flag_token_anomalies"`
+	LogRequestBodies    *bool             `toml:"log_request_bodies"`
+	LogBodyCompression  *bool             `toml:"log_body_compression"`
+	LogBodyMaxKB        *int              `toml:"log_request_body_max_kb"`
+	Default             *DefaultRoute     `toml:"default"`
+	Models              []ModelAlias      `toml:"models"`
+	Shadow              *ShadowRoute      `toml:"shadow"`
+	TokenizerEncodings  map[string]string `toml:"tokenizer_encodings"`
+	TokenizerOverheads  map[string]int    `toml:"tokenizer_overheads"`
+	Pricing             map[string]Price  `toml:"pricing"`
+	Providers           []ProviderDef     `toml:"providers"`
+
+	CredentialLimits map[string]CredentialLimit `toml:"credential_limits"`
+	EndpointDefaults map[string]DefaultRoute    `toml:"endpoint_defaults"`
+	Tenants          map[string]TenantRoutes    `toml:"tenants"`
 }
 
 // ConfigPath returns the path to the config file (~/.goatway/config.toml).
@@ -66,28 +109,5 @@ func EnsureConfigFile() error {
 		return err
 	}
 
-	defaultConfig := `# Goatway Configuration
-# server_port = ":8080"
-# enable_web_ui = true
-
-# Optional default routing for unaliased models
-# [default]
-# provider = "openrouter"
-# credential_name = "my-openrouter-key"  # Name of credential to use
-
-# Model aliases - map short names to provider/model combinations
-# [[models]]
-# slug = "gpt4"
-# provider = "openrouter"
-# model = "openai/gpt-4o"
-# credential_name = "my-openrouter-key"  # Required: name of credential to use
-
-# [[models]]
-# slug = "claude"
-# provider = "openrouter"
-# model = "anthropic/claude-3.5-sonnet"
-# credential_name = "my-openrouter-key"
-`
-
-	return os.WriteFile(path, []byte(defaultConfig), 0644)
+	return os.WriteFile(path, []byte(defaultConfigTemplate), 0644)
 }
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// Request log fields that LogOmitFields and LogHashFields can name.
+const (
+	LogFieldModel = "model"
+	LogFieldUser  = "user"
+)
+
+// LogConfig holds request logging, usage accounting and tracing settings.
+type LogConfig struct {
+	// LogOmitFields and LogHashFields minimize stored request logs and daily
+	// usage: listed fields ("model", "user") are cleared or replaced by an
+	// HMAC keyed with LogHashKey, which is required when hashing
+	LogOmitFields []string
+	LogHashFields []string
+	LogHashKey    string
+
+	// LogSampleRate stores one in N successful request logs (0 or 1 keeps all);
+	// failed requests are always logged and daily usage counts every request
+	LogSampleRate int
+
+	// LogRequestBodies stores each chat request body with its log (debug aid,
+	// off by default); LogBodyCompression gzips stored bodies and
+	// LogBodyMaxBytes truncates longer ones
+	LogRequestBodies   bool
+	LogBodyCompression bool
+	LogBodyMaxBytes    int
+
+	// FlagTokenAnomalies marks chat logs whose upstream token counts look like
+	// billing errors (completion far above max_tokens, no prompt tokens billed)
+	FlagTokenAnomalies bool
+
+	// UsageFlushInterval batches daily usage updates in memory and writes them
+	// in one transaction this often, easing SQLite write contention at high QPS.
+	// Up to one interval of usage is lost on a crash (0 writes every request)
+	UsageFlushInterval time.Duration
+
+	// RequestIDHeader and RequestIDFormat configure request tracing IDs
+	// ("hex" or "uuid"); inbound X-Correlation-ID is honored as a fallback
+	RequestIDHeader string
+	RequestIDFormat string
+}
+
+// loadLogConfig reads the logging settings from env and file.
+func loadLogConfig(f *FileConfig) LogConfig {
+	return LogConfig{
+		LogOmitFields:      getEnvListOrFile("LOG_OMIT_FIELDS", f.LogOmitFields),
+		LogHashFields:      getEnvListOrFile("LOG_HASH_FIELDS", f.LogHashFields),
+		LogHashKey:         os.Getenv("LOG_HASH_KEY"),
+		LogSampleRate:      getEnvIntOrFile("LOG_SAMPLE_RATE", f.LogSampleRate, 1),
+		LogRequestBodies:   getEnvBoolOrFile("LOG_REQUEST_BODIES", f.LogRequestBodies, false),
+		LogBodyCompression: getEnvBoolOrFile("LOG_BODY_COMPRESSION", f.LogBodyCompression, true),
+		LogBodyMaxBytes:    getEnvIntOrFile("LOG_REQUEST_BODY_MAX_KB", f.LogBodyMaxKB, 64) << 10,
+		FlagTokenAnomalies: getEnvBoolOrFile("FLAG_TOKEN_ANOMALIES", f.FlagAnomalies, true),
+		UsageFlushInterval: time.Duration(getEnvIntOrFile("USAGE_FLUSH_INTERVAL", f.UsageFlushInterval, 0)) * time.Second,
+		RequestIDHeader:    getEnvOrFile("REQUEST_ID_HEADER", f.RequestIDHeader, "X-Request-ID"),
+		RequestIDFormat:    getEnvOrFile("REQUEST_ID_FORMAT", f.RequestIDFormat, "hex"),
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+// Price is a model's cost in USD per million tokens.
+type Price struct {
+	Input  float64 `toml:"input" json:"input"`
+	Output float64 `toml:"output" json:"output"`
+}
+
+// Cost returns the USD cost of inputTokens and outputTokens at p, whose
+// rates are per million tokens.
+func (p Price) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// LookupPrice finds the [pricing] entry for an alias slug, then for its
+// upstream model. An empty slug looks up the model alone. The cost guard and
+// the admin usage reports both price requests this way.
+func LookupPrice(pricing map[string]Price, slug, model string) (Price, bool) {
+	if price, ok := pricing[slug]; ok && slug != "" {
+		return price, true
+	}
+	price, ok := pricing[model]
+	return price, ok
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookupPrice(t *testing.T) {
+	pricing := map[string]Price{"gpt4": {Input: 1}, "openai/gpt-4o": {Input: 2}}
+	tests := []struct {
+		slug, model string
+		want        float64
+		wantOK      bool
+	}{
+		{"gpt4", "openai/gpt-4o", 1, true},
+		{"other", "openai/gpt-4o", 2, true},
+		{"", "openai/gpt-4o", 2, true},
+		{"other", "meta/llama", 0, false},
+	}
+	for _, tt := range tests {
+		price, ok := LookupPrice(pricing, tt.slug, tt.model)
+		if ok != tt.wantOK || price.Input != tt.want {
+			t.Errorf("LookupPrice(%q, %q) = %v, %v; want input %v, %v", tt.slug, tt.model, price, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestPriceCost(t *testing.T) {
+	if got := (Price{Input: 2.5, Output: 10}).Cost(2000, 1000); math.Abs(got-0.015) > 1e-12 {
+		t.Errorf("Cost = %v, want 0.015", got)
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+// ResponseConfig holds how upstream responses are relayed to clients.
+type ResponseConfig struct {
+	// ResponseParseLimit caps how much of a JSON response is buffered to read usage;
+	// larger responses are forwarded unparsed with unknown token counts (0 disables)
+	ResponseParseLimit int64
+
+	// NormalizeSSE drops non-data SSE lines (comments, keep-alives, vendor events)
+	// and malformed data frames so strict OpenAI SDKs only see chat chunks
+	NormalizeSSE bool
+
+	// StreamRequestID leads every stream with ": goatway-request-id=<id>" so
+	// clients can correlate it with logs (opt-in; some strict parsers reject comments)
+	StreamRequestID bool
+
+	// StreamErrorFormat is how errors reach stream:true requests: "sse" sends
+	// one SSE error frame and [DONE], "json" the usual JSON error body
+	StreamErrorFormat string
+
+	// RewriteResponseModel reports the model alias the client requested in the
+	// response's model field instead of the upstream model it resolved to
+	RewriteResponseModel bool
+}
+
+// loadResponseConfig reads the response relay settings from env and file.
+func loadResponseConfig(f *FileConfig) ResponseConfig {
+	return ResponseConfig{
+		ResponseParseLimit:   int64(getEnvIntOrFile("RESPONSE_PARSE_LIMIT_MB", f.ResponseParseMB, 16)) << 20,
+		NormalizeSSE:         getEnvBoolOrFile("NORMALIZE_SSE", f.NormalizeSSE, false),
+		StreamRequestID:      getEnvBoolOrFile("STREAM_REQUEST_ID", f.StreamRequestID, false),
+		StreamErrorFormat:    getEnvOrFile("STREAM_ERROR_FORMAT", f.StreamErrorFormat, "sse"),
+		RewriteResponseModel: getEnvBoolOrFile("REWRITE_RESPONSE_MODEL", f.RewriteModel, false),
+	}
+}
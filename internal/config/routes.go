@@ -0,0 +1,55 @@
+package config
+
+// DefaultRoute defines the fallback provider and model for unknown slugs.
+type DefaultRoute struct {
+	Provider       string `toml:"provider"`
+	Model          string `toml:"model"`
+	CredentialName string `toml:"credential_name"`
+}
+
+// ModelAlias maps a short slug to a provider and model combination.
+type ModelAlias struct {
+	Slug            string   `toml:"slug"`
+	Provider        string   `toml:"provider"`
+	Model           string   `toml:"model"`
+	CredentialName  string   `toml:"credential_name"`
+	MaxOutputTokens int      `toml:"max_output_tokens"` // Optional ceiling for max_tokens (0 = none)
+	MaxConcurrent   int      `toml:"max_concurrent"`    // Optional in-flight request cap (0 = none)
+	QueueTimeout    int      `toml:"queue_timeout"`     // Seconds to wait for a free slot (0 = reject with 429)
+	MaxCostUSD      float64  `toml:"max_cost_usd"`      // Optional worst-case cost ceiling per request (0 = none)
+	Fallbacks       []string `toml:"fallbacks"`         // Alias slugs tried in order when the upstream misses first_byte_timeout
+}
+
+// TenantRoutes is the isolated routing config of one tenant, selected by the
+// API key bound to it. Its requests resolve only against these
+// aliases and default route, and can only use the credentials they name.
+type TenantRoutes struct {
+	Default *DefaultRoute `toml:"default"`
+	Models  []ModelAlias  `toml:"models"`
+}
+
+// CredentialLimit caps the in-flight requests sent with one credential,
+// matching an account-level concurrency limit at the provider.
+type CredentialLimit struct {
+	MaxConcurrent int `toml:"max_concurrent"` // In-flight request cap (0 = none)
+	QueueTimeout  int `toml:"queue_timeout"`  // Seconds to wait for a free slot (0 = reject with 429)
+}
+
+// ProviderDef declares a provider instance built at startup.
+// Name is the routing key used by [default] and [[models]]; it defaults to Type.
+type ProviderDef struct {
+	Name    string `toml:"name"`
+	Type    string `toml:"type"`     // "openrouter", "bedrock", "groq" or "openai"
+	BaseURL string `toml:"base_url"` // Optional endpoint override (all but bedrock)
+
+	// Optional model name rewrite before forwarding: the strip prefix is
+	// removed, then the add prefix is prepended unless already present
+	StripModelPrefix string `toml:"strip_model_prefix"`
+	AddModelPrefix   string `toml:"add_model_prefix"`
+}
+
+// ShadowRoute mirrors non-streaming chat requests to a secondary model for comparison.
+// Model is resolved like a client-supplied slug (alias or default route).
+type ShadowRoute struct {
+	Model string `toml:"model"`
+}
@@ -0,0 +1,140 @@
+package config
+
+// defaultConfigTemplate is written by EnsureConfigFile on first run.
+const defaultConfigTemplate = `# Goatway Configuration
+# server_port = ":8080"
+# enable_web_ui = true
+# web_security_headers = true  # CSP, nosniff, X-Frame-Options and Referrer-Policy on /web responses
+# web_csp = "default-src 'self'; ..."  # Override the dashboard's Content-Security-Policy
+# web_frame_options = "DENY"  # e.g. "SAMEORIGIN" to embed the dashboard in your own frontend
+# stream_idle_timeout = 120  # Seconds without upstream bytes before a stream is aborted (0 disables)
+# first_byte_timeout = 0  # Seconds to wait for upstream response headers before failing over to an alias's fallbacks (or 504; 0 disables)
+# max_request_timeout = 600  # Cap in seconds on the X-Goatway-Timeout request header (0 = no cap)
+# error_alert_percent = 0     # Warn when a provider's failure rate reaches this percentage (0 disables)
+# error_alert_window = 300    # Seconds of outcomes the failure rate is computed over
+# upstream_max_idle_conns = 100     # Idle upstream connections each provider keeps for reuse
+# upstream_max_conns_per_host = 0   # Cap on connections per upstream host (0 = unlimited)
+# upstream_idle_conn_timeout = 90   # Seconds an idle upstream connection stays open
+# normalize_sse = false  # Drop SSE comments, keep-alives and non-JSON frames so strict OpenAI SDKs only see chunks
+# stream_request_id = false  # Lead each stream with ": goatway-request-id=<id>" to correlate it with logs
+# rewrite_response_model = false  # Answer with the requested alias (e.g. "gpt4") in the response's model field instead of the upstream model
+# cache_metrics = true  # Count cache hits, misses and evictions for /api/admin/cache/metrics
+# token_count_workers = 8  # Concurrent prompt token counters; extra requests queue
+# max_request_body_mb = 32  # Largest JSON request body accepted on /v1 routes (larger bodies get 413)
+# response_parse_limit_mb = 16  # Larger JSON responses are forwarded without reading usage (0 = no limit)
+# models_fetch_timeout = 10  # Seconds to wait for the upstream /v1/models list (504 on timeout unless a cached list exists)
+# models_response_limit_mb = 8  # Largest upstream /v1/models list accepted (0 = no limit)
+# admin_cors_origins = ["https://admin.example.com"]  # Origins allowed to call /api/admin cross-origin
+# disabled_endpoints = ["images", "audio"]  # Route groups answered with 404: chat, completions, embeddings, audio, images, moderations, models, admin
+# strip_headers = ["Cookie", "X-Forwarded-For"]  # Client headers never forwarded upstream (hop-by-hop headers are always dropped)
+# upstream_allowed_hosts = ["openrouter.ai", "api.groq.com", "api.openai.com", "bedrock-runtime.*.amazonaws.com"]  # Hosts providers may connect to ("*" allows any)
+# clamp_sampling_params = false  # Clamp temperature to [0, 2] and top_p to [0, 1] before proxying
+# api_key_prefix = "gw_"  # Prefix for client API keys (changing it invalidates existing keys)
+# api_key_length = 64     # Random characters per key (minimum 32)
+# api_key_expiry_grace = 0  # Minutes an expired key keeps working (with a Warning header) to ease rotation
+# api_key_default_ttl = 0  # Days until a key created without expires_in expires (0 = never)
+# default_chat_model = "gpt4"  # Model (or alias) used when a chat request omits "model"
+# max_tokens_policy = "clamp"  # "clamp" or "reject" requests above an alias's max_output_tokens
+# rate_limit_backend = "memory"  # "memory" (per process) or "redis" (shared across instances)
+# global_rate_limit = 0  # Proxy requests per second for the whole server, any key; beyond it 503 (0 = off)
+# redis_url = "redis://localhost:6379/0"
+# storage_backend = "sqlite"  # "sqlite" (persistent) or "memory" (nothing survives a restart)
+# usage_flush_interval = 0  # Seconds between batched daily usage writes; eases SQLite lock contention (0 = every request)
+# require_client_auth = false  # Set true to require a client API key on /v1 routes (recommended beyond localhost)
+# request_id_header = "X-Request-ID"  # Header read and echoed for tracing (X-Correlation-ID is also accepted)
+# request_id_format = "hex"  # Generated IDs: "hex" (16 chars) or "uuid"
+# stream_error_format = "sse"  # Errors to stream:true requests: "sse" (error frame + [DONE]) or "json"
+# log_omit_fields = ["model"]  # Request log fields never stored: "model", "user"
+# log_hash_fields = ["user"]   # Request log fields stored as an HMAC keyed by LOG_HASH_KEY (env only) instead of the raw value
+# log_sample_rate = 1  # Store 1 in N successful request logs under heavy load; errors and daily usage are always kept
+# flag_token_anomalies = true  # Flag chat logs with implausible upstream token counts (filter with ?anomalous=true)
+# log_request_bodies = false   # Store each chat request body with its log (debugging only; bodies hold prompts)
+# log_body_compression = true  # Gzip stored request bodies
+# log_request_body_max_kb = 64  # Truncate stored request bodies longer than this
+# strict_aliases = false  # Only accept aliased slugs; unknown models return 400 even with [default]
+# model_not_found_hints = false  # Name the closest alias and list available aliases in "Model not found" errors
+
+# Providers to build at startup (omit to enable every built-in provider)
+# [[providers]]
+# type = "openrouter"
+
+# [[providers]]
+# name = "bedrock"  # Routing key used by provider = "..." below (defaults to type)
+# type = "bedrock"
+
+# [[providers]]
+# type = "groq"  # OpenAI-compatible Groq API; credentials use provider "groq"
+# strip_model_prefix = "meta-llama/"  # Optional: removed from model names before forwarding
+# add_model_prefix = ""  # Optional: prepended to model names (after stripping) unless present
+
+# [[providers]]
+# type = "openai"  # Credentials use provider "openai"; their organization and project set the OpenAI account headers
+
+# Optional default routing for unaliased models
+# [default]
+# provider = "openrouter"
+# credential_name = "my-openrouter-key"  # Name of credential to use
+
+# Optional per-endpoint default routing, overriding [default] for unaliased
+# models on that endpoint (chat, completions, embeddings, audio, images, moderations)
+# [endpoint_defaults.audio]
+# provider = "groq"
+# credential_name = "my-groq-key"
+
+# Optional tenants, selected by the API key they are bound to (admin "tenant").
+# A tenant's requests use only its own default route and aliases (not the
+# ones above) and only the credentials they name; unknown tenants get 400
+# [tenants.acme.default]
+# provider = "openrouter"
+# credential_name = "acme-openrouter-key"
+# [[tenants.acme.models]]
+# slug = "fast"
+# provider = "openrouter"
+# model = "openai/gpt-4o-mini"
+# credential_name = "acme-openrouter-key"
+
+# Model aliases - map short names to provider/model combinations
+# [[models]]
+# slug = "gpt4"
+# provider = "openrouter"
+# model = "openai/gpt-4o"
+# credential_name = "my-openrouter-key"  # Required: name of credential to use
+# max_output_tokens = 16384  # Optional: ceiling for max_tokens / max_completion_tokens
+# max_concurrent = 2  # Optional: in-flight requests allowed for this alias (extra ones get 429)
+# queue_timeout = 30  # Optional: seconds an extra request waits for a free slot before the 429
+# max_cost_usd = 0.50  # Optional: reject (400) requests whose worst-case cost exceeds this, or is unbounded (needs [pricing])
+# fallbacks = ["claude"]  # Optional: aliases tried in order when this one misses first_byte_timeout
+
+# [[models]]
+# slug = "claude"
+# provider = "openrouter"
+# model = "anthropic/claude-3.5-sonnet"
+# credential_name = "my-openrouter-key"
+
+# Cap in-flight requests per credential name, shared by every alias using it,
+# to stay under the provider's account concurrency limit. Extra requests get
+# 429, or first wait up to queue_timeout seconds for a free slot.
+# [credential_limits.my-openrouter-key]
+# max_concurrent = 8
+# queue_timeout = 5
+
+# Prices in USD per million tokens, keyed by alias slug or upstream model.
+# Used by max_cost_usd: prompt tokens at input plus max_tokens at output.
+# [pricing]
+# "openai/gpt-4o" = { input = 2.50, output = 10.00 }
+
+# Pin the token counting encoding for models the tokenizer doesn't recognize
+# (unknown models default to cl100k_base). Values: "cl100k_base" or "o200k_base".
+# [tokenizer_encodings]
+# "gpt-5" = "o200k_base"
+
+# Per-message token overhead by model prefix (longest prefix wins). Defaults:
+# 4 for gpt-3.5*, 3 for everything else.
+# [tokenizer_overheads]
+# "o1" = 4
+
+# Optional shadow mode: mirror non-streaming chat requests to a second model.
+# Responses are discarded; latency and tokens are logged as shadow requests.
+# [shadow]
+# model = "claude"  # Alias slug (or model name routed via [default])
+`
@@ -0,0 +1,51 @@
+package config
+
+import "time"
+
+// defaultUpstreamHosts are the endpoints of the built-in providers.
+var defaultUpstreamHosts = []string{"openrouter.ai", "api.groq.com", "api.openai.com", "bedrock-runtime.*.amazonaws.com"}
+
+// UpstreamConfig holds the connection, timeout and header settings of calls
+// to providers.
+type UpstreamConfig struct {
+	// MaxIdleConns, MaxConnsPerHost and IdleConnTimeout tune each provider's
+	// upstream connection pool (MaxConnsPerHost 0 = unlimited)
+	MaxIdleConns    int
+	MaxConnsPerHost int
+	IdleConnTimeout time.Duration
+
+	// ModelsFetchTimeout and ModelsResponseLimit bound the upstream fetch behind
+	// /v1/models (0 disables either); failures serve the last good list
+	ModelsFetchTimeout  time.Duration
+	ModelsResponseLimit int64
+
+	// StreamIdleTimeout aborts a stream when the upstream sends nothing for this long (0 disables)
+	StreamIdleTimeout time.Duration
+
+	// FirstByteTimeout aborts an upstream call whose response headers take longer
+	// than this, falling back to the alias's fallbacks when it has any (0 disables)
+	FirstByteTimeout time.Duration
+
+	// StripHeaders lists client headers never forwarded upstream, in addition
+	// to the hop-by-hop headers that are always dropped
+	StripHeaders []string
+
+	// UpstreamHosts lists the hosts provider clients may connect to: exact
+	// hosts, "*.example.com" for subdomains or "*" for any host
+	UpstreamHosts []string
+}
+
+// loadUpstreamConfig reads the upstream settings from env and file.
+func loadUpstreamConfig(f *FileConfig) UpstreamConfig {
+	return UpstreamConfig{
+		MaxIdleConns:        getEnvIntOrFile("UPSTREAM_MAX_IDLE_CONNS", f.MaxIdleConns, 100),
+		MaxConnsPerHost:     getEnvIntOrFile("UPSTREAM_MAX_CONNS_PER_HOST", f.MaxConnsPerHost, 0),
+		IdleConnTimeout:     time.Duration(getEnvIntOrFile("UPSTREAM_IDLE_CONN_TIMEOUT", f.IdleConnTimeout, 90)) * time.Second,
+		ModelsFetchTimeout:  time.Duration(getEnvIntOrFile("MODELS_FETCH_TIMEOUT", f.ModelsFetchTimeout, 10)) * time.Second,
+		ModelsResponseLimit: int64(getEnvIntOrFile("MODELS_RESPONSE_LIMIT_MB", f.ModelsResponseMB, 8)) << 20,
+		StreamIdleTimeout:   time.Duration(getEnvIntOrFile("STREAM_IDLE_TIMEOUT", f.StreamIdleTimeout, 120)) * time.Second,
+		FirstByteTimeout:    time.Duration(getEnvIntOrFile("FIRST_BYTE_TIMEOUT", f.FirstByteTimeout, 0)) * time.Second,
+		StripHeaders:        getEnvListOrFile("STRIP_HEADERS", f.StripHeaders),
+		UpstreamHosts:       listOrDefault(getEnvListOrFile("UPSTREAM_ALLOWED_HOSTS", f.UpstreamHosts), defaultUpstreamHosts),
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validate reports the first setting whose value is not one the gateway
+// understands, so a typo fails at startup instead of silently using a default.
+func (c *Config) Validate() error {
+	if err := oneOf("REQUEST_ID_FORMAT", c.RequestIDFormat, "hex", "uuid"); err != nil {
+		return err
+	}
+	if err := oneOf("MAX_TOKENS_POLICY", c.MaxTokensPolicy, MaxTokensPolicyClamp, MaxTokensPolicyReject); err != nil {
+		return err
+	}
+	if err := oneOf("STREAM_ERROR_FORMAT", c.StreamErrorFormat, "sse", "json"); err != nil {
+		return err
+	}
+	if c.APIKeyLength < MinAPIKeyLength {
+		return fmt.Errorf("API_KEY_LENGTH: %d is below the minimum of %d", c.APIKeyLength, MinAPIKeyLength)
+	}
+	if err := logFields("LOG_OMIT_FIELDS", c.LogOmitFields); err != nil {
+		return err
+	}
+	if err := logFields("LOG_HASH_FIELDS", c.LogHashFields); err != nil {
+		return err
+	}
+	if len(c.LogHashFields) > 0 && c.LogHashKey == "" {
+		return errors.New("LOG_HASH_FIELDS: LOG_HASH_KEY must be set to hash log fields")
+	}
+	return nil
+}
+
+// logFields returns an error naming key unless every name is a request log
+// field the privacy settings know (matched case-insensitively).
+func logFields(key string, names []string) error {
+	for _, name := range names {
+		if err := oneOf(key, strings.ToLower(strings.TrimSpace(name)), LogFieldModel, LogFieldUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// oneOf returns an error naming key unless value is one of allowed.
+func oneOf(key, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: unknown value %q (want one of %q)", key, value, allowed)
+}
@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			LogConfig:       LogConfig{RequestIDFormat: "hex"},
+			AuthConfig:      AuthConfig{APIKeyLength: 64},
+			ResponseConfig:  ResponseConfig{StreamErrorFormat: "sse"},
+			MaxTokensPolicy: MaxTokensPolicyClamp,
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr bool
+	}{
+		{"defaults", func(c *Config) {}, false},
+		{"uuid request IDs", func(c *Config) { c.RequestIDFormat = "uuid" }, false},
+		{"unknown request ID format", func(c *Config) { c.RequestIDFormat = "UUID4" }, true},
+		{"reject max tokens", func(c *Config) { c.MaxTokensPolicy = MaxTokensPolicyReject }, false},
+		{"unknown max tokens policy", func(c *Config) { c.MaxTokensPolicy = "truncate" }, true},
+		{"json stream errors", func(c *Config) { c.StreamErrorFormat = "json" }, false},
+		{"unknown stream error format", func(c *Config) { c.StreamErrorFormat = "jsn" }, true},
+		{"minimum key length", func(c *Config) { c.APIKeyLength = MinAPIKeyLength }, false},
+		{"short key length", func(c *Config) { c.APIKeyLength = 8 }, true},
+		{"omit log fields", func(c *Config) { c.LogOmitFields = []string{"model", " User "} }, false},
+		{"unknown omit field", func(c *Config) { c.LogOmitFields = []string{"users"} }, true},
+		{"hash with key", func(c *Config) { c.LogHashFields, c.LogHashKey = []string{"user"}, "k" }, false},
+		{"hash without key", func(c *Config) { c.LogHashFields = []string{"user"} }, true},
+		{"unknown hash field", func(c *Config) { c.LogHashFields, c.LogHashKey = []string{"prompt"}, "k" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.mutate(c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
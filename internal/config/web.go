@@ -0,0 +1,33 @@
+package config
+
+// DefaultWebCSP is the Content-Security-Policy of the web UI. The dashboard
+// uses inline event handlers and styles and loads Chart.js from jsDelivr.
+const DefaultWebCSP = "default-src 'self'; script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+	"style-src 'self' 'unsafe-inline'; img-src 'self' data:; object-src 'none'; base-uri 'self'; form-action 'self'"
+
+// WebConfig holds the web dashboard and browser-facing settings.
+type WebConfig struct {
+	// EnableWebUI enables the web dashboard at /web
+	EnableWebUI bool
+
+	// WebSecurityHeaders sets CSP, nosniff, framing and referrer headers on /web
+	// responses; WebCSP and WebFrameOptions override the first two for
+	// deployments that embed the dashboard behind their own frontend
+	WebSecurityHeaders bool
+	WebCSP             string
+	WebFrameOptions    string
+
+	// AdminCORSOrigins lists browser origins allowed to call the admin API cross-origin
+	AdminCORSOrigins []string
+}
+
+// loadWebConfig reads the web settings from env and file.
+func loadWebConfig(f *FileConfig) WebConfig {
+	return WebConfig{
+		EnableWebUI:        getEnvBoolOrFile("ENABLE_WEB_UI", f.EnableWebUI, true),
+		WebSecurityHeaders: getEnvBoolOrFile("WEB_SECURITY_HEADERS", f.WebSecurityHeaders, true),
+		WebCSP:             getEnvOrFile("WEB_CSP", f.WebCSP, DefaultWebCSP),
+		WebFrameOptions:    getEnvOrFile("WEB_FRAME_OPTIONS", f.WebFrameOptions, "DENY"),
+		AdminCORSOrigins:   getEnvListOrFile("ADMIN_CORS_ORIGINS", f.AdminCORSOrigins),
+	}
+}
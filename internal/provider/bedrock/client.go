@@ -0,0 +1,61 @@
+// Package bedrock implements the Amazon Bedrock LLM provider using the Converse API.
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+)
+
+// errInvalidCredential is returned when the Bedrock credential is incomplete.
+var errInvalidCredential = errors.New("bedrock credential requires access_key_id, secret_access_key and region")
+
+// Provider implements the provider.Provider interface for Amazon Bedrock.
+// Credentials are resolved per-request from storage and used for SigV4 signing.
+type Provider struct {
+	client *http.Client // Shared so upstream connections are reused
+}
+
+// New creates a new Bedrock provider instance.
+func New() *Provider {
+	return NewWithPool(upstream.DefaultPool())
+}
+
+// NewWithPool creates a Bedrock provider whose upstream connections are pooled per pool.
+func NewWithPool(pool upstream.PoolConfig) *Provider {
+	return &Provider{client: upstream.NewClient(pool)}
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() string {
+	return "bedrock"
+}
+
+// BaseURL returns empty since the endpoint is built from the credential region.
+func (p *Provider) BaseURL() string {
+	return ""
+}
+
+// PrepareRequest is a no-op; authentication is added by SigV4 signing.
+func (p *Provider) PrepareRequest(ctx context.Context, req *http.Request) error {
+	return nil
+}
+
+// endpoint builds the Converse (or ConverseStream) URL for a model in a region.
+// The model ID is escaped because Bedrock IDs contain ':' (e.g. "...-v1:0").
+func endpoint(region, model string, streaming bool) string {
+	action := "converse"
+	if streaming {
+		action = "converse-stream"
+	}
+	u := url.URL{
+		Scheme:  "https",
+		Host:    "bedrock-runtime." + region + ".amazonaws.com",
+		Path:    "/model/" + model + "/" + action,
+		RawPath: "/model/" + uriEncode(model) + "/" + action,
+	}
+	return u.String()
+}
@@ -0,0 +1,107 @@
+package bedrock
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// errMalformedEvent is returned when an event stream frame cannot be decoded.
+var errMalformedEvent = errors.New("malformed event stream frame")
+
+// streamEvent is a decoded application/vnd.amazon.eventstream message.
+type streamEvent struct {
+	EventType     string // :event-type header (e.g. "contentBlockDelta")
+	MessageType   string // :message-type header ("event" or "exception")
+	ExceptionType string // :exception-type header for exception messages
+	Payload       []byte
+}
+
+// readEvent reads a single binary event stream message from r.
+// Frame layout: total length, headers length, prelude CRC, headers, payload, message CRC.
+func readEvent(r io.Reader) (*streamEvent, error) {
+	var prelude [12]byte
+	if _, err := io.ReadFull(r, prelude[:]); err != nil {
+		return nil, err
+	}
+
+	total := binary.BigEndian.Uint32(prelude[0:4])
+	headersLen := binary.BigEndian.Uint32(prelude[4:8])
+	if total < 16+headersLen || crc32.ChecksumIEEE(prelude[:8]) != binary.BigEndian.Uint32(prelude[8:12]) {
+		return nil, errMalformedEvent
+	}
+
+	rest := make([]byte, total-12)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+
+	crc := crc32.NewIEEE()
+	crc.Write(prelude[:])
+	crc.Write(rest[:len(rest)-4])
+	if crc.Sum32() != binary.BigEndian.Uint32(rest[len(rest)-4:]) {
+		return nil, errMalformedEvent
+	}
+
+	event := &streamEvent{Payload: rest[headersLen : len(rest)-4]}
+	if err := parseHeaders(rest[:headersLen], event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// parseHeaders decodes event stream headers, keeping the string headers we use.
+func parseHeaders(data []byte, event *streamEvent) error {
+	for len(data) > 0 {
+		nameLen := int(data[0])
+		if len(data) < 1+nameLen+1 {
+			return errMalformedEvent
+		}
+		name := string(data[1 : 1+nameLen])
+		valueType := data[1+nameLen]
+		data = data[2+nameLen:]
+
+		size, prefix := headerValueSize(valueType, data)
+		if size < 0 || len(data) < prefix+size {
+			return errMalformedEvent
+		}
+		value := data[prefix : prefix+size]
+		data = data[prefix+size:]
+
+		switch name {
+		case ":event-type":
+			event.EventType = string(value)
+		case ":message-type":
+			event.MessageType = string(value)
+		case ":exception-type":
+			event.ExceptionType = string(value)
+		}
+	}
+	return nil
+}
+
+// headerValueSize returns the value size and length-prefix size for a header type.
+func headerValueSize(valueType byte, data []byte) (int, int) {
+	switch valueType {
+	case 0, 1: // bool true / false
+		return 0, 0
+	case 2: // byte
+		return 1, 0
+	case 3: // short
+		return 2, 0
+	case 4: // int
+		return 4, 0
+	case 5, 8: // long, timestamp
+		return 8, 0
+	case 6, 7: // bytes, string (2-byte length prefix)
+		if len(data) < 2 {
+			return -1, 0
+		}
+		return int(binary.BigEndian.Uint16(data[:2])), 2
+	case 9: // uuid
+		return 16, 0
+	default:
+		return -1, 0
+	}
+}
@@ -0,0 +1,109 @@
+package bedrock
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// ProxyRequest translates the OpenAI chat request to Converse, signs it and
+// translates the response (or ConverseStream events) back to OpenAI format.
+func (p *Provider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	startTime := time.Now()
+	result := &types.ProxyResult{
+		Model:        opts.Model,
+		PromptTokens: opts.PromptTokens,
+		IsStreaming:  opts.IsStreaming,
+	}
+	defer func() { result.UpstreamDuration = time.Since(startTime) }()
+
+	if opts.Credential == nil {
+		return fail(w, result, http.StatusUnauthorized, "No credential configured", types.ErrNoAPIKey)
+	}
+	cred, err := opts.Credential.GetBedrockCredential()
+	if err != nil || cred.AccessKeyID == "" || cred.SecretAccessKey == "" || cred.Region == "" {
+		return fail(w, result, http.StatusUnauthorized, "Invalid Bedrock credential", errInvalidCredential)
+	}
+
+	var body io.Reader = req.Body
+	if opts.Body != nil {
+		body = opts.Body
+	}
+	var chatReq types.ChatCompletionRequest
+	if err := json.NewDecoder(body).Decode(&chatReq); err != nil {
+		return fail(w, result, http.StatusBadRequest, "Failed to process request body", err)
+	}
+	payload, err := json.Marshal(toConverse(&chatReq))
+	if err != nil {
+		return fail(w, result, http.StatusBadRequest, "Failed to process request body", err)
+	}
+
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint(cred.Region, opts.Model, opts.IsStreaming), bytes.NewReader(payload))
+	if err != nil {
+		return fail(w, result, http.StatusInternalServerError, "Failed to create request", err)
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	s := &signer{
+		accessKeyID:     cred.AccessKeyID,
+		secretAccessKey: cred.SecretAccessKey,
+		sessionToken:    cred.SessionToken,
+		region:          cred.Region,
+		service:         "bedrock",
+	}
+	s.Sign(upstreamReq, payload, time.Now())
+
+	resp, err := upstream.DoWithFirstByteTimeout(p.client, upstreamReq, opts.FirstByteTimeout)
+	if errors.Is(err, upstream.ErrFirstByteTimeout) {
+		// Not answered here: the router falls back or replies 504
+		result.Error = err
+		result.ErrorType = types.ErrorClassTimeout
+		result.StatusCode = http.StatusGatewayTimeout
+		return result, err
+	}
+	if err != nil {
+		result.ErrorType = types.ClassifyTransportError(err)
+		status := types.TransportErrorStatus(err)
+		return fail(w, result, status, http.StatusText(status)+": "+err.Error(), err)
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Duration = time.Since(startTime)
+
+	if resp.StatusCode >= 400 {
+		return handleErrorResponse(w, resp, result)
+	}
+
+	st := &streamState{
+		id:           "chatcmpl-" + uuid.New().String(),
+		model:        cmp.Or(opts.ResponseModel, opts.Model),
+		created:      time.Now().Unix(),
+		includeUsage: chatReq.StreamOptions != nil && chatReq.StreamOptions.IncludeUsage,
+		idleTimeout:  opts.IdleTimeout,
+		start:        startTime,
+	}
+	if opts.StreamRequestID {
+		st.requestID = opts.RequestID
+	}
+	if opts.IsStreaming {
+		return handleStreamingResponse(w, resp, result, st)
+	}
+	return handleJSONResponse(w, resp, result, st)
+}
+
+// fail writes a plain error response and records it on the result.
+func fail(w http.ResponseWriter, result *types.ProxyResult, status int, message string, err error) (*types.ProxyResult, error) {
+	result.Error = err
+	result.StatusCode = status
+	http.Error(w, message, status)
+	return result, err
+}
@@ -0,0 +1,91 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// handleJSONResponse translates a Converse response into an OpenAI chat completion.
+func handleJSONResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, st *streamState) (*types.ProxyResult, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = err
+		http.Error(w, "Failed to read response", http.StatusBadGateway)
+		return result, err
+	}
+
+	var converse converseResponse
+	if err := json.Unmarshal(body, &converse); err != nil {
+		result.Error = err
+		result.StatusCode = http.StatusBadGateway
+		types.WriteError(w, http.StatusBadGateway, types.ErrServer("invalid Bedrock response"))
+		return result, err
+	}
+
+	completion := fromConverse(&converse, st.id, st.model, st.created)
+	result.PromptTokens = completion.Usage.PromptTokens
+	result.CompletionTokens = completion.Usage.CompletionTokens
+	result.TotalTokens = completion.Usage.TotalTokens
+	result.FinishReason = completion.Choices[0].FinishReason
+	result.ToolCalls = completion.ToolCallCount()
+
+	w.Header().Set("Content-Type", "application/json")
+	result.TTFB = time.Since(st.start)
+	w.WriteHeader(resp.StatusCode)
+	_ = json.NewEncoder(w).Encode(completion)
+	return result, nil
+}
+
+// handleErrorResponse converts a Bedrock error body into an OpenAI-compatible error.
+func handleErrorResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult) (*types.ProxyResult, error) {
+	body, _ := io.ReadAll(resp.Body)
+
+	var bedrockErr struct {
+		Message string `json:"message"`
+	}
+	message := string(body)
+	if err := json.Unmarshal(body, &bedrockErr); err == nil && bedrockErr.Message != "" {
+		message = bedrockErr.Message
+	}
+	result.ErrorMessage = message
+	result.ErrorType = types.ClassifyUpstreamError(resp.StatusCode, message)
+
+	types.WriteError(w, resp.StatusCode, types.NewAPIError(message, errorTypeForStatus(resp.StatusCode)))
+	return result, nil
+}
+
+// errorTypeForStatus maps an upstream HTTP status to an OpenAI error type.
+func errorTypeForStatus(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return types.ErrorTypeAuthentication
+	case status == http.StatusForbidden:
+		return types.ErrorTypePermission
+	case status == http.StatusNotFound:
+		return types.ErrorTypeNotFound
+	case status == http.StatusTooManyRequests:
+		return types.ErrorTypeRateLimit
+	case status < 500:
+		return types.ErrorTypeInvalidRequest
+	default:
+		return types.ErrorTypeServer
+	}
+}
+
+// classifyException maps a ConverseStream exception type to an error category.
+func classifyException(exceptionType string) string {
+	switch exceptionType {
+	case "throttlingException", "serviceQuotaExceededException":
+		return types.ErrorClassRateLimit
+	case "validationException":
+		return types.ErrorClassInvalidRequest
+	case "modelTimeoutException":
+		return types.ErrorClassTimeout
+	default:
+		return types.ErrorClassServer
+	}
+}
@@ -0,0 +1,109 @@
+package bedrock
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// AWS SigV4 test suite "get-vanilla" credentials and timestamp.
+var vanillaSigner = &signer{
+	accessKeyID:     "AKIDEXAMPLE",
+	secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	region:          "us-east-1",
+	service:         "service",
+}
+
+var vanillaTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+func TestSign_GetVanilla(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	vanillaSigner.Sign(req, nil, vanillaTime)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization mismatch\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestCanonicalRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		headers       map[string]string
+		wantURI       string
+		wantQuery     string
+		wantSignedHdr string
+	}{
+		{
+			name:          "vanilla",
+			url:           "https://example.amazonaws.com/",
+			headers:       map[string]string{"X-Amz-Date": "20150830T123600Z"},
+			wantURI:       "/",
+			wantQuery:     "",
+			wantSignedHdr: "host;x-amz-date",
+		},
+		{
+			name:          "bedrock model id is double encoded",
+			url:           endpoint("us-east-1", "anthropic.claude-3-haiku-20240307-v1:0", false),
+			headers:       map[string]string{"Content-Type": "application/json", "X-Amz-Date": "20150830T123600Z"},
+			wantURI:       "/model/anthropic.claude-3-haiku-20240307-v1%253A0/converse",
+			wantQuery:     "",
+			wantSignedHdr: "content-type;host;x-amz-date",
+		},
+		{
+			name:          "query parameters sorted and encoded",
+			url:           "https://example.amazonaws.com/?b=2&a=x y",
+			headers:       map[string]string{"X-Amz-Date": "20150830T123600Z", "User-Agent": "ignored"},
+			wantURI:       "/",
+			wantQuery:     "a=x%20y&b=2",
+			wantSignedHdr: "host;x-amz-date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tt.url, nil)
+			if err != nil {
+				t.Fatalf("NewRequest: %v", err)
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			canonical, signed := canonicalRequest(req, hashHex(nil))
+			lines := strings.Split(canonical, "\n")
+
+			if lines[1] != tt.wantURI {
+				t.Errorf("canonical URI = %q, want %q", lines[1], tt.wantURI)
+			}
+			if lines[2] != tt.wantQuery {
+				t.Errorf("canonical query = %q, want %q", lines[2], tt.wantQuery)
+			}
+			if signed != tt.wantSignedHdr {
+				t.Errorf("signed headers = %q, want %q", signed, tt.wantSignedHdr)
+			}
+			if lines[len(lines)-1] != hashHex(nil) {
+				t.Errorf("payload hash = %q", lines[len(lines)-1])
+			}
+		})
+	}
+}
+
+func TestSign_SessionToken(t *testing.T) {
+	s := *vanillaSigner
+	s.sessionToken = "session-token"
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	s.Sign(req, nil, vanillaTime)
+
+	if req.Header.Get("X-Amz-Security-Token") != "session-token" {
+		t.Error("expected X-Amz-Security-Token header")
+	}
+	if !strings.Contains(req.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token") {
+		t.Errorf("session token not signed: %s", req.Header.Get("Authorization"))
+	}
+}
@@ -0,0 +1,144 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// streamState carries the per-response fields repeated on every chunk.
+type streamState struct {
+	id           string
+	model        string
+	created      int64
+	includeUsage bool
+	idleTimeout  time.Duration
+	requestID    string    // Written as a leading SSE comment when set
+	start        time.Time // Upstream call start, for TTFB
+}
+
+// handleStreamingResponse converts a ConverseStream event stream into OpenAI SSE chunks.
+// Each translated chunk is written and flushed immediately; a writer that
+// cannot flush receives the whole stream when the handler returns.
+func handleStreamingResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, st *streamState) (*types.ProxyResult, error) {
+	flusher := upstream.Flusher(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(resp.StatusCode)
+	if st.requestID != "" {
+		_, _ = w.Write(types.FormatSSERequestID(st.requestID))
+		flusher.Flush()
+	}
+
+	emit := func(data []byte) error {
+		if result.TTFB == 0 {
+			result.TTFB = time.Since(st.start)
+		}
+		if _, err := w.Write(types.FormatSSE(data)); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	var body io.Reader = resp.Body
+	if st.idleTimeout > 0 {
+		idle := upstream.NewIdleReader(resp.Body, st.idleTimeout)
+		defer idle.Stop()
+		body = idle
+	}
+
+	for {
+		event, err := readEvent(body)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, upstream.ErrIdleTimeout) {
+			result.ErrorMessage = err.Error()
+			result.ErrorType = types.ErrorClassTimeout
+			_, _ = w.Write(types.FormatSSEError(types.NewAPIError(err.Error(), types.ErrorTypeServer)))
+			flusher.Flush()
+		}
+		if err != nil {
+			result.Error = err
+			return result, err
+		}
+
+		if event.MessageType == "exception" {
+			result.ErrorMessage = event.ExceptionType + ": " + string(event.Payload)
+			result.ErrorType = classifyException(event.ExceptionType)
+			_, _ = w.Write(types.FormatSSEError(types.NewAPIError(result.ErrorMessage, types.ErrorTypeServer)))
+			flusher.Flush()
+			break
+		}
+
+		chunk := st.translateEvent(event, result)
+		if chunk == nil {
+			continue
+		}
+		data, _ := json.Marshal(chunk)
+		if err := emit(data); err != nil {
+			result.Error = err
+			return result, err
+		}
+	}
+
+	_, _ = w.Write([]byte(types.SSEDone))
+	flusher.Flush()
+	return result, nil
+}
+
+// translateEvent maps a Converse stream event to an OpenAI chunk (nil to skip).
+func (st *streamState) translateEvent(event *streamEvent, result *types.ProxyResult) *types.ChatCompletionChunk {
+	var payload struct {
+		Delta struct {
+			Text string `json:"text"`
+		} `json:"delta"`
+		StopReason string        `json:"stopReason"`
+		Usage      converseUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil
+	}
+
+	chunk := &types.ChatCompletionChunk{
+		ID:      st.id,
+		Object:  types.ObjectChatCompletionChunk,
+		Created: st.created,
+		Model:   st.model,
+	}
+
+	switch event.EventType {
+	case "messageStart":
+		chunk.Choices = []types.ChunkChoice{{Delta: types.Delta{Role: types.RoleAssistant}}}
+	case "contentBlockDelta":
+		chunk.Choices = []types.ChunkChoice{{Delta: types.Delta{Content: payload.Delta.Text}}}
+	case "messageStop":
+		reason := mapStopReason(payload.StopReason)
+		result.FinishReason = reason
+		chunk.Choices = []types.ChunkChoice{{FinishReason: &reason}}
+	case "metadata":
+		result.PromptTokens = payload.Usage.InputTokens
+		result.CompletionTokens = payload.Usage.OutputTokens
+		result.TotalTokens = payload.Usage.TotalTokens
+		if !st.includeUsage {
+			return nil
+		}
+		chunk.Choices = []types.ChunkChoice{}
+		chunk.Usage = &types.Usage{
+			PromptTokens:     payload.Usage.InputTokens,
+			CompletionTokens: payload.Usage.OutputTokens,
+			TotalTokens:      payload.Usage.TotalTokens,
+		}
+	default:
+		return nil
+	}
+	return chunk
+}
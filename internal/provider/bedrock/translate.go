@@ -0,0 +1,126 @@
+package bedrock
+
+import (
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// converseRequest is the Bedrock Converse API request body.
+type converseRequest struct {
+	Messages        []converseMessage `json:"messages"`
+	System          []contentBlock    `json:"system,omitempty"`
+	InferenceConfig *inferenceConfig  `json:"inferenceConfig,omitempty"`
+}
+
+// converseMessage is a single Converse conversation turn.
+type converseMessage struct {
+	Role    string         `json:"role"`
+	Content []contentBlock `json:"content"`
+}
+
+// contentBlock holds text content (the only block type translated today).
+type contentBlock struct {
+	Text string `json:"text"`
+}
+
+// inferenceConfig maps OpenAI sampling parameters to Converse.
+type inferenceConfig struct {
+	MaxTokens     *int     `json:"maxTokens,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"topP,omitempty"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+// converseResponse is the non-streaming Converse API response body.
+type converseResponse struct {
+	Output struct {
+		Message converseMessage `json:"message"`
+	} `json:"output"`
+	StopReason string        `json:"stopReason"`
+	Usage      converseUsage `json:"usage"`
+}
+
+// converseUsage reports Bedrock token usage.
+type converseUsage struct {
+	InputTokens  int `json:"inputTokens"`
+	OutputTokens int `json:"outputTokens"`
+	TotalTokens  int `json:"totalTokens"`
+}
+
+// toConverse translates an OpenAI chat request into a Converse request.
+// System messages become system blocks; consecutive turns with the same role
+// are merged because Converse requires alternating user/assistant turns.
+func toConverse(req *types.ChatCompletionRequest) *converseRequest {
+	out := &converseRequest{}
+
+	for _, msg := range req.Messages {
+		text := msg.Content.String()
+		if msg.Role == types.RoleSystem {
+			out.System = append(out.System, contentBlock{Text: text})
+			continue
+		}
+
+		role := types.RoleUser
+		if msg.Role == types.RoleAssistant {
+			role = types.RoleAssistant
+		}
+
+		if n := len(out.Messages); n > 0 && out.Messages[n-1].Role == role {
+			out.Messages[n-1].Content = append(out.Messages[n-1].Content, contentBlock{Text: text})
+			continue
+		}
+		out.Messages = append(out.Messages, converseMessage{Role: role, Content: []contentBlock{{Text: text}}})
+	}
+
+	cfg := &inferenceConfig{
+		Temperature:   req.Temperature,
+		TopP:          req.TopP,
+		StopSequences: req.Stop.Values,
+	}
+	if maxTokens := req.GetMaxTokens(); maxTokens > 0 {
+		cfg.MaxTokens = &maxTokens
+	}
+	if cfg.MaxTokens != nil || cfg.Temperature != nil || cfg.TopP != nil || len(cfg.StopSequences) > 0 {
+		out.InferenceConfig = cfg
+	}
+
+	return out
+}
+
+// fromConverse translates a Converse response into an OpenAI chat completion.
+func fromConverse(resp *converseResponse, id, model string, created int64) *types.ChatCompletionResponse {
+	var text string
+	for _, block := range resp.Output.Message.Content {
+		text += block.Text
+	}
+
+	return &types.ChatCompletionResponse{
+		ID:      id,
+		Object:  types.ObjectChatCompletion,
+		Created: created,
+		Model:   model,
+		Choices: []types.Choice{{
+			Index:        0,
+			Message:      types.NewTextMessage(types.RoleAssistant, text),
+			FinishReason: mapStopReason(resp.StopReason),
+		}},
+		Usage: &types.Usage{
+			PromptTokens:     resp.Usage.InputTokens,
+			CompletionTokens: resp.Usage.OutputTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}
+}
+
+// mapStopReason converts a Bedrock stop reason to an OpenAI finish reason.
+func mapStopReason(reason string) string {
+	switch reason {
+	case "max_tokens":
+		return types.FinishReasonLength
+	case "tool_use":
+		return types.FinishReasonToolCalls
+	case "content_filtered", "guardrail_intervened":
+		return types.FinishReasonContentFilter
+	default:
+		return types.FinishReasonStop
+	}
+}
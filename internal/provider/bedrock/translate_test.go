@@ -0,0 +1,115 @@
+package bedrock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestToConverse(t *testing.T) {
+	temp := 0.5
+	maxTokens := 256
+	req := &types.ChatCompletionRequest{
+		Model: "anthropic.claude-3-haiku-20240307-v1:0",
+		Messages: []types.Message{
+			types.NewTextMessage(types.RoleSystem, "be brief"),
+			types.NewTextMessage(types.RoleUser, "hello"),
+			types.NewTextMessage(types.RoleUser, "again"),
+			types.NewTextMessage(types.RoleAssistant, "hi"),
+		},
+		Temperature: &temp,
+		MaxTokens:   &maxTokens,
+		Stop:        types.Stop{Values: []string{"END"}},
+	}
+
+	out := toConverse(req)
+
+	if len(out.System) != 1 || out.System[0].Text != "be brief" {
+		t.Errorf("system = %+v", out.System)
+	}
+	if len(out.Messages) != 2 {
+		t.Fatalf("expected consecutive user turns merged into 2 messages, got %d", len(out.Messages))
+	}
+	if out.Messages[0].Role != "user" || len(out.Messages[0].Content) != 2 {
+		t.Errorf("first message = %+v", out.Messages[0])
+	}
+	if out.Messages[1].Role != "assistant" || out.Messages[1].Content[0].Text != "hi" {
+		t.Errorf("second message = %+v", out.Messages[1])
+	}
+
+	cfg := out.InferenceConfig
+	if cfg == nil || *cfg.MaxTokens != 256 || *cfg.Temperature != 0.5 || cfg.StopSequences[0] != "END" {
+		t.Errorf("inference config = %+v", cfg)
+	}
+}
+
+func TestFromConverse(t *testing.T) {
+	raw := `{"output":{"message":{"role":"assistant","content":[{"text":"Hello"},{"text":" world"}]}},
+		"stopReason":"max_tokens","usage":{"inputTokens":12,"outputTokens":5,"totalTokens":17}}`
+
+	var resp converseResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	out := fromConverse(&resp, "chatcmpl-1", "model-x", 100)
+
+	if out.Choices[0].Message.Content.Text != "Hello world" {
+		t.Errorf("content = %q", out.Choices[0].Message.Content.Text)
+	}
+	if out.Choices[0].FinishReason != types.FinishReasonLength {
+		t.Errorf("finish reason = %q", out.Choices[0].FinishReason)
+	}
+	if out.Usage.PromptTokens != 12 || out.Usage.CompletionTokens != 5 || out.Usage.TotalTokens != 17 {
+		t.Errorf("usage = %+v", out.Usage)
+	}
+}
+
+// encodeEvent builds a binary event stream frame with string headers.
+func encodeEvent(eventType string, payload []byte) []byte {
+	var headers bytes.Buffer
+	for _, h := range [][2]string{{":event-type", eventType}, {":message-type", "event"}} {
+		headers.WriteByte(byte(len(h[0])))
+		headers.WriteString(h[0])
+		headers.WriteByte(7)
+		_ = binary.Write(&headers, binary.BigEndian, uint16(len(h[1])))
+		headers.WriteString(h[1])
+	}
+
+	total := uint32(12 + headers.Len() + len(payload) + 4)
+	var frame bytes.Buffer
+	_ = binary.Write(&frame, binary.BigEndian, total)
+	_ = binary.Write(&frame, binary.BigEndian, uint32(headers.Len()))
+	_ = binary.Write(&frame, binary.BigEndian, crc32.ChecksumIEEE(frame.Bytes()))
+	frame.Write(headers.Bytes())
+	frame.Write(payload)
+	_ = binary.Write(&frame, binary.BigEndian, crc32.ChecksumIEEE(frame.Bytes()))
+	return frame.Bytes()
+}
+
+func TestReadEvent(t *testing.T) {
+	frame := encodeEvent("contentBlockDelta", []byte(`{"delta":{"text":"Hi"}}`))
+
+	event, err := readEvent(bytes.NewReader(frame))
+	if err != nil {
+		t.Fatalf("readEvent: %v", err)
+	}
+	if event.EventType != "contentBlockDelta" || event.MessageType != "event" {
+		t.Errorf("headers = %+v", event)
+	}
+
+	st := &streamState{id: "chatcmpl-1", model: "m"}
+	chunk := st.translateEvent(event, &types.ProxyResult{})
+	if chunk == nil || chunk.Choices[0].Delta.Content != "Hi" {
+		t.Errorf("chunk = %+v", chunk)
+	}
+
+	frame[len(frame)-1] ^= 0xFF
+	if _, err := readEvent(bytes.NewReader(frame)); err != errMalformedEvent {
+		t.Errorf("expected CRC failure, got %v", err)
+	}
+}
@@ -57,6 +57,12 @@ func (r *CredentialResolver) Resolve(credentialName string) (*models.Credential,
 	return cred, nil
 }
 
+// ResolveID returns a credential by ID, bypassing the cache.
+// Used for admin credential overrides, which should always see current data.
+func (r *CredentialResolver) ResolveID(id string) (*models.Credential, error) {
+	return r.storage.GetCredential(id)
+}
+
 // Invalidate removes a cached credential (call after credential update).
 func (r *CredentialResolver) Invalidate(credentialName string) {
 	r.mu.Lock()
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	alertBuckets     = 10 // Buckets per window; the window slides one bucket at a time
+	alertMinRequests = 10 // Outcomes needed in the window before the rate is judged
+)
+
+// ErrorRateEvent describes a provider whose failure rate reached the threshold.
+type ErrorRateEvent struct {
+	Provider string
+	Rate     float64 // Failures / Requests over Window
+	Failures int
+	Requests int
+	Window   time.Duration
+}
+
+// ErrorRateAlert watches each provider's failure rate over a rolling window.
+// It fires once when the rate reaches the threshold and re-arms when the rate
+// falls back below it. Failures are counted as the HealthTracker counts them.
+type ErrorRateAlert struct {
+	threshold float64 // Failure fraction that fires the alert
+	window    time.Duration
+	notify    func(ErrorRateEvent) // Called after the warning is logged (nil = log only)
+	now       func() time.Time
+
+	mu        sync.Mutex
+	providers map[string]*alertWindow
+}
+
+// alertWindow counts outcomes in time buckets of window/alertBuckets.
+type alertWindow struct {
+	buckets [alertBuckets]alertBucket
+	firing  bool
+}
+
+type alertBucket struct {
+	slot               int64 // Bucket number since the epoch; stale buckets are reset
+	requests, failures int
+}
+
+// NewErrorRateAlert returns an alert that fires when a provider's failure rate
+// over window reaches percent. A non-positive percent or window disables it (nil).
+func NewErrorRateAlert(percent int, window time.Duration, notify func(ErrorRateEvent)) *ErrorRateAlert {
+	if percent <= 0 || window <= 0 {
+		return nil
+	}
+	return &ErrorRateAlert{
+		threshold: float64(min(percent, 100)) / 100,
+		window:    window,
+		notify:    notify,
+		now:       time.Now,
+		providers: make(map[string]*alertWindow),
+	}
+}
+
+// Record adds one outcome for provider and fires the alert on crossing.
+// A nil alert ignores outcomes.
+func (a *ErrorRateAlert) Record(provider string, failed bool) {
+	if a == nil {
+		return
+	}
+	width := int64(a.window / alertBuckets)
+	slot := a.now().UnixNano() / max(width, 1)
+
+	a.mu.Lock()
+	w, ok := a.providers[provider]
+	if !ok {
+		w = &alertWindow{}
+		a.providers[provider] = w
+	}
+	b := &w.buckets[slot%alertBuckets]
+	if b.slot != slot {
+		*b = alertBucket{slot: slot}
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+
+	event := ErrorRateEvent{Provider: provider, Window: a.window}
+	for _, b := range w.buckets {
+		if slot-b.slot < alertBuckets {
+			event.Requests += b.requests
+			event.Failures += b.failures
+		}
+	}
+	event.Rate = float64(event.Failures) / float64(event.Requests)
+
+	above := event.Requests >= alertMinRequests && event.Rate >= a.threshold
+	fire, recovered := above && !w.firing, !above && w.firing && event.Rate < a.threshold
+	if fire || recovered {
+		w.firing = fire
+	}
+	a.mu.Unlock()
+
+	switch {
+	case fire:
+		slog.Warn("provider error rate above alert threshold",
+			"provider", provider,
+			"error_rate", event.Rate,
+			"failures", event.Failures,
+			"requests", event.Requests,
+			"window", a.window,
+		)
+		if a.notify != nil {
+			a.notify(event)
+		}
+	case recovered:
+		slog.Info("provider error rate back below alert threshold", "provider", provider, "error_rate", event.Rate)
+	}
+}
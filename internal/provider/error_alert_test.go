@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// outcome is one seeded proxy result, optionally advancing the clock first.
+type outcome struct {
+	status  int
+	err     error
+	advance time.Duration
+}
+
+func repeat(n int, o outcome) []outcome {
+	out := make([]outcome, n)
+	for i := range out {
+		out[i] = o
+	}
+	return out
+}
+
+func TestErrorRateAlert(t *testing.T) {
+	ok := outcome{status: http.StatusOK}
+	fail := outcome{status: http.StatusBadGateway}
+
+	tests := []struct {
+		name     string
+		outcomes []outcome
+		want     int // Alerts fired
+	}{
+		{"seeded failures cross threshold", append(repeat(5, ok), repeat(5, fail)...), 1},
+		{"fires once while above", append(repeat(10, fail), repeat(10, fail)...), 1},
+		{"below threshold", append(repeat(8, ok), repeat(2, fail)...), 0},
+		{"too few requests", repeat(alertMinRequests-1, fail), 0},
+		{"transport errors count", repeat(10, outcome{err: errors.New("connection refused")}), 1},
+		{"client errors ignored", repeat(10, outcome{status: http.StatusBadRequest}), 0},
+		{"old failures leave the window", append(repeat(6, fail), append([]outcome{{status: http.StatusBadGateway, advance: 2 * time.Minute}}, append(repeat(3, fail), repeat(6, ok)...)...)...), 0},
+		{"re-arms after recovery", append(append(repeat(10, fail), repeat(20, ok)...), repeat(30, fail)...), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []ErrorRateEvent
+			alert := NewErrorRateAlert(50, time.Minute, func(e ErrorRateEvent) { events = append(events, e) })
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			alert.now = func() time.Time { return now }
+			tracker := NewHealthTracker().WithAlert(alert)
+
+			for _, o := range tt.outcomes {
+				now = now.Add(o.advance)
+				var result *types.ProxyResult
+				if o.err == nil {
+					result = &types.ProxyResult{StatusCode: o.status}
+				}
+				tracker.Record("openrouter", result, o.err)
+			}
+
+			if len(events) != tt.want {
+				t.Fatalf("alerts = %d, want %d (%+v)", len(events), tt.want, events)
+			}
+			for _, e := range events {
+				if e.Provider != "openrouter" || e.Rate < 0.5 || e.Requests < alertMinRequests || e.Window != time.Minute {
+					t.Errorf("event = %+v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestNewErrorRateAlertDisabled(t *testing.T) {
+	if NewErrorRateAlert(0, time.Minute, nil) != nil || NewErrorRateAlert(50, 0, nil) != nil {
+		t.Fatal("expected nil alert when disabled")
+	}
+	var alert *ErrorRateAlert
+	alert.Record("openrouter", true) // Must not panic
+}
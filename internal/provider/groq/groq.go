@@ -0,0 +1,27 @@
+// Package groq implements the Groq LLM provider.
+// Groq serves an OpenAI-compatible API, so requests go through the
+// OpenRouter client pointed at Groq's endpoint.
+package groq
+
+import (
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+)
+
+// defaultBaseURL is the Groq chat completions endpoint.
+const defaultBaseURL = "https://api.groq.com/openai/v1/chat/completions"
+
+// New creates a Groq provider instance.
+// API key is resolved per-request from storage via ProxyOptions.
+func New() *openrouter.Provider {
+	return NewWithPool("", upstream.DefaultPool())
+}
+
+// NewWithPool creates a Groq provider whose upstream connections are pooled
+// per pool. An empty baseURL selects the Groq endpoint.
+func NewWithPool(baseURL string, pool upstream.PoolConfig) *openrouter.Provider {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return openrouter.NewCompatible("groq", baseURL, pool, nil)
+}
@@ -0,0 +1,97 @@
+package groq
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		response    string
+		streaming   bool
+	}{
+		{
+			name:        "json usage",
+			contentType: "application/json",
+			response:    `{"model":"llama-3.1-8b-instant","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`,
+		},
+		{
+			name:        "stream usage in x_groq",
+			contentType: "text/event-stream",
+			response: "data: {\"model\":\"llama-3.1-8b-instant\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+				"data: {\"model\":\"llama-3.1-8b-instant\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}],\"x_groq\":{\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":1,\"total_tokens\":6}}}\n\n" +
+				"data: [DONE]\n\n",
+			streaming: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Clone(context.Background())
+				body, _ := io.ReadAll(r.Body)
+				gotBody = string(body)
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			p := NewWithPool(server.URL, upstream.DefaultPool())
+			opts := &types.ProxyOptions{
+				Model:       "llama-3.1-8b-instant",
+				IsStreaming: tt.streaming,
+				Credential:  &models.Credential{Provider: "groq", Data: []byte(`{"api_key":"gsk_test"}`)},
+				Body:        strings.NewReader(`{"model":"fast","messages":[{"role":"user","content":"hi"}]}`),
+			}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set(upstream.OrganizationHeader, "org-client")
+
+			result, err := p.ProxyRequest(context.Background(), rec, req, opts)
+			if err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+			if p.Name() != "groq" {
+				t.Errorf("Name() = %q, want groq", p.Name())
+			}
+			if auth := got.Header.Get("Authorization"); auth != "Bearer gsk_test" {
+				t.Errorf("Authorization = %q", auth)
+			}
+			for _, name := range []string{"HTTP-Referer", "X-Title", upstream.OrganizationHeader} {
+				if v := got.Header.Get(name); v != "" {
+					t.Errorf("%s sent to Groq: %q", name, v)
+				}
+			}
+			if !strings.Contains(gotBody, `"model":"llama-3.1-8b-instant"`) {
+				t.Errorf("model not rewritten: %s", gotBody)
+			}
+			if result.StatusCode != http.StatusOK || result.IsStreaming != tt.streaming {
+				t.Errorf("status = %d, streaming = %v", result.StatusCode, result.IsStreaming)
+			}
+			if result.PromptTokens != 5 || result.CompletionTokens != 1 || result.TotalTokens != 6 {
+				t.Errorf("usage = %d/%d/%d, want 5/1/6", result.PromptTokens, result.CompletionTokens, result.TotalTokens)
+			}
+			if rec.Body.String() != tt.response {
+				t.Errorf("client body altered: %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNew_DefaultBaseURL(t *testing.T) {
+	if got := New().BaseURL(); got != defaultBaseURL {
+		t.Errorf("BaseURL() = %q, want %q", got, defaultBaseURL)
+	}
+}
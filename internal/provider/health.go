@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// Provider health states reported by HealthTracker.
+const (
+	HealthStateHealthy  = "healthy"  // No failures in the recent window
+	HealthStateDegraded = "degraded" // Some recent failures
+	HealthStateFailing  = "failing"  // failingThreshold consecutive failures
+)
+
+const (
+	healthWindow     = 50 // Recent outcomes kept per provider
+	failingThreshold = 5
+)
+
+// ProviderHealth is a point-in-time view of a provider's recent outcomes.
+type ProviderHealth struct {
+	State       string     `json:"state"`
+	Requests    int        `json:"recent_requests"`
+	SuccessRate float64    `json:"success_rate"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// HealthTracker records upstream outcomes per provider passively.
+// Only server errors, rate limits, timeouts and transport failures count as failures;
+// client mistakes (other 4xx) say nothing about provider health.
+type HealthTracker struct {
+	mu        sync.Mutex
+	providers map[string]*providerOutcomes
+	alert     *ErrorRateAlert // Optional error-rate alert (nil = disabled)
+}
+
+type providerOutcomes struct {
+	window      [healthWindow]bool // true = success
+	next, count int
+	consecutive int
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// NewHealthTracker creates an empty tracker.
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{providers: make(map[string]*providerOutcomes)}
+}
+
+// WithAlert feeds every recorded outcome to alert as well and returns t.
+func (t *HealthTracker) WithAlert(alert *ErrorRateAlert) *HealthTracker {
+	t.alert = alert
+	return t
+}
+
+// Record stores the outcome of a proxied request for a provider.
+func (t *HealthTracker) Record(provider string, result *types.ProxyResult, err error) {
+	failed, message := isProviderFailure(result, err)
+	t.alert.Record(provider, failed)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.providers[provider]
+	if !ok {
+		o = &providerOutcomes{}
+		t.providers[provider] = o
+	}
+	o.window[o.next] = !failed
+	o.next = (o.next + 1) % healthWindow
+	o.count = min(o.count+1, healthWindow)
+
+	if !failed {
+		o.consecutive = 0
+		return
+	}
+	o.consecutive++
+	o.lastError = message
+	o.lastErrorAt = time.Now()
+}
+
+// Snapshot returns the current health of a provider (healthy with no data if unseen).
+// A nil tracker reports every provider as unseen.
+func (t *HealthTracker) Snapshot(provider string) ProviderHealth {
+	if t == nil {
+		return ProviderHealth{State: HealthStateHealthy, SuccessRate: 1}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.providers[provider]
+	if !ok || o.count == 0 {
+		return ProviderHealth{State: HealthStateHealthy, SuccessRate: 1}
+	}
+
+	successes := 0
+	for i := 0; i < o.count; i++ {
+		if o.window[i] {
+			successes++
+		}
+	}
+	h := ProviderHealth{
+		State:       HealthStateHealthy,
+		Requests:    o.count,
+		SuccessRate: float64(successes) / float64(o.count),
+		LastError:   o.lastError,
+	}
+	if !o.lastErrorAt.IsZero() {
+		at := o.lastErrorAt
+		h.LastErrorAt = &at
+	}
+	switch {
+	case o.consecutive >= failingThreshold:
+		h.State = HealthStateFailing
+	case successes < o.count:
+		h.State = HealthStateDegraded
+	}
+	return h
+}
+
+// isProviderFailure reports whether an outcome reflects on provider health.
+func isProviderFailure(result *types.ProxyResult, err error) (bool, string) {
+	if result == nil {
+		return err != nil, errString(err)
+	}
+	if result.StatusCode >= 500 || result.StatusCode == http.StatusTooManyRequests ||
+		result.ErrorType == types.ErrorClassTimeout {
+		switch {
+		case result.ErrorMessage != "":
+			return true, result.ErrorMessage
+		case result.Error != nil:
+			return true, result.Error.Error()
+		}
+		return true, errString(err)
+	}
+	return false, ""
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// modelTransform rewrites upstream model names for one provider instance,
+// so an alias written for one naming scheme (e.g. "openai/gpt-4o") also
+// works on a provider that expects another ("gpt-4o").
+type modelTransform struct {
+	Provider
+	strip string // Prefix removed first, if present
+	add   string // Prefix added after, unless already present
+}
+
+// withModelTransform wraps p when def configures a model name transform.
+func withModelTransform(p Provider, def config.ProviderDef) Provider {
+	if def.StripModelPrefix == "" && def.AddModelPrefix == "" {
+		return p
+	}
+	return &modelTransform{Provider: p, strip: def.StripModelPrefix, add: def.AddModelPrefix}
+}
+
+// apply returns the upstream name for model.
+func (t *modelTransform) apply(model string) string {
+	model = strings.TrimPrefix(model, t.strip)
+	if !strings.HasPrefix(model, t.add) {
+		model = t.add + model
+	}
+	return model
+}
+
+// ProxyRequest forwards the request with the transformed model name.
+func (t *modelTransform) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	opts.Model = t.apply(opts.Model)
+	return t.Provider.ProxyRequest(ctx, w, req, opts)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestModelTransform(t *testing.T) {
+	tests := []struct {
+		name  string
+		def   config.ProviderDef
+		model string
+		want  string
+	}{
+		{"no transform", config.ProviderDef{}, "openai/gpt-4o", "openai/gpt-4o"},
+		{"strip prefix", config.ProviderDef{StripModelPrefix: "openai/"}, "openai/gpt-4o", "gpt-4o"},
+		{"strip absent prefix", config.ProviderDef{StripModelPrefix: "openai/"}, "gpt-4o", "gpt-4o"},
+		{"add prefix", config.ProviderDef{AddModelPrefix: "openai/"}, "gpt-4o", "openai/gpt-4o"},
+		{"add present prefix", config.ProviderDef{AddModelPrefix: "openai/"}, "openai/gpt-4o", "openai/gpt-4o"},
+		{"strip then add", config.ProviderDef{StripModelPrefix: "openai/", AddModelPrefix: "azure/"}, "openai/gpt-4o", "azure/gpt-4o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockProvider{name: "openrouter"}
+			p := withModelTransform(mock, tt.def)
+			if p.Name() != "openrouter" {
+				t.Errorf("Name() = %q, want the wrapped provider's name", p.Name())
+			}
+
+			opts := &types.ProxyOptions{Model: tt.model}
+			if _, err := p.ProxyRequest(context.Background(), httptest.NewRecorder(), httptest.NewRequest("POST", "/v1/chat/completions", nil), opts); err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+			if mock.lastModel != tt.want {
+				t.Errorf("forwarded model = %q, want %q", mock.lastModel, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouter_ModelTransformPerProvider(t *testing.T) {
+	hosted := &mockProvider{name: "openrouter"}
+	direct := &mockProvider{name: "openrouter"}
+	cfg := &config.Config{Models: []config.ModelAlias{
+		{Slug: "gpt4", Provider: "hosted", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+		{Slug: "gpt4", Provider: "direct", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+	}}
+	router := NewRouter(map[string]types.Provider{
+		"hosted": hosted,
+		"direct": withModelTransform(direct, config.ProviderDef{StripModelPrefix: "openai/"}),
+	}, cfg, newTestStore(t))
+
+	for hint, want := range map[string]struct {
+		mock  *mockProvider
+		model string
+	}{"hosted": {hosted, "openai/gpt-4o"}, "direct": {direct, "gpt-4o"}} {
+		req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+		req.Header.Set(ProviderHintHeader, hint)
+		if _, err := router.ProxyRequest(context.Background(), httptest.NewRecorder(), req, &types.ProxyOptions{Model: "gpt4"}); err != nil {
+			t.Fatalf("%s: ProxyRequest: %v", hint, err)
+		}
+		if want.mock.lastModel != want.model {
+			t.Errorf("%s received %q, want %q", hint, want.mock.lastModel, want.model)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package openai implements the OpenAI LLM provider.
+// Requests go through the OpenRouter client (OpenAI's API is the one it
+// mirrors) with the OpenAI account headers forwarded.
+package openai
+
+import (
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+)
+
+// defaultBaseURL is the OpenAI chat completions endpoint.
+const defaultBaseURL = "https://api.openai.com/v1/chat/completions"
+
+// New creates an OpenAI provider instance.
+// API key is resolved per-request from storage via ProxyOptions.
+func New() *openrouter.Provider {
+	return NewWithPool("", upstream.DefaultPool())
+}
+
+// NewWithPool creates an OpenAI provider whose upstream connections are pooled
+// per pool. An empty baseURL selects the OpenAI endpoint. The credential's
+// organization and project set the account headers, falling back to the
+// client's own.
+func NewWithPool(baseURL string, pool upstream.PoolConfig) *openrouter.Provider {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return openrouter.NewCompatible("openai", baseURL, pool, nil).WithAccountHeaders()
+}
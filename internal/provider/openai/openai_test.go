@@ -0,0 +1,70 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_AccountHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		credData    string
+		wantOrg     string
+		wantProject string
+	}{
+		{"forwards client headers", `{"api_key":"sk"}`, "org-client", ""},
+		{"credential wins", `{"api_key":"sk","organization":"org-cred","project":"proj-cred"}`, "org-cred", "proj-cred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"model":"gpt-4o","choices":[]}`))
+			}))
+			defer server.Close()
+
+			p := NewWithPool(server.URL, upstream.DefaultPool())
+			opts := &types.ProxyOptions{
+				Model:      "gpt-4o",
+				Credential: &models.Credential{Provider: "openai", Data: []byte(tt.credData)},
+				Body:       strings.NewReader(`{"model":"gpt-4o","messages":[]}`),
+			}
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set(upstream.OrganizationHeader, "org-client")
+
+			if _, err := p.ProxyRequest(context.Background(), httptest.NewRecorder(), req, opts); err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+			if p.Name() != "openai" {
+				t.Errorf("Name() = %q, want openai", p.Name())
+			}
+			if v := got.Get(upstream.OrganizationHeader); v != tt.wantOrg {
+				t.Errorf("%s = %q, want %q", upstream.OrganizationHeader, v, tt.wantOrg)
+			}
+			if v := got.Get(upstream.ProjectHeader); v != tt.wantProject {
+				t.Errorf("%s = %q, want %q", upstream.ProjectHeader, v, tt.wantProject)
+			}
+			for _, name := range []string{"HTTP-Referer", "X-Title"} {
+				if v := got.Get(name); v != "" {
+					t.Errorf("%s sent to OpenAI: %q", name, v)
+				}
+			}
+		})
+	}
+}
+
+func TestNew_DefaultBaseURL(t *testing.T) {
+	if got := New().BaseURL(); got != defaultBaseURL {
+		t.Errorf("BaseURL() = %q, want %q", got, defaultBaseURL)
+	}
+}
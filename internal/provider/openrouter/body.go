@@ -7,6 +7,9 @@ import (
 )
 
 // rewriteModelInBody reads the request body and replaces the model field with the resolved model.
+// Only the model field is replaced; other fields keep their original bytes, so
+// fields the gateway does not model (parallel_tool_calls, service_tier, metadata,
+// large integer seeds, ...) reach the upstream unmodified.
 func rewriteModelInBody(optsBody io.Reader, reqBody io.Reader, resolvedModel string) (io.Reader, error) {
 	var body io.Reader = reqBody
 	if optsBody != nil {
@@ -18,14 +21,17 @@ func rewriteModelInBody(optsBody io.Reader, reqBody io.Reader, resolvedModel str
 		return nil, err
 	}
 
-	var payload map[string]any
-	if err := json.Unmarshal(bodyBytes, &payload); err != nil {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &fields); err != nil {
 		return nil, err
 	}
 
-	payload["model"] = resolvedModel
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	fields["model"], _ = json.Marshal(resolvedModel)
 
-	rewritten, err := json.Marshal(payload)
+	rewritten, err := json.Marshal(fields)
 	if err != nil {
 		return nil, err
 	}
@@ -1,127 +1,67 @@
 // Package openrouter implements the OpenRouter LLM provider.
+// Its client also serves other OpenAI-compatible upstreams (see NewCompatible).
 package openrouter
 
 import (
 	"context"
 	"net/http"
-	"strings"
-	"time"
 
-	"github.com/mandalnilabja/goatway/internal/types"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
 )
 
+// defaultBaseURL is the OpenRouter chat completions endpoint.
+const defaultBaseURL = "https://openrouter.ai/api/v1/chat/completions"
+
 // Provider implements the provider.Provider interface for OpenRouter.
 // API key is resolved per-request from storage, not stored on the provider.
-type Provider struct{}
+type Provider struct {
+	name    string
+	baseURL string
+	client  *http.Client        // Shared so upstream connections are reused
+	prepare func(h http.Header) // Upstream-specific headers (nil adds none)
+
+	accountHeaders bool // Send the OpenAI account headers rather than strip them
+}
 
 // New creates a new OpenRouter provider instance.
 // API key is resolved per-request from storage via ProxyOptions.
 func New() *Provider {
-	return &Provider{}
+	return NewWithPool("", upstream.DefaultPool())
+}
+
+// NewWithBaseURL creates an OpenRouter provider that sends requests to baseURL
+// (e.g. a self-hosted OpenRouter-compatible gateway).
+func NewWithBaseURL(baseURL string) *Provider {
+	return NewWithPool(baseURL, upstream.DefaultPool())
+}
+
+// NewWithPool creates an OpenRouter provider whose upstream connections are
+// pooled per pool. An empty baseURL selects the OpenRouter endpoint.
+func NewWithPool(baseURL string, pool upstream.PoolConfig) *Provider {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return NewCompatible("openrouter", baseURL, pool, setAttributionHeaders)
 }
 
 // Name returns the provider identifier
 func (p *Provider) Name() string {
-	return "openrouter"
+	return p.name
 }
 
 // BaseURL returns the OpenRouter API endpoint
 func (p *Provider) BaseURL() string {
-	return "https://openrouter.ai/api/v1/chat/completions"
+	return p.baseURL
 }
 
-// PrepareRequest adds OpenRouter-specific headers to the request
+// PrepareRequest adds the upstream-specific headers to the request.
+// OpenAI account headers are dropped unless the upstream accepts them.
 func (p *Provider) PrepareRequest(ctx context.Context, req *http.Request) error {
-	req.Header.Set("HTTP-Referer", "https://github.com/mandalnilabja/goatway")
-	req.Header.Set("X-Title", "Goatway Proxy")
-	return nil
-}
-
-// ProxyRequest handles the proxy to OpenRouter with result tracking.
-// CRITICAL: Maintains streaming semantics with no buffering.
-func (p *Provider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
-	startTime := time.Now()
-	result := &types.ProxyResult{
-		Model:        opts.Model,
-		PromptTokens: opts.PromptTokens,
-		IsStreaming:  opts.IsStreaming,
-	}
-
-	// API key must be provided via credential (resolved by Router)
-	if opts.Credential == nil {
-		result.Error = types.ErrNoAPIKey
-		result.StatusCode = http.StatusUnauthorized
-		http.Error(w, "No credential configured", http.StatusUnauthorized)
-		return result, types.ErrNoAPIKey
-	}
-	apiKey := opts.Credential.GetAPIKey()
-
-	// Read and rewrite body with resolved model name
-	body, err := rewriteModelInBody(opts.Body, req.Body, opts.Model)
-	if err != nil {
-		result.Error = err
-		result.StatusCode = http.StatusBadRequest
-		http.Error(w, "Failed to process request body", http.StatusBadRequest)
-		return result, err
+	if !p.accountHeaders {
+		upstream.StripOpenAIAccountHeaders(req.Header)
 	}
-
-	// Create upstream request
-	upstreamReq, err := http.NewRequestWithContext(ctx, req.Method, p.BaseURL(), body)
-	if err != nil {
-		result.Error = err
-		result.StatusCode = http.StatusInternalServerError
-		http.Error(w, "Failed to create request", http.StatusInternalServerError)
-		return result, err
+	if p.prepare != nil {
+		p.prepare(req.Header)
 	}
-
-	// Copy headers (skip hop-by-hop)
-	for k, v := range req.Header {
-		if k == "Content-Length" || k == "Connection" || k == "Host" || k == "Authorization" {
-			continue
-		}
-		upstreamReq.Header[k] = v
-	}
-
-	// Set authorization with the resolved API key
-	upstreamReq.Header.Set("Authorization", "Bearer "+apiKey)
-
-	// Add provider-specific headers
-	if err := p.PrepareRequest(ctx, upstreamReq); err != nil {
-		result.Error = err
-		result.StatusCode = http.StatusInternalServerError
-		http.Error(w, "Failed to prepare request", http.StatusInternalServerError)
-		return result, err
-	}
-
-	// Setup client (DisableCompression required for streaming)
-	client := &http.Client{
-		Transport: &http.Transport{
-			DisableCompression: true,
-		},
-	}
-
-	// Execute request
-	resp, err := client.Do(upstreamReq)
-	if err != nil {
-		result.Error = err
-		result.StatusCode = http.StatusBadGateway
-		http.Error(w, "Bad Gateway: "+err.Error(), http.StatusBadGateway)
-		return result, err
-	}
-	defer resp.Body.Close()
-
-	result.StatusCode = resp.StatusCode
-	result.Duration = time.Since(startTime)
-
-	// Handle error responses
-	if resp.StatusCode >= 400 {
-		return handleErrorResponse(w, resp, result)
-	}
-
-	// Route based on content type
-	contentType := resp.Header.Get("Content-Type")
-	if strings.Contains(contentType, "text/event-stream") {
-		return handleStreamingResponse(w, resp, result)
-	}
-	return handleJSONResponse(w, resp, result)
+	return nil
 }
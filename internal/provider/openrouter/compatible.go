@@ -0,0 +1,29 @@
+package openrouter
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+)
+
+// NewCompatible creates a provider named name for another OpenAI-compatible
+// chat completions endpoint at baseURL. Requests use Bearer auth from the
+// resolved credential and get OpenRouter's streaming, usage and error
+// handling; prepare, when non-nil, adds upstream-specific headers.
+func NewCompatible(name, baseURL string, pool upstream.PoolConfig, prepare func(h http.Header)) *Provider {
+	return &Provider{name: name, baseURL: baseURL, client: upstream.NewClient(pool), prepare: prepare}
+}
+
+// WithAccountHeaders makes p send the OpenAI account headers (see
+// upstream.SetOpenAIAccountHeaders) instead of stripping them, for upstreams
+// that bill by organization and project. It returns p.
+func (p *Provider) WithAccountHeaders() *Provider {
+	p.accountHeaders = true
+	return p
+}
+
+// setAttributionHeaders identifies the gateway to OpenRouter's app rankings.
+func setAttributionHeaders(h http.Header) {
+	h.Set("HTTP-Referer", "https://github.com/mandalnilabja/goatway")
+	h.Set("X-Title", "Goatway Proxy")
+}
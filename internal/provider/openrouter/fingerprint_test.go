@@ -0,0 +1,66 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestSystemFingerprintHeader(t *testing.T) {
+	jsonBody := `{"id":"c1","model":"m","system_fingerprint":"fp_44709d6fcb","choices":[]}`
+	sseBody := `data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"}}]}` + "\n\n" +
+		`data: {"model":"m","system_fingerprint":"fp_44709d6fcb","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	tests := []struct {
+		name   string
+		stream bool
+		seeded bool
+		want   string
+	}{
+		{"JSON with seed", false, true, "fp_44709d6fcb"},
+		{"JSON without seed", false, false, ""},
+		{"stream with seed", true, true, "fp_44709d6fcb"},
+		{"stream without seed", true, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body := "application/json", jsonBody
+			if tt.stream {
+				contentType, body = "text/event-stream", sseBody
+			}
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{contentType}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}
+			rec := httptest.NewRecorder()
+			opts := &types.ProxyOptions{Seeded: tt.seeded}
+
+			var err error
+			if tt.stream {
+				_, err = handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), opts)
+			} else {
+				_, err = handleJSONResponse(rec, resp, &types.ProxyResult{}, time.Now(), opts)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Streams send the fingerprint as a trailer once the chunk carrying it arrives
+			got := rec.Result().Header.Get(types.SystemFingerprintHeader)
+			if tt.stream {
+				got = rec.Result().Trailer.Get(types.SystemFingerprintHeader)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %q, want %q", types.SystemFingerprintHeader, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_StripsOpenAIAccountHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"m","choices":[]}`))
+	}))
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set(upstream.OrganizationHeader, "org-client")
+	req.Header.Set(upstream.ProjectHeader, "proj-client")
+	req.Header.Set("X-Custom", "kept")
+	opts := &types.ProxyOptions{
+		Model:      "m",
+		Credential: &models.Credential{Provider: "openrouter", Data: []byte(`{"api_key":"sk","organization":"org-cred"}`)},
+		Body:       strings.NewReader(`{"model":"m","messages":[]}`),
+	}
+
+	if _, err := NewWithBaseURL(server.URL).ProxyRequest(context.Background(), httptest.NewRecorder(), req, opts); err != nil {
+		t.Fatalf("ProxyRequest: %v", err)
+	}
+	for _, name := range []string{upstream.OrganizationHeader, upstream.ProjectHeader} {
+		if v := got.Get(name); v != "" {
+			t.Errorf("%s forwarded to OpenRouter: %q", name, v)
+		}
+	}
+	if got.Get("X-Custom") != "kept" {
+		t.Error("unrelated client header dropped")
+	}
+}
+
+func TestProxyRequest_StripsHopByHopHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Keep-Alive", "timeout=5")
+		_, _ = w.Write([]byte(`{"model":"m","choices":[]}`))
+	}))
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	for _, name := range []string{"Keep-Alive", "Proxy-Authorization", "TE", "Upgrade", "X-Secret", "X-Custom"} {
+		req.Header.Set(name, "v")
+	}
+	opts := &types.ProxyOptions{
+		Model:        "m",
+		Credential:   &models.Credential{Provider: "openrouter", Data: []byte(`{"api_key":"sk"}`)},
+		Body:         strings.NewReader(`{"model":"m","messages":[]}`),
+		StripHeaders: []string{"X-Secret"},
+	}
+
+	rec := httptest.NewRecorder()
+	if _, err := NewWithBaseURL(server.URL).ProxyRequest(context.Background(), rec, req, opts); err != nil {
+		t.Fatalf("ProxyRequest: %v", err)
+	}
+	for _, name := range []string{"Keep-Alive", "Proxy-Authorization", "TE", "Upgrade", "X-Secret"} {
+		if v := got.Get(name); v != "" {
+			t.Errorf("%s forwarded to upstream: %q", name, v)
+		}
+	}
+	if got.Get("X-Custom") != "v" {
+		t.Error("end-to-end client header dropped")
+	}
+	if v := rec.Header().Get("Keep-Alive"); v != "" {
+		t.Errorf("upstream Keep-Alive forwarded to client: %q", v)
+	}
+}
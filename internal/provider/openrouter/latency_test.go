@@ -0,0 +1,54 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_RecordsStreamingTTFB(t *testing.T) {
+	const firstByteDelay = 40 * time.Millisecond
+	const tailDelay = 40 * time.Millisecond
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		time.Sleep(firstByteDelay)
+		_, _ = w.Write([]byte(`data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"}}]}` + "\n\n"))
+		w.(http.Flusher).Flush()
+
+		time.Sleep(tailDelay)
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer upstream.Close()
+
+	data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+	opts := &types.ProxyOptions{
+		Model:       "m",
+		IsStreaming: true,
+		Credential:  &models.Credential{Provider: "openrouter", Data: data},
+		Body:        strings.NewReader(`{"model":"m","stream":true,"messages":[]}`),
+	}
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+	result, err := NewWithBaseURL(upstream.URL).ProxyRequest(context.Background(), httptest.NewRecorder(), req, opts)
+	if err != nil {
+		t.Fatalf("ProxyRequest: %v", err)
+	}
+
+	if result.TTFB < firstByteDelay {
+		t.Errorf("TTFB = %v, want >= %v", result.TTFB, firstByteDelay)
+	}
+	if result.UpstreamDuration < result.TTFB+tailDelay {
+		t.Errorf("UpstreamDuration = %v, want >= TTFB (%v) + %v", result.UpstreamDuration, result.TTFB, tailDelay)
+	}
+}
@@ -0,0 +1,68 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// topLogprobs returns an OpenAI-style logprobs entry with n alternatives.
+func topLogprobs(n int) string {
+	alts := make([]string, n)
+	for i := range alts {
+		alts[i] = `{"token":"t","logprob":-0.5,"bytes":[116]}`
+	}
+	return `{"content":[{"token":"Hi","logprob":-0.1,"bytes":[72,105],"top_logprobs":[` +
+		strings.Join(alts, ",") + `]}],"refusal":null}`
+}
+
+func TestHandleResponse_UsageWithLogprobs(t *testing.T) {
+	logprobs := topLogprobs(20)
+	usage := `"usage":{"prompt_tokens":4,"completion_tokens":1,"total_tokens":5}`
+
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+	}{
+		{"json", "application/json",
+			`{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"},"logprobs":` + logprobs + `,"finish_reason":"stop"}],` + usage + `}`},
+		{"stream", "text/event-stream",
+			`data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"},"logprobs":` + logprobs + `,"finish_reason":null}]}` + "\n\n" +
+				`data: {"model":"m","choices":[],` + usage + `}` + "\n\n" + "data: [DONE]\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{tt.mediaType}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			rec := httptest.NewRecorder()
+			opts := &types.ProxyOptions{ParseLimit: 1 << 20}
+
+			var result *types.ProxyResult
+			var err error
+			if tt.mediaType == "text/event-stream" {
+				result, err = handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), opts)
+			} else {
+				result, err = handleJSONResponse(rec, resp, &types.ProxyResult{}, time.Now(), opts)
+			}
+			if err != nil {
+				t.Fatalf("handle response: %v", err)
+			}
+			if result.PromptTokens != 4 || result.CompletionTokens != 1 || result.TotalTokens != 5 {
+				t.Errorf("usage = %d/%d/%d, want 4/1/5", result.PromptTokens, result.CompletionTokens, result.TotalTokens)
+			}
+			if !strings.Contains(rec.Body.String(), `"top_logprobs":[`) {
+				t.Error("logprobs not forwarded to the client")
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// ProxyRequest handles the proxy to OpenRouter with result tracking.
+// CRITICAL: Maintains streaming semantics with no buffering.
+func (p *Provider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	startTime := time.Now()
+	result := &types.ProxyResult{
+		Model:        opts.Model,
+		PromptTokens: opts.PromptTokens,
+		IsStreaming:  opts.IsStreaming,
+	}
+	defer func() { result.UpstreamDuration = time.Since(startTime) }()
+
+	// API key must be provided via credential (resolved by Router)
+	if opts.Credential == nil {
+		result.Error = types.ErrNoAPIKey
+		result.StatusCode = http.StatusUnauthorized
+		http.Error(w, "No credential configured", http.StatusUnauthorized)
+		return result, types.ErrNoAPIKey
+	}
+	apiKey := opts.Credential.GetAPIKey()
+
+	// Read and rewrite body with resolved model name
+	body, err := rewriteModelInBody(opts.Body, req.Body, opts.Model)
+	if err != nil {
+		result.Error = err
+		result.StatusCode = http.StatusBadRequest
+		http.Error(w, "Failed to process request body", http.StatusBadRequest)
+		return result, err
+	}
+
+	// Create upstream request
+	upstreamReq, err := http.NewRequestWithContext(ctx, req.Method, p.BaseURL(), body)
+	if err != nil {
+		result.Error = err
+		result.StatusCode = http.StatusInternalServerError
+		http.Error(w, "Failed to create request", http.StatusInternalServerError)
+		return result, err
+	}
+
+	// Copy headers (skip hop-by-hop and operator-stripped ones)
+	upstream.CopyRequestHeaders(upstreamReq.Header, req.Header, opts.StripHeaders)
+
+	// Set authorization with the resolved API key
+	upstreamReq.Header.Set("Authorization", "Bearer "+apiKey)
+	if p.accountHeaders {
+		upstream.SetOpenAIAccountHeaders(upstreamReq.Header, req.Header, opts.Credential)
+	}
+
+	// Add provider-specific headers
+	if err := p.PrepareRequest(ctx, upstreamReq); err != nil {
+		result.Error = err
+		result.StatusCode = http.StatusInternalServerError
+		http.Error(w, "Failed to prepare request", http.StatusInternalServerError)
+		return result, err
+	}
+
+	// Execute request; a missed first-byte deadline is left to the router to answer
+	resp, err := upstream.DoWithFirstByteTimeout(p.client, upstreamReq, opts.FirstByteTimeout)
+	if errors.Is(err, upstream.ErrFirstByteTimeout) {
+		result.Error = err
+		result.ErrorType = types.ErrorClassTimeout
+		result.StatusCode = http.StatusGatewayTimeout
+		return result, err
+	}
+	if err != nil {
+		result.Error = err
+		result.ErrorType = types.ClassifyTransportError(err)
+		result.StatusCode = types.TransportErrorStatus(err)
+		http.Error(w, http.StatusText(result.StatusCode)+": "+err.Error(), result.StatusCode)
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Duration = time.Since(startTime)
+	result.RateLimit = types.RateLimitHeaders(resp.Header)
+
+	// Handle error responses
+	if resp.StatusCode >= 400 {
+		return handleErrorResponse(w, resp, result)
+	}
+
+	// Route based on content type, not the requested mode
+	streaming := isEventStream(resp.Header.Get("Content-Type"))
+	reconcileStreaming(result, opts.IsStreaming, streaming)
+	if streaming {
+		return handleStreamingResponse(w, resp, result, startTime, opts)
+	}
+	if isBinaryBody(resp.Header.Get("Content-Type")) {
+		return handleBinaryResponse(w, resp, result, startTime, opts)
+	}
+	return handleJSONResponse(w, resp, result, startTime, opts)
+}
@@ -0,0 +1,65 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_NormalizesRateLimitHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"json", http.StatusOK, "application/json", `{"model":"m","choices":[]}`},
+		{"streaming", http.StatusOK, "text/event-stream", "data: [DONE]\n\n"},
+		{"rate limited", http.StatusTooManyRequests, "application/json", `{"error":{"message":"slow down"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Header().Set("X-RateLimit-Limit", "200")
+				w.Header().Set("X-RateLimit-Remaining", "17")
+				w.Header().Set("X-RateLimit-Reset", "1735689600000")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer upstream.Close()
+
+			data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+			opts := &types.ProxyOptions{
+				Model:      "m",
+				Credential: &models.Credential{Provider: "openrouter", Data: data},
+				Body:       strings.NewReader(`{"model":"m","messages":[]}`),
+			}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+			if _, err := NewWithBaseURL(upstream.URL).ProxyRequest(context.Background(), rec, req, opts); err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+
+			want := map[string]string{
+				"x-ratelimit-limit-requests":     "200",
+				"x-ratelimit-remaining-requests": "17",
+				"x-ratelimit-reset-requests":     "1735689600000",
+				"X-RateLimit-Remaining":          "17", // Upstream header still forwarded
+			}
+			for name, value := range want {
+				if got := rec.Header().Get(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
@@ -4,63 +4,29 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
-// handleStreamingResponse processes SSE streaming responses.
-func handleStreamingResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult) (*types.ProxyResult, error) {
-	// Copy headers
-	for k, v := range resp.Header {
-		w.Header()[k] = v
-	}
-	w.WriteHeader(resp.StatusCode)
-
-	flusher, ok := w.(http.Flusher)
-	if !ok {
-		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
-		result.Error = io.ErrNoProgress
-		return result, nil
-	}
-
-	// Process stream while forwarding to client
-	processor := NewStreamProcessor()
-	err := processor.ProcessReader(resp.Body, func(chunk []byte) error {
-		if _, wErr := w.Write(chunk); wErr != nil {
-			return wErr
-		}
-		flusher.Flush()
-		return nil
-	})
-
-	// Extract results from processor
-	result.FinishReason = processor.GetFinishReason()
-	if processor.GetModel() != "" {
-		result.Model = processor.GetModel()
-	}
-
-	// Use upstream usage if available
-	if usage := processor.GetUsage(); usage != nil {
-		result.PromptTokens = usage.PromptTokens
-		result.CompletionTokens = usage.CompletionTokens
-		result.TotalTokens = usage.TotalTokens
-	}
-
-	if err != nil {
-		result.Error = err
-	}
-	return result, err
-}
-
 // handleJSONResponse processes non-streaming JSON responses.
-func handleJSONResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult) (*types.ProxyResult, error) {
-	// Read full response for parsing
-	body, err := io.ReadAll(resp.Body)
+// Bodies above opts.ParseLimit are forwarded without extracting usage (or
+// rewriting the model to opts.ResponseModel).
+func handleJSONResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, start time.Time, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	// Read the response for parsing, at most one byte past the limit
+	var reader io.Reader = resp.Body
+	if opts.ParseLimit > 0 {
+		reader = io.LimitReader(resp.Body, opts.ParseLimit+1)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		result.Error = err
 		http.Error(w, "Failed to read response", http.StatusBadGateway)
 		return result, err
 	}
+	if opts.ParseLimit > 0 && int64(len(body)) > opts.ParseLimit {
+		return forwardUnparsed(w, resp, result, start, body, opts)
+	}
 
 	// Parse response to extract usage
 	var completion types.ChatCompletionResponse
@@ -73,15 +39,24 @@ func handleJSONResponse(w http.ResponseWriter, resp *http.Response, result *type
 		if len(completion.Choices) > 0 {
 			result.FinishReason = completion.Choices[0].FinishReason
 		}
+		result.ToolCalls = completion.ToolCallCount()
 		if completion.Model != "" {
 			result.Model = completion.Model
 		}
 	}
 
 	// Forward response to client
-	for k, v := range resp.Header {
-		w.Header()[k] = v
+	copyUpstreamHeaders(w, resp, result)
+	if opts.Seeded && completion.SystemFingerprint != "" {
+		w.Header().Set(types.SystemFingerprintHeader, completion.SystemFingerprint)
 	}
+	if opts.ResponseModel != "" {
+		if rewritten, ok := rewriteResponseModel(body, opts.ResponseModel); ok {
+			body = rewritten
+			w.Header().Del("Content-Length")
+		}
+	}
+	result.TTFB = time.Since(start)
 	w.WriteHeader(resp.StatusCode)
 	_, _ = w.Write(body)
 
@@ -97,11 +72,10 @@ func handleErrorResponse(w http.ResponseWriter, resp *http.Response, result *typ
 	if err := json.Unmarshal(body, &apiErr); err == nil {
 		result.ErrorMessage = apiErr.Error.Message
 	}
+	result.ErrorType = types.ClassifyUpstreamError(resp.StatusCode, result.ErrorMessage)
 
 	// Forward error to client
-	for k, v := range resp.Header {
-		w.Header()[k] = v
-	}
+	copyUpstreamHeaders(w, resp, result)
 	w.WriteHeader(resp.StatusCode)
 	_, _ = w.Write(body)
 
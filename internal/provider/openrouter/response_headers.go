@@ -0,0 +1,29 @@
+package openrouter
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// copyUpstreamHeaders forwards the upstream end-to-end headers plus the normalized
+// rate-limit headers, so clients see one naming scheme across providers.
+func copyUpstreamHeaders(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult) {
+	header := resp.Header.Clone()
+	upstream.RemoveHopByHop(header)
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	for k, v := range result.RateLimit {
+		w.Header()[k] = v
+	}
+}
+
+// setStreamHeaders sets the headers every SSE response to the client carries.
+func setStreamHeaders(h http.Header) {
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Del("Content-Length")
+}
@@ -0,0 +1,30 @@
+package openrouter
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// forwardUnparsed sends a JSON response too large to parse for usage.
+// The buffered prefix is written first and the rest is copied straight through,
+// so the client still receives the full body; token counts stay unknown.
+func forwardUnparsed(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, start time.Time, prefix []byte, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	slog.Warn("response exceeds parse limit; usage not extracted",
+		"request_id", opts.RequestID, "limit_bytes", opts.ParseLimit)
+
+	copyUpstreamHeaders(w, resp, result)
+	result.TTFB = time.Since(start)
+	w.WriteHeader(resp.StatusCode)
+	if _, err := w.Write(prefix); err != nil {
+		result.Error = err
+		return result, nil
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		result.Error = err
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_ParseLimit(t *testing.T) {
+	content := strings.Repeat("x", 4096)
+	body := `{"model":"m","choices":[{"message":{"content":"` + content + `"},"finish_reason":"stop"}],` +
+		`"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`
+
+	tests := []struct {
+		name       string
+		limit      int64
+		wantTokens int
+	}{
+		{"under limit parses usage", 1 << 20, 8},
+		{"no limit parses usage", 0, 8},
+		{"oversized forwarded unparsed", 1024, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			opts := &types.ProxyOptions{
+				Model:      "m",
+				Credential: &models.Credential{Provider: "openrouter", Data: []byte(`{"api_key":"sk"}`)},
+				Body:       strings.NewReader(`{"model":"m","messages":[]}`),
+				ParseLimit: tt.limit,
+			}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+			result, err := NewWithBaseURL(server.URL).ProxyRequest(context.Background(), rec, req, opts)
+			if err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+			if rec.Body.String() != body {
+				t.Errorf("client got %d bytes, want the full %d-byte body", rec.Body.Len(), len(body))
+			}
+			if result.TotalTokens != tt.wantTokens {
+				t.Errorf("TotalTokens = %d, want %d", result.TotalTokens, tt.wantTokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// rewriteResponseModel replaces the value of the top-level model field of a
+// JSON object with model, leaving every other byte in place. ok is false (and
+// body is returned as is) when the body is not a JSON object or has no model
+// field.
+func rewriteResponseModel(body []byte, model string) (out []byte, ok bool) {
+	if !json.Valid(body) {
+		return body, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return body, false
+	}
+	value, _ := json.Marshal(model)
+	last := 0
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return body, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return body, false
+		}
+		if key != "model" {
+			continue
+		}
+		end := int(dec.InputOffset())
+		out = append(out, body[last:end-len(raw)]...)
+		out = append(out, value...)
+		last = end
+	}
+	if out == nil {
+		return body, false
+	}
+	return append(out, body[last:]...), true
+}
+
+// streamModelRewriter rewrites the model of every SSE data event in a raw
+// stream. An event's data lines are held until they form complete JSON (or
+// the event ends), then forwarded with the model spliced in; lines outside
+// held events pass through untouched.
+type streamModelRewriter struct {
+	model string
+	next  func([]byte) error
+	held  [][]byte // Raw lines of the event whose data is still incomplete
+}
+
+func newStreamModelRewriter(model string, next func([]byte) error) *streamModelRewriter {
+	return &streamModelRewriter{model: model, next: next}
+}
+
+// forward is the onChunk callback for one raw line (newline included).
+func (s *streamModelRewriter) forward(chunk []byte) error {
+	line := bytes.TrimRight(chunk, "\r\n")
+	_, isData := sseData(line)
+	switch {
+	case isData:
+		s.held = append(s.held, bytes.Clone(chunk))
+		if data := eventData(s.held); bytes.Equal(data, []byte("[DONE]")) || json.Valid(data) {
+			return s.flush()
+		}
+		return nil
+	case len(s.held) == 0:
+		return s.next(chunk)
+	case len(line) == 0:
+		if err := s.flush(); err != nil {
+			return err
+		}
+		return s.next(chunk)
+	default:
+		s.held = append(s.held, bytes.Clone(chunk))
+		return nil
+	}
+}
+
+// flush forwards the held lines, with the model rewritten when their data
+// is a JSON object that has one.
+func (s *streamModelRewriter) flush() error {
+	held := s.held
+	s.held = nil
+	if rewritten, ok := rewriteResponseModel(eventData(held), s.model); ok {
+		parts := bytes.Split(rewritten, []byte("\n"))
+		for i, chunk := range held {
+			line := bytes.TrimRight(chunk, "\r\n")
+			if data, isData := sseData(line); isData {
+				prefix := line[:len(line)-len(data)]
+				held[i] = append(append(bytes.Clone(prefix), parts[0]...), chunk[len(line):]...)
+				parts = parts[1:]
+			}
+		}
+	}
+	for _, chunk := range held {
+		if err := s.next(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventData joins the data of the SSE data lines among chunks with "\n".
+func eventData(chunks [][]byte) []byte {
+	var data []byte
+	for _, chunk := range chunks {
+		if d, ok := sseData(bytes.TrimRight(chunk, "\r\n")); ok {
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, d...)
+		}
+	}
+	return data
+}
+
+// sseData returns the value of an SSE data line, without the optional space
+// after the field name.
+func sseData(line []byte) ([]byte, bool) {
+	data, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
+		return nil, false
+	}
+	return bytes.TrimPrefix(data, []byte(" ")), true
+}
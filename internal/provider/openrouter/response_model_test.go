@@ -0,0 +1,143 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRewriteResponseModel(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   string
+		wantOK bool
+	}{
+		{"splices only the value", `{"id":"c1",  "model" : "openai/gpt-4o","x":1.50,"u":"\u00e9"}`, `{"id":"c1",  "model" : "gpt4","x":1.50,"u":"\u00e9"}`, true},
+		{"nested model untouched", `{"m":{"model":"a"},"model":"b"}`, `{"m":{"model":"a"},"model":"gpt4"}`, true},
+		{"no model field", `{"id":"c1"}`, `{"id":"c1"}`, false},
+		{"not an object", `["model"]`, `["model"]`, false},
+		{"invalid json", `{"model":`, `{"model":`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rewriteResponseModel([]byte(tt.body), "gpt4")
+			if string(got) != tt.want || ok != tt.wantOK {
+				t.Errorf("rewriteResponseModel = %s, %v; want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestStreamModelRewriter_MultiLineEvent(t *testing.T) {
+	stream := ": keep-alive\n" +
+		"data: {\"model\":\"openai/gpt-4o\",\n" +
+		"data: \"choices\":[]}\n\n" +
+		"data:{\"model\":\"openai/gpt-4o\"}\n\n" +
+		"data: [DONE]\n\n"
+	want := ": keep-alive\n" +
+		"data: {\"model\":\"gpt4\",\n" +
+		"data: \"choices\":[]}\n\n" +
+		"data:{\"model\":\"gpt4\"}\n\n" +
+		"data: [DONE]\n\n"
+
+	var out strings.Builder
+	rw := newStreamModelRewriter("gpt4", func(chunk []byte) error {
+		out.Write(chunk)
+		return nil
+	})
+	for _, line := range strings.SplitAfter(stream, "\n") {
+		if line != "" {
+			if err := rw.forward([]byte(line)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := rw.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want {
+		t.Errorf("stream = %q, want %q", out.String(), want)
+	}
+}
+
+func TestHandleJSONResponse_ResponseModel(t *testing.T) {
+	upstream := `{"id":"c1","model":"openai/gpt-4o","choices":[],"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`
+
+	tests := []struct {
+		name      string
+		opts      types.ProxyOptions
+		wantModel string
+	}{
+		{"disabled keeps upstream model", types.ProxyOptions{}, `"model":"openai/gpt-4o"`},
+		{"enabled reports alias", types.ProxyOptions{ResponseModel: "gpt4"}, `"model":"gpt4"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}, "Content-Length": []string{"118"}},
+				Body:       io.NopCloser(strings.NewReader(upstream)),
+			}
+			rec := httptest.NewRecorder()
+
+			result, err := handleJSONResponse(rec, resp, &types.ProxyResult{}, time.Now(), &tt.opts)
+			if err != nil {
+				t.Fatalf("handleJSONResponse: %v", err)
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, tt.wantModel) {
+				t.Errorf("client body = %q, want %s", body, tt.wantModel)
+			}
+			if tt.opts.ResponseModel != "" && rec.Header().Get("Content-Length") != "" {
+				t.Error("stale Content-Length forwarded after rewrite")
+			}
+			if result.Model != "openai/gpt-4o" || result.TotalTokens != 3 {
+				t.Errorf("result model = %q tokens = %d, want upstream model and usage", result.Model, result.TotalTokens)
+			}
+		})
+	}
+}
+
+func TestHandleStreamingResponse_ResponseModel(t *testing.T) {
+	stream := "data: {\"model\":\"openai/gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\ndata: [DONE]\n\n"
+
+	tests := []struct {
+		name      string
+		opts      types.ProxyOptions
+		wantModel string
+	}{
+		{"disabled keeps upstream model", types.ProxyOptions{}, `"model":"openai/gpt-4o"`},
+		{"enabled reports alias", types.ProxyOptions{ResponseModel: "gpt4"}, `"model":"gpt4"`},
+		{"enabled when normalizing", types.ProxyOptions{ResponseModel: "gpt4", NormalizeSSE: true}, `"model":"gpt4"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+				Body:       io.NopCloser(strings.NewReader(stream)),
+			}
+			rec := httptest.NewRecorder()
+
+			result, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &tt.opts)
+			if err != nil {
+				t.Fatalf("handleStreamingResponse: %v", err)
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, tt.wantModel) || !strings.Contains(body, "data: [DONE]") {
+				t.Errorf("client body = %q, want %s and [DONE]", body, tt.wantModel)
+			}
+			if result.CompletionText != "Hi" || result.Model != "openai/gpt-4o" {
+				t.Errorf("result = %q/%q, want Hi from openai/gpt-4o", result.CompletionText, result.Model)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package openrouter
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// handleStreamingResponse forwards an SSE stream to the client as opts
+// directs, collecting usage and completion output into result.
+func handleStreamingResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, start time.Time, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	// Copy headers, then normalize the streaming ones (upstreams vary charset and caching)
+	copyUpstreamHeaders(w, resp, result)
+	setStreamHeaders(w.Header())
+	if opts.Seeded {
+		// Headers are sent before the first chunk, so the fingerprint is a trailer
+		w.Header().Set("Trailer", types.SystemFingerprintHeader)
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	flusher := upstream.Flusher(w)
+	if opts.StreamRequestID && opts.RequestID != "" {
+		_, _ = w.Write(types.FormatSSERequestID(opts.RequestID))
+		flusher.Flush()
+	}
+
+	var body io.Reader = resp.Body
+	if opts.IdleTimeout > 0 {
+		idle := upstream.NewIdleReader(resp.Body, opts.IdleTimeout)
+		defer idle.Stop()
+		body = idle
+	}
+
+	// Process stream while forwarding to client
+	processor := NewStreamProcessor()
+	forward := func(chunk []byte) error {
+		if result.TTFB == 0 {
+			result.TTFB = time.Since(start)
+		}
+		if _, wErr := w.Write(chunk); wErr != nil {
+			return wErr
+		}
+		flusher.Flush()
+		return nil
+	}
+	if opts.NormalizeSSE {
+		forward = newSSENormalizer().wrap(forward)
+	}
+	var modelRewriter *streamModelRewriter
+	if opts.ResponseModel != "" {
+		modelRewriter = newStreamModelRewriter(opts.ResponseModel, forward)
+		forward = modelRewriter.forward
+	}
+	err := processor.ProcessReader(body, forward)
+	if modelRewriter != nil && err == nil {
+		err = modelRewriter.flush()
+	}
+
+	// Extract results from processor
+	result.FinishReason = processor.GetFinishReason()
+	result.CompletionText = processor.GetContent()
+	result.CompletionToolCalls = processor.GetToolCalls()
+	result.ToolCalls = len(result.CompletionToolCalls)
+	if processor.GetModel() != "" {
+		result.Model = processor.GetModel()
+	}
+	if fp := processor.GetSystemFingerprint(); opts.Seeded && fp != "" {
+		w.Header().Set(types.SystemFingerprintHeader, fp)
+	}
+
+	// Use upstream usage if available
+	if usage := processor.GetUsage(); usage != nil {
+		result.PromptTokens = usage.PromptTokens
+		result.CompletionTokens = usage.CompletionTokens
+		result.TotalTokens = usage.TotalTokens
+	}
+
+	if errors.Is(err, upstream.ErrIdleTimeout) {
+		result.ErrorMessage = err.Error()
+		result.ErrorType = types.ErrorClassTimeout
+		_, _ = w.Write(types.FormatSSEError(types.NewAPIError(err.Error(), types.ErrorTypeServer)))
+		flusher.Flush()
+	}
+	if err != nil {
+		result.Error = err
+	}
+	return result, err
+}
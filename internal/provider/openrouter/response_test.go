@@ -0,0 +1,149 @@
+package openrouter
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestHandleStreamingResponse_IdleTimeout(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	// Upstream sends one chunk and then stalls without closing the stream
+	go func() {
+		_, _ = pw.Write([]byte(`data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"}}]}` + "\n\n"))
+	}()
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       pr,
+	}
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	var result *types.ProxyResult
+	var err error
+	go func() {
+		result, err = handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{IdleTimeout: 50 * time.Millisecond})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream was not aborted after idle timeout")
+	}
+
+	if !errors.Is(err, upstream.ErrIdleTimeout) {
+		t.Errorf("err = %v, want ErrIdleTimeout", err)
+	}
+	if result.ErrorMessage == "" {
+		t.Error("expected error message on result")
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `"content":"Hi"`) {
+		t.Errorf("chunk before stall not forwarded: %q", body)
+	}
+	if !strings.HasSuffix(body, `data: {"error":{"message":"upstream stream idle timeout","type":"server_error"}}`+"\n\n") {
+		t.Errorf("missing SSE error frame: %q", body)
+	}
+}
+
+func TestHandleStreamingResponse_NoTimeoutWhenDisabled(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader("data: [DONE]\n\n")),
+	}
+	rec := httptest.NewRecorder()
+
+	if _, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(rec.Body.String(), "error") {
+		t.Errorf("unexpected error frame: %q", rec.Body.String())
+	}
+}
+
+func TestHandleStreamingResponse_NormalizesHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream http.Header
+	}{
+		{"charset suffix", http.Header{"Content-Type": []string{"text/event-stream; charset=utf-8"}}},
+		{"caching and length from upstream", http.Header{
+			"Content-Type":   []string{"text/event-stream"},
+			"Cache-Control":  []string{"public, max-age=60"},
+			"Content-Length": []string{"42"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     tt.upstream,
+				Body:       io.NopCloser(strings.NewReader("data: [DONE]\n\n")),
+			}
+			rec := httptest.NewRecorder()
+
+			if _, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{}); err != nil {
+				t.Fatalf("handleStreamingResponse: %v", err)
+			}
+
+			want := map[string]string{
+				"Content-Type":   "text/event-stream",
+				"Cache-Control":  "no-cache",
+				"Connection":     "keep-alive",
+				"Content-Length": "",
+			}
+			for k, v := range want {
+				if got := rec.Header().Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleStreamingResponse_OpenRouterUsageChunk(t *testing.T) {
+	stream := strings.Join([]string{
+		`: OPENROUTER PROCESSING`,
+		`data: {"id":"gen-1","model":"openai/gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`,
+		`data: {"id":"gen-1","model":"openai/gpt-4o","choices":[{"index":0,"delta":{"content":" there"}}]}`,
+		`data: {"id":"gen-1","model":"openai/gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+		`data:{"id":"gen-1","model":"openai/gpt-4o","choices":[],"usage":{"prompt_tokens":12,"completion_tokens":2,"total_tokens":14,"cost":0.00004}}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(stream)),
+	}
+	rec := httptest.NewRecorder()
+
+	result, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.PromptTokens != 12 || result.CompletionTokens != 2 || result.TotalTokens != 14 {
+		t.Errorf("usage = %d/%d/%d, want 12/2/14", result.PromptTokens, result.CompletionTokens, result.TotalTokens)
+	}
+	if result.CompletionText != "Hello there" || result.FinishReason != "stop" {
+		t.Errorf("content = %q, finish = %q", result.CompletionText, result.FinishReason)
+	}
+	if rec.Body.String() != stream {
+		t.Errorf("stream not forwarded verbatim:\n%q\nwant\n%q", rec.Body.String(), stream)
+	}
+}
@@ -16,6 +16,17 @@ type StreamProcessor struct {
 	usage         *types.Usage
 	finishReason  string
 	model         string
+	fingerprint   string           // First system_fingerprint seen
+	toolCalls     []types.ToolCall // Reconstructed from deltas, ordered by index
+	pending       []byte           // Data lines of the event being read, joined by "\n"
+}
+
+// streamChunk is a chat completion chunk plus vendor extensions that carry usage.
+type streamChunk struct {
+	types.ChatCompletionChunk
+	XGroq *struct {
+		Usage *types.Usage `json:"usage"`
+	} `json:"x_groq,omitempty"` // Groq reports stream usage here
 }
 
 // NewStreamProcessor creates a new SSE stream processor.
@@ -24,7 +35,9 @@ func NewStreamProcessor() *StreamProcessor {
 }
 
 // ProcessReader reads and processes an SSE stream, calling onChunk for each raw chunk.
-// Returns after the stream ends or on error.
+// Lines split across reads are reassembled before forwarding, and an event
+// whose data spans several lines is parsed once complete. Returns after the
+// stream ends or on error.
 func (p *StreamProcessor) ProcessReader(r io.Reader, onChunk func([]byte) error) error {
 	scanner := bufio.NewScanner(r)
 	// Set a larger buffer for potentially large chunks
@@ -34,7 +47,8 @@ func (p *StreamProcessor) ProcessReader(r io.Reader, onChunk func([]byte) error)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 
-		// Forward the raw line plus newline
+		// Forward the raw line plus newline before parsing so metadata
+		// extraction never delays bytes to the client
 		chunk := append(line, '\n')
 		if err := onChunk(chunk); err != nil {
 			return err
@@ -47,34 +61,67 @@ func (p *StreamProcessor) ProcessReader(r io.Reader, onChunk func([]byte) error)
 	return scanner.Err()
 }
 
-// processLine parses a single SSE line.
+// processLine collects the data lines of an SSE event. The event is parsed
+// at its closing blank line, or as soon as its data is complete JSON so
+// upstreams that omit blank lines are not delayed.
 func (p *StreamProcessor) processLine(line []byte) {
-	// Skip empty lines and non-data lines
-	if !bytes.HasPrefix(line, []byte("data: ")) {
+	if len(line) == 0 {
+		p.dispatch()
+		return
+	}
+
+	// Skip comments (": OPENROUTER PROCESSING") and non-data fields
+	data, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
 		return
 	}
+	// The space after the field name is optional in SSE
+	data = bytes.TrimPrefix(data, []byte(" "))
+
+	if len(p.pending) > 0 {
+		p.pending = append(p.pending, '\n')
+	}
+	p.pending = append(p.pending, data...)
+	if bytes.Equal(p.pending, []byte("[DONE]")) || json.Valid(p.pending) {
+		p.dispatch()
+	}
+}
 
-	data := bytes.TrimPrefix(line, []byte("data: "))
+// dispatch parses the pending event data, if any, and starts a new event.
+func (p *StreamProcessor) dispatch() {
+	if len(p.pending) > 0 {
+		p.processData(p.pending)
+		p.pending = p.pending[:0]
+	}
+}
 
+// processData parses the data of one SSE event.
+func (p *StreamProcessor) processData(data []byte) {
 	// Skip [DONE] marker
 	if bytes.Equal(data, []byte("[DONE]")) {
 		return
 	}
 
 	// Parse the JSON chunk
-	var chunk types.ChatCompletionChunk
+	var chunk streamChunk
 	if err := json.Unmarshal(data, &chunk); err != nil {
 		return // Skip malformed chunks
 	}
 
-	// Extract model if not set
+	// Extract model and fingerprint if not set
 	if p.model == "" && chunk.Model != "" {
 		p.model = chunk.Model
 	}
+	if p.fingerprint == "" {
+		p.fingerprint = chunk.SystemFingerprint
+	}
 
-	// Extract usage from final chunk (if stream_options.include_usage=true)
+	// Extract usage from the final chunk. OpenRouter sends it after the
+	// finish_reason chunk with empty choices; OpenAI only with include_usage.
 	if chunk.Usage != nil {
 		p.usage = chunk.Usage
+	} else if chunk.XGroq != nil && chunk.XGroq.Usage != nil {
+		p.usage = chunk.XGroq.Usage
 	}
 
 	// Process choices
@@ -84,34 +131,14 @@ func (p *StreamProcessor) processLine(line []byte) {
 			p.contentBuffer.WriteString(choice.Delta.Content)
 		}
 
+		// Accumulate streamed tool calls
+		for _, call := range choice.Delta.ToolCalls {
+			p.mergeToolCall(call)
+		}
+
 		// Extract finish reason
 		if choice.FinishReason != nil && *choice.FinishReason != "" {
 			p.finishReason = *choice.FinishReason
 		}
 	}
 }
-
-// GetContent returns the accumulated content from the stream.
-func (p *StreamProcessor) GetContent() string {
-	return p.contentBuffer.String()
-}
-
-// GetUsage returns the usage info if provided by upstream.
-func (p *StreamProcessor) GetUsage() *types.Usage {
-	return p.usage
-}
-
-// GetFinishReason returns the finish reason from the stream.
-func (p *StreamProcessor) GetFinishReason() string {
-	return p.finishReason
-}
-
-// GetModel returns the model from the stream.
-func (p *StreamProcessor) GetModel() string {
-	return p.model
-}
-
-// HasUpstreamUsage returns true if upstream provided usage info.
-func (p *StreamProcessor) HasUpstreamUsage() bool {
-	return p.usage != nil
-}
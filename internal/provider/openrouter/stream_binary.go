@@ -0,0 +1,72 @@
+package openrouter
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// binaryChunkSize is the read size when pumping binary responses; each read
+// is forwarded and flushed as soon as it arrives.
+const binaryChunkSize = 32 * 1024
+
+// isBinaryBody reports whether an upstream Content-Type is a binary payload
+// (audio, images, octet streams) rather than JSON, text or SSE. Responses
+// without a Content-Type are treated as JSON.
+func isBinaryBody(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		return false
+	}
+	return !strings.HasSuffix(mediaType, "json") && !strings.HasPrefix(mediaType, "text/")
+}
+
+// handleBinaryResponse pumps a binary response (such as streamed TTS audio)
+// to the client, flushing each chunk as it arrives instead of buffering the
+// whole body. A positive opts.IdleTimeout aborts the transfer when the
+// upstream stalls; the client then sees a truncated body.
+// TTFB is measured from start to the first chunk forwarded to the client.
+func handleBinaryResponse(w http.ResponseWriter, resp *http.Response, result *types.ProxyResult, start time.Time, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	copyUpstreamHeaders(w, resp, result)
+	w.WriteHeader(resp.StatusCode)
+	flusher := upstream.Flusher(w)
+
+	var body io.Reader = resp.Body
+	if opts.IdleTimeout > 0 {
+		idle := upstream.NewIdleReader(resp.Body, opts.IdleTimeout)
+		defer idle.Stop()
+		body = idle
+	}
+
+	buf := make([]byte, binaryChunkSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if result.TTFB == 0 {
+				result.TTFB = time.Since(start)
+			}
+			if _, wErr := w.Write(buf[:n]); wErr != nil {
+				result.Error = wErr
+				return result, wErr
+			}
+			flusher.Flush()
+		}
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			if errors.Is(err, upstream.ErrIdleTimeout) {
+				result.ErrorMessage = err.Error()
+				result.ErrorType = types.ErrorClassTimeout
+			}
+			result.Error = err
+			return result, err
+		}
+	}
+}
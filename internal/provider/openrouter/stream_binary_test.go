@@ -0,0 +1,110 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// flushSignalWriter reports each flush on flushed so a test can observe
+// chunks reaching the client while the upstream is still sending.
+type flushSignalWriter struct {
+	*httptest.ResponseRecorder
+	flushed chan struct{}
+}
+
+func (w *flushSignalWriter) Flush() {
+	w.ResponseRecorder.Flush()
+	select {
+	case w.flushed <- struct{}{}:
+	default:
+	}
+}
+
+func TestProxyRequest_StreamsChunkedAudio(t *testing.T) {
+	first, second := bytes.Repeat([]byte{0xff, 0xf3}, 512), bytes.Repeat([]byte{0x44}, 1024)
+	release := make(chan struct{})
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(first)
+		w.(http.Flusher).Flush()
+
+		// Hold the rest back until the client has received the first chunk
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		_, _ = w.Write(second)
+	}))
+	defer upstream.Close()
+
+	data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+	opts := &types.ProxyOptions{
+		Model:      "tts-1",
+		Credential: &models.Credential{Provider: "openrouter", Data: data},
+		Body:       strings.NewReader(`{"model":"tts-1","input":"hi","voice":"alloy"}`),
+	}
+	req := httptest.NewRequest(http.MethodPost, "/v1/audio/speech", nil)
+	w := &flushSignalWriter{ResponseRecorder: httptest.NewRecorder(), flushed: make(chan struct{}, 1)}
+
+	done := make(chan error, 1)
+	var result *types.ProxyResult
+	go func() {
+		var err error
+		result, err = NewWithBaseURL(upstream.URL).ProxyRequest(context.Background(), w, req, opts)
+		done <- err
+	}()
+
+	select {
+	case <-w.flushed:
+		close(release)
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("first audio chunk was not flushed before the upstream finished")
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("ProxyRequest: %v", err)
+	}
+
+	if got := w.Body.Bytes(); !bytes.Equal(got, append(first, second...)) {
+		t.Errorf("client received %d bytes, want the %d-byte audio unchanged", len(got), len(first)+len(second))
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "audio/mpeg" {
+		t.Errorf("Content-Type = %q, want audio/mpeg", ct)
+	}
+	if result.StatusCode != http.StatusOK || result.TTFB == 0 || result.IsStreaming {
+		t.Errorf("result = status %d, ttfb %v, streaming %v", result.StatusCode, result.TTFB, result.IsStreaming)
+	}
+}
+
+func TestIsBinaryBody(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"audio/mpeg", true},
+		{"audio/ogg; codecs=opus", true},
+		{"application/octet-stream", true},
+		{"application/json", false},
+		{"application/json; charset=utf-8", false},
+		{"application/problem+json", false},
+		{"text/plain", false},
+		{"text/event-stream", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isBinaryBody(tt.contentType); got != tt.want {
+			t.Errorf("isBinaryBody(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// nonFlushingWriter hides the recorder's Flush method.
+type nonFlushingWriter struct {
+	rec *httptest.ResponseRecorder
+}
+
+func (w *nonFlushingWriter) Header() http.Header         { return w.rec.Header() }
+func (w *nonFlushingWriter) Write(b []byte) (int, error) { return w.rec.Write(b) }
+func (w *nonFlushingWriter) WriteHeader(code int)        { w.rec.WriteHeader(code) }
+
+func TestHandleStreamingResponse_NonFlushingWriter(t *testing.T) {
+	stream := `data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"}}]}` + "\n\n" +
+		`data: {"model":"m","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+		"data: [DONE]\n\n"
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(stream)),
+	}
+	w := &nonFlushingWriter{rec: httptest.NewRecorder()}
+
+	done := make(chan struct{})
+	var result *types.ProxyResult
+	var err error
+	go func() {
+		result, err = handleStreamingResponse(w, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream to a non-flushing writer did not complete")
+	}
+
+	if err != nil || result.Error != nil {
+		t.Fatalf("err = %v, result.Error = %v", err, result.Error)
+	}
+	if w.rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.rec.Code)
+	}
+	if body := w.rec.Body.String(); body != stream {
+		t.Errorf("body = %q, want the full stream", body)
+	}
+	if result.CompletionText != "Hi" || result.FinishReason != "stop" {
+		t.Errorf("result = %q/%q, want Hi/stop", result.CompletionText, result.FinishReason)
+	}
+}
@@ -0,0 +1,27 @@
+package openrouter
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// isEventStream reports whether an upstream Content-Type is a server-sent event stream.
+func isEventStream(contentType string) bool {
+	return strings.Contains(contentType, "text/event-stream")
+}
+
+// reconcileStreaming records whether the upstream actually streamed.
+// Upstreams occasionally ignore the stream flag, so the logged mode and usage
+// extraction follow the response rather than the request.
+func reconcileStreaming(result *types.ProxyResult, requested, actual bool) {
+	if requested != actual {
+		slog.Warn("upstream streaming mode differs from request",
+			"model", result.Model,
+			"requested_stream", requested,
+			"actual_stream", actual,
+		)
+	}
+	result.IsStreaming = actual
+}
@@ -0,0 +1,68 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyRequest_StreamingMismatch(t *testing.T) {
+	jsonBody := `{"id":"c1","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"},"finish_reason":"stop"}],` +
+		`"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}`
+	sseBody := `data: {"model":"m","choices":[{"index":0,"delta":{"content":"Hi"},"finish_reason":"stop"}]}` + "\n\n" +
+		`data: {"model":"m","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	tests := []struct {
+		name        string
+		requested   bool
+		contentType string
+		body        string
+		wantStream  bool
+	}{
+		{"stream requested, JSON returned", true, "application/json", jsonBody, false},
+		{"JSON requested, stream returned", false, "text/event-stream", sseBody, true},
+		{"stream requested and returned", true, "text/event-stream", sseBody, true},
+		{"JSON requested and returned", false, "application/json", jsonBody, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer upstream.Close()
+
+			data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+			opts := &types.ProxyOptions{
+				Model:       "m",
+				IsStreaming: tt.requested,
+				Credential:  &models.Credential{Provider: "openrouter", Data: data},
+				Body:        strings.NewReader(`{"model":"m","messages":[]}`),
+			}
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			rec := httptest.NewRecorder()
+
+			result, err := NewWithBaseURL(upstream.URL).ProxyRequest(context.Background(), rec, req, opts)
+			if err != nil {
+				t.Fatalf("ProxyRequest: %v", err)
+			}
+			if result.IsStreaming != tt.wantStream {
+				t.Errorf("IsStreaming = %v, want %v", result.IsStreaming, tt.wantStream)
+			}
+			if result.PromptTokens != 3 || result.CompletionTokens != 1 || result.TotalTokens != 4 {
+				t.Errorf("usage = %d/%d/%d, want 3/1/4", result.PromptTokens, result.CompletionTokens, result.TotalTokens)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("client body = %q, want upstream body forwarded", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// sseNormalizer filters a raw SSE stream down to what strict OpenAI clients
+// accept: "data: <json>" and "data: [DONE]" lines, each followed by one blank
+// line. Comments (": OPENROUTER PROCESSING"), keep-alives, event/id/retry
+// fields and data frames that are not JSON are dropped.
+type sseNormalizer struct {
+	open bool // a data line was forwarded and still needs its terminating blank line
+}
+
+func newSSENormalizer() *sseNormalizer {
+	return &sseNormalizer{}
+}
+
+// wrap returns an onChunk callback that forwards only normalized lines to next.
+func (n *sseNormalizer) wrap(next func([]byte) error) func([]byte) error {
+	return func(chunk []byte) error {
+		if out := n.normalize(chunk); out != nil {
+			return next(out)
+		}
+		return nil
+	}
+}
+
+// normalize returns the line to forward (newline included), or nil to drop it.
+func (n *sseNormalizer) normalize(chunk []byte) []byte {
+	line := bytes.TrimRight(chunk, "\r\n")
+
+	if len(line) == 0 {
+		// Blank lines only end an event we forwarded; others would be noise
+		if !n.open {
+			return nil
+		}
+		n.open = false
+		return []byte("\n")
+	}
+
+	data, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
+		return nil
+	}
+	data = bytes.TrimSpace(data)
+	if !bytes.Equal(data, []byte("[DONE]")) && !json.Valid(data) {
+		return nil
+	}
+
+	n.open = true
+	out := make([]byte, 0, len(types.SSEPrefix)+len(data)+1)
+	out = append(out, types.SSEPrefix...)
+	out = append(out, data...)
+	return append(out, '\n')
+}
@@ -0,0 +1,69 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestHandleStreamingResponse_NormalizeSSE(t *testing.T) {
+	chunk1 := `{"model":"m","choices":[{"index":0,"delta":{"content":"Hi"}}]}`
+	chunk2 := `{"model":"m","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}`
+	mixed := strings.Join([]string{
+		": OPENROUTER PROCESSING",
+		"",
+		"event: ping",
+		"data: {}",
+		"",
+		"data: " + chunk1,
+		"",
+		":",
+		"",
+		"id: 7",
+		"retry: 3000",
+		"data: not json",
+		"",
+		"data:" + chunk2,
+		"",
+		"data: [DONE]",
+	}, "\n") + "\n\n"
+
+	tests := []struct {
+		name      string
+		normalize bool
+		want      string
+	}{
+		{"off forwards everything", false, mixed},
+		{"on keeps only data chunks", true, "data: {}\n\n" +
+			"data: " + chunk1 + "\n\n" +
+			"data: " + chunk2 + "\n\n" +
+			"data: [DONE]\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+				Body:       io.NopCloser(strings.NewReader(mixed)),
+			}
+			rec := httptest.NewRecorder()
+
+			result, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{NormalizeSSE: tt.normalize})
+			if err != nil {
+				t.Fatalf("handleStreamingResponse: %v", err)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("client body =\n%q\nwant\n%q", got, tt.want)
+			}
+			if result.CompletionText != "Hi" || result.TotalTokens != 4 {
+				t.Errorf("content = %q, total tokens = %d; want %q, 4", result.CompletionText, result.TotalTokens, "Hi")
+			}
+		})
+	}
+}
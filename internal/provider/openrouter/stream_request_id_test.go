@@ -0,0 +1,49 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestHandleStreamingResponse_RequestIDComment(t *testing.T) {
+	stream := "data: {\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\ndata: [DONE]\n\n"
+
+	tests := []struct {
+		name      string
+		opts      types.ProxyOptions
+		wantStart string
+	}{
+		{"disabled by default", types.ProxyOptions{RequestID: "req-1"}, "data: "},
+		{"enabled leads with comment", types.ProxyOptions{RequestID: "req-1", StreamRequestID: true}, ": goatway-request-id=req-1\n\ndata: "},
+		{"kept when normalizing", types.ProxyOptions{RequestID: "req-1", StreamRequestID: true, NormalizeSSE: true}, ": goatway-request-id=req-1\n\ndata: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+				Body:       io.NopCloser(strings.NewReader(stream)),
+			}
+			rec := httptest.NewRecorder()
+
+			result, err := handleStreamingResponse(rec, resp, &types.ProxyResult{}, time.Now(), &tt.opts)
+			if err != nil {
+				t.Fatalf("handleStreamingResponse: %v", err)
+			}
+			body := rec.Body.String()
+			if !strings.HasPrefix(body, tt.wantStart) || strings.Count(body, "goatway-request-id") > 1 {
+				t.Errorf("client body = %q, want prefix %q", body, tt.wantStart)
+			}
+			if result.CompletionText != "Hi" {
+				t.Errorf("content = %q, want Hi", result.CompletionText)
+			}
+		})
+	}
+}
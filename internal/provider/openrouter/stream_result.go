@@ -0,0 +1,33 @@
+package openrouter
+
+import "github.com/mandalnilabja/goatway/internal/types"
+
+// GetContent returns the accumulated content from the stream.
+func (p *StreamProcessor) GetContent() string {
+	return p.contentBuffer.String()
+}
+
+// GetUsage returns the usage info if provided by upstream.
+func (p *StreamProcessor) GetUsage() *types.Usage {
+	return p.usage
+}
+
+// GetFinishReason returns the finish reason from the stream.
+func (p *StreamProcessor) GetFinishReason() string {
+	return p.finishReason
+}
+
+// GetModel returns the model from the stream.
+func (p *StreamProcessor) GetModel() string {
+	return p.model
+}
+
+// GetSystemFingerprint returns the first system_fingerprint in the stream.
+func (p *StreamProcessor) GetSystemFingerprint() string {
+	return p.fingerprint
+}
+
+// HasUpstreamUsage returns true if upstream provided usage info.
+func (p *StreamProcessor) HasUpstreamUsage() bool {
+	return p.usage != nil
+}
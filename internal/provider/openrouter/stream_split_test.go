@@ -0,0 +1,72 @@
+package openrouter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamProcessor_SplitFrames(t *testing.T) {
+	split := strings.Join([]string{
+		": OPENROUTER PROCESSING",
+		"",
+		`data: {"model":"openai/gpt-4o","choices":[{"index":0,"delta":{"content":"Hel"}}]}`,
+		"",
+		// One event whose JSON spans two data lines
+		`data: {"model":"openai/gpt-4o",`,
+		`data: "choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+		"",
+		"data: {\"model\":\"openai/gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\r",
+		"\r",
+		`data: {"model":"openai/gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+		"",
+		"data: [DONE]",
+		"",
+	}, "\n")
+
+	// Some upstreams omit the blank line between events
+	compact := strings.Join([]string{
+		`data: {"model":"openai/gpt-4o","choices":[{"index":0,"delta":{"content":"Hel"}}]}`,
+		`data: {"model":"openai/gpt-4o","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+		`data: {"model":"openai/gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+		"data: [DONE]",
+	}, "\n")
+
+	tests := []struct {
+		name   string
+		stream string
+	}{
+		{"frames split mid-line", split},
+		{"no blank lines between events", compact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded bytes.Buffer
+			p := NewStreamProcessor()
+			// One byte per read splits every frame mid-line
+			err := p.ProcessReader(iotest.OneByteReader(strings.NewReader(tt.stream)), func(chunk []byte) error {
+				forwarded.Write(chunk)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ProcessReader: %v", err)
+			}
+
+			if got := p.GetContent(); got != "Hello" {
+				t.Errorf("content = %q, want Hello", got)
+			}
+			if usage := p.GetUsage(); usage == nil || usage.TotalTokens != 7 {
+				t.Errorf("usage = %+v, want total 7", usage)
+			}
+			if p.GetFinishReason() != "stop" || p.GetModel() != "openai/gpt-4o" {
+				t.Errorf("finish reason = %q, model = %q", p.GetFinishReason(), p.GetModel())
+			}
+			want := strings.TrimSuffix(strings.ReplaceAll(tt.stream, "\r", ""), "\n") + "\n"
+			if forwarded.String() != want {
+				t.Errorf("forwarded lines differ from upstream:\n%q\nwant\n%q", forwarded.String(), want)
+			}
+		})
+	}
+}
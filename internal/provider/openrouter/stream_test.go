@@ -0,0 +1,41 @@
+package openrouter
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamProcessor_ReconstructsToolCalls(t *testing.T) {
+	stream := strings.Join([]string{
+		`data: {"model":"m","choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+		`data: {"model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`,
+		`data: {"model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]}}]}`,
+		`data: {"model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}]}`,
+		`data: {"model":"m","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		`data: [DONE]`,
+	}, "\n\n")
+
+	p := NewStreamProcessor()
+	if err := p.ProcessReader(strings.NewReader(stream), func([]byte) error { return nil }); err != nil && err != io.EOF {
+		t.Fatalf("ProcessReader: %v", err)
+	}
+
+	calls := p.GetToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+
+	want := []struct{ id, name, args string }{
+		{"call_1", "get_weather", `{"city":"Paris"}`},
+		{"call_2", "get_time", `{}`},
+	}
+	for i, w := range want {
+		if calls[i].ID != w.id || calls[i].Function.Name != w.name || calls[i].Function.Arguments != w.args {
+			t.Errorf("call %d = %+v, want %+v", i, calls[i], w)
+		}
+	}
+	if p.GetFinishReason() != "tool_calls" {
+		t.Errorf("finish reason = %q", p.GetFinishReason())
+	}
+}
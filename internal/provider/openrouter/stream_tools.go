@@ -0,0 +1,30 @@
+package openrouter
+
+import "github.com/mandalnilabja/goatway/internal/types"
+
+// mergeToolCall folds a tool-call delta into the call at the same index.
+// The first delta carries id and name; later deltas append argument fragments.
+func (p *StreamProcessor) mergeToolCall(delta types.ToolCall) {
+	idx := len(p.toolCalls)
+	if delta.Index != nil {
+		idx = *delta.Index
+	}
+	for len(p.toolCalls) <= idx {
+		p.toolCalls = append(p.toolCalls, types.ToolCall{Type: types.ToolTypeFunction})
+	}
+
+	call := &p.toolCalls[idx]
+	if delta.ID != "" {
+		call.ID = delta.ID
+	}
+	if delta.Type != "" {
+		call.Type = delta.Type
+	}
+	call.Function.Name += delta.Function.Name
+	call.Function.Arguments += delta.Function.Arguments
+}
+
+// GetToolCalls returns the tool calls reconstructed from the stream.
+func (p *StreamProcessor) GetToolCalls() []types.ToolCall {
+	return p.toolCalls
+}
@@ -0,0 +1,54 @@
+package openrouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestProxyResult_CountsToolCalls(t *testing.T) {
+	toolCalls := `[{"id":"call_0","type":"function","function":{"name":"f","arguments":"{}"}},` +
+		`{"id":"call_1","type":"function","function":{"name":"g","arguments":"{}"}}]`
+
+	tests := []struct {
+		name      string
+		streaming bool
+		body      string
+		want      int
+	}{
+		{"json tool calls", false, `{"model":"m","choices":[{"index":0,"message":{"role":"assistant","tool_calls":` + toolCalls + `},"finish_reason":"tool_calls"}]}`, 2},
+		{"json text only", false, `{"model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`, 0},
+		{"stream tool calls", true, `data: {"model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_0","type":"function","function":{"name":"f","arguments":"{}"}},{"index":1,"id":"call_1","type":"function","function":{"name":"g","arguments":"{}"}}]}}]}` + "\n\ndata: [DONE]\n\n", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType := "application/json"
+			if tt.streaming {
+				contentType = "text/event-stream"
+			}
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{contentType}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			handle := handleJSONResponse
+			if tt.streaming {
+				handle = handleStreamingResponse
+			}
+			result, err := handle(httptest.NewRecorder(), resp, &types.ProxyResult{}, time.Now(), &types.ProxyOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ToolCalls != tt.want {
+				t.Errorf("ToolCalls = %d, want %d", result.ToolCalls, tt.want)
+			}
+		})
+	}
+}
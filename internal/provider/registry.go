@@ -1,14 +1,119 @@
 package provider
 
-import "github.com/mandalnilabja/goatway/internal/provider/openrouter"
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
 
-// NewProviders returns a map of all available LLM providers.
-// The map key is the provider identifier used in config routing.
-func NewProviders() map[string]Provider {
-	return map[string]Provider{
-		"openrouter": openrouter.New(),
-		// Future providers:
-		// "openai": openai.New(),
-		// "ollama": ollama.New(),
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider/bedrock"
+	"github.com/mandalnilabja/goatway/internal/provider/groq"
+	"github.com/mandalnilabja/goatway/internal/provider/openai"
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+)
+
+// factory builds a provider instance from its config entry and the shared pool settings.
+type factory func(def config.ProviderDef, pool upstream.PoolConfig) (Provider, error)
+
+// registry maps a provider type to its constructor.
+// Future providers: "ollama".
+var registry = map[string]factory{
+	"openrouter": func(def config.ProviderDef, pool upstream.PoolConfig) (Provider, error) {
+		return openrouter.NewWithPool(def.BaseURL, pool), nil
+	},
+	"bedrock": func(def config.ProviderDef, pool upstream.PoolConfig) (Provider, error) {
+		if def.BaseURL != "" {
+			return nil, fmt.Errorf("provider %q: bedrock does not support base_url", def.Name)
+		}
+		return bedrock.NewWithPool(pool), nil
+	},
+	"groq": func(def config.ProviderDef, pool upstream.PoolConfig) (Provider, error) {
+		return groq.NewWithPool(def.BaseURL, pool), nil
+	},
+	"openai": func(def config.ProviderDef, pool upstream.PoolConfig) (Provider, error) {
+		return openai.NewWithPool(def.BaseURL, pool), nil
+	},
+}
+
+// UpstreamPool returns the connection pool and host allowlist from cfg that
+// every upstream client shares.
+func UpstreamPool(cfg *config.Config) upstream.PoolConfig {
+	return upstream.PoolConfig{
+		MaxIdleConns:    cfg.MaxIdleConns,
+		MaxConnsPerHost: cfg.MaxConnsPerHost,
+		IdleConnTimeout: cfg.IdleConnTimeout,
+		AllowedHosts:    cfg.UpstreamHosts,
+	}
+}
+
+// NewProviders builds the providers listed in cfg.Providers.
+// The map key is the provider name used in config routing. When no providers
+// are configured every built-in type is built under its own name.
+func NewProviders(cfg *config.Config) (map[string]Provider, error) {
+	defs, implicit := cfg.Providers, false
+	if len(defs) == 0 {
+		defs, implicit = builtinDefs(), true
+	}
+
+	pool := UpstreamPool(cfg)
+	providers := make(map[string]Provider, len(defs))
+	for _, def := range defs {
+		def.Type = strings.TrimSpace(def.Type)
+		if def.Name = strings.TrimSpace(def.Name); def.Name == "" {
+			def.Name = def.Type
+		}
+
+		build, ok := registry[def.Type]
+		if !ok {
+			return nil, fmt.Errorf("provider %q: unknown type %q", def.Name, def.Type)
+		}
+		if _, dup := providers[def.Name]; dup {
+			return nil, fmt.Errorf("provider %q: defined more than once", def.Name)
+		}
+		p, err := build(def, pool)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBaseURL(def.Name, p.BaseURL(), pool.AllowedHosts); err != nil {
+			if !implicit {
+				return nil, err
+			}
+			// Unlisted built-ins are optional; skip those the allowlist excludes
+			slog.Warn("skipping built-in provider", "error", err)
+			continue
+		}
+		providers[def.Name] = withModelTransform(p, def)
+	}
+	return providers, nil
+}
+
+// checkBaseURL rejects a provider whose effective endpoint (its base_url or
+// built-in default) is outside the allowlist at startup, rather than on the
+// provider's first request. Providers without a fixed endpoint (bedrock,
+// which derives it from the credential's region) are checked per request.
+func checkBaseURL(name, baseURL string, allowed []string) error {
+	if baseURL == "" || allowed == nil {
+		return nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("provider %q: invalid base_url %q", name, baseURL)
+	}
+	if !upstream.HostAllowed(u.Hostname(), allowed) {
+		return fmt.Errorf("provider %q: endpoint host %q is not in UPSTREAM_ALLOWED_HOSTS", name, u.Hostname())
+	}
+	return nil
+}
+
+// builtinDefs returns one entry per registered provider type, sorted by type.
+func builtinDefs() []config.ProviderDef {
+	defs := make([]config.ProviderDef, 0, len(registry))
+	for typ := range registry {
+		defs = append(defs, config.ProviderDef{Name: typ, Type: typ})
 	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Type < defs[j].Type })
+	return defs
 }
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestNewProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		defs     []config.ProviderDef
+		hosts    []string          // UPSTREAM_ALLOWED_HOSTS (nil allows any)
+		want     map[string]string // routing name -> provider Name()
+		wantURL  map[string]string // routing name -> BaseURL() (optional)
+		wantFail bool
+	}{
+		{
+			name: "no config builds all built-ins",
+			want: map[string]string{"openrouter": "openrouter", "bedrock": "bedrock", "groq": "groq", "openai": "openai"},
+		},
+		{
+			name: "only listed providers are built",
+			defs: []config.ProviderDef{{Type: "bedrock"}},
+			want: map[string]string{"bedrock": "bedrock"},
+		},
+		{
+			name: "named instances with base url override",
+			defs: []config.ProviderDef{
+				{Type: "openrouter"},
+				{Name: "gateway", Type: "openrouter", BaseURL: "https://gw.example/v1/chat/completions"},
+				{Name: "aws", Type: "bedrock"},
+				{Type: "groq"},
+				{Type: "openai"},
+			},
+			want: map[string]string{"openrouter": "openrouter", "gateway": "openrouter", "aws": "bedrock", "groq": "groq", "openai": "openai"},
+			wantURL: map[string]string{
+				"openrouter": "https://openrouter.ai/api/v1/chat/completions",
+				"gateway":    "https://gw.example/v1/chat/completions",
+				"groq":       "https://api.groq.com/openai/v1/chat/completions",
+				"openai":     "https://api.openai.com/v1/chat/completions",
+			},
+		},
+		{
+			name:     "unknown type",
+			defs:     []config.ProviderDef{{Type: "ollama"}},
+			wantFail: true,
+		},
+		{
+			name:     "duplicate name",
+			defs:     []config.ProviderDef{{Type: "openrouter"}, {Name: "openrouter", Type: "bedrock"}},
+			wantFail: true,
+		},
+		{
+			name:  "base url on the host allowlist",
+			defs:  []config.ProviderDef{{Name: "gateway", Type: "openrouter", BaseURL: "https://gw.example/v1/chat/completions"}},
+			hosts: []string{"openrouter.ai", "gw.example"},
+			want:  map[string]string{"gateway": "openrouter"},
+		},
+		{
+			name:     "base url off the host allowlist",
+			defs:     []config.ProviderDef{{Name: "internal", Type: "groq", BaseURL: "http://169.254.169.254/latest"}},
+			hosts:    []string{"openrouter.ai", "api.groq.com"},
+			wantFail: true,
+		},
+		{
+			name:     "default endpoint off the host allowlist",
+			defs:     []config.ProviderDef{{Type: "groq"}},
+			hosts:    []string{"openrouter.ai"},
+			wantFail: true,
+		},
+		{
+			name:  "built-ins off the host allowlist are skipped",
+			hosts: []string{"api.openai.com"},
+			want:  map[string]string{"bedrock": "bedrock", "openai": "openai"},
+		},
+		{
+			name:     "bedrock rejects base url",
+			defs:     []config.ProviderDef{{Type: "bedrock", BaseURL: "https://example.test"}},
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers, err := NewProviders(&config.Config{Providers: tt.defs, UpstreamConfig: config.UpstreamConfig{UpstreamHosts: tt.hosts}})
+			if tt.wantFail {
+				if err == nil {
+					t.Fatalf("expected error, got providers %v", providers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewProviders: %v", err)
+			}
+			if len(providers) != len(tt.want) {
+				t.Errorf("got %d providers, want %d", len(providers), len(tt.want))
+			}
+			for name, typ := range tt.want {
+				p, ok := providers[name]
+				if !ok {
+					t.Errorf("missing provider %q", name)
+					continue
+				}
+				if p.Name() != typ {
+					t.Errorf("providers[%q].Name() = %q, want %q", name, p.Name(), typ)
+				}
+			}
+			for name, url := range tt.wantURL {
+				if got := providers[name].BaseURL(); got != url {
+					t.Errorf("providers[%q].BaseURL() = %q, want %q", name, got, url)
+				}
+			}
+		})
+	}
+}
+
+func TestNewProviders_RoutesAliasToNamedInstance(t *testing.T) {
+	cfg := &config.Config{
+		Providers: []config.ProviderDef{{Type: "openrouter"}, {Name: "aws", Type: "bedrock"}},
+		Models: []config.ModelAlias{
+			{Slug: "haiku", Provider: "aws", Model: "anthropic.claude-3-haiku-20240307-v1:0"},
+		},
+	}
+	providers, err := NewProviders(cfg)
+	if err != nil {
+		t.Fatalf("NewProviders: %v", err)
+	}
+
+	r := NewRouter(providers, cfg, newTestStore(t))
+	route, ok := r.routes.Load().slugMap["haiku"]
+	if !ok || route.provider.Name() != "bedrock" {
+		t.Fatalf("alias not routed to bedrock instance: %+v", route)
+	}
+}
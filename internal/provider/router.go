@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
 	"github.com/mandalnilabja/goatway/internal/storage"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
@@ -14,41 +17,65 @@ import (
 // ErrModelNotFound is returned when a model slug cannot be resolved.
 var ErrModelNotFound = errors.New("model not found")
 
-// resolvedRoute holds a pre-resolved provider and model for fast lookup.
-type resolvedRoute struct {
-	provider       types.Provider
-	model          string
-	credentialName string // From config alias or [default]
-}
+// ErrProviderMismatch is returned when the provider hint cannot serve the model.
+var ErrProviderMismatch = errors.New("provider cannot serve model")
+
+// ProviderHintHeader lets a client pick which provider serves a slug that is
+// aliased on several providers. The value is the provider's routing name.
+const ProviderHintHeader = "X-Goatway-Model-Provider"
 
 // Router routes requests to the appropriate provider based on model aliases.
 // It implements the types.Provider interface.
 type Router struct {
 	providers    map[string]types.Provider
-	slugMap      map[string]*resolvedRoute // Pre-resolved for O(1) lookup
+	configRoutes []aliasRoute               // Resolved [[models]] from the config file
+	store        storage.Storage            // Source of runtime-managed aliases
+	routes       atomic.Pointer[routeTable] // Swapped whole by ReloadAliases
 	default_     *config.DefaultRoute
 	credResolver *CredentialResolver
+	credLimits   map[string]*modelLimiter // Concurrency caps by credential name
+	health       *HealthTracker
+	idleTimeout  time.Duration
+	firstByte    time.Duration // First-byte deadline per upstream attempt (0 = none)
+	parseLimit   int64
+	normalizeSSE bool
+	streamReqID  bool
+	stripHeaders []string
+	strict       bool // Reject unaliased slugs instead of using default_
+	echoAlias    bool // Report the requested slug as the response model
+	modelHints   bool // Suggest and list aliases in "Model not found" errors
+
+	endpointDefaults map[string]config.DefaultRoute // Per-endpoint overrides of default_
+	tenants          map[string]*tenant             // Isolated routing configs selected by TenantHeader
 }
 
 // NewRouter creates a Router with pre-resolved model aliases and credential resolution.
 func NewRouter(providers map[string]types.Provider, cfg *config.Config, store storage.Storage) *Router {
 	r := &Router{
 		providers:    providers,
-		slugMap:      make(map[string]*resolvedRoute),
+		store:        store,
 		default_:     cfg.Default,
 		credResolver: NewCredentialResolver(store, 5*time.Minute),
+		credLimits:   newCredentialLimiters(cfg.CredentialLimits),
+		health:       NewHealthTracker().WithAlert(NewErrorRateAlert(cfg.ErrorAlertPercent, cfg.ErrorAlertWindow, nil)),
+		idleTimeout:  cfg.StreamIdleTimeout,
+		firstByte:    cfg.FirstByteTimeout,
+		parseLimit:   cfg.ResponseParseLimit,
+		normalizeSSE: cfg.NormalizeSSE,
+		streamReqID:  cfg.StreamRequestID,
+		stripHeaders: cfg.StripHeaders,
+		strict:       cfg.StrictAliases,
+		echoAlias:    cfg.RewriteResponseModel,
+		modelHints:   cfg.ModelNotFoundHints,
+
+		endpointDefaults: cfg.EndpointDefaults,
 	}
 
-	// Build slug map at startup (not per-request)
-	for _, alias := range cfg.Models {
-		if p, ok := providers[alias.Provider]; ok {
-			r.slugMap[alias.Slug] = &resolvedRoute{
-				provider:       p,
-				model:          alias.Model,
-				credentialName: alias.CredentialName,
-			}
-		}
-	}
+	warnUnknownEndpoints(cfg.EndpointDefaults)
+
+	// Build routes at startup (not per-request)
+	r.loadRoutes(cfg.Models)
+	r.tenants = r.newTenants(cfg.Tenants)
 	return r
 }
 
@@ -69,9 +96,10 @@ func (r *Router) PrepareRequest(ctx context.Context, req *http.Request) error {
 
 // ProxyRequest resolves the model and credentials, then delegates to the appropriate provider.
 func (r *Router) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
-	resolved, err := r.resolveModel(opts.Model)
+	start := time.Now()
+	scope, err := r.scope(req)
 	if err != nil {
-		http.Error(w, "Model not found: "+opts.Model, http.StatusBadRequest)
+		http.Error(w, "Unknown tenant: "+types.Tenant(req.Context()), http.StatusBadRequest)
 		return &types.ProxyResult{
 			Model:      opts.Model,
 			StatusCode: http.StatusBadRequest,
@@ -79,55 +107,38 @@ func (r *Router) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *h
 		}, err
 	}
 
-	// Check if credential name is configured
-	if resolved.credentialName == "" {
-		http.Error(w, "No credential configured for model: "+opts.Model, http.StatusUnauthorized)
-		return &types.ProxyResult{
-			Model:      opts.Model,
-			StatusCode: http.StatusUnauthorized,
-			Error:      errors.New("no credential configured"),
-		}, errors.New("no credential configured")
-	}
-
-	// Resolve credential by name
-	cred, err := r.credResolver.Resolve(resolved.credentialName)
+	hint := strings.TrimSpace(req.Header.Get(ProviderHintHeader))
+	resolved, err := r.resolveModel(scope, opts.Model, hint)
 	if err != nil {
-		http.Error(w, "Credential not found: "+resolved.credentialName, http.StatusUnauthorized)
+		message := r.modelNotFoundMessage(scope, opts.Model)
+		if errors.Is(err, ErrProviderMismatch) {
+			message = "Provider " + hint + " cannot serve model: " + opts.Model
+		}
+		http.Error(w, message, http.StatusBadRequest)
 		return &types.ProxyResult{
 			Model:      opts.Model,
-			StatusCode: http.StatusUnauthorized,
+			StatusCode: http.StatusBadRequest,
 			Error:      err,
 		}, err
 	}
 
-	// Set credential and model, then delegate
-	opts.Credential = cred
-	opts.Model = resolved.model
-	return resolved.provider.ProxyRequest(ctx, w, req, opts)
-}
-
-// resolveModel performs O(1) lookup for a model slug.
-func (r *Router) resolveModel(slug string) (*resolvedRoute, error) {
-	// Check explicit aliases first
-	if route, ok := r.slugMap[slug]; ok {
-		return route, nil
+	if r.echoAlias {
+		opts.ResponseModel = opts.Model
 	}
-
-	// Fall back to default provider if configured
-	if r.default_ != nil {
-		if p, ok := r.providers[r.default_.Provider]; ok {
-			return &resolvedRoute{
-				provider:       p,
-				model:          slug, // Use original slug as model name
-				credentialName: r.default_.CredentialName,
-			}, nil
-		}
+	slug := opts.Model
+	result, err := r.forward(ctx, w, req, opts, scope, resolved, slug, start)
+	if errors.Is(err, upstream.ErrFirstByteTimeout) {
+		return r.fallBack(ctx, w, req, opts, scope, slug, resolved.fallbacks, result, err)
 	}
-
-	return nil, ErrModelNotFound
+	return result, err
 }
 
 // CredentialResolver returns the credential resolver for cache invalidation.
 func (r *Router) CredentialResolver() *CredentialResolver {
 	return r.credResolver
 }
+
+// Health returns the per-provider health tracker.
+func (r *Router) Health() *HealthTracker {
+	return r.health
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// routeTable is the set of alias routes a Router resolves against. It is
+// rebuilt whole and swapped in, so lookups never see a half-applied reload.
+type routeTable struct {
+	slugMap    map[string]*resolvedRoute            // Pre-resolved for O(1) lookup
+	candidates map[string]map[string]*resolvedRoute // Slug -> provider name -> route, for hints
+}
+
+// loadRoutes installs the config aliases, then merges in the stored ones.
+// A storage failure leaves the router serving the config aliases alone.
+func (r *Router) loadRoutes(aliases []config.ModelAlias) {
+	r.configRoutes = r.resolveAliases(aliases)
+	r.routes.Store(r.buildRoutes(nil))
+	if err := r.ReloadAliases(); err != nil {
+		slog.Warn("stored model aliases not loaded, serving config aliases only", "error", err)
+	}
+}
+
+// ReloadAliases rebuilds the routes from the config aliases and the aliases
+// in storage, which replace config aliases with the same slug. On a storage
+// error the current routes stay in place.
+func (r *Router) ReloadAliases() error {
+	var stored []*storage.ModelAlias
+	if r.store != nil {
+		var err error
+		if stored, err = r.store.ListAliases(); err != nil {
+			return err
+		}
+	}
+	r.routes.Store(r.buildRoutes(stored))
+	return nil
+}
+
+// aliasRoute is a resolved alias before it is indexed by slug.
+type aliasRoute struct {
+	slug, provider string
+	route          *resolvedRoute
+}
+
+// resolveAliases resolves aliases against the registered providers,
+// skipping those that name an unknown provider.
+func (r *Router) resolveAliases(aliases []config.ModelAlias) []aliasRoute {
+	var resolved []aliasRoute
+	for _, alias := range aliases {
+		p, ok := r.providers[alias.Provider]
+		if !ok {
+			continue
+		}
+		resolved = append(resolved, aliasRoute{slug: alias.Slug, provider: alias.Provider, route: &resolvedRoute{
+			provider:       p,
+			model:          alias.Model,
+			credentialName: alias.CredentialName,
+			limiter:        newModelLimiter(alias.MaxConcurrent, time.Duration(alias.QueueTimeout)*time.Second),
+			fallbacks:      alias.Fallbacks,
+			alias:          &alias,
+		}})
+	}
+	return resolved
+}
+
+// buildRoutes indexes the config routes, minus those whose slug a stored
+// alias replaces, together with the stored aliases. Config routes are reused
+// across reloads so their concurrency limiters keep counting.
+func (r *Router) buildRoutes(stored []*storage.ModelAlias) *routeTable {
+	overridden := make(map[string]bool, len(stored))
+	aliases := make([]config.ModelAlias, 0, len(stored))
+	for _, a := range stored {
+		overridden[a.Slug] = true
+		aliases = append(aliases, config.ModelAlias{Slug: a.Slug, Provider: a.Provider, Model: a.Model, CredentialName: a.CredentialName})
+	}
+	routes := r.resolveAliases(aliases)
+	for _, ar := range r.configRoutes {
+		if !overridden[ar.slug] {
+			routes = append(routes, ar)
+		}
+	}
+
+	return indexRoutes(routes)
+}
+
+// indexRoutes builds the lookup table for resolved alias routes.
+func indexRoutes(routes []aliasRoute) *routeTable {
+	t := &routeTable{
+		slugMap:    make(map[string]*resolvedRoute),
+		candidates: make(map[string]map[string]*resolvedRoute),
+	}
+	for _, ar := range routes {
+		t.slugMap[ar.slug] = ar.route
+		if t.candidates[ar.slug] == nil {
+			t.candidates[ar.slug] = make(map[string]*resolvedRoute)
+		}
+		t.candidates[ar.slug][ar.provider] = ar.route
+	}
+	return t
+}
+
+// HasProvider reports whether name is a registered provider routing name.
+func (r *Router) HasProvider(name string) bool {
+	_, ok := r.providers[name]
+	return ok
+}
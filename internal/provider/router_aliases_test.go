@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_ReloadAliases(t *testing.T) {
+	mock := &mockProvider{name: "openrouter"}
+	cfg := &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+			{Slug: "claude", Provider: "openrouter", Model: "anthropic/claude-3.5-sonnet", CredentialName: "test-cred"},
+		},
+		StrictAliases: true,
+	}
+	store := newTestStore(t)
+	router := NewRouter(map[string]types.Provider{"openrouter": mock}, cfg, store)
+
+	for _, alias := range []*models.ModelAlias{
+		{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o-mini", CredentialName: "test-cred"},
+		{Slug: "llama", Provider: "openrouter", Model: "meta-llama/llama-3.1-8b-instruct", CredentialName: "test-cred"},
+		{Slug: "orphan", Provider: "unregistered", Model: "m", CredentialName: "test-cred"},
+	} {
+		if err := store.CreateAlias(alias); err != nil {
+			t.Fatalf("CreateAlias: %v", err)
+		}
+	}
+
+	tests := []struct {
+		slug         string
+		beforeReload string // Upstream model before ReloadAliases ("" = not found)
+		afterReload  string
+	}{
+		{"gpt4", "openai/gpt-4o", "openai/gpt-4o-mini"},
+		{"claude", "anthropic/claude-3.5-sonnet", "anthropic/claude-3.5-sonnet"},
+		{"llama", "", "meta-llama/llama-3.1-8b-instruct"},
+		{"orphan", "", ""},
+	}
+
+	resolve := func(slug string) string {
+		mock.lastModel = ""
+		req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+		_, _ = router.ProxyRequest(context.Background(), httptest.NewRecorder(), req, &types.ProxyOptions{Model: slug})
+		return mock.lastModel
+	}
+
+	for _, tt := range tests {
+		if got := resolve(tt.slug); got != tt.beforeReload {
+			t.Errorf("before reload %s -> %q, want %q", tt.slug, got, tt.beforeReload)
+		}
+	}
+	if err := router.ReloadAliases(); err != nil {
+		t.Fatalf("ReloadAliases: %v", err)
+	}
+	for _, tt := range tests {
+		if got := resolve(tt.slug); got != tt.afterReload {
+			t.Errorf("after reload %s -> %q, want %q", tt.slug, got, tt.afterReload)
+		}
+	}
+
+	// Deleting the stored override restores the config alias
+	if err := store.DeleteAlias("gpt4"); err != nil {
+		t.Fatalf("DeleteAlias: %v", err)
+	}
+	if err := router.ReloadAliases(); err != nil {
+		t.Fatalf("ReloadAliases: %v", err)
+	}
+	if got := resolve("gpt4"); got != "openai/gpt-4o" {
+		t.Errorf("after delete gpt4 -> %q, want config alias", got)
+	}
+}
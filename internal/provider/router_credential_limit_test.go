@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_CredentialConcurrencyLimit(t *testing.T) {
+	prov := &blockingProvider{
+		mockProvider: mockProvider{name: "openrouter"},
+		blockModel:   "openai/o1",
+		entered:      make(chan struct{}, 8),
+		unblock:      make(chan struct{}),
+	}
+	cfg := &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "o1-a", Provider: "openrouter", Model: "openai/o1", CredentialName: "cred-a"},
+			{Slug: "gpt4-a", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "cred-a"},
+			{Slug: "o1-b", Provider: "openrouter", Model: "openai/o1", CredentialName: "cred-b"},
+			{Slug: "gpt4-c", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "cred-c"},
+		},
+		CredentialLimits: map[string]config.CredentialLimit{
+			"cred-a": {MaxConcurrent: 1},
+			"cred-b": {MaxConcurrent: 1},
+		},
+	}
+	store := newTestStore(t,
+		&models.Credential{Name: "cred-a", Provider: "openrouter"},
+		&models.Credential{Name: "cred-b", Provider: "openrouter"},
+		&models.Credential{Name: "cred-c", Provider: "openrouter"},
+	)
+	router := NewRouter(map[string]types.Provider{"openrouter": prov}, cfg, store)
+
+	heldA := proxyAsync(router, "o1-a")
+	<-prov.entered
+
+	// Another alias on the saturated credential is rejected
+	w := httptest.NewRecorder()
+	_, err := router.ProxyRequest(context.Background(), w, httptest.NewRequest("POST", "/v1/chat/completions", nil), &types.ProxyOptions{Model: "gpt4-a"})
+	if w.Code != http.StatusTooManyRequests || !errors.Is(err, ErrCredentialBusy) {
+		t.Errorf("gpt4-a on busy cred-a: status = %d, err = %v; want 429, ErrCredentialBusy", w.Code, err)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("429 without Retry-After")
+	}
+
+	// Each credential has its own cap, and uncapped ones have none
+	heldB := proxyAsync(router, "o1-b")
+	<-prov.entered
+	if code := <-proxyAsync(router, "gpt4-c"); code != http.StatusOK {
+		t.Errorf("uncapped cred-c: status = %d, want 200", code)
+	}
+
+	close(prov.unblock)
+	for _, done := range []<-chan int{heldA, heldB} {
+		if code := <-done; code != http.StatusOK {
+			t.Errorf("held request: status = %d, want 200", code)
+		}
+	}
+	if code := <-proxyAsync(router, "gpt4-a"); code != http.StatusOK {
+		t.Errorf("gpt4-a after cred-a freed: status = %d, want 200", code)
+	}
+}
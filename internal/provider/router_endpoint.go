@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+// endpointGroups are the proxy endpoints a default route can be set for.
+var endpointGroups = []string{"chat", "completions", "embeddings", "audio", "images", "moderations"}
+
+// endpointOf returns the endpoint group of a proxy path: the first segment
+// after /v1/ ("chat", "embeddings", "audio", "images", "moderations", ...),
+// matching the group names used by disabled_endpoints.
+func endpointOf(path string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(path, "/v1/"), "/")
+	return group
+}
+
+// defaultRoute returns the default route for unaliased slugs on req's
+// endpoint: its [endpoint_defaults] entry if configured, else [default].
+func (r *Router) defaultRoute(req *http.Request) *config.DefaultRoute {
+	if req != nil {
+		if def, ok := r.endpointDefaults[endpointOf(req.URL.Path)]; ok {
+			return &def
+		}
+	}
+	return r.default_
+}
+
+// warnUnknownEndpoints logs [endpoint_defaults] entries that match no endpoint,
+// since a misspelled name silently leaves that endpoint on [default].
+func warnUnknownEndpoints(defaults map[string]config.DefaultRoute) {
+	for name := range defaults {
+		if !slices.Contains(endpointGroups, name) {
+			slog.Warn("unknown endpoint in endpoint defaults", "endpoint", name, "known", endpointGroups)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_EndpointDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		hint       string
+		wantStatus int
+		wantProv   string
+		wantCred   string
+	}{
+		{"chat uses [default]", "/v1/chat/completions", "", http.StatusOK, "openrouter", "or-cred"},
+		{"speech uses audio default", "/v1/audio/speech", "", http.StatusOK, "groq", "groq-cred"},
+		{"transcription uses audio default", "/v1/audio/transcriptions", "", http.StatusOK, "groq", "groq-cred"},
+		{"unset endpoint falls back", "/v1/embeddings", "", http.StatusOK, "openrouter", "or-cred"},
+		{"hint must match endpoint default", "/v1/audio/speech", "openrouter", http.StatusBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openrouter := &mockProvider{name: "openrouter"}
+			groq := &mockProvider{name: "groq"}
+			cfg := &config.Config{
+				Default:          &config.DefaultRoute{Provider: "openrouter", CredentialName: "or-cred"},
+				EndpointDefaults: map[string]config.DefaultRoute{"audio": {Provider: "groq", CredentialName: "groq-cred"}},
+			}
+			store := newTestStore(t,
+				&models.Credential{ID: "or-cred", Name: "or-cred", Provider: "openrouter"},
+				&models.Credential{ID: "groq-cred", Name: "groq-cred", Provider: "groq"},
+			)
+			router := NewRouter(map[string]types.Provider{"openrouter": openrouter, "groq": groq}, cfg, store)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.hint != "" {
+				req.Header.Set(ProviderHintHeader, tt.hint)
+			}
+			opts := &types.ProxyOptions{Model: "whisper-large-v3"}
+
+			_, _ = router.ProxyRequest(context.Background(), w, req, opts)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if opts.Credential == nil || opts.Credential.Name != tt.wantCred || opts.Credential.Provider != tt.wantProv {
+				t.Errorf("credential = %+v, want %s for %s", opts.Credential, tt.wantCred, tt.wantProv)
+			}
+			served := map[string]*mockProvider{"openrouter": openrouter, "groq": groq}[tt.wantProv]
+			if served.lastModel != "whisper-large-v3" {
+				t.Errorf("%s provider got model %q, want the unaliased slug", tt.wantProv, served.lastModel)
+			}
+		})
+	}
+}
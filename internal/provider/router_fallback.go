@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// fallBack re-sends a request whose upstream missed the first-byte deadline to
+// each fallback slug in turn, resolved in the request's scope. Slugs that no
+// longer resolve, or that opts.AllowFallback rejects, are skipped. The first
+// attempt that does not time out answers the client; when every one does (or
+// the body cannot be replayed) the router answers 504 itself, since providers
+// leave first-byte timeouts unanswered. The returned result counts every
+// attempt in Attempts.
+func (r *Router) fallBack(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions, scope *routeScope, slug string, fallbacks []string, result *types.ProxyResult, err error) (*types.ProxyResult, error) {
+	attempts := attemptsOf(result)
+	for _, next := range fallbacks {
+		route, resolveErr := r.resolveModel(scope, next, "")
+		if resolveErr != nil {
+			continue
+		}
+		if opts.AllowFallback != nil {
+			if limitErr := opts.AllowFallback(next); limitErr != nil {
+				slog.Warn("skipping fallback", "model", slug, "fallback", next, "reason", limitErr)
+				continue
+			}
+		}
+		if opts.ResetBody() != nil {
+			break
+		}
+		slog.Warn("upstream missed first-byte deadline, trying fallback",
+			"model", slug, "fallback", next, "timeout", r.firstByte)
+		result, err = r.forward(ctx, w, req, opts, scope, route, next, time.Now())
+		attempts += attemptsOf(result)
+		if !errors.Is(err, upstream.ErrFirstByteTimeout) {
+			break
+		}
+		slug = next
+	}
+
+	if errors.Is(err, upstream.ErrFirstByteTimeout) {
+		http.Error(w, "Gateway Timeout: upstream did not respond within the first-byte deadline", http.StatusGatewayTimeout)
+	}
+	if result != nil {
+		result.Attempts = attempts
+	}
+	return result, err
+}
+
+// attemptsOf returns the upstream calls behind result (0 for nil).
+func attemptsOf(result *types.ProxyResult) int {
+	if result == nil {
+		return 0
+	}
+	return result.Attempts
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// newTimedUpstream returns an OpenAI-compatible provider whose upstream waits
+// delay before answering, and the channel of models it was asked for.
+func newTimedUpstream(t *testing.T, name string, delay time.Duration) (types.Provider, <-chan string) {
+	t.Helper()
+	models := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		models <- body.Model
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"` + body.Model + `","choices":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return openrouter.NewCompatible(name, srv.URL, upstream.DefaultPool(), nil), models
+}
+
+func TestRouter_FirstByteFallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		fallbacks  []string
+		backup     time.Duration // Delay of the fallback upstream
+		wantStatus int
+		wantModel  string
+		wantTries  int // Upstream attempts reported in the result
+	}{
+		{"slow primary falls back", []string{"backup"}, 0, http.StatusOK, "backup-model", 2},
+		{"unknown fallback skipped", []string{"missing", "backup"}, 0, http.StatusOK, "backup-model", 2},
+		{"no fallbacks answers 504", nil, 0, http.StatusGatewayTimeout, "", 1},
+		{"slow fallback answers 504", []string{"backup"}, time.Second, http.StatusGatewayTimeout, "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slow, _ := newTimedUpstream(t, "slow", time.Second)
+			fast, backupModels := newTimedUpstream(t, "fast", tt.backup)
+			cfg := &config.Config{
+				Models: []config.ModelAlias{
+					{Slug: "primary", Provider: "slow", Model: "primary-model", CredentialName: "test-cred", Fallbacks: tt.fallbacks},
+					{Slug: "backup", Provider: "fast", Model: "backup-model", CredentialName: "test-cred"},
+				},
+				UpstreamConfig: config.UpstreamConfig{FirstByteTimeout: 50 * time.Millisecond},
+			}
+			router := NewRouter(map[string]types.Provider{"slow": slow, "fast": fast}, cfg, newTestStore(t))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+			opts := &types.ProxyOptions{Model: "primary", Body: bytes.NewReader([]byte(`{"model":"primary","messages":[]}`))}
+
+			result, err := router.ProxyRequest(context.Background(), w, req, opts)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if result.Attempts != tt.wantTries {
+				t.Errorf("attempts = %d, want %d", result.Attempts, tt.wantTries)
+			}
+			if tt.wantStatus == http.StatusGatewayTimeout {
+				if !errors.Is(err, upstream.ErrFirstByteTimeout) || result.StatusCode != http.StatusGatewayTimeout {
+					t.Errorf("err = %v, status = %d, want first byte timeout", err, result.StatusCode)
+				}
+				return
+			}
+			if err != nil || result.Model != tt.wantModel {
+				t.Errorf("result model = %q, err = %v, want %q", result.Model, err, tt.wantModel)
+			}
+			if got := <-backupModels; got != tt.wantModel {
+				t.Errorf("fallback upstream got model %q, want replayed body for %q", got, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestRouter_FallbackSkippedByAllowFallback(t *testing.T) {
+	slow, _ := newTimedUpstream(t, "slow", time.Second)
+	fast, backupModels := newTimedUpstream(t, "fast", 0)
+	cfg := &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "primary", Provider: "slow", Model: "primary-model", CredentialName: "test-cred", Fallbacks: []string{"backup"}},
+			{Slug: "backup", Provider: "fast", Model: "backup-model", CredentialName: "test-cred"},
+		},
+		UpstreamConfig: config.UpstreamConfig{FirstByteTimeout: 50 * time.Millisecond},
+	}
+	router := NewRouter(map[string]types.Provider{"slow": slow, "fast": fast}, cfg, newTestStore(t))
+
+	w := httptest.NewRecorder()
+	opts := &types.ProxyOptions{
+		Model:         "primary",
+		Body:          bytes.NewReader([]byte(`{"model":"primary","messages":[]}`)),
+		AllowFallback: func(slug string) error { return errors.New("over " + slug + " limits") },
+	}
+	result, _ := router.ProxyRequest(context.Background(), w, httptest.NewRequest("POST", "/v1/chat/completions", nil), opts)
+	if w.Code != http.StatusGatewayTimeout || result.Attempts != 1 {
+		t.Errorf("status = %d, attempts = %d; want 504 after the primary only", w.Code, result.Attempts)
+	}
+	select {
+	case got := <-backupModels:
+		t.Errorf("rejected fallback was called for %q", got)
+	default:
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// forward admits the request on route, resolves its credential within scope
+// and delegates to the route's provider. start is when routing began, for
+// GatewayOverhead.
+func (r *Router) forward(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions, scope *routeScope, route *resolvedRoute, slug string, start time.Time) (*types.ProxyResult, error) {
+	release, busy, err := r.admit(ctx, w, route, slug)
+	if err != nil {
+		return busy, err
+	}
+	defer release()
+
+	cred, status, err := r.resolveCredential(ctx, scope, route, slug)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return &types.ProxyResult{
+			Model:      slug,
+			StatusCode: status,
+			Error:      err,
+		}, err
+	}
+	releaseCred, busy, err := r.admitCredential(ctx, w, cred, slug)
+	if err != nil {
+		return busy, err
+	}
+	defer releaseCred()
+
+	// Set credential and model, then delegate
+	opts.Credential = cred
+	opts.Model = route.model
+	opts.IdleTimeout = r.idleTimeout
+	opts.FirstByteTimeout = r.firstByte
+	opts.ParseLimit = r.parseLimit
+	opts.NormalizeSSE = r.normalizeSSE
+	opts.StreamRequestID = r.streamReqID
+	opts.StripHeaders = r.stripHeaders
+	overhead := time.Since(start)
+	result, err := route.provider.ProxyRequest(ctx, w, req, opts)
+	if result != nil {
+		result.GatewayOverhead = overhead
+		result.Attempts = 1
+	}
+	r.health.Record(route.provider.Name(), result, err)
+	return result, err
+}
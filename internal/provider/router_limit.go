@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// ErrModelBusy is returned when a model alias is at its concurrency cap.
+var ErrModelBusy = errors.New("model concurrency limit reached")
+
+// ErrCredentialBusy is returned when a credential is at its concurrency cap.
+var ErrCredentialBusy = errors.New("credential concurrency limit reached")
+
+// modelLimiter caps in-flight requests for one model alias or credential.
+// A nil limiter imposes no cap.
+type modelLimiter struct {
+	slots chan struct{}
+	wait  time.Duration // How long to queue for a slot; 0 rejects at once
+}
+
+// newModelLimiter returns a limiter for max concurrent requests, or nil when max <= 0.
+func newModelLimiter(max int, wait time.Duration) *modelLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &modelLimiter{slots: make(chan struct{}, max), wait: wait}
+}
+
+// acquire takes a slot, queueing up to l.wait, and returns the func that frees it.
+func (l *modelLimiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+	if l.wait <= 0 {
+		return nil, ErrModelBusy
+	}
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-timer.C:
+		return nil, ErrModelBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *modelLimiter) release() {
+	<-l.slots
+}
+
+// newCredentialLimiters returns a limiter per credential name with a cap.
+func newCredentialLimiters(limits map[string]config.CredentialLimit) map[string]*modelLimiter {
+	limiters := make(map[string]*modelLimiter, len(limits))
+	for name, limit := range limits {
+		if l := newModelLimiter(limit.MaxConcurrent, time.Duration(limit.QueueTimeout)*time.Second); l != nil {
+			limiters[name] = l
+		}
+	}
+	return limiters
+}
+
+// admit reserves a concurrency slot on route, writing 429 when none frees up in time.
+func (r *Router) admit(ctx context.Context, w http.ResponseWriter, route *resolvedRoute, slug string) (func(), *types.ProxyResult, error) {
+	return admitOn(ctx, w, route.limiter, ErrModelBusy, "Too many concurrent requests for model: "+slug, slug)
+}
+
+// admitCredential reserves a slot on the credential's cap, if it has one.
+// The 429 names the model rather than the credential.
+func (r *Router) admitCredential(ctx context.Context, w http.ResponseWriter, cred *models.Credential, slug string) (func(), *types.ProxyResult, error) {
+	return admitOn(ctx, w, r.credLimits[cred.Name], ErrCredentialBusy, "Too many concurrent requests on the credential for model: "+slug, slug)
+}
+
+// admitOn takes a slot on l, writing 429 with message and returning busy
+// when none frees up in time.
+func admitOn(ctx context.Context, w http.ResponseWriter, l *modelLimiter, busy error, message, slug string) (func(), *types.ProxyResult, error) {
+	release, err := l.acquire(ctx)
+	if err == nil {
+		return release, nil, nil
+	}
+	if errors.Is(err, ErrModelBusy) {
+		err = busy
+	}
+	retryAfter := max(int(l.wait.Seconds()), 1)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	http.Error(w, message, http.StatusTooManyRequests)
+	return nil, &types.ProxyResult{
+		Model:      slug,
+		StatusCode: http.StatusTooManyRequests,
+		Error:      err,
+	}, err
+}
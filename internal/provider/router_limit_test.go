@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// blockingProvider holds requests for blockModel until unblock is closed.
+type blockingProvider struct {
+	mockProvider
+	blockModel string
+	entered    chan struct{}
+	unblock    chan struct{}
+}
+
+func (p *blockingProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	if opts.Model == p.blockModel {
+		p.entered <- struct{}{}
+		<-p.unblock
+	}
+	w.WriteHeader(http.StatusOK)
+	return &types.ProxyResult{Model: opts.Model, StatusCode: http.StatusOK}, nil
+}
+
+func newLimitRouter(t *testing.T, maxConcurrent, queueTimeout int) (*Router, *blockingProvider) {
+	t.Helper()
+	prov := &blockingProvider{
+		mockProvider: mockProvider{name: "openrouter"},
+		blockModel:   "openai/o1",
+		entered:      make(chan struct{}, 8),
+		unblock:      make(chan struct{}),
+	}
+	cfg := &config.Config{Models: []config.ModelAlias{
+		{Slug: "o1", Provider: "openrouter", Model: "openai/o1", CredentialName: "test-cred", MaxConcurrent: maxConcurrent, QueueTimeout: queueTimeout},
+		{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+	}}
+	return NewRouter(map[string]types.Provider{"openrouter": prov}, cfg, newTestStore(t)), prov
+}
+
+// proxyAsync sends a request for slug and delivers the response status on the returned channel.
+func proxyAsync(router *Router, slug string) <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		_, _ = router.ProxyRequest(context.Background(), w, httptest.NewRequest("POST", "/v1/chat/completions", nil), &types.ProxyOptions{Model: slug})
+		done <- w.Code
+	}()
+	return done
+}
+
+func TestRouter_ModelConcurrencyLimit(t *testing.T) {
+	router, prov := newLimitRouter(t, 2, 0)
+
+	held := []<-chan int{proxyAsync(router, "o1"), proxyAsync(router, "o1")}
+	<-prov.entered
+	<-prov.entered
+
+	w := httptest.NewRecorder()
+	_, err := router.ProxyRequest(context.Background(), w, httptest.NewRequest("POST", "/v1/chat/completions", nil), &types.ProxyOptions{Model: "o1"})
+	if w.Code != http.StatusTooManyRequests || !errors.Is(err, ErrModelBusy) {
+		t.Errorf("third o1 request: status = %d, err = %v; want 429, ErrModelBusy", w.Code, err)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("429 without Retry-After")
+	}
+
+	if code := <-proxyAsync(router, "gpt4"); code != http.StatusOK {
+		t.Errorf("other model while o1 saturated: status = %d, want 200", code)
+	}
+
+	close(prov.unblock)
+	for _, done := range held {
+		if code := <-done; code != http.StatusOK {
+			t.Errorf("held o1 request: status = %d, want 200", code)
+		}
+	}
+	if code := <-proxyAsync(router, "o1"); code != http.StatusOK {
+		t.Errorf("o1 after slots freed: status = %d, want 200", code)
+	}
+}
+
+func TestRouter_ModelConcurrencyQueue(t *testing.T) {
+	router, prov := newLimitRouter(t, 1, 5)
+
+	first := proxyAsync(router, "o1")
+	<-prov.entered
+	queued := proxyAsync(router, "o1")
+
+	select {
+	case code := <-queued:
+		t.Fatalf("queued request finished early with %d", code)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(prov.unblock)
+	if code := <-first; code != http.StatusOK {
+		t.Errorf("first request: status = %d, want 200", code)
+	}
+	if code := <-queued; code != http.StatusOK {
+		t.Errorf("queued request: status = %d, want 200 once a slot frees", code)
+	}
+}
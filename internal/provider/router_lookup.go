@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+// Alias returns the alias slug resolves to for req, as the router would route
+// it: in the tenant bound to the request, or in the gateway's own routes,
+// where a stored alias replaces the config alias with its slug (limits and
+// price included). It returns nil when slug is not aliased in that scope,
+// including when it would use a default route or the tenant is unknown.
+func (r *Router) Alias(req *http.Request, slug string) *config.ModelAlias {
+	scope, err := r.scope(req)
+	if err != nil {
+		return nil
+	}
+	hint := ""
+	if req != nil {
+		hint = strings.TrimSpace(req.Header.Get(ProviderHintHeader))
+	}
+	route, err := r.resolveModel(scope, slug, hint)
+	if err != nil || route.alias == nil {
+		return nil
+	}
+	alias := *route.alias
+	return &alias
+}
+
+// Aliases returns every alias currently routed, in the gateway's routes
+// (stored aliases included) and in each tenant's, sorted by slug.
+func (r *Router) Aliases() []config.ModelAlias {
+	var aliases []config.ModelAlias
+	collect := func(t *routeTable) {
+		for _, byProvider := range t.candidates {
+			for _, route := range byProvider {
+				aliases = append(aliases, *route.alias)
+			}
+		}
+	}
+	collect(r.routes.Load())
+	for _, t := range r.tenants {
+		collect(t.routes)
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].Slug != aliases[j].Slug {
+			return aliases[i].Slug < aliases[j].Slug
+		}
+		return aliases[i].Provider < aliases[j].Provider
+	})
+	return aliases
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_Alias(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.CreateAlias(&models.ModelAlias{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o-mini", CredentialName: "test-cred"}); err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+	cfg := &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred", MaxOutputTokens: 100},
+			{Slug: "claude", Provider: "openrouter", Model: "anthropic/claude-3.5-sonnet", CredentialName: "test-cred", MaxOutputTokens: 200},
+		},
+		Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "test-cred"},
+		Tenants: map[string]config.TenantRoutes{
+			"acme": {Models: []config.ModelAlias{{Slug: "claude", Provider: "openrouter", Model: "acme/claude", CredentialName: "acme-cred", MaxOutputTokens: 50}}},
+		},
+	}
+	router := NewRouter(map[string]types.Provider{"openrouter": &mockProvider{name: "openrouter"}}, cfg, store)
+
+	tests := []struct {
+		name      string
+		tenant    string
+		slug      string
+		wantModel string // "" when no alias is returned
+		wantMax   int
+	}{
+		{"config alias", "", "claude", "anthropic/claude-3.5-sonnet", 200},
+		{"stored alias replaces limits", "", "gpt4", "openai/gpt-4o-mini", 0},
+		{"default route", "", "meta/llama", "", 0},
+		{"tenant alias", "acme", "claude", "acme/claude", 50},
+		{"not in tenant", "acme", "gpt4", "", 0},
+		{"unknown tenant", "globex", "claude", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+			if tt.tenant != "" {
+				req = req.WithContext(types.WithTenant(req.Context(), tt.tenant))
+			}
+			alias := router.Alias(req, tt.slug)
+			if tt.wantModel == "" {
+				if alias != nil {
+					t.Errorf("Alias = %+v, want nil", alias)
+				}
+				return
+			}
+			if alias == nil || alias.Model != tt.wantModel || alias.MaxOutputTokens != tt.wantMax {
+				t.Errorf("Alias = %+v, want model %q with max_output_tokens %d", alias, tt.wantModel, tt.wantMax)
+			}
+		})
+	}
+
+	var models []string
+	for _, a := range router.Aliases() {
+		models = append(models, a.Model)
+	}
+	want := []string{"anthropic/claude-3.5-sonnet", "acme/claude", "openai/gpt-4o-mini"}
+	if len(models) != len(want) {
+		t.Fatalf("Aliases models = %q, want %q", models, want)
+	}
+	seen := make(map[string]bool)
+	for _, m := range models {
+		seen[m] = true
+	}
+	for _, m := range want {
+		if !seen[m] {
+			t.Errorf("Aliases models = %q, missing %q", models, m)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// resolvedRoute holds a pre-resolved provider and model for fast lookup.
+type resolvedRoute struct {
+	provider       types.Provider
+	model          string
+	credentialName string             // From config alias or [default]
+	limiter        *modelLimiter      // Per-alias concurrency cap (nil = unlimited)
+	fallbacks      []string           // Slugs tried in order after a missed first-byte deadline
+	alias          *config.ModelAlias // The alias behind the route (nil for default routes)
+}
+
+// resolveModel performs O(1) lookup for a model slug in scope. Unaliased
+// slugs use the scope's default route (for the gateway's own routes, that of
+// the request's endpoint). A non-empty hint restricts the lookup to the named
+// provider's alias (or the default route when the hint names the default
+// provider and the slug is not aliased).
+func (r *Router) resolveModel(scope *routeScope, slug, hint string) (*resolvedRoute, error) {
+	if hint != "" {
+		return r.resolveHinted(scope, slug, hint)
+	}
+
+	// Check explicit aliases first
+	if route, ok := scope.routes.slugMap[slug]; ok {
+		return route, nil
+	}
+
+	// Fall back to default provider if configured (disabled in strict mode)
+	if def := scope.default_; def != nil && !r.strict {
+		if p, ok := r.providers[def.Provider]; ok {
+			return &resolvedRoute{
+				provider:       p,
+				model:          slug, // Use original slug as model name
+				credentialName: def.CredentialName,
+			}, nil
+		}
+	}
+
+	return nil, ErrModelNotFound
+}
+
+// resolveHinted returns the route for slug on the hinted provider.
+func (r *Router) resolveHinted(scope *routeScope, slug, hint string) (*resolvedRoute, error) {
+	if routes, aliased := scope.routes.candidates[slug]; aliased {
+		if route, ok := routes[hint]; ok {
+			return route, nil
+		}
+		return nil, ErrProviderMismatch
+	}
+	if scope.default_ == nil || r.strict {
+		return nil, ErrModelNotFound
+	}
+	if scope.default_.Provider != hint {
+		return nil, ErrProviderMismatch
+	}
+	return r.resolveModel(scope, slug, "")
+}
+
+// resolveCredential returns the credential for a route, or an error message and
+// HTTP status. An admin credential override replaces the route's credential but
+// must belong to the route's provider, and within a tenant to its credentials.
+func (r *Router) resolveCredential(ctx context.Context, scope *routeScope, resolved *resolvedRoute, slug string) (*models.Credential, int, error) {
+	if id := types.CredentialOverride(ctx); id != "" {
+		cred, err := r.credResolver.ResolveID(id)
+		if err != nil || cred == nil {
+			return nil, http.StatusUnauthorized, fmt.Errorf("Credential not found: %s", id)
+		}
+		if scope.tenant != nil && !scope.tenant.credentials[cred.Name] {
+			return nil, http.StatusForbidden, fmt.Errorf("Credential %s is not available to tenant %s", id, scope.tenant.name)
+		}
+		if cred.Provider != resolved.provider.Name() {
+			return nil, http.StatusBadRequest, fmt.Errorf("Credential %s is for provider %s, but model %s routes to %s",
+				id, cred.Provider, slug, resolved.provider.Name())
+		}
+		return cred, 0, nil
+	}
+
+	// Check if credential name is configured
+	if resolved.credentialName == "" {
+		return nil, http.StatusUnauthorized, fmt.Errorf("No credential configured for model: %s", slug)
+	}
+
+	// Resolve credential by name
+	cred, err := r.credResolver.Resolve(resolved.credentialName)
+	if err != nil {
+		return nil, http.StatusUnauthorized, fmt.Errorf("Credential not found: %s", resolved.credentialName)
+	}
+	return cred, 0, nil
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maxListedAliases caps how many aliases a "Model not found" error lists.
+const maxListedAliases = 20
+
+// maxSuggestDistance is the largest edit distance at which an alias is
+// suggested for a slug that did not resolve.
+const maxSuggestDistance = 3
+
+// modelNotFoundMessage returns the error for a slug that resolves to nothing
+// in scope. With hints enabled it names the closest alias and lists the
+// available ones, which reveals the alias names to any client.
+func (r *Router) modelNotFoundMessage(scope *routeScope, slug string) string {
+	message := "Model not found: " + slug
+	if !r.modelHints || len(scope.routes.slugMap) == 0 {
+		return message
+	}
+
+	slugs := slices.Sorted(maps.Keys(scope.routes.slugMap))
+	if match := closestSlug(slug, slugs); match != "" {
+		message += fmt.Sprintf(". Did you mean %q?", match)
+	} else {
+		message += "."
+	}
+	listed := slugs[:min(len(slugs), maxListedAliases)]
+	message += " Available models: " + strings.Join(listed, ", ")
+	if more := len(slugs) - len(listed); more > 0 {
+		message += fmt.Sprintf(" (and %d more)", more)
+	}
+	return message
+}
+
+// closestSlug returns the candidate nearest to slug by case-insensitive edit
+// distance, or "" when none is within maxSuggestDistance or the edits would
+// rewrite half or more of slug. Ties go to the first candidate.
+func closestSlug(slug string, candidates []string) string {
+	best, bestDist := "", maxSuggestDistance+1
+	limit := min(maxSuggestDistance, (len([]rune(slug))-1)/2)
+	for _, c := range candidates {
+		if d := levenshtein(strings.ToLower(slug), strings.ToLower(c)); d <= limit && d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_ModelNotFoundHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		hints    bool
+		model    string
+		wantBody string
+	}{
+		{"hints off", false, "gpt4o", "Model not found: gpt4o"},
+		{"near miss suggested", true, "gpt4o", `Model not found: gpt4o. Did you mean "gpt-4o"? Available models: claude-sonnet, gpt-4o, gpt-4o-mini`},
+		{"case-insensitive match", true, "Claude-Sonet", `Did you mean "claude-sonnet"?`},
+		{"no close match lists only", true, "llama", "Model not found: llama. Available models: claude-sonnet, gpt-4o, gpt-4o-mini"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				ModelNotFoundHints: tt.hints,
+				Models: []config.ModelAlias{
+					{Slug: "gpt-4o", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+					{Slug: "gpt-4o-mini", Provider: "openrouter", Model: "openai/gpt-4o-mini", CredentialName: "test-cred"},
+					{Slug: "claude-sonnet", Provider: "openrouter", Model: "anthropic/claude-sonnet-4", CredentialName: "test-cred"},
+				},
+			}
+			router := NewRouter(map[string]types.Provider{"openrouter": &mockProvider{name: "openrouter"}}, cfg, newTestStore(t))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			_, err := router.ProxyRequest(context.Background(), w, req, &types.ProxyOptions{Model: tt.model})
+
+			if err != ErrModelNotFound || w.Code != http.StatusBadRequest {
+				t.Fatalf("err = %v, status = %d, want ErrModelNotFound and 400", err, w.Code)
+			}
+			body := strings.TrimSpace(w.Body.String())
+			if !strings.Contains(body, tt.wantBody) || (!tt.hints && body != tt.wantBody) {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestClosestSlug(t *testing.T) {
+	candidates := []string{"gpt-4o", "gpt-4o-mini", "o1"}
+	tests := []struct {
+		slug, want string
+	}{
+		{"gpt-4", "gpt-4o"},
+		{"gpt-40-mini", "gpt-4o-mini"},
+		{"o3", ""}, // One edit, but it would rewrite half the slug
+		{"mistral-large", ""},
+	}
+	for _, tt := range tests {
+		if got := closestSlug(tt.slug, candidates); got != tt.want {
+			t.Errorf("closestSlug(%q) = %q, want %q", tt.slug, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// TenantHeader names the tenant whose routing config serves a request. The
+// router itself reads the tenant of the client's API key, which the auth
+// middleware puts in the request context; the header may only repeat it.
+// Requests without a tenant use the gateway's own routes.
+const TenantHeader = types.TenantHeader
+
+// ErrUnknownTenant is returned when the request's tenant is not configured.
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// routeScope is what a request resolves against: the gateway's routes and
+// endpoint default route, or a single tenant's.
+type routeScope struct {
+	routes   *routeTable
+	default_ *config.DefaultRoute
+	tenant   *tenant // nil outside a tenant
+}
+
+// tenant is one tenant's routing config, resolved at startup.
+type tenant struct {
+	name        string
+	routes      *routeTable
+	default_    *config.DefaultRoute
+	credentials map[string]bool // Credential names its routes use; the only ones it may reach
+}
+
+// newTenants resolves the [tenants] config against the registered providers.
+// Tenant aliases are not merged with stored aliases, which belong to the
+// gateway's own routes.
+func (r *Router) newTenants(cfg map[string]config.TenantRoutes) map[string]*tenant {
+	tenants := make(map[string]*tenant, len(cfg))
+	for name, tc := range cfg {
+		t := &tenant{
+			name:        name,
+			routes:      indexRoutes(r.resolveAliases(tc.Models)),
+			default_:    tc.Default,
+			credentials: make(map[string]bool),
+		}
+		for _, alias := range tc.Models {
+			t.credentials[alias.CredentialName] = true
+		}
+		if tc.Default != nil {
+			t.credentials[tc.Default.CredentialName] = true
+		}
+		delete(t.credentials, "")
+		tenants[name] = t
+	}
+	return tenants
+}
+
+// scope returns the routes req resolves against, or ErrUnknownTenant when
+// the tenant bound to it (see types.Tenant) is not configured.
+func (r *Router) scope(req *http.Request) (*routeScope, error) {
+	name := ""
+	if req != nil {
+		name = types.Tenant(req.Context())
+	}
+	if name == "" {
+		return &routeScope{routes: r.routes.Load(), default_: r.defaultRoute(req)}, nil
+	}
+	t, ok := r.tenants[name]
+	if !ok {
+		return nil, ErrUnknownTenant
+	}
+	return &routeScope{routes: t.routes, default_: t.default_, tenant: t}, nil
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestRouter_Tenants(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenant     string
+		model      string
+		override   string // Admin credential override ID
+		wantStatus int
+		wantModel  string
+		wantCred   string
+	}{
+		{"no header uses gateway alias", "", "fast", "", http.StatusOK, "gateway-fast", "gw-cred"},
+		{"no header uses gateway default", "", "gpt-4o", "", http.StatusOK, "gpt-4o", "gw-cred"},
+		{"tenant alias", "acme", "fast", "", http.StatusOK, "acme-fast", "acme-cred"},
+		{"tenant default", "acme", "gpt-4o", "", http.StatusOK, "gpt-4o", "acme-cred"},
+		{"gateway alias hidden from tenant", "acme", "smart", "", http.StatusOK, "smart", "acme-cred"},
+		{"tenant without default rejects unaliased", "beta", "gpt-4o", "", http.StatusBadRequest, "", ""},
+		{"tenant cannot reach gateway alias", "beta", "smart", "", http.StatusBadRequest, "", ""},
+		{"unknown tenant rejected", "nobody", "fast", "", http.StatusBadRequest, "", ""},
+		{"override within tenant", "acme", "fast", "acme-spare", http.StatusOK, "acme-fast", "acme-spare"},
+		{"override outside tenant", "acme", "fast", "gw-cred", http.StatusForbidden, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &mockProvider{name: "openrouter"}
+			cfg := &config.Config{
+				Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "gw-cred"},
+				Models: []config.ModelAlias{
+					{Slug: "fast", Provider: "openrouter", Model: "gateway-fast", CredentialName: "gw-cred"},
+					{Slug: "smart", Provider: "openrouter", Model: "gateway-smart", CredentialName: "gw-cred"},
+				},
+				Tenants: map[string]config.TenantRoutes{
+					"acme": {
+						Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "acme-cred"},
+						Models: []config.ModelAlias{
+							{Slug: "fast", Provider: "openrouter", Model: "acme-fast", CredentialName: "acme-cred"},
+							{Slug: "spare", Provider: "openrouter", Model: "acme-spare", CredentialName: "acme-spare"},
+						},
+					},
+					"beta": {Models: []config.ModelAlias{{Slug: "fast", Provider: "openrouter", Model: "beta-fast", CredentialName: "beta-cred"}}},
+				},
+			}
+			store := newTestStore(t,
+				&models.Credential{ID: "gw-cred", Name: "gw-cred", Provider: "openrouter"},
+				&models.Credential{ID: "acme-cred", Name: "acme-cred", Provider: "openrouter"},
+				&models.Credential{ID: "acme-spare", Name: "acme-spare", Provider: "openrouter"},
+				&models.Credential{ID: "beta-cred", Name: "beta-cred", Provider: "openrouter"},
+			)
+			router := NewRouter(map[string]types.Provider{"openrouter": p}, cfg, store)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			if tt.tenant != "" {
+				req = req.WithContext(types.WithTenant(req.Context(), tt.tenant))
+			}
+			ctx := context.Background()
+			if tt.override != "" {
+				ctx = types.WithCredentialOverride(ctx, tt.override)
+			}
+			opts := &types.ProxyOptions{Model: tt.model}
+
+			_, _ = router.ProxyRequest(ctx, w, req, opts)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.name == "unknown tenant rejected" && !strings.Contains(w.Body.String(), tt.tenant) {
+				t.Errorf("body %q does not name tenant %q", w.Body.String(), tt.tenant)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if p.lastModel != "" {
+					t.Errorf("provider got model %q for a rejected request", p.lastModel)
+				}
+				return
+			}
+			if p.lastModel != tt.wantModel || opts.Credential == nil || opts.Credential.Name != tt.wantCred {
+				t.Errorf("routed to %q with %+v, want %q with %s", p.lastModel, opts.Credential, tt.wantModel, tt.wantCred)
+			}
+		})
+	}
+}
@@ -2,20 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage/memory"
 	"github.com/mandalnilabja/goatway/internal/storage/models"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
 // mockProvider implements types.Provider for testing.
 type mockProvider struct {
-	name      string
-	lastModel string
+	name          string
+	lastModel     string
+	responseModel string
 }
 
 func (m *mockProvider) Name() string                                                { return m.name }
@@ -23,56 +26,28 @@ func (m *mockProvider) BaseURL() string
 func (m *mockProvider) PrepareRequest(ctx context.Context, req *http.Request) error { return nil }
 func (m *mockProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
 	m.lastModel = opts.Model
+	m.responseModel = opts.ResponseModel
 	w.WriteHeader(http.StatusOK)
 	return &types.ProxyResult{Model: opts.Model, StatusCode: http.StatusOK}, nil
 }
 
-// mockStorage implements storage.Storage for tests.
-type mockStorage struct {
-	credentials map[string]*models.Credential // nil means return all, empty means return none
-}
-
-func (m *mockStorage) GetCredentialByName(name string) (*models.Credential, error) {
-	if m.credentials != nil {
-		if cred, ok := m.credentials[name]; ok {
-			return cred, nil
+// newTestStore returns an in-memory store holding creds, or a single
+// "test-cred" openrouter credential when none are given.
+func newTestStore(t *testing.T, creds ...*models.Credential) *memory.Storage {
+	t.Helper()
+	if len(creds) == 0 {
+		creds = []*models.Credential{{ID: "test-cred", Name: "test-cred", Provider: "openrouter"}}
+	}
+	store := memory.New()
+	for _, cred := range creds {
+		cred.Data = json.RawMessage(`{"api_key":"k"}`)
+		if err := store.CreateCredential(cred); err != nil {
+			t.Fatalf("CreateCredential: %v", err)
 		}
-		return nil, errors.New("credential not found")
 	}
-	// Default behavior: return a credential for any name
-	return &models.Credential{ID: "test-cred", Name: name, Provider: "openrouter"}, nil
+	return store
 }
 
-// Stub implementations for storage.Storage interface
-func (m *mockStorage) CreateCredential(cred *models.Credential) error      { return nil }
-func (m *mockStorage) GetCredential(id string) (*models.Credential, error) { return nil, nil }
-func (m *mockStorage) ListCredentials() ([]*models.Credential, error)      { return nil, nil }
-func (m *mockStorage) UpdateCredential(cred *models.Credential) error      { return nil }
-func (m *mockStorage) DeleteCredential(id string) error                    { return nil }
-func (m *mockStorage) LogRequest(log *models.RequestLog) error             { return nil }
-func (m *mockStorage) GetRequestLogs(f models.LogFilter) ([]*models.RequestLog, error) {
-	return nil, nil
-}
-func (m *mockStorage) DeleteRequestLogs(olderThan string) (int64, error) { return 0, nil }
-func (m *mockStorage) GetUsageStats(f models.StatsFilter) (*models.UsageStats, error) {
-	return nil, nil
-}
-func (m *mockStorage) GetDailyUsage(start, end string) ([]*models.DailyUsage, error) { return nil, nil }
-func (m *mockStorage) UpdateDailyUsage(usage *models.DailyUsage) error               { return nil }
-func (m *mockStorage) CreateAPIKey(key *models.ClientAPIKey) error                   { return nil }
-func (m *mockStorage) GetAPIKey(id string) (*models.ClientAPIKey, error)             { return nil, nil }
-func (m *mockStorage) GetAPIKeyByPrefix(prefix string) ([]*models.ClientAPIKey, error) {
-	return nil, nil
-}
-func (m *mockStorage) ListAPIKeys() ([]*models.ClientAPIKey, error) { return nil, nil }
-func (m *mockStorage) UpdateAPIKey(key *models.ClientAPIKey) error  { return nil }
-func (m *mockStorage) DeleteAPIKey(id string) error                 { return nil }
-func (m *mockStorage) UpdateAPIKeyLastUsed(id string) error         { return nil }
-func (m *mockStorage) GetAdminPasswordHash() (string, error)        { return "", nil }
-func (m *mockStorage) SetAdminPasswordHash(hash string) error       { return nil }
-func (m *mockStorage) HasAdminPassword() (bool, error)              { return false, nil }
-func (m *mockStorage) Close() error                                 { return nil }
-
 func TestRouter_ResolveKnownAlias(t *testing.T) {
 	mock := &mockProvider{name: "openrouter"}
 	providers := map[string]types.Provider{"openrouter": mock}
@@ -83,7 +58,7 @@ func TestRouter_ResolveKnownAlias(t *testing.T) {
 		},
 	}
 
-	router := NewRouter(providers, cfg, &mockStorage{})
+	router := NewRouter(providers, cfg, newTestStore(t))
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
@@ -101,6 +76,38 @@ func TestRouter_ResolveKnownAlias(t *testing.T) {
 	}
 }
 
+func TestRouter_RewriteResponseModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{"disabled by default", false, ""},
+		{"enabled reports alias", true, "gpt4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockProvider{name: "openrouter"}
+			cfg := &config.Config{
+				Models: []config.ModelAlias{
+					{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+				},
+				ResponseConfig: config.ResponseConfig{RewriteResponseModel: tt.enabled},
+			}
+			router := NewRouter(map[string]types.Provider{"openrouter": mock}, cfg, newTestStore(t))
+
+			req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+			if _, err := router.ProxyRequest(context.Background(), httptest.NewRecorder(), req, &types.ProxyOptions{Model: "gpt4"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mock.responseModel != tt.want || mock.lastModel != "openai/gpt-4o" {
+				t.Errorf("response model = %q (upstream %q), want %q", mock.responseModel, mock.lastModel, tt.want)
+			}
+		})
+	}
+}
+
 func TestRouter_ResolveWithDefault(t *testing.T) {
 	mock := &mockProvider{name: "openrouter"}
 	providers := map[string]types.Provider{"openrouter": mock}
@@ -110,7 +117,7 @@ func TestRouter_ResolveWithDefault(t *testing.T) {
 		Models:  []config.ModelAlias{},
 	}
 
-	router := NewRouter(providers, cfg, &mockStorage{})
+	router := NewRouter(providers, cfg, newTestStore(t))
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
@@ -135,7 +142,7 @@ func TestRouter_ResolveWithoutDefault(t *testing.T) {
 		Models:  []config.ModelAlias{},
 	}
 
-	router := NewRouter(providers, cfg, &mockStorage{})
+	router := NewRouter(providers, cfg, newTestStore(t))
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
@@ -160,7 +167,7 @@ func TestRouter_NoCredentialConfigured(t *testing.T) {
 		},
 	}
 
-	router := NewRouter(providers, cfg, &mockStorage{})
+	router := NewRouter(providers, cfg, newTestStore(t))
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
@@ -184,7 +191,7 @@ func TestRouter_DefaultNoCredentialConfigured(t *testing.T) {
 		Models:  []config.ModelAlias{},
 	}
 
-	router := NewRouter(providers, cfg, &mockStorage{})
+	router := NewRouter(providers, cfg, newTestStore(t))
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
@@ -209,8 +216,8 @@ func TestRouter_CredentialNotFound(t *testing.T) {
 		},
 	}
 
-	// Use empty credentials map so GetCredentialByName returns error
-	store := &mockStorage{credentials: map[string]*models.Credential{}}
+	// Store only holds "test-cred", so GetCredentialByName returns ErrNotFound
+	store := newTestStore(t)
 	router := NewRouter(providers, cfg, store)
 
 	w := httptest.NewRecorder()
@@ -225,3 +232,165 @@ func TestRouter_CredentialNotFound(t *testing.T) {
 		t.Errorf("expected status 401, got %d", w.Code)
 	}
 }
+
+func TestRouter_StrictAliases(t *testing.T) {
+	tests := []struct {
+		name       string
+		strict     bool
+		model      string
+		wantErr    error
+		wantStatus int
+	}{
+		{"permissive passes unknown model to default", false, "unknown-model", nil, http.StatusOK},
+		{"strict rejects unknown model", true, "unknown-model", ErrModelNotFound, http.StatusBadRequest},
+		{"strict accepts aliased slug", true, "gpt4", nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockProvider{name: "openrouter"}
+			providers := map[string]types.Provider{"openrouter": mock}
+			cfg := &config.Config{
+				Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "test-cred"},
+				Models: []config.ModelAlias{
+					{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+				},
+				StrictAliases: tt.strict,
+			}
+			router := NewRouter(providers, cfg, newTestStore(t))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+			_, err := router.ProxyRequest(context.Background(), w, req, &types.ProxyOptions{Model: tt.model})
+
+			if err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// credentialProvider records the credential the Router resolved.
+type credentialProvider struct {
+	mockProvider
+	credentialID string
+}
+
+func (p *credentialProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	p.credentialID = opts.Credential.ID
+	return p.mockProvider.ProxyRequest(ctx, w, req, opts)
+}
+
+func TestRouter_CredentialOverride(t *testing.T) {
+	store := newTestStore(t,
+		&models.Credential{ID: "cred-a", Name: "alias-cred", Provider: "openrouter"},
+		&models.Credential{ID: "cred-b", Name: "other-cred", Provider: "openrouter"},
+		&models.Credential{ID: "cred-c", Name: "aws-cred", Provider: "bedrock"},
+	)
+	cfg := &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "alias-cred"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		override   string
+		wantStatus int
+		wantCred   string
+	}{
+		{"alias credential without override", "", http.StatusOK, "cred-a"},
+		{"override replaces alias credential", "cred-b", http.StatusOK, "cred-b"},
+		{"unknown credential id", "cred-missing", http.StatusUnauthorized, ""},
+		{"credential for another provider", "cred-c", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &credentialProvider{mockProvider: mockProvider{name: "openrouter"}}
+			router := NewRouter(map[string]types.Provider{"openrouter": prov}, cfg, store)
+
+			ctx := context.Background()
+			if tt.override != "" {
+				ctx = types.WithCredentialOverride(ctx, tt.override)
+			}
+			w := httptest.NewRecorder()
+			_, _ = router.ProxyRequest(ctx, w, httptest.NewRequest("POST", "/v1/chat/completions", nil), &types.ProxyOptions{Model: "gpt4"})
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if prov.credentialID != tt.wantCred {
+				t.Errorf("credential = %q, want %q", prov.credentialID, tt.wantCred)
+			}
+		})
+	}
+}
+
+func TestRouter_ProviderHint(t *testing.T) {
+	cfg := &config.Config{
+		Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "test-cred"},
+		Models: []config.ModelAlias{
+			{Slug: "llama", Provider: "openrouter", Model: "meta-llama/llama-3.1-8b-instruct", CredentialName: "test-cred"},
+			{Slug: "llama", Provider: "local", Model: "llama3.1:8b", CredentialName: "test-cred"},
+			{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "test-cred"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		model      string
+		hint       string
+		wantStatus int
+		wantErr    error
+		wantServed string // provider routing name that received the request
+		wantModel  string
+	}{
+		{"hint picks openrouter", "llama", "openrouter", http.StatusOK, nil, "openrouter", "meta-llama/llama-3.1-8b-instruct"},
+		{"hint picks local", "llama", "local", http.StatusOK, nil, "local", "llama3.1:8b"},
+		{"no hint uses last alias", "llama", "", http.StatusOK, nil, "local", "llama3.1:8b"},
+		{"provider without alias rejected", "gpt4", "local", http.StatusBadRequest, ErrProviderMismatch, "", ""},
+		{"unknown provider rejected", "llama", "bedrock", http.StatusBadRequest, ErrProviderMismatch, "", ""},
+		{"unaliased model on default provider", "mistral/small", "openrouter", http.StatusOK, nil, "openrouter", "mistral/small"},
+		{"unaliased model on other provider", "mistral/small", "local", http.StatusBadRequest, ErrProviderMismatch, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := map[string]*mockProvider{
+				"openrouter": {name: "openrouter"},
+				"local":      {name: "openrouter"},
+			}
+			router := NewRouter(map[string]types.Provider{
+				"openrouter": providers["openrouter"],
+				"local":      providers["local"],
+			}, cfg, newTestStore(t))
+
+			req := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+			if tt.hint != "" {
+				req.Header.Set(ProviderHintHeader, tt.hint)
+			}
+			w := httptest.NewRecorder()
+			_, err := router.ProxyRequest(context.Background(), w, req, &types.ProxyOptions{Model: tt.model})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for name, p := range providers {
+				served := p.lastModel != ""
+				if served != (name == tt.wantServed) {
+					t.Errorf("provider %q served = %v, want served by %q", name, served, tt.wantServed)
+				}
+				if served && p.lastModel != tt.wantModel {
+					t.Errorf("model = %q, want %q", p.lastModel, tt.wantModel)
+				}
+			}
+		})
+	}
+}
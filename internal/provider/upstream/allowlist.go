@@ -0,0 +1,69 @@
+package upstream
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrHostNotAllowed is returned for requests to a host outside the allowlist.
+var ErrHostNotAllowed = errors.New("upstream host not allowed")
+
+// HostAllowed reports whether host matches one of patterns. A pattern is an
+// exact host, "*.example.com" for any subdomain of example.com, "a.*.b.com"
+// where an inner "*" matches exactly one label, or "*" for any host.
+// Matching ignores case; ports are not part of the host.
+func HostAllowed(host string, patterns []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		switch {
+		case p == "*":
+			return true
+		case strings.HasPrefix(p, "*."):
+			if strings.HasSuffix(host, p[1:]) {
+				return true
+			}
+		case strings.Contains(p, "*"):
+			if labelsMatch(host, p) {
+				return true
+			}
+		case host == p:
+			return true
+		}
+	}
+	return false
+}
+
+// labelsMatch reports whether host has the same labels as pattern, where a
+// "*" label in pattern matches any single non-empty label.
+func labelsMatch(host, pattern string) bool {
+	hl, pl := strings.Split(host, "."), strings.Split(pattern, ".")
+	if len(hl) != len(pl) {
+		return false
+	}
+	for i, l := range pl {
+		if hl[i] == "" || (l != "*" && l != hl[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowlistTransport refuses requests, including redirects, to hosts that
+// HostAllowed rejects, so a base_url or region cannot reach internal services.
+type allowlistTransport struct {
+	next  http.RoundTripper
+	hosts []string
+}
+
+func (t *allowlistTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if host := req.URL.Hostname(); !HostAllowed(host, t.hosts) {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: %q is not in UPSTREAM_ALLOWED_HOSTS", ErrHostNotAllowed, host)
+	}
+	return t.next.RoundTrip(req)
+}
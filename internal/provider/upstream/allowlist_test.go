@@ -0,0 +1,94 @@
+package upstream
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHostAllowed(t *testing.T) {
+	patterns := []string{"openrouter.ai", "*.amazonaws.com", "API.Groq.com"}
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"openrouter.ai", true},
+		{"OpenRouter.ai", true},
+		{"api.groq.com", true},
+		{"bedrock-runtime.us-east-1.amazonaws.com", true},
+		{"amazonaws.com", false},
+		{"evilamazonaws.com", false},
+		{"openrouter.ai.evil.test", false},
+		{"169.254.169.254", false},
+		{"localhost", false},
+	}
+	for _, tt := range tests {
+		if got := HostAllowed(tt.host, patterns); got != tt.want {
+			t.Errorf("HostAllowed(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+
+	patterns = []string{"bedrock-runtime.*.amazonaws.com"}
+	tests = []struct {
+		host string
+		want bool
+	}{
+		{"bedrock-runtime.us-east-1.amazonaws.com", true},
+		{"BEDROCK-RUNTIME.eu-west-3.amazonaws.com", true},
+		{"s3.us-east-1.amazonaws.com", false},
+		{"bedrock-runtime.amazonaws.com", false},
+		{"bedrock-runtime..amazonaws.com", false},
+		{"bedrock-runtime.a.b.amazonaws.com", false},
+		{"evil.test", false},
+	}
+	for _, tt := range tests {
+		if got := HostAllowed(tt.host, patterns); got != tt.want {
+			t.Errorf("HostAllowed(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+	if !HostAllowed("10.0.0.1", []string{"*"}) {
+		t.Error(`"*" should allow any host`)
+	}
+}
+
+func TestNewClient_AllowedHosts(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		hosts   []string
+		wantErr bool
+	}{
+		{"no allowlist", nil, false},
+		{"allowed host", []string{"openrouter.ai", "127.0.0.1"}, false},
+		{"blocked host", []string{"openrouter.ai"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits = 0
+			client := NewClient(PoolConfig{AllowedHosts: tt.hosts})
+			resp, err := client.Get(srv.URL)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrHostNotAllowed) || hits != 0 {
+					t.Errorf("err = %v, hits = %d; want ErrHostNotAllowed before connecting", err, hits)
+				}
+				return
+			}
+			if err != nil || hits != 1 {
+				t.Errorf("err = %v, hits = %d; want the request to reach the server", err, hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrFirstByteTimeout is returned when the upstream response headers do not
+// arrive within the first-byte deadline.
+var ErrFirstByteTimeout = errors.New("upstream first byte timeout")
+
+// DoWithFirstByteTimeout sends req, aborting it with ErrFirstByteTimeout when
+// the response headers do not arrive within timeout (0 or less disables the
+// watchdog). The deadline ends once the headers arrive; a slow body is left to
+// the stream idle timeout.
+func DoWithFirstByteTimeout(client *http.Client, req *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return client.Do(req)
+	}
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(timeout, func() { cancel(ErrFirstByteTimeout) })
+	resp, err := client.Do(req.WithContext(ctx))
+
+	// A watchdog that fired as the headers arrived still counts as a miss,
+	// since it has already cancelled the body
+	if !timer.Stop() && errors.Is(context.Cause(ctx), ErrFirstByteTimeout) {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return nil, ErrFirstByteTimeout
+	}
+	if err != nil {
+		cancel(nil)
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the watchdog context once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelCauseFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel(nil)
+	return err
+}
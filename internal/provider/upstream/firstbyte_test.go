@@ -0,0 +1,56 @@
+package upstream
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDoWithFirstByteTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		headerDelay time.Duration
+		bodyDelay   time.Duration // Pause between headers and body
+		timeout     time.Duration
+		wantErr     error
+	}{
+		{"disabled", 100 * time.Millisecond, 0, 0, nil},
+		{"headers in time", 0, 0, 200 * time.Millisecond, nil},
+		{"slow body after headers", 0, 300 * time.Millisecond, 100 * time.Millisecond, nil},
+		{"headers too late", time.Second, 0, 50 * time.Millisecond, ErrFirstByteTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.headerDelay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				time.Sleep(tt.bodyDelay)
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer srv.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			resp, err := DoWithFirstByteTimeout(srv.Client(), req, tt.timeout)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil || string(body) != "ok" {
+				t.Errorf("body = %q, err = %v, want ok", body, err)
+			}
+		})
+	}
+}
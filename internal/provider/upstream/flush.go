@@ -0,0 +1,28 @@
+package upstream
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// flushFunc adapts a function to http.Flusher.
+type flushFunc func()
+
+func (f flushFunc) Flush() { f() }
+
+// Flusher returns an http.Flusher for w that flushes through
+// http.ResponseController, so wrapping writers are followed via Unwrap. A
+// writer that cannot flush makes Flush a no-op: the stream is then buffered
+// by the writer and sent when the handler returns, rather than being rejected
+// after the headers have gone out.
+func Flusher(w http.ResponseWriter) http.Flusher {
+	rc := http.NewResponseController(w)
+	warned := false
+	return flushFunc(func() {
+		if err := rc.Flush(); errors.Is(err, http.ErrNotSupported) && !warned {
+			warned = true
+			slog.Warn("response writer cannot flush; streaming response will be buffered")
+		}
+	})
+}
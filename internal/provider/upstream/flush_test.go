@@ -0,0 +1,29 @@
+package upstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// wrapped hides the recorder's Flush method behind Unwrap.
+type wrapped struct{ http.ResponseWriter }
+
+func (w wrapped) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// plain is a writer that cannot flush.
+type plain struct{ http.ResponseWriter }
+
+func TestFlusher(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Flusher(wrapped{rec}).Flush()
+	if !rec.Flushed {
+		t.Error("Flush through an unwrapping writer did not reach the recorder")
+	}
+
+	rec = httptest.NewRecorder()
+	Flusher(plain{rec}).Flush() // must not panic
+	if rec.Flushed {
+		t.Error("Flush reached a writer that hides its Flusher")
+	}
+}
@@ -0,0 +1,67 @@
+package upstream
+
+import (
+	"net/http"
+	"strings"
+)
+
+// hopByHopHeaders apply to a single connection (RFC 7230 section 6.1) and
+// must not be forwarded by a proxy. Proxy-Connection is non-standard but
+// still sent by some clients.
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"TE",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// gatewayHeaders are set by the gateway on every upstream request, or only
+// mean something to the gateway (the admin session Cookie), so the client's
+// values are never forwarded.
+var gatewayHeaders = []string{"Host", "Content-Length", "Authorization", "Cookie"}
+
+// gatewayHeaderPrefix marks the gateway's own control headers (credential
+// override, provider hint, tenant, timeout, ...), which stay with the gateway.
+const gatewayHeaderPrefix = "X-Goatway-"
+
+// RemoveHopByHop deletes the hop-by-hop headers from h, including any
+// extra ones named in its Connection header.
+func RemoveHopByHop(h http.Header) {
+	for _, value := range h.Values("Connection") {
+		for name := range strings.SplitSeq(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				h.Del(name)
+			}
+		}
+	}
+	for _, name := range hopByHopHeaders {
+		h.Del(name)
+	}
+}
+
+// CopyRequestHeaders copies the client's headers onto an upstream request,
+// dropping hop-by-hop headers, the gateway's own headers and the
+// operator-configured strip list.
+func CopyRequestHeaders(dst, src http.Header, strip []string) {
+	h := src.Clone()
+	RemoveHopByHop(h)
+	for _, name := range gatewayHeaders {
+		h.Del(name)
+	}
+	for name := range h {
+		if strings.HasPrefix(http.CanonicalHeaderKey(name), gatewayHeaderPrefix) {
+			delete(h, name)
+		}
+	}
+	for _, name := range strip {
+		h.Del(name)
+	}
+	for k, v := range h {
+		dst[k] = v
+	}
+}
@@ -0,0 +1,65 @@
+package upstream
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCopyRequestHeaders(t *testing.T) {
+	src := http.Header{}
+	for _, name := range []string{
+		"Connection", "Keep-Alive", "Proxy-Authorization", "Proxy-Connection", "TE",
+		"Trailer", "Transfer-Encoding", "Upgrade", "Host", "Content-Length", "Authorization",
+		"X-Listed-In-Connection", "Cookie", "X-Custom", "Accept",
+		"X-Goatway-Credential-Id", "X-Goatway-Model-Provider", "X-Goatway-Tenant", "X-Goatway-Timeout",
+	} {
+		src.Set(name, "v")
+	}
+	src.Set("Connection", "keep-alive, X-Listed-In-Connection")
+
+	dst := http.Header{}
+	CopyRequestHeaders(dst, src, nil)
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Connection", false},
+		{"Keep-Alive", false},
+		{"Proxy-Authorization", false},
+		{"Proxy-Connection", false},
+		{"TE", false},
+		{"Trailer", false},
+		{"Transfer-Encoding", false},
+		{"Upgrade", false},
+		{"Host", false},
+		{"Content-Length", false},
+		{"Authorization", false},
+		{"X-Listed-In-Connection", false}, // Named by Connection
+		{"Cookie", false},                 // Admin session stays with the gateway
+		{"X-Goatway-Credential-Id", false},
+		{"X-Goatway-Model-Provider", false},
+		{"X-Goatway-Tenant", false},
+		{"X-Goatway-Timeout", false},
+		{"X-Custom", true},
+		{"Accept", true},
+	}
+	for _, tt := range tests {
+		if got := dst.Get(tt.name) != ""; got != tt.want {
+			t.Errorf("%s forwarded = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if src.Get("Upgrade") == "" {
+		t.Error("source headers modified")
+	}
+}
+
+func TestCopyRequestHeaders_StripList(t *testing.T) {
+	src := http.Header{"X-Internal": {"v"}, "X-Custom": {"v"}}
+	dst := http.Header{}
+	CopyRequestHeaders(dst, src, []string{"x-internal"})
+
+	if dst.Get("X-Internal") != "" || dst.Get("X-Custom") == "" {
+		t.Errorf("forwarded = %v, want only X-Custom", dst)
+	}
+}
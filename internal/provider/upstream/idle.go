@@ -0,0 +1,48 @@
+// Package upstream provides HTTP helpers shared by provider clients.
+package upstream
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ErrIdleTimeout is returned when the upstream sends no bytes within the idle timeout.
+var ErrIdleTimeout = errors.New("upstream stream idle timeout")
+
+// IdleReader wraps an upstream body and closes it if no bytes arrive within
+// the timeout, unblocking the pending Read. Each successful read resets the timer.
+type IdleReader struct {
+	body    io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	fired   atomic.Bool
+}
+
+// NewIdleReader starts the idle watchdog for body. Call Stop when done reading.
+func NewIdleReader(body io.ReadCloser, timeout time.Duration) *IdleReader {
+	r := &IdleReader{body: body, timeout: timeout}
+	r.timer = time.AfterFunc(timeout, func() {
+		r.fired.Store(true)
+		_ = body.Close()
+	})
+	return r
+}
+
+// Read reads from the upstream body, returning ErrIdleTimeout once the watchdog fired.
+func (r *IdleReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if r.fired.Load() {
+		return n, ErrIdleTimeout
+	}
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// Stop disarms the watchdog.
+func (r *IdleReader) Stop() {
+	r.timer.Stop()
+}
@@ -0,0 +1,43 @@
+package upstream
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// OpenAI account headers select the organization and project a request is billed to.
+const (
+	OrganizationHeader = "OpenAI-Organization"
+	ProjectHeader      = "OpenAI-Project"
+)
+
+// SetOpenAIAccountHeaders sets the account headers for providers that accept them.
+// Values from the credential win, since the gateway's key is the one billed;
+// otherwise the client's inbound headers are forwarded as-is.
+func SetOpenAIAccountHeaders(dst, inbound http.Header, cred *models.Credential) {
+	var account models.APIKeyCredential
+	if cred != nil {
+		_ = json.Unmarshal(cred.Data, &account)
+	}
+	setOrForward(dst, inbound, OrganizationHeader, account.Organization)
+	setOrForward(dst, inbound, ProjectHeader, account.Project)
+}
+
+// StripOpenAIAccountHeaders removes the account headers for providers that reject them.
+func StripOpenAIAccountHeaders(h http.Header) {
+	h.Del(OrganizationHeader)
+	h.Del(ProjectHeader)
+}
+
+func setOrForward(dst, inbound http.Header, name, configured string) {
+	switch {
+	case configured != "":
+		dst.Set(name, configured)
+	case inbound.Get(name) != "":
+		dst.Set(name, inbound.Get(name))
+	default:
+		dst.Del(name)
+	}
+}
@@ -0,0 +1,42 @@
+package upstream
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestSetOpenAIAccountHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		credData    string
+		inboundOrg  string
+		wantOrg     string
+		wantProject string
+	}{
+		{"forwards client headers", `{"api_key":"sk"}`, "org-client", "org-client", ""},
+		{"credential sets headers", `{"api_key":"sk","organization":"org-cred","project":"proj-cred"}`, "", "org-cred", "proj-cred"},
+		{"credential overrides client", `{"api_key":"sk","organization":"org-cred"}`, "org-client", "org-cred", ""},
+		{"nothing configured", `{"api_key":"sk"}`, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inbound := http.Header{}
+			if tt.inboundOrg != "" {
+				inbound.Set(OrganizationHeader, tt.inboundOrg)
+			}
+			dst := inbound.Clone()
+
+			SetOpenAIAccountHeaders(dst, inbound, &models.Credential{Data: []byte(tt.credData)})
+
+			if got := dst.Get(OrganizationHeader); got != tt.wantOrg {
+				t.Errorf("%s = %q, want %q", OrganizationHeader, got, tt.wantOrg)
+			}
+			if got := dst.Get(ProjectHeader); got != tt.wantProject {
+				t.Errorf("%s = %q, want %q", ProjectHeader, got, tt.wantProject)
+			}
+		})
+	}
+}
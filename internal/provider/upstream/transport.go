@@ -0,0 +1,39 @@
+package upstream
+
+import (
+	"net/http"
+	"time"
+)
+
+// PoolConfig tunes the connection pool of a provider's upstream client and
+// restricts the hosts it may connect to.
+type PoolConfig struct {
+	MaxIdleConns    int           // Idle connections kept open for reuse (also the per-host cap)
+	MaxConnsPerHost int           // Dialing, active and idle connections per host (0 = unlimited)
+	IdleConnTimeout time.Duration // How long an idle connection stays in the pool
+	AllowedHosts    []string      // Host patterns requests may target (nil allows any; see HostAllowed)
+}
+
+// DefaultPool matches the pool of http.DefaultTransport, but lets every idle
+// connection go to one host since a provider usually talks to a single endpoint.
+func DefaultPool() PoolConfig {
+	return PoolConfig{MaxIdleConns: 100, IdleConnTimeout: 90 * time.Second}
+}
+
+// NewClient returns a client to share across a provider's requests so
+// keep-alive connections (and their TLS sessions) are reused. Proxy, dial
+// and HTTP/2 settings follow http.DefaultTransport. Compression is disabled
+// so streamed responses reach the client unbuffered. Requests to hosts
+// outside pool.AllowedHosts fail with ErrHostNotAllowed.
+func NewClient(pool PoolConfig) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DisableCompression = true
+	t.MaxIdleConns = pool.MaxIdleConns
+	t.MaxIdleConnsPerHost = pool.MaxIdleConns
+	t.MaxConnsPerHost = pool.MaxConnsPerHost
+	t.IdleConnTimeout = pool.IdleConnTimeout
+	if pool.AllowedHosts != nil {
+		return &http.Client{Transport: &allowlistTransport{next: t, hosts: pool.AllowedHosts}}
+	}
+	return &http.Client{Transport: t}
+}
@@ -0,0 +1,70 @@
+package upstream
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+)
+
+// trustServer points client's transport at srv's test certificate.
+func trustServer(client *http.Client, srv *httptest.Server) *http.Client {
+	client.Transport.(*http.Transport).TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig
+	return client
+}
+
+// get performs one request and reports whether it reused a pooled connection.
+func get(tb testing.TB, client *http.Client, url string) bool {
+	var reused bool
+	trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) { reused = info.Reused }}
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err != nil {
+		tb.Fatalf("request: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return reused
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := trustServer(NewClient(DefaultPool()), srv)
+	if !client.Transport.(*http.Transport).DisableCompression {
+		t.Error("compression enabled; streamed responses would be buffered")
+	}
+	if get(t, client, srv.URL) {
+		t.Error("first request reused a connection")
+	}
+	if !get(t, client, srv.URL) {
+		t.Error("second request opened a new connection, want the pooled one")
+	}
+}
+
+// BenchmarkUpstreamClient compares a shared pooled client with the former
+// per-request transport, which paid a TCP and TLS handshake every time.
+func BenchmarkUpstreamClient(b *testing.B) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	b.Run("shared", func(b *testing.B) {
+		client := trustServer(NewClient(DefaultPool()), srv)
+		for b.Loop() {
+			get(b, client, srv.URL)
+		}
+	})
+	b.Run("per-request", func(b *testing.B) {
+		for b.Loop() {
+			client := trustServer(&http.Client{Transport: &http.Transport{DisableCompression: true}}, srv)
+			get(b, client, srv.URL)
+			client.CloseIdleConnections()
+		}
+	})
+}
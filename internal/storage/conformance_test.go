@@ -0,0 +1,25 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/storage/storagetest"
+)
+
+func TestSQLiteConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+		if err != nil {
+			t.Fatalf("NewSQLiteStorage: %v", err)
+		}
+		return s
+	})
+}
+
+func TestMemoryConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return storage.NewMemoryStorage()
+	})
+}
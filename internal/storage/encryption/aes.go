@@ -5,12 +5,9 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"io"
-	"os"
-	"runtime"
 )
 
 // Encryptor provides encryption/decryption for sensitive data
@@ -19,25 +16,30 @@ type Encryptor interface {
 	Decrypt(ciphertext string) (string, error)
 }
 
+// Key sources reported by Source
+const (
+	KeySourceFile       = "file"       // GOATWAY_AES_KEY_FILE
+	KeySourceEnv        = "env"        // GOATWAY_AES_KEY
+	KeySourcePassphrase = "passphrase" // GOATWAY_ENCRYPTION_KEY, hashed with SHA-256
+	KeySourceMachine    = "machine"    // Derived from hostname, home directory and platform
+	KeySourceStatic     = "static"     // Passed to NewWithKey
+)
+
 // AES implements AES-256-GCM encryption
 type AES struct {
-	key []byte
+	key    []byte
+	source string
 }
 
-// New creates a new AES encryptor with a derived key
-// Priority: GOATWAY_ENCRYPTION_KEY env var > machine-derived key
+// New creates a new AES encryptor with the configured key
+// Priority: GOATWAY_AES_KEY_FILE or GOATWAY_AES_KEY (base64, 32 bytes) >
+// GOATWAY_ENCRYPTION_KEY passphrase > machine-derived key
 func New() (*AES, error) {
-	var keyMaterial string
-
-	if envKey := os.Getenv("GOATWAY_ENCRYPTION_KEY"); envKey != "" {
-		keyMaterial = envKey
-	} else {
-		keyMaterial = deriveMachineKey()
+	key, source, err := loadKey()
+	if err != nil {
+		return nil, err
 	}
-
-	// Derive a 256-bit key using SHA-256
-	hash := sha256.Sum256([]byte(keyMaterial))
-	return &AES{key: hash[:]}, nil
+	return &AES{key: key, source: source}, nil
 }
 
 // NewWithKey creates an encryptor with a specific key (for testing)
@@ -45,7 +47,12 @@ func NewWithKey(key []byte) (*AES, error) {
 	if len(key) != 32 {
 		return nil, errors.New("key must be 32 bytes for AES-256")
 	}
-	return &AES{key: key}, nil
+	return &AES{key: key, source: KeySourceStatic}, nil
+}
+
+// Source reports where the key came from (one of the KeySource constants)
+func (e *AES) Source() string {
+	return e.source
 }
 
 // Encrypt encrypts plaintext using AES-256-GCM
@@ -99,25 +106,3 @@ func (e *AES) Decrypt(ciphertext string) (string, error) {
 
 	return string(plaintext), nil
 }
-
-// deriveMachineKey creates a machine-specific key from available identifiers
-func deriveMachineKey() string {
-	// Combine multiple sources for a machine-specific key
-	// This provides basic protection without requiring user configuration
-	material := "goatway-default-key"
-
-	// Add hostname
-	if hostname, err := os.Hostname(); err == nil {
-		material += hostname
-	}
-
-	// Add user home directory
-	if home, err := os.UserHomeDir(); err == nil {
-		material += home
-	}
-
-	// Add OS/arch info
-	material += runtime.GOOS + runtime.GOARCH
-
-	return material
-}
@@ -0,0 +1,87 @@
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Environment variables that supply the key
+const (
+	envKeyFile    = "GOATWAY_AES_KEY_FILE"
+	envKey        = "GOATWAY_AES_KEY"
+	envPassphrase = "GOATWAY_ENCRYPTION_KEY"
+)
+
+// loadKey resolves the 256-bit key and where it came from.
+// An explicit key (env or file) keeps the database portable across hosts;
+// without one the key falls back to a passphrase or the machine.
+func loadKey() ([]byte, string, error) {
+	path, encoded := os.Getenv(envKeyFile), os.Getenv(envKey)
+	switch {
+	case path != "" && encoded != "":
+		return nil, "", fmt.Errorf("set only one of %s and %s", envKey, envKeyFile)
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", envKeyFile, err)
+		}
+		key, err := decodeKey(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", envKeyFile, err)
+		}
+		return key, KeySourceFile, nil
+	case encoded != "":
+		key, err := decodeKey(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", envKey, err)
+		}
+		return key, KeySourceEnv, nil
+	}
+
+	material, source := deriveMachineKey(), KeySourceMachine
+	if passphrase := os.Getenv(envPassphrase); passphrase != "" {
+		material, source = passphrase, KeySourcePassphrase
+	}
+
+	// Derive a 256-bit key using SHA-256
+	hash := sha256.Sum256([]byte(material))
+	return hash[:], source, nil
+}
+
+// decodeKey parses a base64-encoded AES-256 key.
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must decode to 32 bytes for AES-256, got %d", len(key))
+	}
+	return key, nil
+}
+
+// deriveMachineKey creates a machine-specific key from available identifiers
+func deriveMachineKey() string {
+	// Combine multiple sources for a machine-specific key
+	// This provides basic protection without requiring user configuration
+	material := "goatway-default-key"
+
+	// Add hostname
+	if hostname, err := os.Hostname(); err == nil {
+		material += hostname
+	}
+
+	// Add user home directory
+	if home, err := os.UserHomeDir(); err == nil {
+		material += home
+	}
+
+	// Add OS/arch info
+	material += runtime.GOOS + runtime.GOARCH
+
+	return material
+}
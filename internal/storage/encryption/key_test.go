@@ -0,0 +1,63 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNew_KeySource(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	keyFile := filepath.Join(t.TempDir(), "aes.key")
+	if err := os.WriteFile(keyFile, []byte(encoded+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	passphraseKey := sha256.Sum256([]byte("passphrase"))
+	machineKey := sha256.Sum256([]byte(deriveMachineKey()))
+	short := base64.StdEncoding.EncodeToString(key[:16])
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantKey    []byte
+		wantSource string
+		wantErr    bool
+	}{
+		{name: "env key", env: map[string]string{envKey: encoded}, wantKey: key, wantSource: KeySourceEnv},
+		{name: "file key", env: map[string]string{envKeyFile: keyFile}, wantKey: key, wantSource: KeySourceFile},
+		{name: "explicit key wins over passphrase", env: map[string]string{envKey: encoded, envPassphrase: "passphrase"}, wantKey: key, wantSource: KeySourceEnv},
+		{name: "passphrase", env: map[string]string{envPassphrase: "passphrase"}, wantKey: passphraseKey[:], wantSource: KeySourcePassphrase},
+		{name: "machine fallback", wantKey: machineKey[:], wantSource: KeySourceMachine},
+		{name: "short key", env: map[string]string{envKey: short}, wantErr: true},
+		{name: "invalid base64", env: map[string]string{envKey: "not base64!"}, wantErr: true},
+		{name: "missing file", env: map[string]string{envKeyFile: filepath.Join(t.TempDir(), "missing")}, wantErr: true},
+		{name: "both env and file", env: map[string]string{envKey: encoded, envKeyFile: keyFile}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{envKey, envKeyFile, envPassphrase} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			enc, err := New()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !bytes.Equal(enc.key, tt.wantKey) {
+				t.Error("New() derived an unexpected key")
+			}
+			if enc.Source() != tt.wantSource {
+				t.Errorf("Source() = %q, want %q", enc.Source(), tt.wantSource)
+			}
+		})
+	}
+}
@@ -3,26 +3,58 @@ package storage
 import (
 	"crypto/rand"
 	"math/big"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
 )
 
 const (
-	// APIKeyPrefix is the prefix for all Goatway API keys
+	// APIKeyPrefix is the default prefix for Goatway API keys
 	APIKeyPrefix = "gw_"
-	// APIKeyLength is the number of random characters after the prefix
+	// APIKeyLength is the default number of random characters after the prefix
 	APIKeyLength = 64
-	// APIKeyPrefixLen is the length of the identifying prefix (e.g., "gw_a1B2c3D4")
+	// APIKeyPrefixLen is the length of the default identifying prefix (e.g., "gw_a1B2c3D4")
 	APIKeyPrefixLen = 11 // "gw_" + 8 chars
+
+	// keyIDChars is the number of random characters kept in the identifying prefix
+	keyIDChars = 8
 )
 
 // base62Alphabet contains characters for key generation (0-9, A-Z, a-z)
 var base62Alphabet = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
 
-// GenerateAPIKey creates a new API key with format: gw_ + 64 base62 chars
+// keyPrefix and keyLength hold the active key scheme (see SetKeyScheme).
+var (
+	keyPrefix = APIKeyPrefix
+	keyLength = APIKeyLength
+)
+
+// SetKeyScheme configures the prefix and random length of generated API keys.
+// Values are used as given; config.Validate rejects unsafe ones at startup.
+// Must be called at startup, before keys are generated or authenticated.
+func SetKeyScheme(prefix string, length int) {
+	keyPrefix = prefix
+	keyLength = length
+}
+
+// SetKeyExpiryGrace configures how long expired API keys keep authenticating.
+// Must be called at startup, before keys are authenticated.
+func SetKeyExpiryGrace(grace time.Duration) {
+	models.SetExpiryGrace(grace)
+}
+
+// KeyPrefix returns the configured API key prefix (default "gw_").
+func KeyPrefix() string {
+	return keyPrefix
+}
+
+// GenerateAPIKey creates a new API key with format: prefix + random base62 chars
+// (default: gw_ + 64 chars)
 func GenerateAPIKey() (string, error) {
-	result := make([]byte, APIKeyLength)
+	result := make([]byte, keyLength)
 	alphabetLen := big.NewInt(int64(len(base62Alphabet)))
 
-	for i := 0; i < APIKeyLength; i++ {
+	for i := 0; i < keyLength; i++ {
 		idx, err := rand.Int(rand.Reader, alphabetLen)
 		if err != nil {
 			return "", err
@@ -30,14 +62,15 @@ func GenerateAPIKey() (string, error) {
 		result[i] = base62Alphabet[idx.Int64()]
 	}
 
-	return APIKeyPrefix + string(result), nil
+	return keyPrefix + string(result), nil
 }
 
-// ExtractKeyPrefix returns the first 11 chars of a key for identification
+// ExtractKeyPrefix returns the key prefix plus the first 8 random chars for identification
 // Format: "gw_" + first 8 random chars (e.g., "gw_a1B2c3D4")
 func ExtractKeyPrefix(key string) string {
-	if len(key) < APIKeyPrefixLen {
+	n := len(keyPrefix) + keyIDChars
+	if len(key) < n {
 		return key
 	}
-	return key[:APIKeyPrefixLen]
+	return key[:n]
 }
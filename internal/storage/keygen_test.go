@@ -116,3 +116,38 @@ func BenchmarkGenerateAPIKey(b *testing.B) {
 		_, _ = GenerateAPIKey()
 	}
 }
+
+func TestSetKeyScheme(t *testing.T) {
+	t.Cleanup(func() { SetKeyScheme(APIKeyPrefix, APIKeyLength) })
+
+	tests := []struct {
+		name       string
+		prefix     string
+		length     int
+		wantPrefix string
+		wantLength int
+	}{
+		{"custom prefix and length", "acme_", 48, "acme_", 48},
+		{"default scheme", APIKeyPrefix, APIKeyLength, APIKeyPrefix, APIKeyLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetKeyScheme(tt.prefix, tt.length)
+
+			key, err := GenerateAPIKey()
+			if err != nil {
+				t.Fatalf("GenerateAPIKey failed: %v", err)
+			}
+			if !strings.HasPrefix(key, tt.wantPrefix) || KeyPrefix() != tt.wantPrefix {
+				t.Errorf("key %q should start with %q", key, tt.wantPrefix)
+			}
+			if len(key) != len(tt.wantPrefix)+tt.wantLength {
+				t.Errorf("expected key length %d, got %d", len(tt.wantPrefix)+tt.wantLength, len(key))
+			}
+			if prefix := ExtractKeyPrefix(key); prefix != key[:len(tt.wantPrefix)+8] {
+				t.Errorf("ExtractKeyPrefix = %q, want configured prefix + 8 chars", prefix)
+			}
+		})
+	}
+}
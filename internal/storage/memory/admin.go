@@ -0,0 +1,36 @@
+package memory
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// GetAdminPasswordHash retrieves the stored admin password hash.
+// An unset password returns an empty hash and no error.
+func (s *Storage) GetAdminPasswordHash() (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return "", models.ErrStorageClosed
+	}
+	return s.adminPwdHash, nil
+}
+
+// SetAdminPasswordHash stores the admin password hash
+func (s *Storage) SetAdminPasswordHash(hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+	s.adminPwdHash = hash
+	return nil
+}
+
+// HasAdminPassword checks if an admin password has been configured
+func (s *Storage) HasAdminPassword() (bool, error) {
+	hash, err := s.GetAdminPasswordHash()
+	if err != nil {
+		return false, err
+	}
+	return hash != "", nil
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// CreateAlias stores a new model alias; a taken slug returns ErrDuplicateKey.
+func (s *Storage) CreateAlias(alias *models.ModelAlias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+	if !alias.Valid() {
+		return models.ErrInvalidInput
+	}
+	if _, ok := s.aliases[alias.Slug]; ok {
+		return models.ErrDuplicateKey
+	}
+
+	now := time.Now().UTC()
+	alias.CreatedAt = now
+	alias.UpdatedAt = now
+	stored := *alias
+	s.aliases[alias.Slug] = &stored
+	return nil
+}
+
+// GetAlias retrieves a model alias by slug.
+func (s *Storage) GetAlias(slug string) (*models.ModelAlias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	alias, ok := s.aliases[slug]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	c := *alias
+	return &c, nil
+}
+
+// ListAliases retrieves all model aliases ordered by slug.
+func (s *Storage) ListAliases() ([]*models.ModelAlias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var aliases []*models.ModelAlias
+	for _, alias := range s.aliases {
+		c := *alias
+		aliases = append(aliases, &c)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Slug < aliases[j].Slug })
+	return aliases, nil
+}
+
+// UpdateAlias replaces the route of the alias with the same slug.
+func (s *Storage) UpdateAlias(alias *models.ModelAlias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+	if !alias.Valid() {
+		return models.ErrInvalidInput
+	}
+	existing, ok := s.aliases[alias.Slug]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	alias.UpdatedAt = time.Now().UTC()
+	stored := *alias
+	stored.CreatedAt = existing.CreatedAt
+	s.aliases[alias.Slug] = &stored
+	return nil
+}
+
+// DeleteAlias removes a model alias by slug.
+func (s *Storage) DeleteAlias(slug string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if _, ok := s.aliases[slug]; !ok {
+		return models.ErrNotFound
+	}
+	delete(s.aliases, slug)
+	return nil
+}
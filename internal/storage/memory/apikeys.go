@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// CreateAPIKey creates a new client API key
+func (s *Storage) CreateAPIKey(key *models.ClientAPIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	if _, ok := s.apiKeys[key.ID]; ok {
+		return models.ErrDuplicateKey
+	}
+	key.CreatedAt = time.Now()
+
+	stored := copyAPIKey(key)
+	stored.LastUsedAt = nil
+	s.apiKeys[key.ID] = stored
+	return nil
+}
+
+// GetAPIKey retrieves an API key by ID
+func (s *Storage) GetAPIKey(id string) (*models.ClientAPIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	key, ok := s.apiKeys[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return copyAPIKey(key), nil
+}
+
+// GetAPIKeyByPrefix retrieves API keys matching a prefix
+func (s *Storage) GetAPIKeyByPrefix(prefix string) ([]*models.ClientAPIKey, error) {
+	return s.listAPIKeys(func(k *models.ClientAPIKey) bool { return k.KeyPrefix == prefix })
+}
+
+// ListAPIKeys returns all API keys, newest first
+func (s *Storage) ListAPIKeys() ([]*models.ClientAPIKey, error) {
+	return s.listAPIKeys(func(*models.ClientAPIKey) bool { return true })
+}
+
+// UpdateAPIKey updates an existing API key.
+// Creation and last-used timestamps are preserved.
+func (s *Storage) UpdateAPIKey(key *models.ClientAPIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	existing, ok := s.apiKeys[key.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+	stored := copyAPIKey(key)
+	stored.CreatedAt = existing.CreatedAt
+	stored.LastUsedAt = existing.LastUsedAt
+	s.apiKeys[key.ID] = stored
+	return nil
+}
+
+// DeleteAPIKey deletes an API key by ID
+func (s *Storage) DeleteAPIKey(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if _, ok := s.apiKeys[id]; !ok {
+		return models.ErrNotFound
+	}
+	delete(s.apiKeys, id)
+	return nil
+}
+
+// UpdateAPIKeyLastUsed updates the last-used timestamp for an API key.
+// Unknown IDs are ignored, as with the SQLite UPDATE.
+func (s *Storage) UpdateAPIKeyLastUsed(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if key, ok := s.apiKeys[id]; ok {
+		now := time.Now()
+		key.LastUsedAt = &now
+	}
+	return nil
+}
+
+// listAPIKeys returns copies of the keys accepted by match, newest first.
+func (s *Storage) listAPIKeys(match func(*models.ClientAPIKey) bool) ([]*models.ClientAPIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var keys []*models.ClientAPIKey
+	for _, key := range s.apiKeys {
+		if match(key) {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// copyAPIKey returns a deep copy so stored data cannot be mutated by callers.
+func copyAPIKey(key *models.ClientAPIKey) *models.ClientAPIKey {
+	k := *key
+	k.Scopes = slices.Clone(key.Scopes)
+	if key.LastUsedAt != nil {
+		t := *key.LastUsedAt
+		k.LastUsedAt = &t
+	}
+	if key.ExpiresAt != nil {
+		t := *key.ExpiresAt
+		k.ExpiresAt = &t
+	}
+	return &k
+}
@@ -0,0 +1,37 @@
+package memory
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// PurgeCredential deletes a credential together with its request logs and daily usage.
+func (s *Storage) PurgeCredential(id string) (*models.CredentialPurge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	if _, ok := s.credentials[id]; !ok {
+		return nil, models.ErrNotFound
+	}
+	delete(s.credentials, id)
+
+	purge := &models.CredentialPurge{}
+	kept := s.logs[:0]
+	for _, log := range s.logs {
+		if log.CredentialID == id {
+			purge.RequestLogs++
+			continue
+		}
+		kept = append(kept, log)
+	}
+	s.logs = kept
+
+	for key := range s.usage {
+		if key.credentialID == id {
+			delete(s.usage, key)
+			purge.DailyUsage++
+		}
+	}
+	return purge, nil
+}
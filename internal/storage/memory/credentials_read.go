@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// GetCredential retrieves a credential by ID.
+func (s *Storage) GetCredential(id string) (*models.Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	cred, ok := s.credentials[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return copyCredential(cred), nil
+}
+
+// GetCredentialByName retrieves a credential by its unique name.
+func (s *Storage) GetCredentialByName(name string) (*models.Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	for _, cred := range s.credentials {
+		if cred.Name == name {
+			return copyCredential(cred), nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+// ListCredentials retrieves all credentials, newest first.
+func (s *Storage) ListCredentials() ([]*models.Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var credentials []*models.Credential
+	for _, cred := range s.credentials {
+		credentials = append(credentials, copyCredential(cred))
+	}
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].CreatedAt.After(credentials[j].CreatedAt)
+	})
+	return credentials, nil
+}
+
+// copyCredential returns a deep copy so stored data cannot be mutated by callers.
+func copyCredential(cred *models.Credential) *models.Credential {
+	c := *cred
+	c.Data = bytes.Clone(cred.Data)
+	return &c
+}
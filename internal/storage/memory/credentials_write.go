@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// CreateCredential stores a new credential.
+func (s *Storage) CreateCredential(cred *models.Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if cred.Provider == "" || cred.Name == "" || len(cred.Data) == 0 {
+		return models.ErrInvalidInput
+	}
+	if cred.ID == "" {
+		cred.ID = generateID("cred")
+	}
+	if _, ok := s.credentials[cred.ID]; ok || s.nameTaken(cred.Name, "") {
+		return models.ErrDuplicateKey
+	}
+
+	now := time.Now().UTC()
+	cred.CreatedAt = now
+	cred.UpdatedAt = now
+	cred.Version = 1
+	s.credentials[cred.ID] = copyCredential(cred)
+	return nil
+}
+
+// UpdateCredential updates an existing credential, rejecting a non-zero
+// cred.Version that no longer matches the stored one with ErrConflict.
+func (s *Storage) UpdateCredential(cred *models.Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if cred.ID == "" {
+		return models.ErrInvalidInput
+	}
+	existing, ok := s.credentials[cred.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+	if cred.Version != 0 && cred.Version != existing.Version {
+		return models.ErrConflict
+	}
+	if s.nameTaken(cred.Name, cred.ID) {
+		return models.ErrDuplicateKey
+	}
+
+	cred.UpdatedAt = time.Now().UTC()
+	cred.Version = existing.Version + 1
+	stored := copyCredential(cred)
+	stored.CreatedAt = existing.CreatedAt
+	s.credentials[cred.ID] = stored
+	return nil
+}
+
+// DeleteCredential removes a credential by ID.
+// History rows keep their credential ID, as in the SQLite backend.
+func (s *Storage) DeleteCredential(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if _, ok := s.credentials[id]; !ok {
+		return models.ErrNotFound
+	}
+	delete(s.credentials, id)
+	return nil
+}
+
+// nameTaken reports whether a credential other than exceptID uses name.
+// Callers must hold s.mu.
+func (s *Storage) nameTaken(name, exceptID string) bool {
+	for id, cred := range s.credentials {
+		if cred.Name == name && id != exceptID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package memory
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// CheckEncryption reports the memory backend as unencrypted: credentials are
+// held in plaintext for the life of the process, so there is no key to lose.
+func (s *Storage) CheckEncryption() (*models.EncryptionHealth, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+	return &models.EncryptionHealth{Credentials: len(s.credentials)}, nil
+}
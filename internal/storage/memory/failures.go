@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// RecordFailure stores an upstream failure record
+func (s *Storage) RecordFailure(f *models.FailedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if f.ID == "" {
+		f.ID = generateID("fail")
+	}
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now().UTC()
+	}
+
+	entry := *f
+	s.failures = append(s.failures, &entry)
+	return nil
+}
+
+// ListFailures retrieves failure records with filtering, newest first
+func (s *Storage) ListFailures(filter models.FailureFilter) ([]*models.FailedRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var failures []*models.FailedRequest
+	for _, f := range s.failures {
+		if matchFailure(f, filter) {
+			entry := *f
+			failures = append(failures, &entry)
+		}
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].CreatedAt.After(failures[j].CreatedAt)
+	})
+
+	if filter.Offset > 0 {
+		failures = failures[min(filter.Offset, len(failures)):]
+	}
+	if filter.Limit > 0 && len(failures) > filter.Limit {
+		failures = failures[:filter.Limit]
+	}
+	return failures, nil
+}
+
+// matchFailure reports whether a failure record passes every set filter field.
+func matchFailure(f *models.FailedRequest, filter models.FailureFilter) bool {
+	switch {
+	case filter.RequestID != "" && f.RequestID != filter.RequestID,
+		filter.Model != "" && f.Model != filter.Model,
+		filter.Provider != "" && f.Provider != filter.Provider,
+		filter.ErrorType != "" && f.ErrorType != filter.ErrorType:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// LogRequest stores a request log entry
+func (s *Storage) LogRequest(log *models.RequestLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	if log.ID == "" {
+		log.ID = generateID("log")
+	}
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now().UTC()
+	}
+
+	entry := *log
+	s.logs = append(s.logs, &entry)
+	return nil
+}
+
+// GetRequestLogs retrieves request logs with filtering, newest first
+func (s *Storage) GetRequestLogs(filter models.LogFilter) ([]*models.RequestLog, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var logs []*models.RequestLog
+	for _, log := range s.logs {
+		if matchLog(log, filter) {
+			entry := *log
+			logs = append(logs, &entry)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].CreatedAt.After(logs[j].CreatedAt)
+	})
+
+	if filter.Offset > 0 {
+		logs = logs[min(filter.Offset, len(logs)):]
+	}
+	if filter.Limit > 0 && len(logs) > filter.Limit {
+		logs = logs[:filter.Limit]
+	}
+	return logs, nil
+}
+
+// DeleteRequestLogs removes logs older than the specified date (YYYY-MM-DD)
+func (s *Storage) DeleteRequestLogs(olderThan string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, models.ErrStorageClosed
+	}
+
+	var deleted int64
+	kept := s.logs[:0]
+	for _, log := range s.logs {
+		if logDate(log) < olderThan {
+			deleted++
+			continue
+		}
+		kept = append(kept, log)
+	}
+	s.logs = kept
+	return deleted, nil
+}
+
+// matchLog reports whether a log entry passes every set filter field.
+func matchLog(log *models.RequestLog, f models.LogFilter) bool {
+	switch {
+	case f.RequestID != "" && log.RequestID != f.RequestID,
+		f.CredentialID != "" && log.CredentialID != f.CredentialID,
+		f.APIKeyID != "" && log.APIKeyID != f.APIKeyID,
+		f.User != "" && log.User != f.User,
+		f.Model != "" && log.Model != f.Model,
+		f.Provider != "" && log.Provider != f.Provider,
+		f.StatusCode != nil && log.StatusCode != *f.StatusCode,
+		f.Anomalous && log.Anomaly == "",
+		f.StartDate != nil && log.CreatedAt.Before(*f.StartDate),
+		f.EndDate != nil && log.CreatedAt.After(*f.EndDate):
+		return false
+	}
+	return true
+}
+
+// logDate returns the calendar date a log was recorded on, as the
+// SQLite backend reads it from the stored timestamp.
+func logDate(log *models.RequestLog) string {
+	return log.CreatedAt.Format("2006-01-02")
+}
+
+// inDateRange reports whether date falls within the filter's optional bounds.
+func inDateRange(date string, f models.StatsFilter) bool {
+	if f.StartDate != nil && date < f.StartDate.Format("2006-01-02") {
+		return false
+	}
+	if f.EndDate != nil && date > f.EndDate.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
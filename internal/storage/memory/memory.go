@@ -0,0 +1,55 @@
+// Package memory provides an in-memory storage implementation.
+// Data lives only for the lifetime of the process, which suits tests and
+// stateless deployments. Behavior mirrors the SQLite backend.
+package memory
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// usageKey identifies a daily usage row, like the SQLite unique index.
+type usageKey struct {
+	date, credentialID, model string
+}
+
+// Storage implements the storage.Storage interface using maps guarded by a mutex.
+// Records are copied on the way in and out so callers never share state.
+type Storage struct {
+	mu     sync.RWMutex
+	closed bool
+
+	credentials  map[string]*models.Credential   // by ID
+	logs         []*models.RequestLog            // in insertion order
+	failures     []*models.FailedRequest         // in insertion order
+	usage        map[usageKey]*models.DailyUsage // upserted per day, credential and model
+	apiKeys      map[string]*models.ClientAPIKey // by ID
+	aliases      map[string]*models.ModelAlias   // by slug
+	adminPwdHash string
+}
+
+// New creates an empty in-memory storage instance
+func New() *Storage {
+	return &Storage{
+		credentials: make(map[string]*models.Credential),
+		usage:       make(map[usageKey]*models.DailyUsage),
+		apiKeys:     make(map[string]*models.ClientAPIKey),
+		aliases:     make(map[string]*models.ModelAlias),
+	}
+}
+
+// Close marks the storage closed; later calls return ErrStorageClosed
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	return nil
+}
+
+// generateID creates a new unique ID with a prefix
+func generateID(prefix string) string {
+	return prefix + "_" + uuid.New().String()[:8]
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// UpdateDailyUsage upserts daily usage data, adding to any existing counters
+func (s *Storage) UpdateDailyUsage(usage *models.DailyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return models.ErrStorageClosed
+	}
+
+	key := usageKey{date: usage.Date, credentialID: usage.CredentialID, model: usage.Model}
+	row, ok := s.usage[key]
+	if !ok {
+		row = &models.DailyUsage{Date: usage.Date, CredentialID: usage.CredentialID, Model: usage.Model}
+		s.usage[key] = row
+	}
+	row.Add(usage)
+	return nil
+}
+
+// GetUsageStats retrieves aggregated usage statistics
+func (s *Storage) GetUsageStats(filter models.StatsFilter) (*models.UsageStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	stats := &models.UsageStats{
+		ModelBreakdown: make(map[string]*models.ModelStats),
+		ErrorsByType:   make(map[string]int),
+	}
+	for _, row := range s.usage {
+		if filter.CredentialID != "" && row.CredentialID != filter.CredentialID {
+			continue
+		}
+		if !inDateRange(row.Date, filter) {
+			continue
+		}
+		stats.TotalRequests += row.RequestCount
+		stats.TotalPromptTokens += row.PromptTokens
+		stats.TotalCompletionTokens += row.CompletionTokens
+		stats.TotalTokens += row.TotalTokens
+		stats.ErrorCount += row.ErrorCount
+		stats.TotalAudioCharacters += row.AudioCharacters
+		stats.TotalImages += row.ImageCount
+		stats.TotalToolCallRequests += row.ToolCallRequests
+		stats.TotalToolCalls += row.ToolCalls
+
+		ms, ok := stats.ModelBreakdown[row.Model]
+		if !ok {
+			ms = &models.ModelStats{Model: row.Model}
+			stats.ModelBreakdown[row.Model] = ms
+		}
+		ms.RequestCount += row.RequestCount
+		ms.PromptTokens += row.PromptTokens
+		ms.CompletionTokens += row.CompletionTokens
+		ms.TotalTokens += row.TotalTokens
+		ms.ErrorCount += row.ErrorCount
+		ms.AudioCharacters += row.AudioCharacters
+		ms.ImageCount += row.ImageCount
+		ms.ToolCallRequests += row.ToolCallRequests
+		ms.ToolCalls += row.ToolCalls
+	}
+
+	// Shadow requests are excluded since clients never saw their responses
+	for _, log := range s.logs {
+		if log.ErrorType == "" || log.IsShadow {
+			continue
+		}
+		if filter.CredentialID != "" && log.CredentialID != filter.CredentialID {
+			continue
+		}
+		if filter.APIKeyID != "" && log.APIKeyID != filter.APIKeyID {
+			continue
+		}
+		if filter.Model != "" && log.Model != filter.Model {
+			continue
+		}
+		if inDateRange(logDate(log), filter) {
+			stats.ErrorsByType[log.ErrorType]++
+		}
+	}
+	return stats, nil
+}
+
+// GetDailyUsage retrieves daily usage data for an inclusive date range
+func (s *Storage) GetDailyUsage(startDate, endDate string) ([]*models.DailyUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	var usage []*models.DailyUsage
+	for _, row := range s.usage {
+		if row.Date >= startDate && row.Date <= endDate {
+			u := *row
+			usage = append(usage, &u)
+		}
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return a.CredentialID < b.CredentialID
+	})
+	return usage, nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// GetAPIKeyUsage aggregates the request logs of filter.APIKeyID into usage
+// statistics with a per-model breakdown. Shadow requests are excluded.
+// Audio characters and image counts are only kept in daily usage and stay zero.
+func (s *Storage) GetAPIKeyUsage(filter models.StatsFilter) (*models.UsageStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	stats := &models.UsageStats{
+		ModelBreakdown: make(map[string]*models.ModelStats),
+		ErrorsByType:   make(map[string]int),
+	}
+	for _, log := range s.logs {
+		if log.APIKeyID == "" || log.APIKeyID != filter.APIKeyID || log.IsShadow {
+			continue
+		}
+		if filter.Model != "" && log.Model != filter.Model {
+			continue
+		}
+		if !inDateRange(logDate(log), filter) {
+			continue
+		}
+
+		delta := &models.ModelStats{
+			RequestCount:     1,
+			PromptTokens:     log.PromptTokens,
+			CompletionTokens: log.CompletionTokens,
+			TotalTokens:      log.TotalTokens,
+			ToolCalls:        log.ToolCalls,
+		}
+		if log.StatusCode >= 400 {
+			delta.ErrorCount = 1
+		}
+		if log.ToolCalls > 0 {
+			delta.ToolCallRequests = 1
+		}
+		if log.ErrorType != "" {
+			stats.ErrorsByType[log.ErrorType]++
+		}
+
+		ms, ok := stats.ModelBreakdown[log.Model]
+		if !ok {
+			ms = &models.ModelStats{Model: log.Model}
+			stats.ModelBreakdown[log.Model] = ms
+		}
+		ms.Add(delta)
+		stats.AddModel(delta)
+	}
+	return stats, nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// GetUserUsage aggregates request logs per (API key, end user).
+// Requests without a "user" field and shadow requests are excluded.
+// An empty apiKeyID returns usage for every key.
+func (s *Storage) GetUserUsage(apiKeyID string, filter models.StatsFilter) ([]*models.UserUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, models.ErrStorageClosed
+	}
+
+	type userKey struct{ apiKeyID, user string }
+	groups := make(map[userKey]*models.UserUsage)
+	var usage []*models.UserUsage
+
+	for _, log := range s.logs {
+		if log.APIKeyID == "" || log.User == "" || log.IsShadow {
+			continue
+		}
+		if apiKeyID != "" && log.APIKeyID != apiKeyID {
+			continue
+		}
+		if filter.Model != "" && log.Model != filter.Model {
+			continue
+		}
+		if !inDateRange(logDate(log), filter) {
+			continue
+		}
+
+		key := userKey{log.APIKeyID, log.User}
+		u, ok := groups[key]
+		if !ok {
+			u = &models.UserUsage{APIKeyID: log.APIKeyID, User: log.User}
+			groups[key] = u
+			usage = append(usage, u)
+		}
+		u.RequestCount++
+		u.PromptTokens += log.PromptTokens
+		u.CompletionTokens += log.CompletionTokens
+		u.TotalTokens += log.TotalTokens
+		if log.StatusCode >= 400 {
+			u.ErrorCount++
+		}
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].TotalTokens != usage[j].TotalTokens {
+			return usage[i].TotalTokens > usage[j].TotalTokens
+		}
+		return usage[i].User < usage[j].User
+	})
+	return usage, nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ModelAlias is a model route managed at runtime through the admin API.
+// Stored aliases are merged with the config file's [[models]] and replace
+// any config alias with the same slug.
+type ModelAlias struct {
+	Slug           string    `json:"slug"`     // Model name clients request
+	Provider       string    `json:"provider"` // Routing name of the serving provider
+	Model          string    `json:"model"`    // Upstream model ID
+	CredentialName string    `json:"credential_name"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Valid reports whether the alias has every field routing needs.
+func (a *ModelAlias) Valid() bool {
+	for _, v := range []string{a.Slug, a.Provider, a.Model, a.CredentialName} {
+		if strings.TrimSpace(v) == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -2,14 +2,26 @@ package models
 
 import "time"
 
+// expiryGrace keeps expired keys usable for a while after ExpiresAt (see SetExpiryGrace).
+var expiryGrace time.Duration
+
+// SetExpiryGrace sets how long an expired key keeps authenticating, so
+// clients are not cut off mid-rotation. Zero or negative disables the grace.
+// Must be called at startup, before keys are authenticated.
+func SetExpiryGrace(d time.Duration) {
+	expiryGrace = max(d, 0)
+}
+
 // ClientAPIKey represents a Goatway client API key for authentication
 type ClientAPIKey struct {
 	ID         string     `json:"id"`
 	Name       string     `json:"name"`
-	KeyHash    string     `json:"-"`          // Argon2id hash (never exposed in JSON)
-	KeyPrefix  string     `json:"key_prefix"` // First 11 chars (e.g., "gw_a1B2c3D4")
-	Scopes     []string   `json:"scopes"`     // ["proxy", "admin"]
-	RateLimit  int        `json:"rate_limit"` // Requests per minute (0 = unlimited)
+	KeyHash    string     `json:"-"`                // Argon2id hash (never exposed in JSON)
+	KeyPrefix  string     `json:"key_prefix"`       // First 11 chars (e.g., "gw_a1B2c3D4")
+	Scopes     []string   `json:"scopes"`           // ["proxy", "admin"]
+	RateLimit  int        `json:"rate_limit"`       // Requests per minute (0 = unlimited)
+	UserLimit  int        `json:"user_rate_limit"`  // Requests per minute per end user (0 = unlimited)
+	Tenant     string     `json:"tenant,omitempty"` // Tenant whose routes the key uses ("" = the gateway's own)
 	IsActive   bool       `json:"is_active"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
@@ -23,6 +35,8 @@ type ClientAPIKeyPreview struct {
 	KeyPrefix  string     `json:"key_prefix"`
 	Scopes     []string   `json:"scopes"`
 	RateLimit  int        `json:"rate_limit"`
+	UserLimit  int        `json:"user_rate_limit"`
+	Tenant     string     `json:"tenant,omitempty"`
 	IsActive   bool       `json:"is_active"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
@@ -37,6 +51,8 @@ func (k *ClientAPIKey) ToPreview() *ClientAPIKeyPreview {
 		KeyPrefix:  k.KeyPrefix,
 		Scopes:     k.Scopes,
 		RateLimit:  k.RateLimit,
+		UserLimit:  k.UserLimit,
+		Tenant:     k.Tenant,
 		IsActive:   k.IsActive,
 		LastUsedAt: k.LastUsedAt,
 		CreatedAt:  k.CreatedAt,
@@ -54,10 +70,15 @@ func (k *ClientAPIKey) HasScope(scope string) bool {
 	return false
 }
 
-// IsExpired checks if the key has expired
+// IsExpired checks if the key has expired, including the configured grace window
 func (k *ClientAPIKey) IsExpired() bool {
 	if k.ExpiresAt == nil {
 		return false
 	}
-	return time.Now().After(*k.ExpiresAt)
+	return time.Now().After(k.ExpiresAt.Add(expiryGrace))
+}
+
+// InExpiryGrace reports whether the key is past ExpiresAt but still within the grace window
+func (k *ClientAPIKey) InExpiryGrace() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt) && !k.IsExpired()
 }
@@ -15,6 +15,14 @@ type Credential struct {
 	Data      json.RawMessage `json:"data"`     // Provider-specific credential data (encrypted at rest)
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+
+	// LogRequests writes a request_logs row per request; when false only the
+	// daily usage aggregates are updated
+	LogRequests bool `json:"log_requests"`
+
+	// Version increments on every update. A non-zero Version passed to
+	// UpdateCredential must match the stored one, else ErrConflict.
+	Version int `json:"version"`
 }
 
 // CredentialPreview is a safe representation of a credential (secrets masked).
@@ -23,6 +31,8 @@ type CredentialPreview struct {
 	Provider    string          `json:"provider"`
 	Name        string          `json:"name"`
 	DataPreview json.RawMessage `json:"data_preview"` // Masked credential data
+	LogRequests bool            `json:"log_requests"`
+	Version     int             `json:"version"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -30,8 +40,11 @@ type CredentialPreview struct {
 // Provider-specific credential types
 
 // APIKeyCredential is for providers that only need an API key (OpenRouter, OpenAI, Anthropic).
+// Organization and Project optionally set the OpenAI account headers.
 type APIKeyCredential struct {
-	APIKey string `json:"api_key"`
+	APIKey       string `json:"api_key"`
+	Organization string `json:"organization,omitempty"`
+	Project      string `json:"project,omitempty"`
 }
 
 // AzureCredential contains Azure OpenAI-specific fields.
@@ -42,6 +55,14 @@ type AzureCredential struct {
 	APIVersion string `json:"api_version"`
 }
 
+// BedrockCredential contains AWS credentials for Amazon Bedrock (SigV4 signing).
+type BedrockCredential struct {
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	Region          string `json:"region"`
+	SessionToken    string `json:"session_token,omitempty"`
+}
+
 // ToPreview converts a Credential to a safe CredentialPreview with masked secrets.
 func (c *Credential) ToPreview() *CredentialPreview {
 	return &CredentialPreview{
@@ -49,6 +70,8 @@ func (c *Credential) ToPreview() *CredentialPreview {
 		Provider:    c.Provider,
 		Name:        c.Name,
 		DataPreview: maskCredentialData(c.Provider, c.Data),
+		LogRequests: c.LogRequests,
+		Version:     c.Version,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
@@ -64,6 +87,16 @@ func maskCredentialData(provider string, data json.RawMessage) json.RawMessage {
 			masked, _ := json.Marshal(cred)
 			return masked
 		}
+	case "bedrock":
+		var cred BedrockCredential
+		if err := json.Unmarshal(data, &cred); err == nil {
+			cred.SecretAccessKey = maskSecret(cred.SecretAccessKey)
+			if cred.SessionToken != "" {
+				cred.SessionToken = maskSecret(cred.SessionToken)
+			}
+			masked, _ := json.Marshal(cred)
+			return masked
+		}
 	default:
 		var cred APIKeyCredential
 		if err := json.Unmarshal(data, &cred); err == nil {
@@ -100,3 +133,18 @@ func (c *Credential) GetAzureCredential() (*AzureCredential, error) {
 	}
 	return &cred, nil
 }
+
+// GetBedrockCredential extracts AWS Bedrock credential data.
+func (c *Credential) GetBedrockCredential() (*BedrockCredential, error) {
+	var cred BedrockCredential
+	if err := json.Unmarshal(c.Data, &cred); err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// CredentialPurge reports rows removed when a credential is purged with its history.
+type CredentialPurge struct {
+	RequestLogs int64 `json:"request_logs_deleted"`
+	DailyUsage  int64 `json:"daily_usage_deleted"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate reports which required Azure fields are empty, so a broken
+// credential is rejected when saved rather than failing every request.
+func (c *AzureCredential) Validate() error {
+	var missing []string
+	for _, f := range []struct{ name, value string }{
+		{"endpoint", c.Endpoint},
+		{"api_key", c.APIKey},
+		{"deployment", c.Deployment},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: azure credential is missing %s", ErrInvalidInput, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ValidateData checks the provider-specific fields of the credential data.
+// Providers without field requirements accept any JSON object.
+func (c *Credential) ValidateData() error {
+	switch c.Provider {
+	case "azure":
+		cred, err := c.GetAzureCredential()
+		if err != nil {
+			return fmt.Errorf("%w: azure credential data: %v", ErrInvalidInput, err)
+		}
+		return cred.Validate()
+	}
+	return nil
+}
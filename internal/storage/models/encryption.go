@@ -0,0 +1,17 @@
+package models
+
+// EncryptionHealth reports whether the current encryption key can read the
+// secrets already in storage. A key mismatch usually means the database was
+// moved to another machine while the key was machine-derived.
+type EncryptionHealth struct {
+	Encrypted     bool   `json:"encrypted"`            // False when the backend keeps secrets in plaintext
+	KeySource     string `json:"key_source,omitempty"` // "file", "env", "passphrase" or "machine"
+	CanaryOK      bool   `json:"canary_ok"`            // Stored canary value decrypts with the current key
+	Credentials   int    `json:"credentials"`
+	Undecryptable int    `json:"undecryptable"` // Credentials the current key cannot decrypt
+}
+
+// Healthy reports whether every stored secret can be decrypted.
+func (h *EncryptionHealth) Healthy() bool {
+	return !h.Encrypted || (h.CanaryOK && h.Undecryptable == 0)
+}
@@ -0,0 +1,13 @@
+package models
+
+import "errors"
+
+// Common errors returned by every storage backend
+var (
+	ErrNotFound        = errors.New("record not found")
+	ErrDuplicateKey    = errors.New("duplicate key")
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrStorageClosed   = errors.New("storage is closed")
+	ErrEncryptionError = errors.New("encryption error")
+	ErrConflict        = errors.New("record was modified concurrently")
+)
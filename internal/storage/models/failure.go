@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// FailedRequest records an upstream failure (5xx or timeout) in full.
+// Unlike request logs, failures are always recorded so they can be
+// analysed and replayed even when detailed logging is off.
+type FailedRequest struct {
+	ID           string    `json:"id"`
+	RequestID    string    `json:"request_id"`
+	Model        string    `json:"model"`
+	Provider     string    `json:"provider"`
+	CredentialID string    `json:"credential_id,omitempty"`
+	APIKeyID     string    `json:"api_key_id,omitempty"`
+	StatusCode   int       `json:"status_code"`
+	ErrorType    string    `json:"error_type"` // server_error or timeout
+	ErrorMessage string    `json:"error_message,omitempty"`
+	Attempts     int       `json:"attempts"` // Upstream calls made before giving up
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// FailureFilter contains parameters for filtering failure records
+type FailureFilter struct {
+	RequestID string
+	Model     string
+	Provider  string
+	ErrorType string
+	Limit     int
+	Offset    int
+}
@@ -7,6 +7,8 @@ type RequestLog struct {
 	ID               string    `json:"id"`
 	RequestID        string    `json:"request_id"`
 	CredentialID     string    `json:"credential_id,omitempty"`
+	APIKeyID         string    `json:"api_key_id,omitempty"` // Client API key that made the request
+	User             string    `json:"user,omitempty"`       // End-user identifier from the request's "user" field
 	Model            string    `json:"model"`
 	Provider         string    `json:"provider"`
 	PromptTokens     int       `json:"prompt_tokens"`
@@ -15,16 +17,34 @@ type RequestLog struct {
 	IsStreaming      bool      `json:"is_streaming"`
 	StatusCode       int       `json:"status_code"`
 	ErrorMessage     string    `json:"error_message,omitempty"`
+	ErrorType        string    `json:"error_type,omitempty"` // auth, rate_limit, invalid_request, server_error, timeout
 	DurationMs       int64     `json:"duration_ms"`
+	TTFBMs           int64     `json:"ttfb_ms,omitempty"`    // Time to first byte sent to the client
+	ToolCalls        int       `json:"tool_calls,omitempty"` // Tool calls in the response
+	Anomaly          string    `json:"anomaly,omitempty"`    // Implausible upstream token counts (see AnomalyCompletionOverLimit)
+	IsShadow         bool      `json:"is_shadow,omitempty"`  // Mirrored request; response was discarded
+	Attempts         int       `json:"attempts"`             // Upstream calls made, counting fallbacks
 	CreatedAt        time.Time `json:"created_at"`
+
+	RequestBody string `json:"request_body,omitempty"` // Client request body, only kept when LOG_REQUEST_BODIES is on
 }
 
+// Anomaly values flag upstream token counts that look like billing errors.
+const (
+	AnomalyCompletionOverLimit = "completion_over_max_tokens" // Completion far above the request's max_tokens
+	AnomalyZeroPrompt          = "zero_prompt_tokens"         // No prompt tokens billed for a non-empty prompt
+)
+
 // LogFilter contains parameters for filtering request logs
 type LogFilter struct {
+	RequestID    string
 	CredentialID string
+	APIKeyID     string
+	User         string
 	Model        string
 	Provider     string
 	StatusCode   *int
+	Anomalous    bool // Only logs with an Anomaly
 	StartDate    *time.Time
 	EndDate      *time.Time
 	Limit        int
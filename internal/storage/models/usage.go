@@ -12,6 +12,23 @@ type DailyUsage struct {
 	CompletionTokens int    `json:"completion_tokens"`
 	TotalTokens      int    `json:"total_tokens"`
 	ErrorCount       int    `json:"error_count"`
+	AudioCharacters  int    `json:"audio_characters"`   // TTS input characters
+	ImageCount       int    `json:"image_count"`        // Images requested from successful calls
+	ToolCallRequests int    `json:"tool_call_requests"` // Responses that contained tool calls
+	ToolCalls        int    `json:"tool_calls"`         // Tool calls across those responses
+}
+
+// Add accumulates the counters of delta into u.
+func (u *DailyUsage) Add(delta *DailyUsage) {
+	u.RequestCount += delta.RequestCount
+	u.PromptTokens += delta.PromptTokens
+	u.CompletionTokens += delta.CompletionTokens
+	u.TotalTokens += delta.TotalTokens
+	u.ErrorCount += delta.ErrorCount
+	u.AudioCharacters += delta.AudioCharacters
+	u.ImageCount += delta.ImageCount
+	u.ToolCallRequests += delta.ToolCallRequests
+	u.ToolCalls += delta.ToolCalls
 }
 
 // ModelStats represents usage statistics for a specific model
@@ -22,6 +39,23 @@ type ModelStats struct {
 	CompletionTokens int    `json:"completion_tokens"`
 	TotalTokens      int    `json:"total_tokens"`
 	ErrorCount       int    `json:"error_count"`
+	AudioCharacters  int    `json:"audio_characters"`
+	ImageCount       int    `json:"image_count"`
+	ToolCallRequests int    `json:"tool_call_requests"`
+	ToolCalls        int    `json:"tool_calls"`
+}
+
+// Add accumulates the counters of delta into ms.
+func (ms *ModelStats) Add(delta *ModelStats) {
+	ms.RequestCount += delta.RequestCount
+	ms.PromptTokens += delta.PromptTokens
+	ms.CompletionTokens += delta.CompletionTokens
+	ms.TotalTokens += delta.TotalTokens
+	ms.ErrorCount += delta.ErrorCount
+	ms.AudioCharacters += delta.AudioCharacters
+	ms.ImageCount += delta.ImageCount
+	ms.ToolCallRequests += delta.ToolCallRequests
+	ms.ToolCalls += delta.ToolCalls
 }
 
 // UsageStats represents aggregated usage statistics
@@ -31,14 +65,45 @@ type UsageStats struct {
 	TotalPromptTokens     int                    `json:"prompt_tokens"`
 	TotalCompletionTokens int                    `json:"completion_tokens"`
 	ErrorCount            int                    `json:"error_count"`
+	TotalAudioCharacters  int                    `json:"audio_characters"`
+	TotalImages           int                    `json:"image_count"`
+	TotalToolCallRequests int                    `json:"tool_call_requests"`
+	TotalToolCalls        int                    `json:"tool_calls"`
 	ModelBreakdown        map[string]*ModelStats `json:"models,omitempty"`
+	ErrorsByType          map[string]int         `json:"errors_by_type,omitempty"`
+}
+
+// AddModel accumulates the counters of a model's stats into the totals.
+func (s *UsageStats) AddModel(ms *ModelStats) {
+	s.TotalRequests += ms.RequestCount
+	s.TotalPromptTokens += ms.PromptTokens
+	s.TotalCompletionTokens += ms.CompletionTokens
+	s.TotalTokens += ms.TotalTokens
+	s.ErrorCount += ms.ErrorCount
+	s.TotalAudioCharacters += ms.AudioCharacters
+	s.TotalImages += ms.ImageCount
+	s.TotalToolCallRequests += ms.ToolCallRequests
+	s.TotalToolCalls += ms.ToolCalls
 }
 
 // StatsFilter contains parameters for filtering usage statistics
 type StatsFilter struct {
 	CredentialID string
+	APIKeyID     string // Client API key; only request-log aggregates honour it
 	Model        string
 	Provider     string
 	StartDate    *time.Time
 	EndDate      *time.Time
 }
+
+// UserUsage attributes requests and tokens to an end user of a client API key.
+// End users are identified by the OpenAI "user" request field.
+type UserUsage struct {
+	APIKeyID         string `json:"api_key_id"`
+	User             string `json:"user"`
+	RequestCount     int    `json:"request_count"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+	ErrorCount       int    `json:"error_count"`
+}
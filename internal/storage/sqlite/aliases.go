@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// CreateAlias stores a new model alias; a taken slug returns ErrDuplicateKey.
+func (s *Storage) CreateAlias(alias *models.ModelAlias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+	if !alias.Valid() {
+		return ErrInvalidInput
+	}
+
+	var exists int
+	err := s.db.QueryRow("SELECT 1 FROM model_aliases WHERE slug = ?", alias.Slug).Scan(&exists)
+	if err == nil {
+		return ErrDuplicateKey
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	now := time.Now().UTC()
+	alias.CreatedAt = now
+	alias.UpdatedAt = now
+	_, err = s.db.Exec(`
+		INSERT INTO model_aliases (slug, provider, model, credential_name, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, alias.Slug, alias.Provider, alias.Model, alias.CredentialName, alias.CreatedAt, alias.UpdatedAt)
+	return err
+}
+
+// GetAlias retrieves a model alias by slug.
+func (s *Storage) GetAlias(slug string) (*models.ModelAlias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	var alias models.ModelAlias
+	err := s.db.QueryRow(`
+		SELECT slug, provider, model, credential_name, created_at, updated_at
+		FROM model_aliases WHERE slug = ?
+	`, slug).Scan(&alias.Slug, &alias.Provider, &alias.Model, &alias.CredentialName, &alias.CreatedAt, &alias.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// ListAliases retrieves all model aliases ordered by slug.
+func (s *Storage) ListAliases() ([]*models.ModelAlias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT slug, provider, model, credential_name, created_at, updated_at
+		FROM model_aliases ORDER BY slug
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []*models.ModelAlias
+	for rows.Next() {
+		var alias models.ModelAlias
+		if err := rows.Scan(&alias.Slug, &alias.Provider, &alias.Model, &alias.CredentialName, &alias.CreatedAt, &alias.UpdatedAt); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, &alias)
+	}
+	return aliases, rows.Err()
+}
+
+// UpdateAlias replaces the route of the alias with the same slug.
+func (s *Storage) UpdateAlias(alias *models.ModelAlias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+	if !alias.Valid() {
+		return ErrInvalidInput
+	}
+
+	updatedAt := time.Now().UTC()
+	result, err := s.db.Exec(`
+		UPDATE model_aliases SET provider = ?, model = ?, credential_name = ?, updated_at = ?
+		WHERE slug = ?
+	`, alias.Provider, alias.Model, alias.CredentialName, updatedAt, alias.Slug)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNotFound
+	}
+	alias.UpdatedAt = updatedAt
+	return nil
+}
+
+// DeleteAlias removes a model alias by slug.
+func (s *Storage) DeleteAlias(slug string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+
+	result, err := s.db.Exec("DELETE FROM model_aliases WHERE slug = ?", slug)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -21,11 +21,11 @@ func (s *Storage) GetAPIKey(id string) (*models.ClientAPIKey, error) {
 	var lastUsedAt, expiresAt sql.NullTime
 
 	err := s.db.QueryRow(`
-		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, is_active, last_used_at, created_at, expires_at
+		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, COALESCE(user_limit, 0), COALESCE(tenant, ''), is_active, last_used_at, created_at, expires_at
 		FROM api_keys WHERE id = ?
 	`, id).Scan(
 		&key.ID, &key.Name, &key.KeyHash, &key.KeyPrefix, &scopesJSON,
-		&key.RateLimit, &key.IsActive, &lastUsedAt, &key.CreatedAt, &expiresAt,
+		&key.RateLimit, &key.UserLimit, &key.Tenant, &key.IsActive, &lastUsedAt, &key.CreatedAt, &expiresAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -59,7 +59,7 @@ func (s *Storage) GetAPIKeyByPrefix(prefix string) ([]*models.ClientAPIKey, erro
 	}
 
 	rows, err := s.db.Query(`
-		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, is_active, last_used_at, created_at, expires_at
+		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, COALESCE(user_limit, 0), COALESCE(tenant, ''), is_active, last_used_at, created_at, expires_at
 		FROM api_keys WHERE key_prefix = ?
 	`, prefix)
 	if err != nil {
@@ -80,7 +80,7 @@ func (s *Storage) ListAPIKeys() ([]*models.ClientAPIKey, error) {
 	}
 
 	rows, err := s.db.Query(`
-		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, is_active, last_used_at, created_at, expires_at
+		SELECT id, name, key_hash, key_prefix, scopes, rate_limit, COALESCE(user_limit, 0), COALESCE(tenant, ''), is_active, last_used_at, created_at, expires_at
 		FROM api_keys ORDER BY created_at DESC
 	`)
 	if err != nil {
@@ -102,7 +102,7 @@ func scanAPIKeys(rows *sql.Rows) ([]*models.ClientAPIKey, error) {
 
 		err := rows.Scan(
 			&key.ID, &key.Name, &key.KeyHash, &key.KeyPrefix, &scopesJSON,
-			&key.RateLimit, &key.IsActive, &lastUsedAt, &key.CreatedAt, &expiresAt,
+			&key.RateLimit, &key.UserLimit, &key.Tenant, &key.IsActive, &lastUsedAt, &key.CreatedAt, &expiresAt,
 		)
 		if err != nil {
 			return nil, err
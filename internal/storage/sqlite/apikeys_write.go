@@ -28,10 +28,10 @@ func (s *Storage) CreateAPIKey(key *models.ClientAPIKey) error {
 	key.CreatedAt = time.Now()
 
 	_, err = s.db.Exec(`
-		INSERT INTO api_keys (id, name, key_hash, key_prefix, scopes, rate_limit, is_active, expires_at, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO api_keys (id, name, key_hash, key_prefix, scopes, rate_limit, user_limit, tenant, is_active, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, key.ID, key.Name, key.KeyHash, key.KeyPrefix, string(scopesJSON),
-		key.RateLimit, key.IsActive, key.ExpiresAt, key.CreatedAt)
+		key.RateLimit, key.UserLimit, nullString(key.Tenant), key.IsActive, key.ExpiresAt, key.CreatedAt)
 
 	return err
 }
@@ -52,10 +52,10 @@ func (s *Storage) UpdateAPIKey(key *models.ClientAPIKey) error {
 
 	result, err := s.db.Exec(`
 		UPDATE api_keys
-		SET name = ?, key_hash = ?, key_prefix = ?, scopes = ?, rate_limit = ?, is_active = ?, expires_at = ?
+		SET name = ?, key_hash = ?, key_prefix = ?, scopes = ?, rate_limit = ?, user_limit = ?, tenant = ?, is_active = ?, expires_at = ?
 		WHERE id = ?
 	`, key.Name, key.KeyHash, key.KeyPrefix, string(scopesJSON),
-		key.RateLimit, key.IsActive, key.ExpiresAt, key.ID)
+		key.RateLimit, key.UserLimit, nullString(key.Tenant), key.IsActive, key.ExpiresAt, key.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// gzipMagic opens every gzip stream; JSON bodies never start with it, so
+// compressed and plain rows can be told apart on read.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// encodeBody returns the stored form of a request body (nil when empty),
+// gzipped when compress is set. Rows are decoded by content, so existing rows
+// stay readable whichever way the setting changes.
+func encodeBody(body string, compress bool) ([]byte, error) {
+	if body == "" {
+		return nil, nil
+	}
+	if !compress {
+		return []byte(body), nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeBody reverses encodeBody, decompressing gzipped rows.
+func decodeBody(stored []byte) (string, error) {
+	if !bytes.HasPrefix(stored, gzipMagic) {
+		return string(stored), nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(stored))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestLogRequest_RequestBodyRoundTrip(t *testing.T) {
+	body := `{"model":"gpt-4o","messages":[{"role":"user","content":"` + strings.Repeat("hello ", 500) + `"}]}`
+
+	tests := []struct {
+		name     string
+		compress bool
+		body     string
+		wantGzip bool
+	}{
+		{"compressed", true, body, true},
+		{"plain", false, body, false},
+		{"empty body", true, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.db")
+			s, err := New(path, Options{CompressBodies: tt.compress})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if err := s.LogRequest(&models.RequestLog{ID: "log-1", RequestID: "r", Model: "m", Provider: "p", RequestBody: tt.body}); err != nil {
+				t.Fatalf("LogRequest: %v", err)
+			}
+
+			var stored []byte
+			if err := s.db.QueryRow("SELECT request_body FROM request_logs WHERE id = 'log-1'").Scan(&stored); err != nil {
+				t.Fatalf("read stored body: %v", err)
+			}
+			if got := bytes.HasPrefix(stored, gzipMagic); got != tt.wantGzip {
+				t.Errorf("stored body gzipped = %v, want %v", got, tt.wantGzip)
+			}
+			if tt.wantGzip && len(stored) >= len(tt.body) {
+				t.Errorf("compressed body is %d bytes, raw %d", len(stored), len(tt.body))
+			}
+
+			// Reads decode by content, so toggling the setting keeps old rows readable
+			_ = s.Close()
+			s, err = New(path, Options{CompressBodies: !tt.compress})
+			if err != nil {
+				t.Fatalf("reopen: %v", err)
+			}
+			t.Cleanup(func() { _ = s.Close() })
+			logs, err := s.GetRequestLogs(models.LogFilter{})
+			if err != nil || len(logs) != 1 {
+				t.Fatalf("GetRequestLogs = %d logs, %v; want 1", len(logs), err)
+			}
+			if logs[0].RequestBody != tt.body {
+				t.Errorf("request body = %.40q..., want the original", logs[0].RequestBody)
+			}
+		})
+	}
+}
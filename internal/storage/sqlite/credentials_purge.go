@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// PurgeCredential deletes a credential together with its request logs and daily usage.
+// The foreign keys only SET NULL, so history rows are deleted explicitly in one transaction.
+func (s *Storage) PurgeCredential(id string) (*models.CredentialPurge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	purge := &models.CredentialPurge{}
+	err := s.inTx(func(tx *sql.Tx) error {
+		result, err := tx.Exec("DELETE FROM credentials WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return ErrNotFound
+		}
+
+		if result, err = tx.Exec("DELETE FROM request_logs WHERE credential_id = ?", id); err != nil {
+			return err
+		}
+		purge.RequestLogs, _ = result.RowsAffected()
+
+		if result, err = tx.Exec("DELETE FROM usage_daily WHERE credential_id = ?", id); err != nil {
+			return err
+		}
+		purge.DailyUsage, _ = result.RowsAffected()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purge, nil
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// newTestStorage opens a fresh database in a temp directory.
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+	s, err := New(filepath.Join(t.TempDir(), "test.db"), Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestPurgeCredential(t *testing.T) {
+	s := newTestStorage(t)
+
+	for _, name := range []string{"purged", "kept"} {
+		cred := &models.Credential{ID: name, Provider: "openrouter", Name: name, Data: json.RawMessage(`{"api_key":"k"}`)}
+		if err := s.CreateCredential(cred); err != nil {
+			t.Fatalf("CreateCredential: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			if err := s.LogRequest(&models.RequestLog{RequestID: "r", CredentialID: name, Model: "m", Provider: "openrouter"}); err != nil {
+				t.Fatalf("LogRequest: %v", err)
+			}
+		}
+		for _, date := range []string{"2026-01-01", "2026-01-02"} {
+			if err := s.UpdateDailyUsage(&models.DailyUsage{Date: date, CredentialID: name, Model: "m", RequestCount: 1}); err != nil {
+				t.Fatalf("UpdateDailyUsage: %v", err)
+			}
+		}
+	}
+
+	purge, err := s.PurgeCredential("purged")
+	if err != nil {
+		t.Fatalf("PurgeCredential: %v", err)
+	}
+	if purge.RequestLogs != 3 || purge.DailyUsage != 2 {
+		t.Errorf("purge = %+v, want 3 logs and 2 usage rows", purge)
+	}
+
+	if _, err := s.GetCredential("purged"); err != ErrNotFound {
+		t.Errorf("credential still present: %v", err)
+	}
+	if logs, _ := s.GetRequestLogs(models.LogFilter{CredentialID: "kept", Limit: 10}); len(logs) != 3 {
+		t.Errorf("other credential's logs affected: %d remain", len(logs))
+	}
+	if logs, _ := s.GetRequestLogs(models.LogFilter{CredentialID: "purged", Limit: 10}); len(logs) != 0 {
+		t.Errorf("purged logs remain: %d", len(logs))
+	}
+
+	if _, err := s.PurgeCredential("missing"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
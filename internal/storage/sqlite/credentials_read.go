@@ -21,9 +21,9 @@ func (s *Storage) GetCredential(id string) (*models.Credential, error) {
 	var encryptedData string
 
 	err := s.db.QueryRow(`
-		SELECT id, provider, name, data, created_at, updated_at
+		SELECT id, provider, name, data, log_requests, version, created_at, updated_at
 		FROM credentials WHERE id = ?
-	`, id).Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.CreatedAt, &cred.UpdatedAt)
+	`, id).Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.LogRequests, &cred.Version, &cred.CreatedAt, &cred.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
@@ -56,9 +56,9 @@ func (s *Storage) GetCredentialByName(name string) (*models.Credential, error) {
 	var encryptedData string
 
 	err := s.db.QueryRow(`
-		SELECT id, provider, name, data, created_at, updated_at
+		SELECT id, provider, name, data, log_requests, version, created_at, updated_at
 		FROM credentials WHERE name = ?
-	`, name).Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.CreatedAt, &cred.UpdatedAt)
+	`, name).Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.LogRequests, &cred.Version, &cred.CreatedAt, &cred.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
@@ -88,7 +88,7 @@ func (s *Storage) ListCredentials() ([]*models.Credential, error) {
 	}
 
 	rows, err := s.db.Query(`
-		SELECT id, provider, name, data, created_at, updated_at
+		SELECT id, provider, name, data, log_requests, version, created_at, updated_at
 		FROM credentials ORDER BY created_at DESC
 	`)
 	if err != nil {
@@ -101,7 +101,7 @@ func (s *Storage) ListCredentials() ([]*models.Credential, error) {
 		var cred models.Credential
 		var encryptedData string
 
-		err := rows.Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.CreatedAt, &cred.UpdatedAt)
+		err := rows.Scan(&cred.ID, &cred.Provider, &cred.Name, &encryptedData, &cred.LogRequests, &cred.Version, &cred.CreatedAt, &cred.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -34,16 +35,19 @@ func (s *Storage) CreateCredential(cred *models.Credential) error {
 	now := time.Now().UTC()
 	cred.CreatedAt = now
 	cred.UpdatedAt = now
+	cred.Version = 1
 
 	_, err = s.db.Exec(`
-		INSERT INTO credentials (id, provider, name, data, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, cred.ID, cred.Provider, cred.Name, encryptedData, cred.CreatedAt, cred.UpdatedAt)
+		INSERT INTO credentials (id, provider, name, data, log_requests, version, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, cred.ID, cred.Provider, cred.Name, encryptedData, cred.LogRequests, cred.Version, cred.CreatedAt, cred.UpdatedAt)
 
 	return err
 }
 
-// UpdateCredential updates an existing credential.
+// UpdateCredential updates an existing credential. When cred.Version is
+// non-zero the row is only written if its stored version still matches,
+// otherwise ErrConflict is returned. On success cred.Version is bumped.
 func (s *Storage) UpdateCredential(cred *models.Credential) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -62,24 +66,49 @@ func (s *Storage) UpdateCredential(cred *models.Credential) error {
 		return fmt.Errorf("%w: %v", ErrEncryptionError, err)
 	}
 
-	cred.UpdatedAt = time.Now().UTC()
-
-	result, err := s.db.Exec(`
-		UPDATE credentials
-		SET provider = ?, name = ?, data = ?, updated_at = ?
-		WHERE id = ?
-	`, cred.Provider, cred.Name, encryptedData, cred.UpdatedAt, cred.ID)
-
+	updatedAt := time.Now().UTC()
+
+	// The new version is read back in the same transaction, so a failed read
+	// never leaves the row updated while the caller keeps the old version.
+	var version int
+	err = s.inTx(func(tx *sql.Tx) error {
+		// A zero cred.Version skips the version check.
+		result, err := tx.Exec(`
+			UPDATE credentials
+			SET provider = ?, name = ?, data = ?, log_requests = ?, version = version + 1, updated_at = ?
+			WHERE id = ? AND (? = 0 OR version = ?)
+		`, cred.Provider, cred.Name, encryptedData, cred.LogRequests, updatedAt, cred.ID, cred.Version, cred.Version)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return updateMissReason(tx, cred.ID)
+		}
+		return tx.QueryRow("SELECT version FROM credentials WHERE id = ?", cred.ID).Scan(&version)
+	})
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
+	cred.UpdatedAt = updatedAt
+	cred.Version = version
+	return nil
+}
+
+// updateMissReason explains an UPDATE that matched no rows: the credential
+// is either gone or was changed since the caller read it.
+func updateMissReason(tx *sql.Tx, id string) error {
+	var exists int
+	err := tx.QueryRow("SELECT 1 FROM credentials WHERE id = ?", id).Scan(&exists)
+	if err == sql.ErrNoRows {
 		return ErrNotFound
 	}
-
-	return nil
+	if err != nil {
+		return err
+	}
+	return ErrConflict
 }
 
 // DeleteCredential removes a credential by ID.
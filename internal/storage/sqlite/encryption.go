@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+const (
+	encryptionCanaryKey = "encryption_canary"
+	canaryPlaintext     = "goatway-encryption-canary"
+)
+
+// ensureCanary stores an encrypted canary value on first open so a later key
+// change is detectable even with no credentials stored. It is not written
+// when an existing credential already fails to decrypt, since a canary under
+// the wrong key would hide the mismatch.
+func (s *Storage) ensureCanary() error {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM admin_settings WHERE key = ?", encryptionCanaryKey).Scan(&value)
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	var data string
+	err = s.db.QueryRow("SELECT data FROM credentials LIMIT 1").Scan(&data)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil {
+		if _, decErr := s.encryptor.Decrypt(data); decErr != nil {
+			return nil
+		}
+	}
+
+	canary, err := s.encryptor.Encrypt(canaryPlaintext)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO admin_settings (key, value, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+	`, encryptionCanaryKey, canary)
+	return err
+}
+
+// CheckEncryption decrypts the canary and every stored credential with the
+// current key and reports how many could not be read.
+func (s *Storage) CheckEncryption() (*models.EncryptionHealth, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	health := &models.EncryptionHealth{Encrypted: true, KeySource: s.encryptor.Source()}
+
+	var canary string
+	err := s.db.QueryRow("SELECT value FROM admin_settings WHERE key = ?", encryptionCanaryKey).Scan(&canary)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if err == nil {
+		plain, decErr := s.encryptor.Decrypt(canary)
+		health.CanaryOK = decErr == nil && plain == canaryPlaintext
+	}
+
+	rows, err := s.db.Query("SELECT data FROM credentials")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		health.Credentials++
+		if _, err := s.encryptor.Decrypt(data); err != nil {
+			health.Undecryptable++
+		}
+	}
+	return health, rows.Err()
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/encryption"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestCheckEncryption_KeyMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	t.Setenv("GOATWAY_ENCRYPTION_KEY", "original-key")
+	s, err := New(path, Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	cred := &models.Credential{ID: "c", Provider: "openrouter", Name: "c", Data: json.RawMessage(`{"api_key":"k"}`)}
+	if err := s.CreateCredential(cred); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	_ = s.Close()
+
+	tests := []struct {
+		name          string
+		key           string
+		wantHealthy   bool
+		wantCanaryOK  bool
+		wantUndecrypt int
+	}{
+		{name: "same key", key: "original-key", wantHealthy: true, wantCanaryOK: true},
+		{name: "different key", key: "moved-machine-key", wantUndecrypt: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOATWAY_ENCRYPTION_KEY", tt.key)
+			s, err := New(path, Options{})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			defer s.Close()
+
+			health, err := s.CheckEncryption()
+			if err != nil {
+				t.Fatalf("CheckEncryption: %v", err)
+			}
+			if health.Healthy() != tt.wantHealthy || health.CanaryOK != tt.wantCanaryOK || health.Undecryptable != tt.wantUndecrypt {
+				t.Errorf("CheckEncryption = %+v, want healthy=%v canary_ok=%v undecryptable=%d",
+					health, tt.wantHealthy, tt.wantCanaryOK, tt.wantUndecrypt)
+			}
+			if health.KeySource != encryption.KeySourcePassphrase {
+				t.Errorf("KeySource = %q, want %q", health.KeySource, encryption.KeySourcePassphrase)
+			}
+		})
+	}
+}
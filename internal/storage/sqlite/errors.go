@@ -1,12 +1,31 @@
 package sqlite
 
-import "errors"
+import (
+	"errors"
 
-// Common errors returned by storage operations
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Common errors returned by storage operations, shared with other backends
 var (
-	ErrNotFound        = errors.New("record not found")
-	ErrDuplicateKey    = errors.New("duplicate key")
-	ErrInvalidInput    = errors.New("invalid input")
-	ErrStorageClosed   = errors.New("storage is closed")
-	ErrEncryptionError = errors.New("encryption error")
+	ErrNotFound        = models.ErrNotFound
+	ErrDuplicateKey    = models.ErrDuplicateKey
+	ErrInvalidInput    = models.ErrInvalidInput
+	ErrStorageClosed   = models.ErrStorageClosed
+	ErrEncryptionError = models.ErrEncryptionError
+	ErrConflict        = models.ErrConflict
 )
+
+// IsBusy reports whether err is SQLite lock contention (SQLITE_BUSY or
+// SQLITE_LOCKED, including their extended codes): the write did not happen
+// and may succeed if tried again.
+func IsBusy(err error) bool {
+	var se *sqlite.Error
+	if !errors.As(err, &se) {
+		return false
+	}
+	code := se.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
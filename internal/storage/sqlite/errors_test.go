@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsBusy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "busy.db")
+	open := func() *sql.DB {
+		db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(0)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	holder, writer := open(), open()
+	if _, err := holder.Exec(`CREATE TABLE t (x INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := holder.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(`INSERT INTO t VALUES (1)`); err != nil {
+		t.Fatal(err)
+	}
+
+	_, busy := writer.Exec(`INSERT INTO t VALUES (2)`)
+	if busy == nil || !IsBusy(busy) {
+		t.Errorf("IsBusy(%v) = false, want true for a locked database", busy)
+	}
+	_, missing := writer.Exec(`INSERT INTO missing VALUES (1)`)
+	if IsBusy(missing) || IsBusy(errors.New("database is locked")) || IsBusy(nil) {
+		t.Error("IsBusy matched an error that is not lock contention")
+	}
+}
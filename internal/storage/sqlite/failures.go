@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// RecordFailure stores an upstream failure record
+func (s *Storage) RecordFailure(f *models.FailedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+
+	if f.ID == "" {
+		f.ID = generateID("fail")
+	}
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO failed_requests (id, request_id, model, provider, credential_id, api_key_id,
+			status_code, error_type, error_message, attempts, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, f.ID, f.RequestID, f.Model, f.Provider, nullString(f.CredentialID), nullString(f.APIKeyID),
+		f.StatusCode, f.ErrorType, f.ErrorMessage, f.Attempts, f.CreatedAt)
+
+	return err
+}
+
+// ListFailures retrieves failure records with filtering, newest first
+func (s *Storage) ListFailures(filter models.FailureFilter) ([]*models.FailedRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	query := `SELECT id, request_id, model, provider, COALESCE(credential_id, ''), COALESCE(api_key_id, ''),
+		COALESCE(status_code, 0), error_type, COALESCE(error_message, ''), attempts, created_at
+		FROM failed_requests WHERE 1=1`
+
+	var args []interface{}
+
+	if filter.RequestID != "" {
+		query += " AND request_id = ?"
+		args = append(args, filter.RequestID)
+	}
+	if filter.Model != "" {
+		query += " AND model = ?"
+		args = append(args, filter.Model)
+	}
+	if filter.Provider != "" {
+		query += " AND provider = ?"
+		args = append(args, filter.Provider)
+	}
+	if filter.ErrorType != "" {
+		query += " AND error_type = ?"
+		args = append(args, filter.ErrorType)
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		if filter.Limit <= 0 {
+			query += " LIMIT -1" // SQLite only accepts OFFSET after a LIMIT
+		}
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var failures []*models.FailedRequest
+	for rows.Next() {
+		var f models.FailedRequest
+		err := rows.Scan(&f.ID, &f.RequestID, &f.Model, &f.Provider, &f.CredentialID, &f.APIKeyID,
+			&f.StatusCode, &f.ErrorType, &f.ErrorMessage, &f.Attempts, &f.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, &f)
+	}
+
+	return failures, rows.Err()
+}
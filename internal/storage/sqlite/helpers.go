@@ -16,3 +16,12 @@ func nullString(s string) interface{} {
 	}
 	return s
 }
+
+// nullInt64 returns nil for zero, otherwise the value itself
+// Used for optional measurements where 0 means "not recorded"
+func nullInt64(n int64) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
@@ -23,14 +23,20 @@ func (s *Storage) LogRequest(log *models.RequestLog) error {
 		log.CreatedAt = time.Now().UTC()
 	}
 
-	_, err := s.db.Exec(`
-		INSERT INTO request_logs (id, request_id, credential_id, model, provider,
+	body, err := encodeBody(log.RequestBody, s.opts.CompressBodies)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO request_logs (id, request_id, credential_id, api_key_id, end_user, model, provider,
 			prompt_tokens, completion_tokens, total_tokens, is_streaming,
-			status_code, error_message, duration_ms, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, log.ID, log.RequestID, nullString(log.CredentialID), log.Model, log.Provider,
+			status_code, error_message, error_type, duration_ms, ttfb_ms, is_shadow, tool_calls, anomaly, request_body, attempts, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, log.ID, log.RequestID, nullString(log.CredentialID), nullString(log.APIKeyID), nullString(log.User), log.Model, log.Provider,
 		log.PromptTokens, log.CompletionTokens, log.TotalTokens, boolToInt(log.IsStreaming),
-		log.StatusCode, log.ErrorMessage, log.DurationMs, log.CreatedAt)
+		log.StatusCode, log.ErrorMessage, nullString(log.ErrorType), log.DurationMs, nullInt64(log.TTFBMs), boolToInt(log.IsShadow), log.ToolCalls,
+		nullString(log.Anomaly), body, log.Attempts, log.CreatedAt)
 
 	return err
 }
@@ -44,47 +50,15 @@ func (s *Storage) GetRequestLogs(filter models.LogFilter) ([]*models.RequestLog,
 		return nil, ErrStorageClosed
 	}
 
-	query := `SELECT id, request_id, COALESCE(credential_id, ''), model, provider,
+	query := `SELECT id, request_id, COALESCE(credential_id, ''), COALESCE(api_key_id, ''),
+		COALESCE(end_user, ''), model, provider,
 		prompt_tokens, completion_tokens, total_tokens, is_streaming,
-		status_code, COALESCE(error_message, ''), duration_ms, created_at
+		status_code, COALESCE(error_message, ''), COALESCE(error_type, ''), duration_ms,
+		COALESCE(ttfb_ms, 0), COALESCE(is_shadow, 0), COALESCE(tool_calls, 0), COALESCE(anomaly, ''), request_body,
+		COALESCE(attempts, 0), created_at
 		FROM request_logs WHERE 1=1`
 
-	var args []interface{}
-
-	if filter.CredentialID != "" {
-		query += " AND credential_id = ?"
-		args = append(args, filter.CredentialID)
-	}
-	if filter.Model != "" {
-		query += " AND model = ?"
-		args = append(args, filter.Model)
-	}
-	if filter.Provider != "" {
-		query += " AND provider = ?"
-		args = append(args, filter.Provider)
-	}
-	if filter.StatusCode != nil {
-		query += " AND status_code = ?"
-		args = append(args, *filter.StatusCode)
-	}
-	if filter.StartDate != nil {
-		query += " AND created_at >= ?"
-		args = append(args, *filter.StartDate)
-	}
-	if filter.EndDate != nil {
-		query += " AND created_at <= ?"
-		args = append(args, *filter.EndDate)
-	}
-
-	query += " ORDER BY created_at DESC"
-
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
-	}
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
-	}
-
+	query, args := appendLogFilter(query, filter)
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -94,23 +68,30 @@ func (s *Storage) GetRequestLogs(filter models.LogFilter) ([]*models.RequestLog,
 	var logs []*models.RequestLog
 	for rows.Next() {
 		var log models.RequestLog
-		var isStreaming int
+		var isStreaming, isShadow int
+		var body []byte
 
-		err := rows.Scan(&log.ID, &log.RequestID, &log.CredentialID, &log.Model, &log.Provider,
+		err := rows.Scan(&log.ID, &log.RequestID, &log.CredentialID, &log.APIKeyID, &log.User, &log.Model, &log.Provider,
 			&log.PromptTokens, &log.CompletionTokens, &log.TotalTokens, &isStreaming,
-			&log.StatusCode, &log.ErrorMessage, &log.DurationMs, &log.CreatedAt)
+			&log.StatusCode, &log.ErrorMessage, &log.ErrorType, &log.DurationMs,
+			&log.TTFBMs, &isShadow, &log.ToolCalls, &log.Anomaly, &body, &log.Attempts, &log.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
+		if log.RequestBody, err = decodeBody(body); err != nil {
+			return nil, fmt.Errorf("failed to decode request body of log %s: %w", log.ID, err)
+		}
 
 		log.IsStreaming = isStreaming == 1
+		log.IsShadow = isShadow == 1
 		logs = append(logs, &log)
 	}
 
 	return logs, rows.Err()
 }
 
-// DeleteRequestLogs removes logs older than the specified date
+// DeleteRequestLogs removes logs older than the specified date (YYYY-MM-DD).
+// The date is read with substr because DATE() cannot parse the driver's timestamp format.
 func (s *Storage) DeleteRequestLogs(olderThan string) (int64, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -119,7 +100,7 @@ func (s *Storage) DeleteRequestLogs(olderThan string) (int64, error) {
 		return 0, ErrStorageClosed
 	}
 
-	result, err := s.db.Exec("DELETE FROM request_logs WHERE DATE(created_at) < ?", olderThan)
+	result, err := s.db.Exec("DELETE FROM request_logs WHERE substr(created_at, 1, 10) < ?", olderThan)
 	if err != nil {
 		return 0, err
 	}
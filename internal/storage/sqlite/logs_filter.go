@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// appendLogFilter appends filter's conditions, newest-first ordering and
+// pagination to a request_logs query ending in a WHERE clause.
+func appendLogFilter(query string, filter models.LogFilter) (string, []interface{}) {
+	var args []interface{}
+
+	if filter.RequestID != "" {
+		query += " AND request_id = ?"
+		args = append(args, filter.RequestID)
+	}
+	if filter.CredentialID != "" {
+		query += " AND credential_id = ?"
+		args = append(args, filter.CredentialID)
+	}
+	if filter.APIKeyID != "" {
+		query += " AND api_key_id = ?"
+		args = append(args, filter.APIKeyID)
+	}
+	if filter.User != "" {
+		query += " AND end_user = ?"
+		args = append(args, filter.User)
+	}
+	if filter.Model != "" {
+		query += " AND model = ?"
+		args = append(args, filter.Model)
+	}
+	if filter.Provider != "" {
+		query += " AND provider = ?"
+		args = append(args, filter.Provider)
+	}
+	if filter.StatusCode != nil {
+		query += " AND status_code = ?"
+		args = append(args, *filter.StatusCode)
+	}
+	if filter.Anomalous {
+		query += " AND anomaly IS NOT NULL AND anomaly != ''"
+	}
+	if filter.StartDate != nil {
+		query += " AND created_at >= ?"
+		args = append(args, *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query += " AND created_at <= ?"
+		args = append(args, *filter.EndDate)
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		if filter.Limit <= 0 {
+			query += " LIMIT -1" // SQLite only accepts OFFSET after a LIMIT
+		}
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	return query, args
+}
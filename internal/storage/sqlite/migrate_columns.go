@@ -0,0 +1,56 @@
+package sqlite
+
+// columnMigrations adds columns introduced after the initial schema.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so new columns
+// must be listed here as well as in createSchema.
+var columnMigrations = []struct {
+	table, column, definition string
+}{
+	{"request_logs", "error_type", "TEXT"},
+	{"request_logs", "is_shadow", "INTEGER DEFAULT 0"},
+	{"usage_daily", "audio_characters", "INTEGER DEFAULT 0"},
+	{"usage_daily", "image_count", "INTEGER DEFAULT 0"},
+	{"request_logs", "api_key_id", "TEXT"},
+	{"request_logs", "end_user", "TEXT"},
+	{"api_keys", "user_limit", "INTEGER DEFAULT 0"},
+	{"request_logs", "ttfb_ms", "INTEGER"},
+	{"credentials", "log_requests", "INTEGER NOT NULL DEFAULT 1"},
+	{"credentials", "version", "INTEGER NOT NULL DEFAULT 1"},
+	{"request_logs", "tool_calls", "INTEGER DEFAULT 0"},
+	{"usage_daily", "tool_call_requests", "INTEGER DEFAULT 0"},
+	{"usage_daily", "tool_calls", "INTEGER DEFAULT 0"},
+	{"request_logs", "anomaly", "TEXT"},
+	{"request_logs", "request_body", "BLOB"},
+	{"request_logs", "attempts", "INTEGER DEFAULT 0"},
+	{"api_keys", "tenant", "TEXT"},
+}
+
+// migrate applies column migrations to databases created by older versions,
+// then converts legacy plaintext credentials.
+func (s *Storage) migrate() error {
+	for _, m := range columnMigrations {
+		if err := s.addColumnIfMissing(m.table, m.column, m.definition); err != nil {
+			return err
+		}
+	}
+	return s.migrateLegacyCredentials()
+}
+
+// addColumnIfMissing adds a column to a table unless it already exists.
+func (s *Storage) addColumnIfMissing(table, column, definition string) error {
+	exists, err := s.columnExists(table, column)
+	if err != nil || exists {
+		return err
+	}
+	_, err = s.db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	return err
+}
+
+// columnExists reports whether a table has the named column.
+func (s *Storage) columnExists(table, column string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column,
+	).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// migrateLegacyCredentials converts credentials tables created before the
+// encrypted data column, which kept each provider key in a plaintext api_key
+// column. Every legacy key is wrapped as {"api_key": ...}, encrypted into
+// data, and the api_key column is dropped so no plaintext copy is left behind
+// (and inserts that omit it keep working). It runs once: afterwards the
+// column no longer exists.
+func (s *Storage) migrateLegacyCredentials() error {
+	legacy, err := s.columnExists("credentials", "api_key")
+	if err != nil || !legacy {
+		return err
+	}
+	if err := s.addColumnIfMissing("credentials", "data", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	return s.inTx(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id, api_key FROM credentials
+			WHERE COALESCE(data, '') = '' AND COALESCE(api_key, '') != ''`)
+		if err != nil {
+			return err
+		}
+		keys := make(map[string]string)
+		for rows.Next() {
+			var id, key string
+			if err := rows.Scan(&id, &key); err != nil {
+				rows.Close()
+				return err
+			}
+			keys[id] = key
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for id, key := range keys {
+			data, err := json.Marshal(models.APIKeyCredential{APIKey: key})
+			if err != nil {
+				return err
+			}
+			encrypted, err := s.encryptor.Encrypt(string(data))
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrEncryptionError, err)
+			}
+			if _, err := tx.Exec("UPDATE credentials SET data = ? WHERE id = ?", encrypted, id); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec("ALTER TABLE credentials DROP COLUMN api_key"); err != nil {
+			return fmt.Errorf("drop legacy api_key column: %w", err)
+		}
+		return nil
+	})
+}
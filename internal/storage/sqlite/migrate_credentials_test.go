@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestMigrateLegacyCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	// A credentials table as created before the encrypted data column
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	_, err = legacy.Exec(`
+		CREATE TABLE credentials (
+			id         TEXT PRIMARY KEY,
+			provider   TEXT NOT NULL,
+			name       TEXT NOT NULL UNIQUE,
+			api_key    TEXT NOT NULL,
+			is_default INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO credentials (id, provider, name, api_key) VALUES ('cred_old', 'openrouter', 'old', 'sk-or-legacy');
+	`)
+	_ = legacy.Close()
+	if err != nil {
+		t.Fatalf("seed legacy db: %v", err)
+	}
+
+	s, err := New(path, Options{})
+	if err != nil {
+		t.Fatalf("New on legacy db: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	cred, err := s.GetCredential("cred_old")
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+	var data models.APIKeyCredential
+	if err := json.Unmarshal(cred.Data, &data); err != nil || data.APIKey != "sk-or-legacy" {
+		t.Errorf("data = %s (%v), want the legacy key wrapped as api_key", cred.Data, err)
+	}
+	if cred.Version != 1 || !cred.LogRequests {
+		t.Errorf("version = %d, log_requests = %v; want column defaults", cred.Version, cred.LogRequests)
+	}
+
+	var stored string
+	if err := s.db.QueryRow("SELECT data FROM credentials WHERE id = 'cred_old'").Scan(&stored); err != nil {
+		t.Fatalf("read stored data: %v", err)
+	}
+	if strings.Contains(stored, "sk-or-legacy") {
+		t.Error("legacy key stored in plaintext")
+	}
+	if exists, err := s.columnExists("credentials", "api_key"); err != nil || exists {
+		t.Errorf("api_key column exists = %v (%v), want dropped", exists, err)
+	}
+
+	// New credentials insert without the legacy column
+	fresh := &models.Credential{Provider: "openrouter", Name: "new", Data: json.RawMessage(`{"api_key":"k"}`)}
+	if err := s.CreateCredential(fresh); err != nil {
+		t.Errorf("CreateCredential after migration: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package sqlite
+
+// createSchema creates the database schema
+func (s *Storage) createSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS credentials (
+		id          TEXT PRIMARY KEY,
+		provider    TEXT NOT NULL,
+		name        TEXT NOT NULL UNIQUE,
+		data         TEXT NOT NULL,
+		log_requests INTEGER NOT NULL DEFAULT 1,
+		version      INTEGER NOT NULL DEFAULT 1,
+		created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS request_logs (
+		id                TEXT PRIMARY KEY,
+		request_id        TEXT NOT NULL,
+		credential_id     TEXT,
+		api_key_id        TEXT,
+		end_user          TEXT,
+		model             TEXT NOT NULL,
+		provider          TEXT NOT NULL,
+		prompt_tokens     INTEGER DEFAULT 0,
+		completion_tokens INTEGER DEFAULT 0,
+		total_tokens      INTEGER DEFAULT 0,
+		is_streaming      INTEGER DEFAULT 0,
+		status_code       INTEGER,
+		error_message     TEXT,
+		error_type        TEXT,
+		duration_ms       INTEGER,
+		ttfb_ms           INTEGER,
+		is_shadow         INTEGER DEFAULT 0,
+		tool_calls        INTEGER DEFAULT 0,
+		anomaly           TEXT,
+		request_body      BLOB,
+		attempts          INTEGER DEFAULT 0,
+		created_at        DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (credential_id) REFERENCES credentials(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS failed_requests (
+		id            TEXT PRIMARY KEY,
+		request_id    TEXT NOT NULL,
+		model         TEXT NOT NULL,
+		provider      TEXT NOT NULL,
+		credential_id TEXT,
+		api_key_id    TEXT,
+		status_code   INTEGER,
+		error_type    TEXT NOT NULL,
+		error_message TEXT,
+		attempts      INTEGER DEFAULT 1,
+		created_at    DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS usage_daily (
+		date              TEXT NOT NULL,
+		credential_id     TEXT,
+		model             TEXT NOT NULL,
+		request_count     INTEGER DEFAULT 0,
+		prompt_tokens     INTEGER DEFAULT 0,
+		completion_tokens INTEGER DEFAULT 0,
+		total_tokens      INTEGER DEFAULT 0,
+		error_count       INTEGER DEFAULT 0,
+		audio_characters  INTEGER DEFAULT 0,
+		image_count       INTEGER DEFAULT 0,
+		tool_call_requests INTEGER DEFAULT 0,
+		tool_calls        INTEGER DEFAULT 0,
+		PRIMARY KEY (date, credential_id, model),
+		FOREIGN KEY (credential_id) REFERENCES credentials(id) ON DELETE SET NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_logs_created ON request_logs(created_at);
+	CREATE INDEX IF NOT EXISTS idx_logs_model ON request_logs(model);
+	CREATE INDEX IF NOT EXISTS idx_logs_credential ON request_logs(credential_id);
+	CREATE INDEX IF NOT EXISTS idx_logs_request ON request_logs(request_id);
+	CREATE INDEX IF NOT EXISTS idx_failures_created ON failed_requests(created_at);
+	CREATE INDEX IF NOT EXISTS idx_failures_request ON failed_requests(request_id);
+	CREATE INDEX IF NOT EXISTS idx_usage_date ON usage_daily(date);
+	CREATE INDEX IF NOT EXISTS idx_creds_provider ON credentials(provider);
+
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id           TEXT PRIMARY KEY,
+		name         TEXT NOT NULL,
+		key_hash     TEXT NOT NULL,
+		key_prefix   TEXT NOT NULL,
+		scopes       TEXT NOT NULL,
+		rate_limit   INTEGER DEFAULT 0,
+		user_limit   INTEGER DEFAULT 0,
+		tenant       TEXT,
+		is_active    INTEGER DEFAULT 1,
+		last_used_at DATETIME,
+		created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+		expires_at   DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys(key_prefix);
+	CREATE INDEX IF NOT EXISTS idx_api_keys_active ON api_keys(is_active);
+
+	CREATE TABLE IF NOT EXISTS model_aliases (
+		slug            TEXT PRIMARY KEY,
+		provider        TEXT NOT NULL,
+		model           TEXT NOT NULL,
+		credential_name TEXT NOT NULL,
+		created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at      DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS admin_settings (
+		key        TEXT PRIMARY KEY,
+		value      TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+
+	_, err := s.db.Exec(schema)
+	return err
+}
@@ -0,0 +1,19 @@
+package sqlite
+
+import "database/sql"
+
+// inTx runs fn in a transaction, committing when it returns nil and rolling
+// back otherwise, so a multi-step write never leaves partial state behind.
+// The caller must hold s.mu.
+func (s *Storage) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestInTx_RollsBackOnError(t *testing.T) {
+	s := newTestStorage(t)
+	injected := errors.New("injected failure")
+
+	err := s.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO model_aliases (slug, provider, model, credential_name) VALUES ('a', 'p', 'm', 'c')`); err != nil {
+			return err
+		}
+		return injected
+	})
+	if !errors.Is(err, injected) {
+		t.Fatalf("inTx = %v, want the injected error", err)
+	}
+	if _, err := s.GetAlias("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("write before the failure was kept: %v", err)
+	}
+}
+
+func TestPurgeCredential_FailureLeavesNoPartialState(t *testing.T) {
+	s := newTestStorage(t)
+	cred := &models.Credential{ID: "c1", Provider: "openrouter", Name: "c1", Data: json.RawMessage(`{"api_key":"k"}`)}
+	if err := s.CreateCredential(cred); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	if err := s.LogRequest(&models.RequestLog{RequestID: "r", CredentialID: "c1", Model: "m", Provider: "openrouter"}); err != nil {
+		t.Fatalf("LogRequest: %v", err)
+	}
+	if err := s.UpdateDailyUsage(&models.DailyUsage{Date: "2026-01-01", CredentialID: "c1", Model: "m", RequestCount: 1}); err != nil {
+		t.Fatalf("UpdateDailyUsage: %v", err)
+	}
+
+	// Fail the last step, after the credential and its logs are deleted
+	if _, err := s.db.Exec(`CREATE TRIGGER fail_usage_delete BEFORE DELETE ON usage_daily
+		BEGIN SELECT RAISE(ABORT, 'injected failure'); END`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	if _, err := s.PurgeCredential("c1"); err == nil {
+		t.Fatal("PurgeCredential succeeded despite the injected failure")
+	}
+	if _, err := s.GetCredential("c1"); err != nil {
+		t.Errorf("credential lost: %v", err)
+	}
+	if logs, _ := s.GetRequestLogs(models.LogFilter{CredentialID: "c1"}); len(logs) != 1 {
+		t.Errorf("request logs = %d, want 1", len(logs))
+	}
+	if usage, _ := s.GetDailyUsage("2026-01-01", "2026-01-01"); len(usage) != 1 {
+		t.Errorf("daily usage rows = %d, want 1", len(usage))
+	}
+}
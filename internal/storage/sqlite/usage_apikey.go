@@ -0,0 +1,62 @@
+package sqlite
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// GetAPIKeyUsage aggregates the request logs of filter.APIKeyID into usage
+// statistics with a per-model breakdown. Shadow requests are excluded.
+// Audio characters and image counts are only kept in daily usage and stay zero.
+func (s *Storage) GetAPIKeyUsage(filter models.StatsFilter) (*models.UsageStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	query := `SELECT model, COUNT(*),
+		COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(total_tokens), 0),
+		COALESCE(SUM(CASE WHEN status_code >= 400 THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN tool_calls > 0 THEN 1 ELSE 0 END), 0), COALESCE(SUM(tool_calls), 0)
+		FROM request_logs
+		WHERE api_key_id = ? AND COALESCE(is_shadow, 0) = 0`
+	args := []interface{}{filter.APIKeyID}
+
+	if filter.Model != "" {
+		query += " AND model = ?"
+		args = append(args, filter.Model)
+	}
+	if filter.StartDate != nil {
+		query += " AND substr(created_at, 1, 10) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query += " AND substr(created_at, 1, 10) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+	query += " GROUP BY model"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &models.UsageStats{
+		ModelBreakdown: make(map[string]*models.ModelStats),
+	}
+	for rows.Next() {
+		var ms models.ModelStats
+		if err := rows.Scan(&ms.Model, &ms.RequestCount, &ms.PromptTokens, &ms.CompletionTokens,
+			&ms.TotalTokens, &ms.ErrorCount, &ms.ToolCallRequests, &ms.ToolCalls); err != nil {
+			return nil, err
+		}
+		stats.ModelBreakdown[ms.Model] = &ms
+		stats.AddModel(&ms)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats.ErrorsByType, err = s.errorsByType(filter)
+	return stats, err
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// errorsByType counts failed requests per error category from request logs.
+// Shadow requests are excluded since clients never saw their responses.
+// Callers must hold s.mu.
+func (s *Storage) errorsByType(filter models.StatsFilter) (map[string]int, error) {
+	query := `SELECT error_type, COUNT(*) FROM request_logs
+		WHERE error_type IS NOT NULL AND error_type != '' AND COALESCE(is_shadow, 0) = 0`
+	var args []interface{}
+
+	if filter.CredentialID != "" {
+		query += " AND credential_id = ?"
+		args = append(args, filter.CredentialID)
+	}
+	if filter.APIKeyID != "" {
+		query += " AND api_key_id = ?"
+		args = append(args, filter.APIKeyID)
+	}
+	if filter.Model != "" {
+		query += " AND model = ?"
+		args = append(args, filter.Model)
+	}
+	if filter.StartDate != nil {
+		query += " AND substr(created_at, 1, 10) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query += " AND substr(created_at, 1, 10) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+	query += " GROUP BY error_type"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var errorType string
+		var count int
+		if err := rows.Scan(&errorType, &count); err != nil {
+			return nil, err
+		}
+		counts[errorType] = count
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestGetUsageStats_ErrorsByType(t *testing.T) {
+	s := newTestStorage(t)
+
+	for _, errorType := range []string{"rate_limit", "rate_limit", "auth", ""} {
+		err := s.LogRequest(&models.RequestLog{RequestID: "r", Model: "m", Provider: "openrouter", ErrorType: errorType})
+		if err != nil {
+			t.Fatalf("LogRequest: %v", err)
+		}
+	}
+
+	stats, err := s.GetUsageStats(models.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetUsageStats: %v", err)
+	}
+	if stats.ErrorsByType["rate_limit"] != 2 || stats.ErrorsByType["auth"] != 1 || len(stats.ErrorsByType) != 2 {
+		t.Errorf("ErrorsByType = %v", stats.ErrorsByType)
+	}
+
+	logs, _ := s.GetRequestLogs(models.LogFilter{Limit: 10})
+	found := false
+	for _, l := range logs {
+		found = found || l.ErrorType == "auth"
+	}
+	if !found {
+		t.Error("error_type not read back from request_logs")
+	}
+}
+
+func TestMigrate_AddsErrorTypeColumn(t *testing.T) {
+	s := newTestStorage(t)
+
+	// Simulate a database created before error_type existed
+	if _, err := s.db.Exec("ALTER TABLE request_logs DROP COLUMN error_type"); err != nil {
+		t.Fatalf("drop column: %v", err)
+	}
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate is not idempotent: %v", err)
+	}
+
+	err := s.LogRequest(&models.RequestLog{RequestID: "r", Model: "m", Provider: "p", ErrorType: "timeout"})
+	if err != nil {
+		t.Fatalf("LogRequest after migration: %v", err)
+	}
+}
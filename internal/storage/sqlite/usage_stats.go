@@ -16,7 +16,11 @@ func (s *Storage) GetUsageStats(filter models.StatsFilter) (*models.UsageStats,
 		COALESCE(SUM(prompt_tokens), 0),
 		COALESCE(SUM(completion_tokens), 0),
 		COALESCE(SUM(total_tokens), 0),
-		COALESCE(SUM(error_count), 0)
+		COALESCE(SUM(error_count), 0),
+		COALESCE(SUM(audio_characters), 0),
+		COALESCE(SUM(image_count), 0),
+		COALESCE(SUM(tool_call_requests), 0),
+		COALESCE(SUM(tool_calls), 0)
 		FROM usage_daily WHERE 1=1`
 
 	var args []interface{}
@@ -44,6 +48,10 @@ func (s *Storage) GetUsageStats(filter models.StatsFilter) (*models.UsageStats,
 		&stats.TotalCompletionTokens,
 		&stats.TotalTokens,
 		&stats.ErrorCount,
+		&stats.TotalAudioCharacters,
+		&stats.TotalImages,
+		&stats.TotalToolCallRequests,
+		&stats.TotalToolCalls,
 	)
 	if err != nil {
 		return nil, err
@@ -55,7 +63,11 @@ func (s *Storage) GetUsageStats(filter models.StatsFilter) (*models.UsageStats,
 		COALESCE(SUM(prompt_tokens), 0),
 		COALESCE(SUM(completion_tokens), 0),
 		COALESCE(SUM(total_tokens), 0),
-		COALESCE(SUM(error_count), 0)
+		COALESCE(SUM(error_count), 0),
+		COALESCE(SUM(audio_characters), 0),
+		COALESCE(SUM(image_count), 0),
+		COALESCE(SUM(tool_call_requests), 0),
+		COALESCE(SUM(tool_calls), 0)
 		FROM usage_daily WHERE 1=1`
 
 	if filter.CredentialID != "" {
@@ -78,14 +90,19 @@ func (s *Storage) GetUsageStats(filter models.StatsFilter) (*models.UsageStats,
 	for rows.Next() {
 		var ms models.ModelStats
 		err := rows.Scan(&ms.Model, &ms.RequestCount, &ms.PromptTokens,
-			&ms.CompletionTokens, &ms.TotalTokens, &ms.ErrorCount)
+			&ms.CompletionTokens, &ms.TotalTokens, &ms.ErrorCount,
+			&ms.AudioCharacters, &ms.ImageCount, &ms.ToolCallRequests, &ms.ToolCalls)
 		if err != nil {
 			return nil, err
 		}
 		stats.ModelBreakdown[ms.Model] = &ms
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return stats, rows.Err()
+	stats.ErrorsByType, err = s.errorsByType(filter)
+	return stats, err
 }
 
 // GetDailyUsage retrieves daily usage data for a date range
@@ -99,7 +116,8 @@ func (s *Storage) GetDailyUsage(startDate, endDate string) ([]*models.DailyUsage
 
 	rows, err := s.db.Query(`
 		SELECT date, COALESCE(credential_id, ''), model, request_count,
-			prompt_tokens, completion_tokens, total_tokens, error_count
+			prompt_tokens, completion_tokens, total_tokens, error_count,
+			audio_characters, image_count, tool_call_requests, tool_calls
 		FROM usage_daily
 		WHERE date >= ? AND date <= ?
 		ORDER BY date ASC, model ASC
@@ -113,7 +131,8 @@ func (s *Storage) GetDailyUsage(startDate, endDate string) ([]*models.DailyUsage
 	for rows.Next() {
 		var u models.DailyUsage
 		err := rows.Scan(&u.Date, &u.CredentialID, &u.Model, &u.RequestCount,
-			&u.PromptTokens, &u.CompletionTokens, &u.TotalTokens, &u.ErrorCount)
+			&u.PromptTokens, &u.CompletionTokens, &u.TotalTokens, &u.ErrorCount,
+			&u.AudioCharacters, &u.ImageCount, &u.ToolCallRequests, &u.ToolCalls)
 		if err != nil {
 			return nil, err
 		}
@@ -1,6 +1,28 @@
 package sqlite
 
-import "github.com/mandalnilabja/goatway/internal/storage/models"
+import (
+	"database/sql"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// upsertUsageSQL adds a usage delta to its (date, credential, model) row.
+const upsertUsageSQL = `
+	INSERT INTO usage_daily (date, credential_id, model, request_count,
+		prompt_tokens, completion_tokens, total_tokens, error_count,
+		audio_characters, image_count, tool_call_requests, tool_calls)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(date, credential_id, model) DO UPDATE SET
+		request_count = request_count + excluded.request_count,
+		prompt_tokens = prompt_tokens + excluded.prompt_tokens,
+		completion_tokens = completion_tokens + excluded.completion_tokens,
+		total_tokens = total_tokens + excluded.total_tokens,
+		error_count = error_count + excluded.error_count,
+		audio_characters = audio_characters + excluded.audio_characters,
+		image_count = image_count + excluded.image_count,
+		tool_call_requests = tool_call_requests + excluded.tool_call_requests,
+		tool_calls = tool_calls + excluded.tool_calls
+`
 
 // UpdateDailyUsage upserts daily usage data
 func (s *Storage) UpdateDailyUsage(usage *models.DailyUsage) error {
@@ -11,24 +33,40 @@ func (s *Storage) UpdateDailyUsage(usage *models.DailyUsage) error {
 		return ErrStorageClosed
 	}
 
-	// Use empty string instead of NULL for credential_id to allow ON CONFLICT to work
-	credID := usage.CredentialID
-	if credID == "" {
-		credID = ""
+	_, err := s.db.Exec(upsertUsageSQL, usageArgs(usage)...)
+	return err
+}
+
+// UpdateDailyUsageBatch upserts several usage deltas in one transaction,
+// so a batch costs a single write lock instead of one per delta.
+func (s *Storage) UpdateDailyUsageBatch(batch []*models.DailyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
 	}
 
-	_, err := s.db.Exec(`
-		INSERT INTO usage_daily (date, credential_id, model, request_count,
-			prompt_tokens, completion_tokens, total_tokens, error_count)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(date, credential_id, model) DO UPDATE SET
-			request_count = request_count + excluded.request_count,
-			prompt_tokens = prompt_tokens + excluded.prompt_tokens,
-			completion_tokens = completion_tokens + excluded.completion_tokens,
-			total_tokens = total_tokens + excluded.total_tokens,
-			error_count = error_count + excluded.error_count
-	`, usage.Date, credID, usage.Model, usage.RequestCount,
-		usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens, usage.ErrorCount)
+	return s.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(upsertUsageSQL)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
 
-	return err
+		for _, usage := range batch {
+			if _, err := stmt.Exec(usageArgs(usage)...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// usageArgs returns the upsertUsageSQL arguments for a usage delta.
+// credential_id is bound as a string (empty, never NULL) so ON CONFLICT matches.
+func usageArgs(usage *models.DailyUsage) []any {
+	return []any{usage.Date, usage.CredentialID, usage.Model, usage.RequestCount,
+		usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens, usage.ErrorCount,
+		usage.AudioCharacters, usage.ImageCount, usage.ToolCallRequests, usage.ToolCalls}
 }
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestUpdateDailyUsage_AudioAndImages(t *testing.T) {
+	s := newTestStorage(t)
+
+	rows := []*models.DailyUsage{
+		{Date: "2026-01-02", Model: "tts-1", RequestCount: 1, PromptTokens: 5, TotalTokens: 5, AudioCharacters: 20},
+		{Date: "2026-01-02", Model: "tts-1", RequestCount: 1, PromptTokens: 3, TotalTokens: 3, AudioCharacters: 12},
+		{Date: "2026-01-02", Model: "dall-e-3", RequestCount: 1, ImageCount: 2},
+	}
+	for _, u := range rows {
+		if err := s.UpdateDailyUsage(u); err != nil {
+			t.Fatalf("UpdateDailyUsage: %v", err)
+		}
+	}
+
+	stats, err := s.GetUsageStats(models.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetUsageStats: %v", err)
+	}
+	if stats.TotalAudioCharacters != 32 || stats.TotalImages != 2 || stats.TotalPromptTokens != 8 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if tts := stats.ModelBreakdown["tts-1"]; tts == nil || tts.AudioCharacters != 32 {
+		t.Errorf("tts-1 breakdown = %+v", tts)
+	}
+
+	daily, err := s.GetDailyUsage("2026-01-01", "2026-01-03")
+	if err != nil {
+		t.Fatalf("GetDailyUsage: %v", err)
+	}
+	if len(daily) != 2 || daily[0].Model != "dall-e-3" || daily[0].ImageCount != 2 {
+		t.Errorf("daily = %+v", daily)
+	}
+}
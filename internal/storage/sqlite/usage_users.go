@@ -0,0 +1,58 @@
+package sqlite
+
+import "github.com/mandalnilabja/goatway/internal/storage/models"
+
+// GetUserUsage aggregates request logs per (API key, end user).
+// Requests without a "user" field and shadow requests are excluded.
+// An empty apiKeyID returns usage for every key.
+func (s *Storage) GetUserUsage(apiKeyID string, filter models.StatsFilter) ([]*models.UserUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	query := `SELECT api_key_id, end_user, COUNT(*),
+		COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(total_tokens), 0),
+		COALESCE(SUM(CASE WHEN status_code >= 400 THEN 1 ELSE 0 END), 0)
+		FROM request_logs
+		WHERE api_key_id IS NOT NULL AND end_user IS NOT NULL AND end_user != ''
+			AND COALESCE(is_shadow, 0) = 0`
+	var args []interface{}
+
+	if apiKeyID != "" {
+		query += " AND api_key_id = ?"
+		args = append(args, apiKeyID)
+	}
+	if filter.Model != "" {
+		query += " AND model = ?"
+		args = append(args, filter.Model)
+	}
+	if filter.StartDate != nil {
+		query += " AND substr(created_at, 1, 10) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query += " AND substr(created_at, 1, 10) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+	query += " GROUP BY api_key_id, end_user ORDER BY SUM(total_tokens) DESC, end_user"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []*models.UserUsage
+	for rows.Next() {
+		var u models.UserUsage
+		if err := rows.Scan(&u.APIKeyID, &u.User, &u.RequestCount,
+			&u.PromptTokens, &u.CompletionTokens, &u.TotalTokens, &u.ErrorCount); err != nil {
+			return nil, err
+		}
+		usage = append(usage, &u)
+	}
+	return usage, rows.Err()
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestGetUserUsage(t *testing.T) {
+	s := newTestStorage(t)
+
+	logs := []*models.RequestLog{
+		{APIKeyID: "k1", User: "alice", TotalTokens: 10, PromptTokens: 6, CompletionTokens: 4, StatusCode: 200},
+		{APIKeyID: "k1", User: "alice", TotalTokens: 5, StatusCode: 500},
+		{APIKeyID: "k1", User: "bob", TotalTokens: 1, StatusCode: 200},
+		{APIKeyID: "k2", User: "alice", TotalTokens: 7, StatusCode: 200},
+		{APIKeyID: "k1", TotalTokens: 100, StatusCode: 200},                               // no user
+		{APIKeyID: "k1", User: "alice", TotalTokens: 50, StatusCode: 200, IsShadow: true}, // mirrored
+	}
+	for _, l := range logs {
+		l.RequestID, l.Model, l.Provider = "r", "m", "openrouter"
+		if err := s.LogRequest(l); err != nil {
+			t.Fatalf("LogRequest: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		apiKeyID string
+		want     map[string]models.UserUsage
+	}{
+		{
+			name:     "single key",
+			apiKeyID: "k1",
+			want: map[string]models.UserUsage{
+				"k1/alice": {RequestCount: 2, PromptTokens: 6, CompletionTokens: 4, TotalTokens: 15, ErrorCount: 1},
+				"k1/bob":   {RequestCount: 1, TotalTokens: 1},
+			},
+		},
+		{
+			name: "all keys",
+			want: map[string]models.UserUsage{
+				"k1/alice": {RequestCount: 2, PromptTokens: 6, CompletionTokens: 4, TotalTokens: 15, ErrorCount: 1},
+				"k1/bob":   {RequestCount: 1, TotalTokens: 1},
+				"k2/alice": {RequestCount: 1, TotalTokens: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := s.GetUserUsage(tt.apiKeyID, models.StatsFilter{})
+			if err != nil {
+				t.Fatalf("GetUserUsage: %v", err)
+			}
+			if len(usage) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(usage), len(tt.want))
+			}
+			for _, u := range usage {
+				key := u.APIKeyID + "/" + u.User
+				w := tt.want[key]
+				w.APIKeyID, w.User = u.APIKeyID, u.User
+				if *u != w {
+					t.Errorf("%s = %+v, want %+v", key, *u, w)
+				}
+			}
+		})
+	}
+
+	got, _ := s.GetRequestLogs(models.LogFilter{APIKeyID: "k2", User: "alice"})
+	if len(got) != 1 || got[0].APIKeyID != "k2" || got[0].User != "alice" {
+		t.Errorf("logs filtered by key and user = %+v", got)
+	}
+}
+
+func TestAPIKey_UserLimitRoundTrip(t *testing.T) {
+	s := newTestStorage(t)
+
+	key := &models.ClientAPIKey{Name: "k", KeyHash: "h", KeyPrefix: "gw_x", Scopes: []string{"proxy"}, UserLimit: 5, IsActive: true}
+	if err := s.CreateAPIKey(key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	got, err := s.GetAPIKey(key.ID)
+	if err != nil || got.UserLimit != 5 {
+		t.Fatalf("GetAPIKey = %+v, %v", got, err)
+	}
+
+	got.UserLimit = 9
+	if err := s.UpdateAPIKey(got); err != nil {
+		t.Fatalf("UpdateAPIKey: %v", err)
+	}
+	if keys, _ := s.ListAPIKeys(); len(keys) != 1 || keys[0].UserLimit != 9 {
+		t.Errorf("ListAPIKeys = %+v", keys)
+	}
+}
@@ -2,6 +2,9 @@
 package storage
 
 import (
+	"fmt"
+
+	"github.com/mandalnilabja/goatway/internal/storage/memory"
 	"github.com/mandalnilabja/goatway/internal/storage/models"
 	"github.com/mandalnilabja/goatway/internal/storage/sqlite"
 )
@@ -18,15 +21,35 @@ type (
 	ModelStats          = models.ModelStats
 	UsageStats          = models.UsageStats
 	StatsFilter         = models.StatsFilter
+	UserUsage           = models.UserUsage
+	CredentialPurge     = models.CredentialPurge
+	FailedRequest       = models.FailedRequest
+	FailureFilter       = models.FailureFilter
+	EncryptionHealth    = models.EncryptionHealth
+	ModelAlias          = models.ModelAlias
+	Options             = sqlite.Options
 )
 
-// Re-export errors from sqlite package
+// Re-export errors shared by every backend
 var (
 	ErrNotFound        = sqlite.ErrNotFound
 	ErrDuplicateKey    = sqlite.ErrDuplicateKey
 	ErrInvalidInput    = sqlite.ErrInvalidInput
 	ErrStorageClosed   = sqlite.ErrStorageClosed
 	ErrEncryptionError = sqlite.ErrEncryptionError
+	ErrConflict        = sqlite.ErrConflict
+)
+
+// IsBusy reports whether err is transient lock contention in the SQLite
+// backend, so the write did not happen and may be retried.
+func IsBusy(err error) bool {
+	return sqlite.IsBusy(err)
+}
+
+// Re-export request log anomaly flags
+const (
+	AnomalyCompletionOverLimit = models.AnomalyCompletionOverLimit
+	AnomalyZeroPrompt          = models.AnomalyZeroPrompt
 )
 
 // Storage defines the interface for persistent data storage
@@ -38,16 +61,23 @@ type Storage interface {
 	ListCredentials() ([]*models.Credential, error)
 	UpdateCredential(cred *models.Credential) error
 	DeleteCredential(id string) error
+	PurgeCredential(id string) (*models.CredentialPurge, error)
 
 	// Request logging operations
 	LogRequest(log *models.RequestLog) error
 	GetRequestLogs(filter models.LogFilter) ([]*models.RequestLog, error)
 	DeleteRequestLogs(olderThan string) (int64, error)
 
+	// Upstream failure operations
+	RecordFailure(failure *models.FailedRequest) error
+	ListFailures(filter models.FailureFilter) ([]*models.FailedRequest, error)
+
 	// Usage statistics operations
 	GetUsageStats(filter models.StatsFilter) (*models.UsageStats, error)
 	GetDailyUsage(startDate, endDate string) ([]*models.DailyUsage, error)
 	UpdateDailyUsage(usage *models.DailyUsage) error
+	GetUserUsage(apiKeyID string, filter models.StatsFilter) ([]*models.UserUsage, error)
+	GetAPIKeyUsage(filter models.StatsFilter) (*models.UsageStats, error)
 
 	// Client API key operations
 	CreateAPIKey(key *models.ClientAPIKey) error
@@ -58,17 +88,50 @@ type Storage interface {
 	DeleteAPIKey(id string) error
 	UpdateAPIKeyLastUsed(id string) error
 
+	// Model alias operations, keyed by slug
+	CreateAlias(alias *models.ModelAlias) error
+	GetAlias(slug string) (*models.ModelAlias, error)
+	ListAliases() ([]*models.ModelAlias, error)
+	UpdateAlias(alias *models.ModelAlias) error
+	DeleteAlias(slug string) error
+
 	// Admin password operations
 	GetAdminPasswordHash() (string, error)
 	SetAdminPasswordHash(hash string) error
 	HasAdminPassword() (bool, error)
 
 	// Maintenance operations
+	CheckEncryption() (*models.EncryptionHealth, error)
 	Close() error
 }
 
+// Storage backends selectable with STORAGE_BACKEND.
+const (
+	BackendSQLite = "sqlite"
+	BackendMemory = "memory"
+)
+
+// Open creates the storage for the configured backend.
+// The memory backend keeps nothing across restarts and ignores opts.
+func Open(backend, dbPath string, opts Options) (Storage, error) {
+	switch backend {
+	case "", BackendSQLite:
+		return NewSQLiteStorage(dbPath, opts)
+	case BackendMemory:
+		return NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
 // NewSQLiteStorage creates a new SQLite storage instance
 // This is the main factory function for creating storage
-func NewSQLiteStorage(dbPath string) (Storage, error) {
-	return sqlite.New(dbPath)
+func NewSQLiteStorage(dbPath string, opts Options) (Storage, error) {
+	return sqlite.New(dbPath, opts)
+}
+
+// NewMemoryStorage creates an in-memory storage instance.
+// Nothing is persisted, so it suits tests and stateless deployments.
+func NewMemoryStorage() Storage {
+	return memory.New()
 }
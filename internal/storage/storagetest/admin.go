@@ -0,0 +1,67 @@
+package storagetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testAdminPassword(t *testing.T, s storage.Storage) {
+	if has, err := s.HasAdminPassword(); err != nil || has {
+		t.Fatalf("HasAdminPassword on empty store = %v, %v", has, err)
+	}
+	for _, hash := range []string{"first", "second"} {
+		if err := s.SetAdminPasswordHash(hash); err != nil {
+			t.Fatalf("SetAdminPasswordHash: %v", err)
+		}
+	}
+	if hash, err := s.GetAdminPasswordHash(); err != nil || hash != "second" {
+		t.Errorf("GetAdminPasswordHash = %q, %v; want overwritten hash", hash, err)
+	}
+}
+
+func testEncryptionHealth(t *testing.T, s storage.Storage) {
+	if err := s.CreateCredential(newCredential("c")); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	health, err := s.CheckEncryption()
+	if err != nil {
+		t.Fatalf("CheckEncryption: %v", err)
+	}
+	if !health.Healthy() || health.Credentials != 1 || health.Undecryptable != 0 {
+		t.Errorf("CheckEncryption = %+v, want healthy with 1 readable credential", health)
+	}
+}
+
+func testClosed(t *testing.T, s storage.Storage) {
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	ops := []struct {
+		name string
+		call func() error
+	}{
+		{"CreateCredential", func() error { return s.CreateCredential(newCredential("c")) }},
+		{"GetCredential", func() error { _, err := s.GetCredential("x"); return err }},
+		{"ListCredentials", func() error { _, err := s.ListCredentials(); return err }},
+		{"LogRequest", func() error { return s.LogRequest(&storage.RequestLog{}) }},
+		{"GetRequestLogs", func() error { _, err := s.GetRequestLogs(storage.LogFilter{}); return err }},
+		{"RecordFailure", func() error { return s.RecordFailure(&storage.FailedRequest{}) }},
+		{"ListFailures", func() error { _, err := s.ListFailures(storage.FailureFilter{}); return err }},
+		{"UpdateDailyUsage", func() error { return s.UpdateDailyUsage(&storage.DailyUsage{}) }},
+		{"GetUsageStats", func() error { _, err := s.GetUsageStats(storage.StatsFilter{}); return err }},
+		{"GetAPIKeyUsage", func() error { _, err := s.GetAPIKeyUsage(storage.StatsFilter{}); return err }},
+		{"CreateAPIKey", func() error { return s.CreateAPIKey(&storage.ClientAPIKey{}) }},
+		{"ListAPIKeys", func() error { _, err := s.ListAPIKeys(); return err }},
+		{"ListAliases", func() error { _, err := s.ListAliases(); return err }},
+		{"HasAdminPassword", func() error { _, err := s.HasAdminPassword(); return err }},
+		{"CheckEncryption", func() error { _, err := s.CheckEncryption(); return err }},
+	}
+	for _, op := range ops {
+		if err := op.call(); !errors.Is(err, storage.ErrStorageClosed) {
+			t.Errorf("%s after Close: err = %v, want ErrStorageClosed", op.name, err)
+		}
+	}
+}
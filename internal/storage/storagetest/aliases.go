@@ -0,0 +1,57 @@
+package storagetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func newAlias(slug, model string) *storage.ModelAlias {
+	return &storage.ModelAlias{Slug: slug, Provider: "openrouter", Model: model, CredentialName: "primary"}
+}
+
+func testAliases(t *testing.T, s storage.Storage) {
+	if err := s.CreateAlias(&storage.ModelAlias{Slug: "incomplete"}); !errors.Is(err, storage.ErrInvalidInput) {
+		t.Errorf("incomplete alias: err = %v, want ErrInvalidInput", err)
+	}
+
+	alias := newAlias("gpt4", "openai/gpt-4o")
+	if err := s.CreateAlias(alias); err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+	if alias.CreatedAt.IsZero() {
+		t.Errorf("CreatedAt not set: %+v", alias)
+	}
+	if err := s.CreateAlias(newAlias("gpt4", "openai/gpt-4")); !errors.Is(err, storage.ErrDuplicateKey) {
+		t.Errorf("duplicate slug: err = %v, want ErrDuplicateKey", err)
+	}
+
+	if err := s.UpdateAlias(newAlias("gpt4", "openai/gpt-4o-mini")); err != nil {
+		t.Fatalf("UpdateAlias: %v", err)
+	}
+	if got, err := s.GetAlias("gpt4"); err != nil || got.Model != "openai/gpt-4o-mini" || !got.CreatedAt.Equal(alias.CreatedAt) {
+		t.Errorf("GetAlias = %+v, %v; want updated model and original CreatedAt", got, err)
+	}
+	if err := s.UpdateAlias(newAlias("missing", "m")); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("update missing: err = %v, want ErrNotFound", err)
+	}
+
+	if err := s.CreateAlias(newAlias("claude", "anthropic/claude-3.5-sonnet")); err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+	list, err := s.ListAliases()
+	if err != nil || len(list) != 2 || list[0].Slug != "claude" {
+		t.Fatalf("ListAliases = %+v, %v; want 2 ordered by slug", list, err)
+	}
+
+	if err := s.DeleteAlias("gpt4"); err != nil {
+		t.Fatalf("DeleteAlias: %v", err)
+	}
+	if _, err := s.GetAlias("gpt4"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("get deleted: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteAlias("gpt4"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("delete twice: err = %v, want ErrNotFound", err)
+	}
+}
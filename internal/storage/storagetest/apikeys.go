@@ -0,0 +1,46 @@
+package storagetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testAPIKeys(t *testing.T, s storage.Storage) {
+	key := &storage.ClientAPIKey{Name: "ci", KeyHash: "h", KeyPrefix: "gw_abc", Scopes: []string{"proxy"}, Tenant: "acme", IsActive: true}
+	if err := s.CreateAPIKey(key); err != nil || key.ID == "" {
+		t.Fatalf("CreateAPIKey: id %q, %v", key.ID, err)
+	}
+	if keys, _ := s.GetAPIKeyByPrefix("gw_abc"); len(keys) != 1 || keys[0].Scopes[0] != "proxy" || keys[0].Tenant != "acme" {
+		t.Errorf("GetAPIKeyByPrefix = %+v", keys)
+	}
+
+	if err := s.UpdateAPIKeyLastUsed(key.ID); err != nil {
+		t.Fatalf("UpdateAPIKeyLastUsed: %v", err)
+	}
+	if err := s.UpdateAPIKeyLastUsed("missing"); err != nil {
+		t.Errorf("UpdateAPIKeyLastUsed on missing key: %v", err)
+	}
+	key.IsActive = false
+	if err := s.UpdateAPIKey(key); err != nil {
+		t.Fatalf("UpdateAPIKey: %v", err)
+	}
+	got, err := s.GetAPIKey(key.ID)
+	if err != nil || got.IsActive || got.LastUsedAt == nil || got.Tenant != "acme" {
+		t.Errorf("GetAPIKey = %+v, %v; want inactive acme key with last-used time", got, err)
+	}
+
+	if err := s.UpdateAPIKey(&storage.ClientAPIKey{ID: "missing"}); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("update missing: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteAPIKey(key.ID); err != nil {
+		t.Fatalf("DeleteAPIKey: %v", err)
+	}
+	if _, err := s.GetAPIKey(key.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("get deleted: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteAPIKey(key.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("delete twice: err = %v, want ErrNotFound", err)
+	}
+}
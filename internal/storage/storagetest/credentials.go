@@ -0,0 +1,94 @@
+package storagetest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func newCredential(name string) *storage.Credential {
+	return &storage.Credential{Provider: "openrouter", Name: name, Data: json.RawMessage(`{"api_key":"k"}`), LogRequests: true}
+}
+
+func testCredentials(t *testing.T, s storage.Storage) {
+	if err := s.CreateCredential(&storage.Credential{Name: "incomplete"}); !errors.Is(err, storage.ErrInvalidInput) {
+		t.Errorf("incomplete credential: err = %v, want ErrInvalidInput", err)
+	}
+
+	cred := newCredential("primary")
+	if err := s.CreateCredential(cred); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	if cred.ID == "" || cred.CreatedAt.IsZero() {
+		t.Errorf("ID and CreatedAt not set: %+v", cred)
+	}
+	if err := s.CreateCredential(newCredential("primary")); err == nil {
+		t.Error("duplicate name accepted")
+	}
+
+	got, err := s.GetCredentialByName("primary")
+	if err != nil || got.ID != cred.ID || string(got.Data) != `{"api_key":"k"}` || !got.LogRequests {
+		t.Fatalf("GetCredentialByName = %+v, %v", got, err)
+	}
+
+	got.Name, got.LogRequests = "renamed", false
+	if err := s.UpdateCredential(got); err != nil {
+		t.Fatalf("UpdateCredential: %v", err)
+	}
+	if got, _ := s.GetCredential(cred.ID); got.Name != "renamed" || got.LogRequests {
+		t.Errorf("update not stored: %+v", got)
+	}
+	if err := s.UpdateCredential(&storage.Credential{ID: "missing", Name: "x"}); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("update missing: err = %v, want ErrNotFound", err)
+	}
+
+	if err := s.CreateCredential(newCredential("second")); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	list, err := s.ListCredentials()
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListCredentials = %d entries, %v; want 2", len(list), err)
+	}
+	if list[0].Name != "second" {
+		t.Errorf("ListCredentials first = %q, want newest first", list[0].Name)
+	}
+
+	if err := s.DeleteCredential(cred.ID); err != nil {
+		t.Fatalf("DeleteCredential: %v", err)
+	}
+	if _, err := s.GetCredential(cred.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("get deleted: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteCredential(cred.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("delete twice: err = %v, want ErrNotFound", err)
+	}
+}
+
+func testPurgeCredential(t *testing.T, s storage.Storage) {
+	for _, name := range []string{"purged", "kept"} {
+		cred := newCredential(name)
+		cred.ID = name
+		if err := s.CreateCredential(cred); err != nil {
+			t.Fatalf("CreateCredential: %v", err)
+		}
+		for range 3 {
+			_ = s.LogRequest(&storage.RequestLog{RequestID: "r", CredentialID: name, Model: "m", Provider: "openrouter"})
+		}
+		for _, date := range []string{"2026-01-01", "2026-01-02"} {
+			_ = s.UpdateDailyUsage(&storage.DailyUsage{Date: date, CredentialID: name, Model: "m", RequestCount: 1})
+		}
+	}
+
+	purge, err := s.PurgeCredential("purged")
+	if err != nil || purge.RequestLogs != 3 || purge.DailyUsage != 2 {
+		t.Fatalf("PurgeCredential = %+v, %v; want 3 logs and 2 usage rows", purge, err)
+	}
+	if logs, _ := s.GetRequestLogs(storage.LogFilter{CredentialID: "kept"}); len(logs) != 3 {
+		t.Errorf("other credential's logs affected: %d remain", len(logs))
+	}
+	if _, err := s.PurgeCredential("purged"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("purge twice: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testCredentialVersion(t *testing.T, s storage.Storage) {
+	cred := newCredential("versioned")
+	if err := s.CreateCredential(cred); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	if cred.Version != 1 {
+		t.Errorf("new credential version = %d, want 1", cred.Version)
+	}
+
+	first, _ := s.GetCredential(cred.ID)
+	stale, _ := s.GetCredential(cred.ID)
+	first.Name = "first"
+	if err := s.UpdateCredential(first); err != nil {
+		t.Fatalf("UpdateCredential: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("updated version = %d, want 2", first.Version)
+	}
+
+	stale.Name = "stale"
+	if err := s.UpdateCredential(stale); !errors.Is(err, storage.ErrConflict) {
+		t.Errorf("stale update: err = %v, want ErrConflict", err)
+	}
+	if got, _ := s.GetCredential(cred.ID); got.Name != "first" || got.Version != 2 {
+		t.Errorf("stale update was stored: %+v", got)
+	}
+
+	stale.Version = 0
+	if err := s.UpdateCredential(stale); err != nil || stale.Version != 3 {
+		t.Errorf("unversioned update: version = %d, err = %v; want 3, nil", stale.Version, err)
+	}
+}
+
+func testConcurrentCredentialUpdates(t *testing.T, s storage.Storage) {
+	cred := newCredential("contended")
+	if err := s.CreateCredential(cred); err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+
+	const writers = 8
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := *cred
+			c.Name = fmt.Sprintf("writer-%d", i)
+			errs[i] = s.UpdateCredential(&c)
+		}()
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, storage.ErrConflict):
+			t.Errorf("UpdateCredential: err = %v, want nil or ErrConflict", err)
+		}
+	}
+	if won != 1 {
+		t.Errorf("%d writers succeeded with the same version, want 1", won)
+	}
+	if got, _ := s.GetCredential(cred.ID); got.Version != 2 {
+		t.Errorf("version after contention = %d, want 2", got.Version)
+	}
+}
@@ -0,0 +1,56 @@
+package storagetest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testFailures(t *testing.T, s storage.Storage) {
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	seed := []*storage.FailedRequest{
+		{Model: "a", Provider: "openrouter", StatusCode: 502, ErrorType: "server_error", CredentialID: "c1"},
+		{Model: "b", Provider: "bedrock", StatusCode: 504, ErrorType: "timeout"},
+		{Model: "a", Provider: "openrouter", StatusCode: 503, ErrorType: "server_error", APIKeyID: "k1"},
+	}
+	for i, f := range seed {
+		f.RequestID, f.Attempts, f.CreatedAt = "r"+strconv.Itoa(i%2), 1, base.AddDate(0, 0, i)
+		if err := s.RecordFailure(f); err != nil || f.ID == "" {
+			t.Fatalf("RecordFailure: id %q, %v", f.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.FailureFilter
+		want   int
+	}{
+		{"no filter", storage.FailureFilter{}, 3},
+		{"request id", storage.FailureFilter{RequestID: "r1"}, 1},
+		{"model", storage.FailureFilter{Model: "a"}, 2},
+		{"provider", storage.FailureFilter{Provider: "bedrock"}, 1},
+		{"error type", storage.FailureFilter{ErrorType: "timeout"}, 1},
+		{"limit", storage.FailureFilter{Limit: 2}, 2},
+		{"offset past end", storage.FailureFilter{Offset: 10}, 0},
+	}
+	for _, tt := range tests {
+		failures, err := s.ListFailures(tt.filter)
+		if err != nil || len(failures) != tt.want {
+			t.Errorf("%s: ListFailures = %d records, %v; want %d", tt.name, len(failures), err, tt.want)
+		}
+	}
+
+	failures, err := s.ListFailures(storage.FailureFilter{Model: "a", Limit: 1})
+	if err != nil || len(failures) != 1 {
+		t.Fatalf("ListFailures = %d records, %v; want 1", len(failures), err)
+	}
+	got := failures[0]
+	if !got.CreatedAt.Equal(base.AddDate(0, 0, 2)) {
+		t.Errorf("failures not newest first: %v", got.CreatedAt)
+	}
+	if got.StatusCode != 503 || got.APIKeyID != "k1" || got.Attempts != 1 || got.RequestID != "r0" {
+		t.Errorf("fields not round-tripped: %+v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package storagetest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testRequestLogs(t *testing.T, s storage.Storage) {
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	seed := []*storage.RequestLog{
+		{Model: "a", Provider: "openrouter", CredentialID: "c1", APIKeyID: "k1", User: "alice", StatusCode: 200},
+		{Model: "b", Provider: "bedrock", CredentialID: "c2", APIKeyID: "k1", StatusCode: 429},
+		{Model: "a", Provider: "openrouter", CredentialID: "c1", APIKeyID: "k2", User: "bob", StatusCode: 200, Attempts: 2},
+		{Model: "a", Provider: "openrouter", CredentialID: "c2", StatusCode: 500},
+		{Model: "b", Provider: "bedrock", CredentialID: "c1", StatusCode: 200, Anomaly: storage.AnomalyZeroPrompt},
+	}
+	for i, log := range seed {
+		log.RequestID, log.CreatedAt = "r"+strconv.Itoa(i%2), base.AddDate(0, 0, i)
+		if err := s.LogRequest(log); err != nil || log.ID == "" {
+			t.Fatalf("LogRequest: id %q, %v", log.ID, err)
+		}
+	}
+
+	ok, start, end := 200, base.AddDate(0, 0, 1), base.AddDate(0, 0, 2)
+	tests := []struct {
+		name   string
+		filter storage.LogFilter
+		want   int
+	}{
+		{"no filter", storage.LogFilter{}, 5},
+		{"request id", storage.LogFilter{RequestID: "r1"}, 2},
+		{"credential", storage.LogFilter{CredentialID: "c1"}, 3},
+		{"api key", storage.LogFilter{APIKeyID: "k1"}, 2},
+		{"user", storage.LogFilter{User: "bob"}, 1},
+		{"model", storage.LogFilter{Model: "a"}, 3},
+		{"provider", storage.LogFilter{Provider: "bedrock"}, 2},
+		{"status code", storage.LogFilter{StatusCode: &ok}, 3},
+		{"anomalous", storage.LogFilter{Anomalous: true}, 1},
+		{"date range", storage.LogFilter{StartDate: &start, EndDate: &end}, 2},
+		{"combined", storage.LogFilter{Model: "a", CredentialID: "c2"}, 1},
+		{"limit", storage.LogFilter{Limit: 3}, 3},
+		{"offset past end", storage.LogFilter{Offset: 10}, 0},
+	}
+	for _, tt := range tests {
+		logs, err := s.GetRequestLogs(tt.filter)
+		if err != nil || len(logs) != tt.want {
+			t.Errorf("%s: GetRequestLogs = %d logs, %v; want %d", tt.name, len(logs), err, tt.want)
+		}
+	}
+
+	logs, err := s.GetRequestLogs(storage.LogFilter{Model: "a", Limit: 2, Offset: 1})
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("GetRequestLogs = %d logs, %v; want 2", len(logs), err)
+	}
+	if !logs[0].CreatedAt.Equal(base.AddDate(0, 0, 2)) || !logs[1].CreatedAt.Equal(base) {
+		t.Errorf("logs not newest first after offset: %v, %v", logs[0].CreatedAt, logs[1].CreatedAt)
+	}
+	if logs[0].User != "bob" || logs[0].APIKeyID != "k2" || logs[0].Attempts != 2 {
+		t.Errorf("fields not round-tripped: %+v", logs[0])
+	}
+
+	flagged, err := s.GetRequestLogs(storage.LogFilter{Anomalous: true})
+	if err != nil || len(flagged) != 1 || flagged[0].Anomaly != storage.AnomalyZeroPrompt {
+		t.Errorf("anomaly not round-tripped: %v, %v", flagged, err)
+	}
+
+	deleted, err := s.DeleteRequestLogs("2026-03-12")
+	if err != nil || deleted != 2 {
+		t.Errorf("DeleteRequestLogs = %d, %v; want 2", deleted, err)
+	}
+}
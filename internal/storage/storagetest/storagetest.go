@@ -0,0 +1,43 @@
+// Package storagetest provides a conformance suite for storage backends.
+// Every backend runs the same table of checks so behavior stays identical
+// across implementations.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// checks lists every behavior a backend must implement identically.
+var checks = []struct {
+	name string
+	fn   func(t *testing.T, s storage.Storage)
+}{
+	{"credentials", testCredentials},
+	{"credential version", testCredentialVersion},
+	{"concurrent credential updates", testConcurrentCredentialUpdates},
+	{"purge credential", testPurgeCredential},
+	{"request logs", testRequestLogs},
+	{"failures", testFailures},
+	{"daily usage", testDailyUsage},
+	{"user usage", testUserUsage},
+	{"api key usage", testAPIKeyUsage},
+	{"api keys", testAPIKeys},
+	{"model aliases", testAliases},
+	{"admin password", testAdminPassword},
+	{"encryption health", testEncryptionHealth},
+	{"closed store", testClosed},
+}
+
+// Run executes the conformance suite against a backend.
+// open must return a fresh, empty store for every check.
+func Run(t *testing.T, open func(t *testing.T) storage.Storage) {
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			s := open(t)
+			t.Cleanup(func() { _ = s.Close() })
+			c.fn(t, s)
+		})
+	}
+}
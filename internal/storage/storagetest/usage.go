@@ -0,0 +1,116 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func testDailyUsage(t *testing.T, s storage.Storage) {
+	rows := []*storage.DailyUsage{
+		{Date: "2026-01-02", CredentialID: "c1", Model: "b", RequestCount: 1, TotalTokens: 10, ToolCallRequests: 1, ToolCalls: 2},
+		{Date: "2026-01-01", CredentialID: "c1", Model: "a", RequestCount: 1, TotalTokens: 5, ErrorCount: 1},
+		{Date: "2026-01-01", CredentialID: "c1", Model: "a", RequestCount: 2, TotalTokens: 7, ImageCount: 3, ToolCallRequests: 1, ToolCalls: 1},
+		{Date: "2026-01-01", CredentialID: "c2", Model: "a", RequestCount: 4, TotalTokens: 1},
+	}
+	for _, u := range rows {
+		if err := s.UpdateDailyUsage(u); err != nil {
+			t.Fatalf("UpdateDailyUsage: %v", err)
+		}
+	}
+	_ = s.LogRequest(&storage.RequestLog{RequestID: "r", CredentialID: "c1", ErrorType: "timeout", StatusCode: 504})
+	_ = s.LogRequest(&storage.RequestLog{RequestID: "r", CredentialID: "c1", ErrorType: "timeout", IsShadow: true})
+
+	daily, err := s.GetDailyUsage("2026-01-01", "2026-01-01")
+	if err != nil || len(daily) != 2 {
+		t.Fatalf("GetDailyUsage = %d rows, %v; want 2", len(daily), err)
+	}
+	for _, u := range daily {
+		if u.CredentialID == "c1" && (u.RequestCount != 3 || u.TotalTokens != 12 || u.ErrorCount != 1 || u.ImageCount != 3) {
+			t.Errorf("upsert did not add counters: %+v", u)
+		}
+	}
+
+	stats, err := s.GetUsageStats(storage.StatsFilter{CredentialID: "c1"})
+	if err != nil {
+		t.Fatalf("GetUsageStats: %v", err)
+	}
+	if stats.TotalRequests != 4 || stats.TotalTokens != 22 || stats.ModelBreakdown["a"].RequestCount != 3 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.TotalToolCallRequests != 2 || stats.TotalToolCalls != 3 || stats.ModelBreakdown["b"].ToolCalls != 2 {
+		t.Errorf("tool call stats = %d requests, %d calls; want 2, 3", stats.TotalToolCallRequests, stats.TotalToolCalls)
+	}
+	if stats.ErrorsByType["timeout"] != 1 {
+		t.Errorf("ErrorsByType = %v, want one non-shadow timeout", stats.ErrorsByType)
+	}
+}
+
+func testUserUsage(t *testing.T, s storage.Storage) {
+	for _, l := range []*storage.RequestLog{
+		{APIKeyID: "k1", User: "alice", TotalTokens: 10, StatusCode: 200},
+		{APIKeyID: "k1", User: "alice", TotalTokens: 5, StatusCode: 500},
+		{APIKeyID: "k1", User: "bob", TotalTokens: 20, StatusCode: 200},
+		{APIKeyID: "k2", User: "alice", TotalTokens: 7, StatusCode: 200},
+		{APIKeyID: "k1", TotalTokens: 100, StatusCode: 200},
+		{APIKeyID: "k1", User: "bob", TotalTokens: 50, IsShadow: true},
+	} {
+		l.RequestID, l.Model, l.Provider = "r", "m", "openrouter"
+		_ = s.LogRequest(l)
+	}
+
+	usage, err := s.GetUserUsage("k1", storage.StatsFilter{})
+	if err != nil || len(usage) != 2 {
+		t.Fatalf("GetUserUsage = %d rows, %v; want 2", len(usage), err)
+	}
+	if usage[0].User != "bob" || usage[1].RequestCount != 2 || usage[1].ErrorCount != 1 {
+		t.Errorf("usage = %+v, %+v; want bob first, then alice with one error", usage[0], usage[1])
+	}
+	if all, _ := s.GetUserUsage("", storage.StatsFilter{}); len(all) != 3 {
+		t.Errorf("all keys returned %d rows, want 3", len(all))
+	}
+}
+
+func testAPIKeyUsage(t *testing.T, s storage.Storage) {
+	jan1 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	jan2 := jan1.AddDate(0, 0, 1)
+	for _, l := range []*storage.RequestLog{
+		{APIKeyID: "k1", Model: "a", PromptTokens: 4, CompletionTokens: 6, TotalTokens: 10, StatusCode: 200, ToolCalls: 2, CreatedAt: jan1},
+		{APIKeyID: "k1", Model: "a", TotalTokens: 5, StatusCode: 429, ErrorType: "rate_limit", CreatedAt: jan1},
+		{APIKeyID: "k1", Model: "b", TotalTokens: 20, StatusCode: 200, CreatedAt: jan2},
+		{APIKeyID: "k2", Model: "a", TotalTokens: 7, StatusCode: 500, ErrorType: "server_error", CreatedAt: jan1},
+		{APIKeyID: "k1", Model: "a", TotalTokens: 50, StatusCode: 200, IsShadow: true, CreatedAt: jan1},
+		{Model: "a", TotalTokens: 100, StatusCode: 200, CreatedAt: jan1},
+	} {
+		l.RequestID, l.Provider = "r", "openrouter"
+		_ = s.LogRequest(l)
+	}
+
+	stats, err := s.GetAPIKeyUsage(storage.StatsFilter{APIKeyID: "k1"})
+	if err != nil {
+		t.Fatalf("GetAPIKeyUsage: %v", err)
+	}
+	if stats.TotalRequests != 3 || stats.TotalTokens != 35 || stats.ErrorCount != 1 || stats.TotalToolCalls != 2 {
+		t.Errorf("totals = %+v; want 3 requests, 35 tokens, 1 error, 2 tool calls", stats)
+	}
+	if a := stats.ModelBreakdown["a"]; a == nil || a.RequestCount != 2 || a.PromptTokens != 4 || a.ToolCallRequests != 1 {
+		t.Errorf("model a = %+v; want 2 requests, 4 prompt tokens, 1 tool call request", a)
+	}
+	if len(stats.ModelBreakdown) != 2 || stats.ErrorsByType["rate_limit"] != 1 || stats.ErrorsByType["server_error"] != 0 {
+		t.Errorf("breakdown = %v, errors = %v; want models a and b, k1's rate limit only", stats.ModelBreakdown, stats.ErrorsByType)
+	}
+
+	byModel := storage.StatsFilter{APIKeyID: "k1", Model: "b"}
+	if stats, _ := s.GetAPIKeyUsage(byModel); stats.TotalRequests != 1 || len(stats.ErrorsByType) != 0 {
+		t.Errorf("model-filtered usage = %+v, errors = %v; want model b only, no errors", stats, stats.ErrorsByType)
+	}
+
+	day := storage.StatsFilter{APIKeyID: "k1", StartDate: &jan2, EndDate: &jan2}
+	if stats, _ := s.GetAPIKeyUsage(day); stats.TotalRequests != 1 || stats.ModelBreakdown["b"] == nil {
+		t.Errorf("date-filtered usage = %+v; want only model b", stats)
+	}
+	if stats, _ := s.GetAPIKeyUsage(storage.StatsFilter{APIKeyID: "missing"}); stats.TotalRequests != 0 {
+		t.Errorf("unknown key usage = %+v; want empty", stats)
+	}
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// usageBatchWriter is implemented by backends that can upsert many usage
+// deltas in one transaction (SQLite); others receive the deltas one by one.
+type usageBatchWriter interface {
+	UpdateDailyUsageBatch(batch []*models.DailyUsage) error
+}
+
+// usageKey identifies the daily usage row a delta is added to.
+type usageKey struct {
+	date, credentialID, model string
+}
+
+// usageBatcher accumulates daily usage deltas in memory and flushes them
+// every interval, so a busy gateway takes the storage write lock once per
+// flush instead of once per request. At most one interval of usage is lost
+// if the process dies without closing the store.
+type usageBatcher struct {
+	Storage
+
+	mu      sync.Mutex
+	pending map[usageKey]*models.DailyUsage
+	closed  bool
+	flushMu sync.Mutex // Serializes flushes so deltas are written in order
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewUsageBatcher wraps s so UpdateDailyUsage is batched and flushed every
+// interval (and on Close). Usage reads flush first, so they never lag.
+// An interval of 0 or less returns s unchanged.
+func NewUsageBatcher(s Storage, interval time.Duration) Storage {
+	if interval <= 0 {
+		return s
+	}
+	b := &usageBatcher{
+		Storage: s,
+		pending: make(map[usageKey]*models.DailyUsage),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run(interval)
+	return b
+}
+
+// UpdateDailyUsage adds usage to the pending batch.
+func (b *usageBatcher) UpdateDailyUsage(usage *models.DailyUsage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return models.ErrStorageClosed
+	}
+
+	key := usageKey{date: usage.Date, credentialID: usage.CredentialID, model: usage.Model}
+	row, ok := b.pending[key]
+	if !ok {
+		row = &models.DailyUsage{Date: usage.Date, CredentialID: usage.CredentialID, Model: usage.Model}
+		b.pending[key] = row
+	}
+	row.Add(usage)
+	return nil
+}
+
+// GetUsageStats flushes pending usage, then reads the aggregates.
+func (b *usageBatcher) GetUsageStats(filter models.StatsFilter) (*models.UsageStats, error) {
+	b.flush()
+	return b.Storage.GetUsageStats(filter)
+}
+
+// GetDailyUsage flushes pending usage, then reads the daily rows.
+func (b *usageBatcher) GetDailyUsage(startDate, endDate string) ([]*models.DailyUsage, error) {
+	b.flush()
+	return b.Storage.GetDailyUsage(startDate, endDate)
+}
+
+// PurgeCredential flushes pending usage first so none of the purged
+// credential's usage is written back after the purge.
+func (b *usageBatcher) PurgeCredential(id string) (*models.CredentialPurge, error) {
+	b.flush()
+	return b.Storage.PurgeCredential(id)
+}
+
+// Close stops the flush loop, writes the remaining usage and closes the store.
+func (b *usageBatcher) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return b.Storage.Close()
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+	b.flush()
+	return b.Storage.Close()
+}
+
+// run flushes pending usage every interval until Close.
+func (b *usageBatcher) run(interval time.Duration) {
+	defer close(b.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.flush()
+		case <-b.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"log/slog"
+
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+// flush writes the pending batch. On failure the deltas are merged back
+// into the next batch; pending holds one row per day, credential and model,
+// so retained usage stays bounded.
+func (b *usageBatcher) flush() {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[usageKey]*models.DailyUsage)
+	b.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	batch := make([]*models.DailyUsage, 0, len(pending))
+	for _, row := range pending {
+		batch = append(batch, row)
+	}
+	if unwritten, err := b.write(batch); err != nil {
+		slog.Warn("daily usage flush failed, retrying next interval", "rows", len(unwritten), "error", err)
+		b.requeue(unwritten)
+	}
+}
+
+// requeue merges unwritten rows back into the pending batch.
+func (b *usageBatcher) requeue(rows []*models.DailyUsage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, row := range rows {
+		key := usageKey{date: row.Date, credentialID: row.CredentialID, model: row.Model}
+		if existing, ok := b.pending[key]; ok {
+			existing.Add(row)
+		} else {
+			b.pending[key] = row
+		}
+	}
+}
+
+// write persists a batch, in one transaction when the backend supports it,
+// and returns the rows that were not written.
+func (b *usageBatcher) write(batch []*models.DailyUsage) ([]*models.DailyUsage, error) {
+	if w, ok := b.Storage.(usageBatchWriter); ok {
+		if err := w.UpdateDailyUsageBatch(batch); err != nil {
+			return batch, err
+		}
+		return nil, nil
+	}
+	for i, row := range batch {
+		if err := b.Storage.UpdateDailyUsage(row); err != nil {
+			return batch[i:], err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,93 @@
+package storage_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/storage/storagetest"
+)
+
+func openSQLite(t *testing.T) storage.Storage {
+	t.Helper()
+	s, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	return s
+}
+
+func TestBatchedSQLiteConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return storage.NewUsageBatcher(openSQLite(t), time.Hour)
+	})
+}
+
+func TestUsageBatcher_SameTotals(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		read     func(batched, inner storage.Storage) storage.Storage
+	}{
+		// Reads through the batcher flush pending deltas first
+		{"read flushes", time.Hour, func(batched, _ storage.Storage) storage.Storage { return batched }},
+		// The background loop flushes without any read
+		{"periodic flush", 10 * time.Millisecond, func(_, inner storage.Storage) storage.Storage {
+			time.Sleep(100 * time.Millisecond)
+			return inner
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			direct, inner := openSQLite(t), openSQLite(t)
+			defer direct.Close()
+			batched := storage.NewUsageBatcher(inner, tt.interval)
+			defer batched.Close()
+
+			var wg sync.WaitGroup
+			for w := 0; w < 8; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 50; i++ {
+						u := storage.DailyUsage{
+							Date: "2026-05-01", CredentialID: fmt.Sprintf("c%d", i%2), Model: fmt.Sprintf("m%d", i%3),
+							RequestCount: 1, PromptTokens: i, CompletionTokens: 2, TotalTokens: i + 2, ErrorCount: i % 5 / 4,
+						}
+						d, b := u, u
+						if err := direct.UpdateDailyUsage(&d); err != nil {
+							t.Errorf("direct UpdateDailyUsage: %v", err)
+						}
+						if err := batched.UpdateDailyUsage(&b); err != nil {
+							t.Errorf("batched UpdateDailyUsage: %v", err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			want, err := direct.GetUsageStats(storage.StatsFilter{})
+			if err != nil {
+				t.Fatalf("direct GetUsageStats: %v", err)
+			}
+			got, err := tt.read(batched, inner).GetUsageStats(storage.StatsFilter{})
+			if err != nil {
+				t.Fatalf("batched GetUsageStats: %v", err)
+			}
+			if got.TotalRequests != 400 || got.TotalRequests != want.TotalRequests ||
+				got.TotalTokens != want.TotalTokens || got.ErrorCount != want.ErrorCount {
+				t.Errorf("batched totals = %d requests, %d tokens, %d errors; want %d, %d, %d",
+					got.TotalRequests, got.TotalTokens, got.ErrorCount, want.TotalRequests, want.TotalTokens, want.ErrorCount)
+			}
+			for model, w := range want.ModelBreakdown {
+				if g := got.ModelBreakdown[model]; g == nil || g.TotalTokens != w.TotalTokens {
+					t.Errorf("model %s = %+v, want %+v", model, g, w)
+				}
+			}
+		})
+	}
+}
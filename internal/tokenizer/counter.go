@@ -1,10 +1,6 @@
 package tokenizer
 
-import (
-	"strings"
-
-	"github.com/mandalnilabja/goatway/internal/types"
-)
+import "github.com/mandalnilabja/goatway/internal/types"
 
 // Message token overhead varies by model family.
 // These values are based on OpenAI's documentation.
@@ -115,12 +111,3 @@ func (t *TiktokenTokenizer) countMessage(msg types.Message, model string) (int,
 
 	return total, nil
 }
-
-// getMessageOverhead returns the per-message token overhead for a model.
-func (t *TiktokenTokenizer) getMessageOverhead(model string) int {
-	modelLower := strings.ToLower(model)
-	if strings.HasPrefix(modelLower, "gpt-3.5") {
-		return messageOverheadGPT35
-	}
-	return messageOverheadGPT4
-}
@@ -0,0 +1,22 @@
+package tokenizer
+
+import "github.com/mandalnilabja/goatway/internal/types"
+
+// CountCompletion counts tokens for generated output: text content plus any tool calls.
+// Used when the upstream stream does not report usage.
+func (t *TiktokenTokenizer) CountCompletion(content string, toolCalls []types.ToolCall, model string) (int, error) {
+	total := 0
+	if content != "" {
+		tokens, err := t.CountTokens(content, model)
+		if err != nil {
+			return 0, err
+		}
+		total += tokens
+	}
+
+	callTokens, err := t.countToolCalls(toolCalls, model)
+	if err != nil {
+		return 0, err
+	}
+	return total + callTokens, nil
+}
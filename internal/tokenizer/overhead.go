@@ -0,0 +1,44 @@
+package tokenizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// modelOverhead pairs a model prefix with its per-message token overhead.
+type modelOverhead struct {
+	prefix string
+	tokens int
+}
+
+// SetMessageOverheads configures the per-message token overhead by model
+// prefix (e.g. "gpt-4o" = 3). The longest matching prefix wins, ignoring
+// case; unmatched models keep the built-in overhead. Call before counting.
+func (t *TiktokenTokenizer) SetMessageOverheads(overheads map[string]int) error {
+	list := make([]modelOverhead, 0, len(overheads))
+	for prefix, tokens := range overheads {
+		prefix = strings.ToLower(strings.TrimSpace(prefix))
+		if prefix == "" || tokens < 0 {
+			return fmt.Errorf("tokenizer overhead for %q: want a model prefix and a non-negative count, got %d", prefix, tokens)
+		}
+		list = append(list, modelOverhead{prefix: prefix, tokens: tokens})
+	}
+	sort.Slice(list, func(i, j int) bool { return len(list[i].prefix) > len(list[j].prefix) })
+	t.overheads = list
+	return nil
+}
+
+// getMessageOverhead returns the per-message token overhead for a model.
+func (t *TiktokenTokenizer) getMessageOverhead(model string) int {
+	modelLower := strings.ToLower(model)
+	for _, o := range t.overheads {
+		if strings.HasPrefix(modelLower, o.prefix) {
+			return o.tokens
+		}
+	}
+	if strings.HasPrefix(modelLower, "gpt-3.5") {
+		return messageOverheadGPT35
+	}
+	return messageOverheadGPT4
+}
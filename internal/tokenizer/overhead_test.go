@@ -0,0 +1,65 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestGetMessageOverhead(t *testing.T) {
+	tok := New()
+	if err := tok.SetMessageOverheads(map[string]int{"o1": 5, "o1-mini": 7, "GPT-4o": 2}); err != nil {
+		t.Fatalf("SetMessageOverheads: %v", err)
+	}
+
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"o1-preview", 5},
+		{"o1-mini-2024", 7}, // Longest prefix wins
+		{"gpt-4o", 2},       // Case-insensitive
+		{"gpt-3.5-turbo", messageOverheadGPT35},
+		{"claude-3", messageOverheadGPT4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := tok.getMessageOverhead(tt.model); got != tt.want {
+				t.Errorf("getMessageOverhead(%q) = %d, want %d", tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMessageOverheads_Invalid(t *testing.T) {
+	for _, overheads := range []map[string]int{{"o1": -1}, {" ": 3}} {
+		if err := New().SetMessageOverheads(overheads); err == nil {
+			t.Errorf("SetMessageOverheads(%v) accepted", overheads)
+		}
+	}
+}
+
+func TestCountMessages_ConfiguredOverhead(t *testing.T) {
+	messages := []types.Message{
+		types.NewTextMessage(types.RoleSystem, "You are a helpful assistant."),
+		types.NewTextMessage(types.RoleUser, "Hello!"),
+	}
+
+	base, err := New().CountMessages(messages, "o1")
+	if err != nil {
+		t.Fatalf("CountMessages: %v", err)
+	}
+
+	tuned := New()
+	if err := tuned.SetMessageOverheads(map[string]int{"o1": messageOverheadGPT4 + 2}); err != nil {
+		t.Fatalf("SetMessageOverheads: %v", err)
+	}
+	got, err := tuned.CountMessages(messages, "o1")
+	if err != nil {
+		t.Fatalf("CountMessages: %v", err)
+	}
+	if want := base + 2*len(messages); got != want {
+		t.Errorf("CountMessages with overhead +2 = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,19 @@
+package tokenizer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Probe counts a trivial prompt to confirm the encoding data loads.
+// It runs at startup and in the readiness check.
+func Probe(t Tokenizer) error {
+	n, err := t.CountTokens("ping", "gpt-4")
+	if err != nil {
+		return fmt.Errorf("tokenizer probe: %w", err)
+	}
+	if n <= 0 {
+		return errors.New("tokenizer probe: counted no tokens")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@
 package tokenizer
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -20,6 +21,9 @@ type Tokenizer interface {
 
 	// CountRequest counts total prompt tokens for a full request.
 	CountRequest(req *types.ChatCompletionRequest) (int, error)
+
+	// CountCompletion counts tokens for generated content and tool calls.
+	CountCompletion(content string, toolCalls []types.ToolCall, model string) (int, error)
 }
 
 // Encoding names used by tiktoken.
@@ -51,6 +55,8 @@ var modelEncodings = []modelEncoding{
 type TiktokenTokenizer struct {
 	mu        sync.RWMutex
 	encodings map[string]*tiktoken.Tiktoken
+	overrides map[string]string // Lowercased model name -> encoding, checked before prefixes
+	overheads []modelOverhead   // Configured per-message overheads, longest prefix first
 }
 
 // New creates a new TiktokenTokenizer.
@@ -60,6 +66,26 @@ func New() *TiktokenTokenizer {
 	}
 }
 
+// NewWithOverrides creates a TiktokenTokenizer that pins the encoding for
+// named models. Encodings may be given as "cl100k_base"/"o200k_base" or the
+// short "cl100k"/"o200k"; model names match exactly, ignoring case.
+func NewWithOverrides(overrides map[string]string) (*TiktokenTokenizer, error) {
+	t := New()
+	t.overrides = make(map[string]string, len(overrides))
+	for model, encoding := range overrides {
+		switch strings.ToLower(strings.TrimSpace(encoding)) {
+		case EncodingCL100kBase, "cl100k":
+			encoding = EncodingCL100kBase
+		case EncodingO200kBase, "o200k":
+			encoding = EncodingO200kBase
+		default:
+			return nil, fmt.Errorf("tokenizer encoding for %q: unsupported encoding %q", model, encoding)
+		}
+		t.overrides[strings.ToLower(strings.TrimSpace(model))] = encoding
+	}
+	return t, nil
+}
+
 // getEncoding returns the tiktoken encoding for a model, with caching.
 func (t *TiktokenTokenizer) getEncoding(model string) (*tiktoken.Tiktoken, error) {
 	encodingName := t.resolveEncoding(model)
@@ -93,6 +119,11 @@ func (t *TiktokenTokenizer) getEncoding(model string) (*tiktoken.Tiktoken, error
 func (t *TiktokenTokenizer) resolveEncoding(model string) string {
 	modelLower := strings.ToLower(model)
 
+	// Operator-pinned encodings win over the built-in heuristics
+	if encoding, ok := t.overrides[modelLower]; ok {
+		return encoding
+	}
+
 	// Check for prefix matches (ordered by length, longest first)
 	for _, me := range modelEncodings {
 		if strings.HasPrefix(modelLower, me.prefix) {
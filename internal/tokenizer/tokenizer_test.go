@@ -117,6 +117,43 @@ func TestResolveEncoding(t *testing.T) {
 	}
 }
 
+func TestResolveEncoding_Overrides(t *testing.T) {
+	tok, err := NewWithOverrides(map[string]string{
+		"gpt-5":         "o200k_base",
+		"My-New-Model":  "o200k",
+		"gpt-4o-legacy": "cl100k",
+	})
+	if err != nil {
+		t.Fatalf("NewWithOverrides: %v", err)
+	}
+
+	tests := []struct {
+		model    string
+		expected string
+	}{
+		{"gpt-5", EncodingO200kBase},          // Unknown model would default to cl100k_base
+		{"my-new-model", EncodingO200kBase},   // Case-insensitive, short encoding name
+		{"gpt-4o-legacy", EncodingCL100kBase}, // Override beats the gpt-4o prefix
+		{"gpt-5-mini", EncodingCL100kBase},    // Exact match only
+		{"gpt-4o-mini", EncodingO200kBase},    // Heuristics still apply
+		{"claude-3-opus", EncodingCL100kBase}, // Default still applies
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.model, func(t *testing.T) {
+			if result := tok.resolveEncoding(tc.model); result != tc.expected {
+				t.Errorf("resolveEncoding(%q) = %q, want %q", tc.model, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNewWithOverrides_RejectsUnknownEncoding(t *testing.T) {
+	if _, err := NewWithOverrides(map[string]string{"gpt-5": "p50k_base"}); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
+
 func TestEncodingCaching(t *testing.T) {
 	tok := New()
 
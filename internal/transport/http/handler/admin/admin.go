@@ -4,25 +4,36 @@ import (
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/config"
 	"github.com/mandalnilabja/goatway/internal/provider"
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
 	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
 )
 
 // Handlers holds the dependencies for admin HTTP handlers.
 type Handlers struct {
+	Config       *config.Config
 	Storage      storage.Storage
 	StartTime    time.Time
 	APIKeyCache  *ristretto.Cache[string, *auth.CachedAPIKey]
 	CredResolver *provider.CredentialResolver
+	Health       *provider.HealthTracker
+	Router       *provider.Router // Reloaded after stored alias changes
+	StatsCache   *ristretto.Cache[string, *storage.UsageStats]
+	Cache        *ristretto.Cache[string, any] // Shared response cache, reported by CacheMetrics
+	Tokenizer    tokenizer.Tokenizer
+	Models       ModelSource // Model list behind ImportAliases (nil disables imports)
 }
 
 // New creates a new instance of admin handlers.
-func New(store storage.Storage, startTime time.Time, apiKeyCache *ristretto.Cache[string, *auth.CachedAPIKey]) *Handlers {
+func New(cfg *config.Config, store storage.Storage, startTime time.Time, apiKeyCache *ristretto.Cache[string, *auth.CachedAPIKey]) *Handlers {
 	return &Handlers{
+		Config:      cfg,
 		Storage:     store,
 		StartTime:   startTime,
 		APIKeyCache: apiKeyCache,
+		StatsCache:  newStatsCache(cfg != nil && cfg.CacheMetrics),
 	}
 }
 
@@ -31,6 +42,31 @@ func (h *Handlers) SetCredentialResolver(cr *provider.CredentialResolver) {
 	h.CredResolver = cr
 }
 
+// SetHealthTracker sets the provider health tracker reported by ProvidersStatus.
+func (h *Handlers) SetHealthTracker(t *provider.HealthTracker) {
+	h.Health = t
+}
+
+// SetRouter sets the router that serves stored aliases.
+func (h *Handlers) SetRouter(router *provider.Router) {
+	h.Router = router
+}
+
+// SetResponseCache sets the shared response cache reported by CacheMetrics.
+func (h *Handlers) SetResponseCache(cache *ristretto.Cache[string, any]) {
+	h.Cache = cache
+}
+
+// SetModelSource sets the model list fetcher used by ImportAliases.
+func (h *Handlers) SetModelSource(models ModelSource) {
+	h.Models = models
+}
+
+// SetTokenizer sets the tokenizer used by the tokenize endpoint.
+func (h *Handlers) SetTokenizer(tok tokenizer.Tokenizer) {
+	h.Tokenizer = tok
+}
+
 // InvalidateAPIKeyCache removes a cached API key entry by its prefix.
 func (h *Handlers) InvalidateAPIKeyCache(keyPrefix string) {
 	if h.APIKeyCache != nil && keyPrefix != "" {
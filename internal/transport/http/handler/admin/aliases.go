@@ -0,0 +1,139 @@
+package admin
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// AliasView is the read-only representation of a configured model route.
+type AliasView struct {
+	Slug            string   `json:"slug,omitempty"`
+	Provider        string   `json:"provider"`
+	Model           string   `json:"model,omitempty"`
+	CredentialName  string   `json:"credential_name"` // Masked
+	MaxOutputTokens int      `json:"max_output_tokens,omitempty"`
+	MaxConcurrent   int      `json:"max_concurrent,omitempty"`
+	QueueTimeout    int      `json:"queue_timeout,omitempty"` // Seconds
+	MaxCostUSD      float64  `json:"max_cost_usd,omitempty"`
+	Fallbacks       []string `json:"fallbacks,omitempty"`
+}
+
+// ListAliases handles GET /api/admin/aliases.
+// Returns configured model aliases, the aliases stored through the admin API
+// (which replace config aliases with the same slug), the default route with
+// credential names masked, and whether strict alias mode disables the default
+// pass-through.
+func (h *Handlers) ListAliases(w http.ResponseWriter, r *http.Request) {
+	var cfg config.Config
+	if h.Config != nil {
+		cfg = *h.Config
+	}
+	stored, err := h.storedAliases()
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to list stored aliases: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	shared.WriteAdminJSON(w, r, map[string]any{
+		"aliases": aliasViews(cfg.Models),
+		"stored":  aliasViews(stored),
+		"default": defaultView(cfg.Default),
+		"strict":  cfg.StrictAliases,
+	}, http.StatusOK)
+}
+
+// aliasViews converts configured aliases with credential names masked.
+func aliasViews(models []config.ModelAlias) []AliasView {
+	aliases := []AliasView{}
+	for _, a := range models {
+		aliases = append(aliases, AliasView{
+			Slug:            a.Slug,
+			Provider:        a.Provider,
+			Model:           a.Model,
+			CredentialName:  maskName(a.CredentialName),
+			MaxOutputTokens: a.MaxOutputTokens,
+			MaxConcurrent:   a.MaxConcurrent,
+			QueueTimeout:    a.QueueTimeout,
+			MaxCostUSD:      a.MaxCostUSD,
+			Fallbacks:       a.Fallbacks,
+		})
+	}
+	return aliases
+}
+
+// storedAliases returns the aliases stored through the admin API as config
+// aliases, or none when there is no storage.
+func (h *Handlers) storedAliases() ([]config.ModelAlias, error) {
+	if h.Storage == nil {
+		return nil, nil
+	}
+	stored, err := h.Storage.ListAliases()
+	if err != nil {
+		return nil, err
+	}
+	aliases := make([]config.ModelAlias, 0, len(stored))
+	for _, a := range stored {
+		aliases = append(aliases, config.ModelAlias{Slug: a.Slug, Provider: a.Provider, Model: a.Model, CredentialName: a.CredentialName})
+	}
+	return aliases, nil
+}
+
+// CredentialLimitView is a per-credential concurrency cap.
+type CredentialLimitView struct {
+	CredentialName string `json:"credential_name"` // Masked
+	MaxConcurrent  int    `json:"max_concurrent"`
+	QueueTimeout   int    `json:"queue_timeout,omitempty"` // Seconds
+}
+
+// credentialLimitViews converts credential limits, sorted by credential name,
+// with the names masked.
+func credentialLimitViews(limits map[string]config.CredentialLimit) []CredentialLimitView {
+	var views []CredentialLimitView
+	for _, name := range slices.Sorted(maps.Keys(limits)) {
+		views = append(views, CredentialLimitView{
+			CredentialName: maskName(name),
+			MaxConcurrent:  limits[name].MaxConcurrent,
+			QueueTimeout:   limits[name].QueueTimeout,
+		})
+	}
+	return views
+}
+
+// defaultView converts the default route with its credential name masked.
+func defaultView(d *config.DefaultRoute) *AliasView {
+	if d == nil {
+		return nil
+	}
+	return &AliasView{
+		Provider:       d.Provider,
+		Model:          d.Model,
+		CredentialName: maskName(d.CredentialName),
+	}
+}
+
+// endpointDefaultViews converts the per-endpoint default routes, or nil when none are set.
+func endpointDefaultViews(defaults map[string]config.DefaultRoute) map[string]*AliasView {
+	if len(defaults) == 0 {
+		return nil
+	}
+	views := make(map[string]*AliasView, len(defaults))
+	for endpoint, d := range defaults {
+		views[endpoint] = defaultView(&d)
+	}
+	return views
+}
+
+// maskName keeps a short recognizable prefix of a credential name.
+func maskName(name string) string {
+	if name == "" {
+		return ""
+	}
+	if len(name) <= 4 {
+		return "***"
+	}
+	return name[:4] + "***"
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// importProvider is the only provider whose model list can be imported.
+const importProvider = "openrouter"
+
+// ModelSource fetches the model IDs the import provider serves.
+type ModelSource func(r *http.Request) ([]string, error)
+
+// AliasSuggestion is a [[models]] entry proposed from a provider's model list.
+type AliasSuggestion struct {
+	Slug       string `json:"slug"`
+	Provider   string `json:"provider"`
+	Model      string `json:"model"`
+	Configured bool   `json:"configured,omitempty"` // Slug is already aliased in config or storage
+}
+
+// ImportAliases handles POST /api/admin/aliases/import?provider=openrouter.
+// Fetches the provider's model list and returns alias suggestions for review;
+// nothing is applied, operators copy the ones they want into config.toml.
+func (h *Handlers) ImportAliases(w http.ResponseWriter, r *http.Request) {
+	providerName := r.URL.Query().Get("provider")
+	if providerName == "" {
+		shared.WriteJSONError(w, "provider query parameter is required", http.StatusBadRequest)
+		return
+	}
+	if providerName != importProvider || h.Models == nil {
+		shared.WriteJSONError(w, "Importing aliases is only supported for provider "+importProvider, http.StatusBadRequest)
+		return
+	}
+
+	ids, err := h.Models(r)
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to fetch models: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	stored, err := h.storedAliases()
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to list stored aliases: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	configured := make(map[string]bool)
+	if h.Config != nil {
+		stored = append(stored, h.Config.Models...)
+	}
+	for _, alias := range stored {
+		configured[alias.Slug] = true
+	}
+	shared.WriteAdminJSON(w, r, map[string]any{
+		"provider":    providerName,
+		"suggestions": suggestAliases(providerName, ids, configured),
+	}, http.StatusOK)
+}
+
+// suggestAliases proposes a slug for each model ID: its name without the
+// vendor prefix ("openai/gpt-4o" -> "gpt-4o"), or the whole ID when several
+// vendors share that name. Suggestions are sorted by slug.
+func suggestAliases(providerName string, ids []string, configured map[string]bool) []AliasSuggestion {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[shortSlug(id)]++
+	}
+
+	suggestions := make([]AliasSuggestion, 0, len(ids))
+	for _, id := range ids {
+		slug := shortSlug(id)
+		if counts[slug] > 1 {
+			slug = slugify(id)
+		}
+		suggestions = append(suggestions, AliasSuggestion{
+			Slug:       slug,
+			Provider:   providerName,
+			Model:      id,
+			Configured: configured[slug],
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Slug < suggestions[j].Slug })
+	return suggestions
+}
+
+// shortSlug returns the slug of a model ID without its vendor prefix.
+func shortSlug(id string) string {
+	return slugify(id[strings.LastIndex(id, "/")+1:])
+}
+
+// slugify lowercases s and turns path and variant separators ("openai/",
+// ":free") into dashes.
+func slugify(s string) string {
+	return strings.ToLower(strings.NewReplacer("/", "-", ":", "-").Replace(s))
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestImportAliases(t *testing.T) {
+	ids := []string{"openai/gpt-4o", "anthropic/claude-3.5-sonnet", "meta-llama/llama-3.1-8b-instruct:free", "openai/o1", "azure/o1"}
+	listModels := func(*http.Request) ([]string, error) { return ids, nil }
+
+	tests := []struct {
+		name       string
+		query      string
+		source     ModelSource
+		wantStatus int
+		want       []AliasSuggestion
+	}{
+		{"suggests short slugs", "?provider=openrouter", listModels, http.StatusOK, []AliasSuggestion{
+			{Slug: "azure-o1", Provider: "openrouter", Model: "azure/o1"},
+			{Slug: "claude-3.5-sonnet", Provider: "openrouter", Model: "anthropic/claude-3.5-sonnet"},
+			{Slug: "gpt-4o", Provider: "openrouter", Model: "openai/gpt-4o", Configured: true},
+			{Slug: "llama-3.1-8b-instruct-free", Provider: "openrouter", Model: "meta-llama/llama-3.1-8b-instruct:free"},
+			{Slug: "openai-o1", Provider: "openrouter", Model: "openai/o1"},
+		}},
+		{"missing provider", "", listModels, http.StatusBadRequest, nil},
+		{"unsupported provider", "?provider=bedrock", listModels, http.StatusBadRequest, nil},
+		{"no model source", "?provider=openrouter", nil, http.StatusBadRequest, nil},
+		{"fetch failure", "?provider=openrouter", func(*http.Request) ([]string, error) {
+			return nil, errors.New("upstream down")
+		}, http.StatusBadGateway, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Models: []config.ModelAlias{{Slug: "gpt-4o", Provider: "openrouter", Model: "openai/gpt-4o"}}}
+			h := &Handlers{Config: cfg, Models: tt.source}
+
+			rec := httptest.NewRecorder()
+			h.ImportAliases(rec, httptest.NewRequest(http.MethodPost, "/api/admin/aliases/import"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Suggestions []AliasSuggestion `json:"suggestions"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(body.Suggestions) != len(tt.want) {
+				t.Fatalf("got %d suggestions, want %d: %+v", len(body.Suggestions), len(tt.want), body.Suggestions)
+			}
+			for i, want := range tt.want {
+				if body.Suggestions[i] != want {
+					t.Errorf("suggestion %d = %+v, want %+v", i, body.Suggestions[i], want)
+				}
+			}
+			if len(h.Config.Models) != 1 {
+				t.Errorf("import changed configured aliases: %+v", h.Config.Models)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// AliasRequest is the request body for creating or updating a stored alias.
+// The slug comes from the path on update; other fields left empty keep their
+// stored value.
+type AliasRequest struct {
+	Slug           string `json:"slug,omitempty"`
+	Provider       string `json:"provider"`
+	Model          string `json:"model"`
+	CredentialName string `json:"credential_name"`
+}
+
+// CreateAlias handles POST /api/admin/aliases.
+// The alias is stored and routed immediately, replacing any config alias
+// with the same slug.
+func (h *Handlers) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	var req AliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	alias := &storage.ModelAlias{Slug: req.Slug, Provider: req.Provider, Model: req.Model, CredentialName: req.CredentialName}
+	if !h.validAlias(w, alias) {
+		return
+	}
+	err := h.Storage.CreateAlias(alias)
+	if errors.Is(err, storage.ErrDuplicateKey) {
+		shared.WriteJSONError(w, "Alias already exists: "+alias.Slug, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to create alias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.reloadRoutes(w) {
+		shared.WriteAdminJSON(w, r, alias, http.StatusCreated)
+	}
+}
+
+// UpdateAlias handles PUT /api/admin/aliases/{slug}.
+func (h *Handlers) UpdateAlias(w http.ResponseWriter, r *http.Request) {
+	alias, err := h.Storage.GetAlias(r.PathValue("slug"))
+	if errors.Is(err, storage.ErrNotFound) {
+		shared.WriteJSONError(w, "Alias not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get alias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var req AliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Provider != "" {
+		alias.Provider = req.Provider
+	}
+	if req.Model != "" {
+		alias.Model = req.Model
+	}
+	if req.CredentialName != "" {
+		alias.CredentialName = req.CredentialName
+	}
+	if !h.validAlias(w, alias) {
+		return
+	}
+
+	if err := h.Storage.UpdateAlias(alias); err != nil {
+		shared.WriteJSONError(w, "Failed to update alias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.reloadRoutes(w) {
+		shared.WriteAdminJSON(w, r, alias, http.StatusOK)
+	}
+}
+
+// DeleteAlias handles DELETE /api/admin/aliases/{slug}.
+// A config alias with the same slug takes effect again.
+func (h *Handlers) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	err := h.Storage.DeleteAlias(r.PathValue("slug"))
+	if errors.Is(err, storage.ErrNotFound) {
+		shared.WriteJSONError(w, "Alias not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to delete alias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.reloadRoutes(w) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validAlias writes a 400 and returns false when the alias is incomplete or
+// names a provider the router does not serve.
+func (h *Handlers) validAlias(w http.ResponseWriter, alias *storage.ModelAlias) bool {
+	if !alias.Valid() {
+		shared.WriteJSONError(w, "slug, provider, model, and credential_name are required", http.StatusBadRequest)
+		return false
+	}
+	if h.Router != nil && !h.Router.HasProvider(alias.Provider) {
+		shared.WriteJSONError(w, "Unknown provider: "+alias.Provider, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// reloadRoutes applies stored alias changes to the router, writing a 500
+// and returning false when the routes could not be rebuilt.
+func (h *Handlers) reloadRoutes(w http.ResponseWriter) bool {
+	if h.Router == nil {
+		return true
+	}
+	if err := h.Router.ReloadAliases(); err != nil {
+		shared.WriteJSONError(w, "Alias saved but routes not reloaded: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestStoredAliases(t *testing.T) {
+	cfg := &config.Config{Providers: []config.ProviderDef{{Type: "openrouter"}}}
+	providers, err := provider.NewProviders(cfg)
+	if err != nil {
+		t.Fatalf("NewProviders: %v", err)
+	}
+	store := storage.NewMemoryStorage()
+	h := &Handlers{Config: cfg, Storage: store, Router: provider.NewRouter(providers, cfg, store)}
+
+	steps := []struct {
+		name       string
+		method     string
+		slug       string // Path value for update and delete
+		body       string
+		wantStatus int
+		wantModel  string // Stored model for "gpt4" afterwards ("" = not stored)
+	}{
+		{"create", http.MethodPost, "", `{"slug":"gpt4","provider":"openrouter","model":"openai/gpt-4o","credential_name":"main"}`, http.StatusCreated, "openai/gpt-4o"},
+		{"duplicate slug", http.MethodPost, "", `{"slug":"gpt4","provider":"openrouter","model":"openai/gpt-4","credential_name":"main"}`, http.StatusConflict, "openai/gpt-4o"},
+		{"missing fields", http.MethodPost, "", `{"slug":"other","provider":"openrouter"}`, http.StatusBadRequest, "openai/gpt-4o"},
+		{"unknown provider", http.MethodPost, "", `{"slug":"other","provider":"nowhere","model":"m","credential_name":"main"}`, http.StatusBadRequest, "openai/gpt-4o"},
+		{"update model", http.MethodPut, "gpt4", `{"model":"openai/gpt-4o-mini"}`, http.StatusOK, "openai/gpt-4o-mini"},
+		{"update unknown provider", http.MethodPut, "gpt4", `{"provider":"nowhere"}`, http.StatusBadRequest, "openai/gpt-4o-mini"},
+		{"update missing", http.MethodPut, "other", `{"model":"m"}`, http.StatusNotFound, "openai/gpt-4o-mini"},
+		{"delete", http.MethodDelete, "gpt4", "", http.StatusNoContent, ""},
+		{"delete missing", http.MethodDelete, "gpt4", "", http.StatusNotFound, ""},
+	}
+
+	handlers := map[string]http.HandlerFunc{
+		http.MethodPost:   h.CreateAlias,
+		http.MethodPut:    h.UpdateAlias,
+		http.MethodDelete: h.DeleteAlias,
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, "/api/admin/aliases/"+step.slug, strings.NewReader(step.body))
+		req.SetPathValue("slug", step.slug)
+		rec := httptest.NewRecorder()
+		handlers[step.method](rec, req)
+
+		if rec.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d (body %q)", step.name, rec.Code, step.wantStatus, rec.Body.String())
+		}
+		alias, err := store.GetAlias("gpt4")
+		if step.wantModel == "" {
+			if err == nil {
+				t.Errorf("%s: alias still stored: %+v", step.name, alias)
+			}
+			continue
+		}
+		if err != nil || alias.Model != step.wantModel {
+			t.Errorf("%s: stored alias = %+v, %v; want model %q", step.name, alias, err, step.wantModel)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestListAliases(t *testing.T) {
+	cfg := &config.Config{
+		Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "my-openrouter-key"},
+		Models: []config.ModelAlias{
+			{Slug: "gpt4", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "my-openrouter-key"},
+			{Slug: "claude", Provider: "bedrock", Model: "anthropic.claude-3-haiku", CredentialName: "aws"},
+		},
+	}
+	h := &Handlers{Config: cfg}
+
+	rec := httptest.NewRecorder()
+	h.ListAliases(rec, httptest.NewRequest(http.MethodGet, "/api/admin/aliases", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var body struct {
+		Aliases []AliasView `json:"aliases"`
+		Default *AliasView  `json:"default"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(body.Aliases) != 2 {
+		t.Fatalf("expected 2 aliases, got %d", len(body.Aliases))
+	}
+	if body.Aliases[0].Slug != "gpt4" || body.Aliases[0].Model != "openai/gpt-4o" {
+		t.Errorf("unexpected alias: %+v", body.Aliases[0])
+	}
+	if body.Aliases[0].CredentialName != "my-o***" || body.Aliases[1].CredentialName != "***" {
+		t.Errorf("credential names not masked: %+v", body.Aliases)
+	}
+	if body.Default == nil || body.Default.Provider != "openrouter" {
+		t.Errorf("expected default route, got %+v", body.Default)
+	}
+}
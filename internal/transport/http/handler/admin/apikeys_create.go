@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
@@ -37,6 +38,11 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !h.knownTenant(req.Tenant) {
+		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("unknown tenant: "+req.Tenant))
+		return
+	}
+
 	// Generate API key
 	plainKey, err := storage.GenerateAPIKey()
 	if err != nil {
@@ -51,10 +57,17 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Calculate expiry
+	// Calculate expiry; an omitted expires_in falls back to the default TTL
+	var ttl time.Duration
+	switch {
+	case req.ExpiresIn == nil:
+		ttl = h.defaultKeyTTL()
+	case !req.ExpiresIn.Never && req.ExpiresIn.Seconds > 0:
+		ttl = time.Duration(req.ExpiresIn.Seconds) * time.Second
+	}
 	var expiresAt *time.Time
-	if req.ExpiresIn != nil && *req.ExpiresIn > 0 {
-		t := time.Now().Add(time.Duration(*req.ExpiresIn) * time.Second)
+	if ttl > 0 {
+		t := time.Now().Add(ttl)
 		expiresAt = &t
 	}
 
@@ -66,6 +79,8 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		KeyPrefix: storage.ExtractKeyPrefix(plainKey),
 		Scopes:    req.Scopes,
 		RateLimit: req.RateLimit,
+		UserLimit: req.UserLimit,
+		Tenant:    req.Tenant,
 		IsActive:  true,
 		ExpiresAt: expiresAt,
 	}
@@ -83,12 +98,33 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		KeyPrefix: apiKey.KeyPrefix,
 		Scopes:    apiKey.Scopes,
 		RateLimit: apiKey.RateLimit,
+		UserLimit: apiKey.UserLimit,
+		Tenant:    apiKey.Tenant,
 		IsActive:  apiKey.IsActive,
 		CreatedAt: apiKey.CreatedAt,
 		ExpiresAt: apiKey.ExpiresAt,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(resp)
+	shared.WriteAdminJSON(w, r, resp, http.StatusCreated)
+}
+
+// knownTenant reports whether a key may be bound to tenant: either no tenant
+// or one configured under [tenants].
+func (h *Handlers) knownTenant(tenant string) bool {
+	if tenant == "" {
+		return true
+	}
+	if h.Config == nil {
+		return false
+	}
+	_, ok := h.Config.Tenants[tenant]
+	return ok
+}
+
+// defaultKeyTTL returns the lifetime of keys created without expires_in (0 = never).
+func (h *Handlers) defaultKeyTTL() time.Duration {
+	if h.Config == nil {
+		return 0
+	}
+	return h.Config.APIKeyDefaultTTL
 }
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestCreateAPIKey_Expiry(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		defaultTTL time.Duration
+		expiresIn  string // Raw JSON value; empty omits the field
+		wantStatus int
+		wantTTL    time.Duration // 0 = never expires
+	}{
+		{"no default never expires", 0, "", http.StatusCreated, 0},
+		{"default applied when omitted", 30 * day, "", http.StatusCreated, 30 * day},
+		{"explicit seconds override default", 30 * day, "3600", http.StatusCreated, time.Hour},
+		{"explicit never overrides default", 30 * day, `"never"`, http.StatusCreated, 0},
+		{"explicit zero never expires", 30 * day, "0", http.StatusCreated, 0},
+		{"unknown word rejected", 30 * day, `"forever"`, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handlers{Config: &config.Config{AuthConfig: config.AuthConfig{APIKeyDefaultTTL: tt.defaultTTL}}, Storage: storage.NewMemoryStorage()}
+			body := `{"name":"k"`
+			if tt.expiresIn != "" {
+				body += `,"expires_in":` + tt.expiresIn
+			}
+			body += "}"
+
+			rec := httptest.NewRecorder()
+			h.CreateAPIKey(rec, httptest.NewRequest(http.MethodPost, "/api/admin/apikeys", strings.NewReader(body)))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var resp CreateAPIKeyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if tt.wantTTL == 0 {
+				if resp.ExpiresAt != nil {
+					t.Errorf("expires_at = %v, want none", resp.ExpiresAt)
+				}
+				return
+			}
+			if resp.ExpiresAt == nil {
+				t.Fatal("expires_at missing")
+			}
+			if got := time.Until(*resp.ExpiresAt); got < tt.wantTTL-time.Minute || got > tt.wantTTL {
+				t.Errorf("expires in %v, want about %v", got, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKey_Tenant(t *testing.T) {
+	cfg := &config.Config{Tenants: map[string]config.TenantRoutes{"acme": {}}}
+	tests := []struct {
+		tenant     string
+		wantStatus int
+	}{
+		{"", http.StatusCreated},
+		{"acme", http.StatusCreated},
+		{"globex", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		h := &Handlers{Config: cfg, Storage: storage.NewMemoryStorage()}
+		body := `{"name":"k","tenant":"` + tt.tenant + `"}`
+		rec := httptest.NewRecorder()
+		h.CreateAPIKey(rec, httptest.NewRequest(http.MethodPost, "/api/admin/apikeys", strings.NewReader(body)))
+		if rec.Code != tt.wantStatus {
+			t.Errorf("tenant %q: status = %d, want %d: %s", tt.tenant, rec.Code, tt.wantStatus, rec.Body.String())
+			continue
+		}
+		if tt.wantStatus != http.StatusCreated {
+			continue
+		}
+		var resp CreateAPIKeyResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Tenant != tt.tenant {
+			t.Errorf("tenant %q: response tenant = %q, %v", tt.tenant, resp.Tenant, err)
+		}
+	}
+}
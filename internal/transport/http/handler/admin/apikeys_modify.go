@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
@@ -49,6 +50,16 @@ func (h *Handlers) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
 	if updates.RateLimit != nil {
 		key.RateLimit = *updates.RateLimit
 	}
+	if updates.UserLimit != nil {
+		key.UserLimit = *updates.UserLimit
+	}
+	if updates.Tenant != nil {
+		if !h.knownTenant(*updates.Tenant) {
+			types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("unknown tenant: "+*updates.Tenant))
+			return
+		}
+		key.Tenant = *updates.Tenant
+	}
 	if updates.IsActive != nil {
 		key.IsActive = *updates.IsActive
 	}
@@ -61,8 +72,7 @@ func (h *Handlers) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
 	// Invalidate cache for immediate effect
 	h.InvalidateAPIKeyCache(key.KeyPrefix)
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(key.ToPreview())
+	shared.WriteAdminJSON(w, r, key.ToPreview(), http.StatusOK)
 }
 
 // DeleteAPIKey deletes an API key (DELETE /api/admin/apikeys/{id}).
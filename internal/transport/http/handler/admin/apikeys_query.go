@@ -1,10 +1,10 @@
 package admin
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
@@ -22,10 +22,9 @@ func (h *Handlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
 		previews[i] = k.ToPreview()
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"data": previews,
-	})
+	}, http.StatusOK)
 }
 
 // GetAPIKeyByID returns a specific API key (GET /api/admin/apikeys/{id}).
@@ -46,6 +45,5 @@ func (h *Handlers) GetAPIKeyByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(key.ToPreview())
+	shared.WriteAdminJSON(w, r, key.ToPreview(), http.StatusOK)
 }
@@ -1,10 +1,10 @@
 package admin
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
@@ -57,11 +57,11 @@ func (h *Handlers) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
 		KeyPrefix: key.KeyPrefix,
 		Scopes:    key.Scopes,
 		RateLimit: key.RateLimit,
+		UserLimit: key.UserLimit,
 		IsActive:  key.IsActive,
 		CreatedAt: key.CreatedAt,
 		ExpiresAt: key.ExpiresAt,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	shared.WriteAdminJSON(w, r, resp, http.StatusOK)
 }
@@ -1,13 +1,39 @@
 package admin
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // CreateAPIKeyRequest is the request body for creating an API key.
 type CreateAPIKeyRequest struct {
-	Name      string   `json:"name"`
-	Scopes    []string `json:"scopes"`     // ["proxy", "admin"]
-	RateLimit int      `json:"rate_limit"` // Requests per minute (0 = unlimited)
-	ExpiresIn *int     `json:"expires_in"` // Seconds until expiry (optional)
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`          // ["proxy", "admin"]
+	RateLimit int        `json:"rate_limit"`      // Requests per minute (0 = unlimited)
+	UserLimit int        `json:"user_rate_limit"` // Requests per minute per end user (0 = unlimited)
+	Tenant    string     `json:"tenant"`          // Configured tenant the key is bound to ("" = none)
+	ExpiresIn *KeyExpiry `json:"expires_in"`      // Omitted uses the configured default TTL
+}
+
+// KeyExpiry is a key lifetime in seconds, or the JSON string "never".
+// Zero seconds also means the key never expires.
+type KeyExpiry struct {
+	Seconds int
+	Never   bool
+}
+
+// UnmarshalJSON accepts a number of seconds or "never".
+func (e *KeyExpiry) UnmarshalJSON(data []byte) error {
+	var word string
+	if json.Unmarshal(data, &word) == nil {
+		if word != "never" {
+			return errors.New(`expires_in must be a number of seconds or "never"`)
+		}
+		e.Never = true
+		return nil
+	}
+	return json.Unmarshal(data, &e.Seconds)
 }
 
 // CreateAPIKeyResponse includes the plaintext key (shown only once).
@@ -18,6 +44,8 @@ type CreateAPIKeyResponse struct {
 	KeyPrefix string     `json:"key_prefix"`
 	Scopes    []string   `json:"scopes"`
 	RateLimit int        `json:"rate_limit"`
+	UserLimit int        `json:"user_rate_limit"`
+	Tenant    string     `json:"tenant,omitempty"`
 	IsActive  bool       `json:"is_active"`
 	CreatedAt time.Time  `json:"created_at"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
@@ -28,5 +56,7 @@ type UpdateAPIKeyRequest struct {
 	Name      *string  `json:"name"`
 	Scopes    []string `json:"scopes"`
 	RateLimit *int     `json:"rate_limit"`
+	UserLimit *int     `json:"user_rate_limit"`
+	Tenant    *string  `json:"tenant"`
 	IsActive  *bool    `json:"is_active"`
 }
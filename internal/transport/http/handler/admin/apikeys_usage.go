@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// apiKeyUsage is the usage summary of one client API key.
+// Cost is estimated from the configured [pricing]; models without a price
+// are listed in UnpricedModels and contribute nothing to CostUSD.
+type apiKeyUsage struct {
+	APIKeyID string `json:"api_key_id"`
+	*storage.UsageStats
+	CostUSD        float64            `json:"cost_usd"`
+	ModelCosts     map[string]float64 `json:"model_costs,omitempty"`
+	UnpricedModels []string           `json:"unpriced_models,omitempty"`
+}
+
+// GetAPIKeyUsage handles GET /api/admin/apikeys/{id}/usage.
+// Accepts the same model, start_date and end_date filters as /api/admin/usage.
+func (h *Handlers) GetAPIKeyUsage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := h.Storage.GetAPIKey(id); err != nil {
+		if err == storage.ErrNotFound {
+			types.WriteError(w, http.StatusNotFound, types.ErrNotFound("key not found"))
+			return
+		}
+		types.WriteError(w, http.StatusInternalServerError, types.ErrServer("failed to get key"))
+		return
+	}
+
+	filter := parseStatsFilter(r)
+	filter.APIKeyID = id
+	stats, err := h.Storage.GetAPIKeyUsage(filter)
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get API key usage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	usage := &apiKeyUsage{APIKeyID: id, UsageStats: stats, ModelCosts: make(map[string]float64)}
+	for model, ms := range stats.ModelBreakdown {
+		price, ok := h.modelPrice(model)
+		if !ok {
+			usage.UnpricedModels = append(usage.UnpricedModels, model)
+			continue
+		}
+		cost := price.Cost(ms.PromptTokens, ms.CompletionTokens)
+		usage.ModelCosts[model] = cost
+		usage.CostUSD += cost
+	}
+	slices.Sort(usage.UnpricedModels)
+
+	shared.WriteAdminJSON(w, r, usage, http.StatusOK)
+}
+
+// modelPrice returns the configured price of a logged (upstream) model. Like
+// the cost guard it prefers the price of an alias slug routing to the model,
+// then the model's own price (see config.LookupPrice).
+func (h *Handlers) modelPrice(model string) (config.Price, bool) {
+	if h.Config == nil {
+		return config.Price{}, false
+	}
+	slug := ""
+	for _, alias := range h.routedAliases() {
+		if _, priced := h.Config.Pricing[alias.Slug]; priced && alias.Model == model {
+			slug = alias.Slug
+			break
+		}
+	}
+	return config.LookupPrice(h.Config.Pricing, slug, model)
+}
+
+// routedAliases returns the aliases the router serves, stored and tenant
+// aliases included, or the config aliases when no router is set.
+func (h *Handlers) routedAliases() []config.ModelAlias {
+	if h.Router != nil {
+		return h.Router.Aliases()
+	}
+	if h.Config == nil {
+		return nil
+	}
+	aliases := slices.Clone(h.Config.Models)
+	for _, name := range slices.Sorted(maps.Keys(h.Config.Tenants)) {
+		aliases = append(aliases, h.Config.Tenants[name].Models...)
+	}
+	return aliases
+}
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestGetAPIKeyUsage(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	key := &storage.ClientAPIKey{Name: "ci", KeyHash: "h", KeyPrefix: "gw_abc", IsActive: true}
+	if err := store.CreateAPIKey(key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	for _, l := range []*storage.RequestLog{
+		{APIKeyID: key.ID, Model: "openai/gpt-4o", PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500, StatusCode: 200},
+		{APIKeyID: key.ID, Model: "openai/gpt-4o", PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500, StatusCode: 200},
+		{APIKeyID: key.ID, Model: "meta/llama", PromptTokens: 10, TotalTokens: 10, StatusCode: 502},
+		{APIKeyID: "other", Model: "openai/gpt-4o", PromptTokens: 9000, TotalTokens: 9000, StatusCode: 200},
+	} {
+		l.RequestID, l.Provider = "r", "openrouter"
+		_ = store.LogRequest(l)
+	}
+	// gpt-4o is priced by its alias slug: $2.50 in, $10 out per million tokens
+	cfg := &config.Config{
+		Models:  []config.ModelAlias{{Slug: "gpt4", Model: "openai/gpt-4o"}},
+		Pricing: map[string]config.Price{"gpt4": {Input: 2.5, Output: 10}},
+	}
+	h := &Handlers{Config: cfg, Storage: store}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"known key", key.ID, http.StatusOK},
+		{"unknown key", "missing", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/apikeys/"+tt.id+"/usage", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+			h.GetAPIKeyUsage(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got apiKeyUsage
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.TotalRequests != 3 || got.TotalTokens != 3010 || got.ErrorCount != 1 {
+				t.Errorf("totals = %d requests, %d tokens, %d errors; want 3, 3010, 1",
+					got.TotalRequests, got.TotalTokens, got.ErrorCount)
+			}
+			if ms := got.ModelBreakdown["openai/gpt-4o"]; ms == nil || ms.RequestCount != 2 {
+				t.Errorf("gpt-4o breakdown = %+v, want 2 requests", ms)
+			}
+			// 2000 prompt * 2.5 + 1000 completion * 10, per million
+			if want := 0.015; math.Abs(got.CostUSD-want) > 1e-9 {
+				t.Errorf("cost_usd = %v, want %v", got.CostUSD, want)
+			}
+			if len(got.UnpricedModels) != 1 || got.UnpricedModels[0] != "meta/llama" {
+				t.Errorf("unpriced_models = %v, want [meta/llama]", got.UnpricedModels)
+			}
+		})
+	}
+}
+
+func TestModelPrice_SlugBeforeModel(t *testing.T) {
+	h := &Handlers{Config: &config.Config{
+		Models: []config.ModelAlias{{Slug: "gpt4", Model: "openai/gpt-4o"}},
+		Pricing: map[string]config.Price{
+			"gpt4":          {Input: 1},
+			"openai/gpt-4o": {Input: 2},
+			"meta/llama":    {Input: 3},
+		},
+	}}
+	for model, want := range map[string]float64{"openai/gpt-4o": 1, "meta/llama": 3} {
+		if price, ok := h.modelPrice(model); !ok || price.Input != want {
+			t.Errorf("modelPrice(%q) = %v, %v; want input %v", model, price, ok, want)
+		}
+	}
+}
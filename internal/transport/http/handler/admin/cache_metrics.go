@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// CacheStats is a point-in-time view of one cache's ristretto counters.
+// Enabled is false when the cache is absent or was created without metrics.
+type CacheStats struct {
+	Enabled      bool    `json:"enabled"`
+	Hits         uint64  `json:"hits"`
+	Misses       uint64  `json:"misses"`
+	HitRatio     float64 `json:"hit_ratio"`
+	KeysAdded    uint64  `json:"keys_added"`
+	KeysEvicted  uint64  `json:"keys_evicted"`
+	SetsRejected uint64  `json:"sets_rejected"`
+	CostAdded    uint64  `json:"cost_added"`
+	CostEvicted  uint64  `json:"cost_evicted"`
+}
+
+// CacheMetrics handles GET /api/admin/cache/metrics.
+// Reports the response, API key and usage stats caches.
+func (h *Handlers) CacheMetrics(w http.ResponseWriter, r *http.Request) {
+	shared.WriteAdminJSON(w, r, map[string]CacheStats{
+		"response":    cacheStats(h.Cache),
+		"api_keys":    cacheStats(h.APIKeyCache),
+		"usage_stats": cacheStats(h.StatsCache),
+	}, http.StatusOK)
+}
+
+// cacheStats snapshots a cache's metrics; ristretto counters are atomic.
+func cacheStats[K ristretto.Key, V any](cache *ristretto.Cache[K, V]) CacheStats {
+	if cache == nil || cache.Metrics == nil {
+		return CacheStats{}
+	}
+	m := cache.Metrics
+	return CacheStats{
+		Enabled:      true,
+		Hits:         m.Hits(),
+		Misses:       m.Misses(),
+		HitRatio:     m.Ratio(),
+		KeysAdded:    m.KeysAdded(),
+		KeysEvicted:  m.KeysEvicted(),
+		SetsRejected: m.SetsRejected(),
+		CostAdded:    m.CostAdded(),
+		CostEvicted:  m.CostEvicted(),
+	}
+}
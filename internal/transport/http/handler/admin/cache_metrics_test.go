@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+)
+
+func newTestCache[V any](t *testing.T, metrics bool) *ristretto.Cache[string, V] {
+	t.Helper()
+	cache, err := ristretto.NewCache(&ristretto.Config[string, V]{
+		NumCounters: 1e3,
+		MaxCost:     1e3,
+		BufferItems: 64,
+		Metrics:     metrics,
+	})
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	t.Cleanup(cache.Close)
+	return cache
+}
+
+func TestCacheMetrics(t *testing.T) {
+	response := newTestCache[any](t, true)
+	apiKeys := newTestCache[*auth.CachedAPIKey](t, true)
+	h := &Handlers{Cache: response, APIKeyCache: apiKeys, StatsCache: newStatsCache(false)}
+
+	// Response cache: one miss, then two hits after the set lands
+	response.Get("models")
+	response.Set("models", "list", 1)
+	response.Wait()
+	response.Get("models")
+	response.Get("models")
+
+	// API key cache: two misses only
+	apiKeys.Get("apikey:gw_a")
+	apiKeys.Get("apikey:gw_b")
+
+	rec := httptest.NewRecorder()
+	h.CacheMetrics(rec, httptest.NewRequest(http.MethodGet, "/api/admin/cache/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var got map[string]CacheStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	tests := []struct {
+		cache        string
+		want         CacheStats
+		wantKeysSeen bool
+	}{
+		{"response", CacheStats{Enabled: true, Hits: 2, Misses: 1, HitRatio: 2.0 / 3}, true},
+		{"api_keys", CacheStats{Enabled: true, Hits: 0, Misses: 2, HitRatio: 0}, false},
+		{"usage_stats", CacheStats{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cache, func(t *testing.T) {
+			s, ok := got[tt.cache]
+			if !ok {
+				t.Fatalf("cache %q missing from %v", tt.cache, got)
+			}
+			if s.Enabled != tt.want.Enabled || s.Hits != tt.want.Hits || s.Misses != tt.want.Misses || s.HitRatio != tt.want.HitRatio {
+				t.Errorf("stats = %+v, want %+v", s, tt.want)
+			}
+			if (s.KeysAdded > 0) != tt.wantKeysSeen {
+				t.Errorf("keys_added = %d, want added = %v", s.KeysAdded, tt.wantKeysSeen)
+			}
+		})
+	}
+}
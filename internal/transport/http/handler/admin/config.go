@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// GetConfig handles GET /api/admin/config.
+func (h *Handlers) GetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := h.Config
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	view := ConfigView{
+		ServerPort:          cfg.ServerPort,
+		EnableWebUI:         cfg.EnableWebUI,
+		WebSecurityHeaders:  cfg.WebSecurityHeaders,
+		WebCSP:              cfg.WebCSP,
+		WebFrameOptions:     cfg.WebFrameOptions,
+		RequireClientAuth:   cfg.RequireClientAuth,
+		StrictAliases:       cfg.StrictAliases,
+		ModelNotFoundHints:  cfg.ModelNotFoundHints,
+		ClampSamplingParams: cfg.ClampSamplingParams,
+		MaxTokensPolicy:     cfg.MaxTokensPolicy,
+		DefaultChatModel:    cfg.DefaultChatModel,
+		StreamIdleTimeout:   int(cfg.StreamIdleTimeout.Seconds()),
+		FirstByteTimeout:    int(cfg.FirstByteTimeout.Seconds()),
+		MaxRequestTimeout:   int(cfg.MaxRequestTimeout.Seconds()),
+		ErrorAlertPercent:   cfg.ErrorAlertPercent,
+		ErrorAlertWindow:    int(cfg.ErrorAlertWindow.Seconds()),
+		NormalizeSSE:        cfg.NormalizeSSE,
+		StreamRequestID:     cfg.StreamRequestID,
+		RewriteModel:        cfg.RewriteResponseModel,
+		CacheMetrics:        cfg.CacheMetrics,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxConnsPerHost:     cfg.MaxConnsPerHost,
+		IdleConnTimeout:     int(cfg.IdleConnTimeout.Seconds()),
+		MaxRequestBodyMB:    cfg.MaxRequestBodyBytes >> 20,
+		ResponseParseMB:     cfg.ResponseParseLimit >> 20,
+		ModelsFetchTimeout:  int(cfg.ModelsFetchTimeout.Seconds()),
+		ModelsResponseMB:    cfg.ModelsResponseLimit >> 20,
+		TokenCountWorkers:   cfg.TokenCountWorkers,
+		APIKeyPrefix:        cfg.APIKeyPrefix,
+		APIKeyLength:        cfg.APIKeyLength,
+		APIKeyExpiryGrace:   int(cfg.APIKeyExpiryGrace.Minutes()),
+		APIKeyDefaultTTL:    int(cfg.APIKeyDefaultTTL.Hours() / 24),
+		RateLimitBackend:    cfg.RateLimitBackend,
+		GlobalRateLimit:     cfg.GlobalRateLimit,
+		StorageBackend:      cfg.StorageBackend,
+		UsageFlushInterval:  int(cfg.UsageFlushInterval.Seconds()),
+		RedisURL:            redactURL(cfg.RedisURL),
+		AdminCORSOrigins:    cfg.AdminCORSOrigins,
+		DisabledEndpoints:   cfg.DisabledEndpoints,
+		StripHeaders:        cfg.StripHeaders,
+		UpstreamHosts:       cfg.UpstreamHosts,
+		RequestIDHeader:     cfg.RequestIDHeader,
+		RequestIDFormat:     cfg.RequestIDFormat,
+		StreamErrorFormat:   cfg.StreamErrorFormat,
+		LogOmitFields:       cfg.LogOmitFields,
+		LogHashFields:       cfg.LogHashFields,
+		LogSampleRate:       cfg.LogSampleRate,
+		FlagAnomalies:       cfg.FlagTokenAnomalies,
+		LogRequestBodies:    cfg.LogRequestBodies,
+		LogBodyCompression:  cfg.LogBodyCompression,
+		LogBodyMaxKB:        cfg.LogBodyMaxBytes >> 10,
+		TokenizerEncodings:  cfg.TokenizerEncodings,
+		TokenizerOverheads:  cfg.TokenizerOverheads,
+		Pricing:             cfg.Pricing,
+		Providers:           []ProviderView{},
+		Aliases:             aliasViews(cfg.Models),
+		Default:             defaultView(cfg.Default),
+		EndpointDefaults:    endpointDefaultViews(cfg.EndpointDefaults),
+		Tenants:             tenantViews(cfg.Tenants),
+	}
+	for _, p := range cfg.Providers {
+		view.Providers = append(view.Providers, ProviderView{
+			Name:             p.Name,
+			Type:             p.Type,
+			BaseURL:          redactURL(p.BaseURL),
+			StripModelPrefix: p.StripModelPrefix,
+			AddModelPrefix:   p.AddModelPrefix,
+		})
+	}
+	if cfg.Shadow != nil {
+		view.ShadowModel = cfg.Shadow.Model
+	}
+	view.CredentialLimits = credentialLimitViews(cfg.CredentialLimits)
+
+	shared.WriteAdminJSON(w, r, view, http.StatusOK)
+}
+
+// redactURL hides the password of a URL such as redis://:password@host:6379.
+// Unparseable values are withheld entirely since they may embed a secret.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "***"
+	}
+	return u.Redacted()
+}
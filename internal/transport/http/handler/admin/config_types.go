@@ -0,0 +1,80 @@
+package admin
+
+import "github.com/mandalnilabja/goatway/internal/config"
+
+// ConfigView is the effective configuration after merging flags, env and
+// config.toml. Fields are copied explicitly so new secrets are never exposed
+// by default; credential names are masked and URL passwords redacted.
+type ConfigView struct {
+	ServerPort          string                  `json:"server_port"`
+	EnableWebUI         bool                    `json:"enable_web_ui"`
+	WebSecurityHeaders  bool                    `json:"web_security_headers"`
+	WebCSP              string                  `json:"web_csp"`
+	WebFrameOptions     string                  `json:"web_frame_options"`
+	RequireClientAuth   bool                    `json:"require_client_auth"`
+	StrictAliases       bool                    `json:"strict_aliases"`
+	ModelNotFoundHints  bool                    `json:"model_not_found_hints"`
+	ClampSamplingParams bool                    `json:"clamp_sampling_params"`
+	MaxTokensPolicy     string                  `json:"max_tokens_policy"`
+	DefaultChatModel    string                  `json:"default_chat_model,omitempty"`
+	StreamIdleTimeout   int                     `json:"stream_idle_timeout"` // Seconds
+	FirstByteTimeout    int                     `json:"first_byte_timeout"`  // Seconds
+	MaxRequestTimeout   int                     `json:"max_request_timeout"` // Seconds
+	ErrorAlertPercent   int                     `json:"error_alert_percent"`
+	ErrorAlertWindow    int                     `json:"error_alert_window"` // Seconds
+	NormalizeSSE        bool                    `json:"normalize_sse"`
+	StreamRequestID     bool                    `json:"stream_request_id"`
+	RewriteModel        bool                    `json:"rewrite_response_model"`
+	CacheMetrics        bool                    `json:"cache_metrics"`
+	MaxIdleConns        int                     `json:"upstream_max_idle_conns"`
+	MaxConnsPerHost     int                     `json:"upstream_max_conns_per_host"`
+	IdleConnTimeout     int                     `json:"upstream_idle_conn_timeout"` // Seconds
+	MaxRequestBodyMB    int64                   `json:"max_request_body_mb"`
+	ResponseParseMB     int64                   `json:"response_parse_limit_mb"`
+	ModelsFetchTimeout  int                     `json:"models_fetch_timeout"` // Seconds
+	ModelsResponseMB    int64                   `json:"models_response_limit_mb"`
+	TokenCountWorkers   int                     `json:"token_count_workers"`
+	APIKeyPrefix        string                  `json:"api_key_prefix"`
+	APIKeyLength        int                     `json:"api_key_length"`
+	APIKeyExpiryGrace   int                     `json:"api_key_expiry_grace"` // Minutes
+	APIKeyDefaultTTL    int                     `json:"api_key_default_ttl"`  // Days
+	RateLimitBackend    string                  `json:"rate_limit_backend"`
+	GlobalRateLimit     int                     `json:"global_rate_limit"` // Requests per second
+	RedisURL            string                  `json:"redis_url,omitempty"`
+	StorageBackend      string                  `json:"storage_backend"`
+	UsageFlushInterval  int                     `json:"usage_flush_interval"` // Seconds
+	AdminCORSOrigins    []string                `json:"admin_cors_origins"`
+	DisabledEndpoints   []string                `json:"disabled_endpoints"`
+	StripHeaders        []string                `json:"strip_headers"`
+	UpstreamHosts       []string                `json:"upstream_allowed_hosts"`
+	RequestIDHeader     string                  `json:"request_id_header"`
+	RequestIDFormat     string                  `json:"request_id_format"`
+	StreamErrorFormat   string                  `json:"stream_error_format"`
+	LogOmitFields       []string                `json:"log_omit_fields"`
+	LogHashFields       []string                `json:"log_hash_fields"`
+	LogSampleRate       int                     `json:"log_sample_rate"`
+	FlagAnomalies       bool                    `json:"flag_token_anomalies"`
+	LogRequestBodies    bool                    `json:"log_request_bodies"`
+	LogBodyCompression  bool                    `json:"log_body_compression"`
+	LogBodyMaxKB        int                     `json:"log_request_body_max_kb"`
+	TokenizerEncodings  map[string]string       `json:"tokenizer_encodings,omitempty"`
+	TokenizerOverheads  map[string]int          `json:"tokenizer_overheads,omitempty"`
+	Pricing             map[string]config.Price `json:"pricing,omitempty"`
+	Providers           []ProviderView          `json:"providers"`
+	Default             *AliasView              `json:"default"`
+	Aliases             []AliasView             `json:"aliases"`
+	ShadowModel         string                  `json:"shadow_model,omitempty"`
+
+	CredentialLimits []CredentialLimitView `json:"credential_limits,omitempty"`
+	EndpointDefaults map[string]*AliasView `json:"endpoint_defaults,omitempty"`
+	Tenants          map[string]TenantView `json:"tenants,omitempty"`
+}
+
+// ProviderView is a configured provider instance.
+type ProviderView struct {
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	BaseURL          string `json:"base_url,omitempty"`
+	StripModelPrefix string `json:"strip_model_prefix,omitempty"`
+	AddModelPrefix   string `json:"add_model_prefix,omitempty"`
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// DeleteCredential handles DELETE /api/admin/credentials/{id}.
+// With ?purge=true the credential's request logs and daily usage are deleted too.
+func (h *Handlers) DeleteCredential(w http.ResponseWriter, r *http.Request) {
+	id := extractCredentialID(r.URL.Path)
+	if id == "" {
+		shared.WriteJSONError(w, "Credential ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get credential first to know provider for cache invalidation
+	cred, err := h.Storage.GetCredential(id)
+	if err == storage.ErrNotFound {
+		shared.WriteJSONError(w, "Credential not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get credential: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if r.URL.Query().Get("purge") == "true" {
+		purge, err := h.Storage.PurgeCredential(id)
+		if err != nil {
+			shared.WriteJSONError(w, "Failed to purge credential: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.InvalidateCredentialCache(cred.Provider)
+		h.invalidateStatsCache()
+		shared.WriteAdminJSON(w, r, map[string]any{
+			"id":                   id,
+			"deleted":              true,
+			"request_logs_deleted": purge.RequestLogs,
+			"daily_usage_deleted":  purge.DailyUsage,
+		}, http.StatusOK)
+		return
+	}
+
+	if err := h.Storage.DeleteCredential(id); err != nil {
+		shared.WriteJSONError(w, "Failed to delete credential: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Invalidate credential cache for this provider
+	h.InvalidateCredentialCache(cred.Provider)
+
+	w.WriteHeader(http.StatusNoContent)
+}
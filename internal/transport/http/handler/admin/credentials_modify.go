@@ -2,6 +2,7 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -23,9 +24,14 @@ func (h *Handlers) CreateCredential(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cred := &storage.Credential{
-		Provider: req.Provider,
-		Name:     req.Name,
-		Data:     req.Data,
+		Provider:    req.Provider,
+		Name:        req.Name,
+		Data:        req.Data,
+		LogRequests: req.LogRequests == nil || *req.LogRequests,
+	}
+	if err := cred.ValidateData(); err != nil {
+		shared.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	if err := h.Storage.CreateCredential(cred); err != nil {
@@ -36,7 +42,7 @@ func (h *Handlers) CreateCredential(w http.ResponseWriter, r *http.Request) {
 	// Invalidate credential cache for this provider
 	h.InvalidateCredentialCache(cred.Provider)
 
-	shared.WriteJSON(w, cred.ToPreview(), http.StatusCreated)
+	shared.WriteAdminJSON(w, r, cred.ToPreview(), http.StatusCreated)
 }
 
 // UpdateCredential handles PUT /api/admin/credentials/{id}.
@@ -72,45 +78,33 @@ func (h *Handlers) UpdateCredential(w http.ResponseWriter, r *http.Request) {
 	if req.Data != nil {
 		cred.Data = *req.Data
 	}
-	cred.UpdatedAt = time.Now()
-
-	if err := h.Storage.UpdateCredential(cred); err != nil {
-		shared.WriteJSONError(w, "Failed to update credential: "+err.Error(), http.StatusInternalServerError)
-		return
+	if req.LogRequests != nil {
+		cred.LogRequests = *req.LogRequests
 	}
-
-	// Invalidate credential cache for this provider
-	h.InvalidateCredentialCache(cred.Provider)
-
-	shared.WriteJSON(w, cred.ToPreview(), http.StatusOK)
-}
-
-// DeleteCredential handles DELETE /api/admin/credentials/{id}.
-func (h *Handlers) DeleteCredential(w http.ResponseWriter, r *http.Request) {
-	id := extractCredentialID(r.URL.Path)
-	if id == "" {
-		shared.WriteJSONError(w, "Credential ID is required", http.StatusBadRequest)
-		return
+	if req.Version != nil {
+		cred.Version = *req.Version
+	}
+	// Stored data is only re-validated when the request changes it
+	if req.Provider != nil || req.Data != nil {
+		if err := cred.ValidateData(); err != nil {
+			shared.WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
+	cred.UpdatedAt = time.Now()
 
-	// Get credential first to know provider for cache invalidation
-	cred, err := h.Storage.GetCredential(id)
-	if err == storage.ErrNotFound {
-		shared.WriteJSONError(w, "Credential not found", http.StatusNotFound)
+	err = h.Storage.UpdateCredential(cred)
+	if errors.Is(err, storage.ErrConflict) {
+		shared.WriteJSONError(w, "Credential was modified since it was read; reload and retry", http.StatusConflict)
 		return
 	}
 	if err != nil {
-		shared.WriteJSONError(w, "Failed to get credential: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if err := h.Storage.DeleteCredential(id); err != nil {
-		shared.WriteJSONError(w, "Failed to delete credential: "+err.Error(), http.StatusInternalServerError)
+		shared.WriteJSONError(w, "Failed to update credential: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Invalidate credential cache for this provider
 	h.InvalidateCredentialCache(cred.Provider)
 
-	w.WriteHeader(http.StatusNoContent)
+	shared.WriteAdminJSON(w, r, cred.ToPreview(), http.StatusOK)
 }
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestUpdateCredential_Version(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantName   string
+	}{
+		{"current version", `{"name":"renamed","version":2}`, http.StatusOK, "renamed"},
+		{"stale version", `{"name":"renamed","version":1}`, http.StatusConflict, "edited"},
+		{"no version", `{"name":"renamed"}`, http.StatusOK, "renamed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			cred := &storage.Credential{Provider: "openrouter", Name: "original", Data: []byte(`{"api_key":"k"}`)}
+			if err := store.CreateCredential(cred); err != nil {
+				t.Fatal(err)
+			}
+			// Another admin edits the credential, moving it to version 2.
+			cred.Name = "edited"
+			if err := store.UpdateCredential(cred); err != nil {
+				t.Fatal(err)
+			}
+
+			h := &Handlers{Storage: store}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/admin/credentials/"+cred.ID, strings.NewReader(tt.body))
+			h.UpdateCredential(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if got, _ := store.GetCredential(cred.ID); got.Name != tt.wantName {
+				t.Errorf("stored name = %q, want %q", got.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestCreateCredential_AzureValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantStatus int
+		wantError  string
+	}{
+		{"complete", `{"endpoint":"r.openai.azure.com","api_key":"k","deployment":"gpt-4o"}`, http.StatusCreated, ""},
+		{"missing endpoint", `{"api_key":"k","deployment":"gpt-4o"}`, http.StatusBadRequest, "missing endpoint"},
+		{"missing deployment", `{"endpoint":"r.openai.azure.com","api_key":"k"}`, http.StatusBadRequest, "missing deployment"},
+		{"missing several", `{"api_key":"k"}`, http.StatusBadRequest, "missing endpoint, deployment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			h := &Handlers{Storage: store}
+			body := `{"provider":"azure","name":"az","data":` + tt.data + `}`
+			rec := httptest.NewRecorder()
+			h.CreateCredential(rec, httptest.NewRequest(http.MethodPost, "/api/admin/credentials", strings.NewReader(body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want it to mention %q", rec.Body, tt.wantError)
+			}
+			if list, _ := store.ListCredentials(); (len(list) == 1) != (tt.wantStatus == http.StatusCreated) {
+				t.Errorf("stored %d credentials after status %d", len(list), rec.Code)
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ func (h *Handlers) ListCredentials(w http.ResponseWriter, r *http.Request) {
 		previews[i] = cred.ToPreview()
 	}
 
-	shared.WriteJSON(w, map[string]any{"credentials": previews}, http.StatusOK)
+	shared.WriteAdminJSON(w, r, map[string]any{"credentials": previews}, http.StatusOK)
 }
 
 // GetCredential handles GET /api/admin/credentials/{id}.
@@ -42,7 +42,7 @@ func (h *Handlers) GetCredential(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, cred.ToPreview(), http.StatusOK)
+	shared.WriteAdminJSON(w, r, cred.ToPreview(), http.StatusOK)
 }
 
 // extractCredentialID extracts the credential ID from URL path.
@@ -7,6 +7,9 @@ type CreateCredentialRequest struct {
 	Provider string          `json:"provider"`
 	Name     string          `json:"name"`
 	Data     json.RawMessage `json:"data"` // Provider-specific credential data
+
+	// LogRequests writes per-request logs for this credential (default true)
+	LogRequests *bool `json:"log_requests,omitempty"`
 }
 
 // UpdateCredentialRequest is the request body for updating a credential.
@@ -14,4 +17,10 @@ type UpdateCredentialRequest struct {
 	Provider *string          `json:"provider,omitempty"`
 	Name     *string          `json:"name,omitempty"`
 	Data     *json.RawMessage `json:"data,omitempty"` // Provider-specific credential data
+
+	LogRequests *bool `json:"log_requests,omitempty"`
+
+	// Version is the credential version the client last read. When set, the
+	// update fails with 409 if the credential has changed since.
+	Version *int `json:"version,omitempty"`
 }
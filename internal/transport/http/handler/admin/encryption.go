@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// encryptionMismatchWarning explains the usual cause of undecryptable secrets.
+const encryptionMismatchWarning = "The current encryption key cannot decrypt stored credentials. " +
+	"The database was likely moved to another machine or the configured key changed; " +
+	"restore the original key (GOATWAY_AES_KEY, GOATWAY_AES_KEY_FILE or GOATWAY_ENCRYPTION_KEY) or re-enter the affected credentials."
+
+// encryptionHealthResponse is the body of GET /api/admin/encryption/health.
+type encryptionHealthResponse struct {
+	*storage.EncryptionHealth
+	Status  string `json:"status"`
+	Warning string `json:"warning,omitempty"`
+}
+
+// EncryptionHealth handles GET /api/admin/encryption/health.
+func (h *Handlers) EncryptionHealth(w http.ResponseWriter, r *http.Request) {
+	health, err := h.Storage.CheckEncryption()
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to check encryption: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := encryptionHealthResponse{EncryptionHealth: health, Status: "healthy"}
+	if !health.Healthy() {
+		resp.Status = "key_mismatch"
+		resp.Warning = encryptionMismatchWarning
+	}
+	shared.WriteAdminJSON(w, r, resp, http.StatusOK)
+}
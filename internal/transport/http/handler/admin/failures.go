@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// GetFailures handles GET /api/admin/failures.
+func (h *Handlers) GetFailures(w http.ResponseWriter, r *http.Request) {
+	filter := parseFailureFilter(r)
+
+	failures, err := h.Storage.ListFailures(filter)
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get failures: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	shared.WriteAdminJSON(w, r, map[string]any{
+		"failures": failures,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	}, http.StatusOK)
+}
+
+// parseFailureFilter creates a FailureFilter from query parameters.
+func parseFailureFilter(r *http.Request) storage.FailureFilter {
+	q := r.URL.Query()
+	filter := storage.FailureFilter{
+		Model:     q.Get("model"),
+		Provider:  q.Get("provider"),
+		ErrorType: q.Get("error_type"),
+		Limit:     50, // default
+	}
+
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(q.Get("offset")); err == nil && offset >= 0 {
+		filter.Offset = offset
+	}
+
+	return filter
+}
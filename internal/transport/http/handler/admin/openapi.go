@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+	"github.com/mandalnilabja/goatway/internal/version"
+)
+
+// GetOpenAPI handles GET /api/admin/openapi.json.
+func (h *Handlers) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	shared.WriteAdminJSON(w, r, OpenAPISpec(), http.StatusOK)
+}
+
+// OpenAPISpec builds the OpenAPI 3 document for the proxy and admin APIs.
+func OpenAPISpec() map[string]any {
+	paths := make(map[string]map[string]any)
+	for _, op := range apiOperations {
+		item, ok := paths[op.path]
+		if !ok {
+			item = make(map[string]any)
+			paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = op.document()
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Goatway API",
+			"description": "OpenAI-compatible proxy and admin API. Proxy routes take a client API key; admin routes take the admin password, a web session or an admin-scoped API key.",
+			"version":     version.Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": map[string]any{
+				"Error": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"message": map[string]any{"type": "string"},
+								"type":    map[string]any{"type": "string"},
+								"code":    map[string]any{},
+							},
+						},
+					},
+				},
+			},
+		},
+		"security": []map[string]any{{"bearerAuth": []string{}}},
+	}
+}
+
+// document renders the operation object.
+func (op apiOperation) document() map[string]any {
+	errorBody := map[string]any{
+		"description": "Error",
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}},
+		},
+	}
+	doc := map[string]any{
+		"tags":        []string{op.tag},
+		"summary":     op.summary,
+		"operationId": operationID(op.method, op.path),
+		"responses": map[string]any{
+			"200":     map[string]any{"description": "Success"},
+			"default": errorBody,
+		},
+	}
+	if op.public {
+		doc["security"] = []map[string]any{}
+	}
+	if params := pathParameters(op.path); len(params) > 0 {
+		doc["parameters"] = params
+	}
+	return doc
+}
+
+// pathParameters returns a parameter object per {name} segment in path.
+func pathParameters(path string) []map[string]any {
+	var params []map[string]any
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, map[string]any{
+				"name":     strings.Trim(segment, "{}"),
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+	}
+	return params
+}
+
+// operationID derives a stable identifier, e.g. "get_api_admin_credentials_id".
+func operationID(method, path string) string {
+	return strings.ToLower(method) + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_").Replace(path)
+}
@@ -0,0 +1,84 @@
+package admin
+
+// Operation tags group endpoints in the OpenAPI document.
+const (
+	tagProxy       = "Proxy"
+	tagCredentials = "Credentials"
+	tagAPIKeys     = "API Keys"
+	tagConfig      = "Configuration"
+	tagUsage       = "Usage"
+	tagSystem      = "System"
+)
+
+// apiOperation describes one endpoint in the OpenAPI document.
+// Keep in sync with the routes registered in internal/app.
+type apiOperation struct {
+	method  string
+	path    string // ServeMux pattern path; {name} segments become path parameters
+	tag     string
+	summary string
+	public  bool // No authentication required
+}
+
+// apiOperations lists every documented endpoint, in registration order.
+var apiOperations = []apiOperation{
+	{method: "GET", path: "/api/health", tag: tagSystem, summary: "Liveness check", public: true},
+
+	// OpenAI-compatible proxy
+	{method: "POST", path: "/v1/chat/completions", tag: tagProxy, summary: "Create a chat completion (streaming or JSON)"},
+	{method: "GET", path: "/v1/models", tag: tagProxy, summary: "List configured model aliases"},
+	{method: "GET", path: "/v1/models/{model}", tag: tagProxy, summary: "Get a model alias"},
+	{method: "POST", path: "/v1/embeddings", tag: tagProxy, summary: "Create embeddings"},
+	{method: "POST", path: "/v1/audio/speech", tag: tagProxy, summary: "Generate speech from text"},
+	{method: "POST", path: "/v1/audio/transcriptions", tag: tagProxy, summary: "Transcribe audio"},
+	{method: "POST", path: "/v1/audio/translations", tag: tagProxy, summary: "Translate audio to English"},
+	{method: "POST", path: "/v1/images/generations", tag: tagProxy, summary: "Generate images"},
+	{method: "POST", path: "/v1/images/edits", tag: tagProxy, summary: "Edit an image"},
+	{method: "POST", path: "/v1/images/variations", tag: tagProxy, summary: "Create image variations"},
+	{method: "POST", path: "/v1/completions", tag: tagProxy, summary: "Create a legacy text completion"},
+	{method: "POST", path: "/v1/moderations", tag: tagProxy, summary: "Classify content for moderation"},
+	{method: "GET", path: "/v1/key/validate", tag: tagProxy, summary: "Validate the calling API key"},
+
+	// Credential management
+	{method: "POST", path: "/api/admin/credentials", tag: tagCredentials, summary: "Create a provider credential"},
+	{method: "GET", path: "/api/admin/credentials", tag: tagCredentials, summary: "List credentials (secrets masked)"},
+	{method: "GET", path: "/api/admin/credentials/{id}", tag: tagCredentials, summary: "Get a credential"},
+	{method: "PUT", path: "/api/admin/credentials/{id}", tag: tagCredentials, summary: "Update a credential"},
+	{method: "DELETE", path: "/api/admin/credentials/{id}", tag: tagCredentials, summary: "Delete a credential"},
+
+	// API key management
+	{method: "POST", path: "/api/admin/apikeys", tag: tagAPIKeys, summary: "Create a client API key"},
+	{method: "GET", path: "/api/admin/apikeys", tag: tagAPIKeys, summary: "List client API keys"},
+	{method: "GET", path: "/api/admin/apikeys/{id}", tag: tagAPIKeys, summary: "Get a client API key"},
+	{method: "PUT", path: "/api/admin/apikeys/{id}", tag: tagAPIKeys, summary: "Update a client API key"},
+	{method: "DELETE", path: "/api/admin/apikeys/{id}", tag: tagAPIKeys, summary: "Delete a client API key"},
+	{method: "POST", path: "/api/admin/apikeys/{id}/rotate", tag: tagAPIKeys, summary: "Rotate a client API key"},
+	{method: "GET", path: "/api/admin/apikeys/{id}/usage", tag: tagAPIKeys, summary: "Get usage and estimated cost for a client API key"},
+
+	// Configuration
+	{method: "PUT", path: "/api/admin/password", tag: tagSystem, summary: "Change the admin password"},
+	{method: "GET", path: "/api/admin/aliases", tag: tagConfig, summary: "List model aliases and the default route"},
+	{method: "POST", path: "/api/admin/aliases", tag: tagConfig, summary: "Store a model alias, routed without a restart"},
+	{method: "PUT", path: "/api/admin/aliases/{slug}", tag: tagConfig, summary: "Update a stored model alias"},
+	{method: "DELETE", path: "/api/admin/aliases/{slug}", tag: tagConfig, summary: "Delete a stored model alias"},
+	{method: "POST", path: "/api/admin/aliases/import", tag: tagConfig, summary: "Suggest model aliases from a provider's model list"},
+	{method: "GET", path: "/api/admin/config", tag: tagConfig, summary: "Get the effective configuration (secrets redacted)"},
+	{method: "GET", path: "/api/admin/providers/status", tag: tagConfig, summary: "Get per-provider health"},
+	{method: "POST", path: "/api/admin/tokenize", tag: tagConfig, summary: "Count tokens for text or messages as the gateway meters them"},
+
+	// Usage and logs
+	{method: "GET", path: "/api/admin/usage", tag: tagUsage, summary: "Get aggregate usage statistics"},
+	{method: "GET", path: "/api/admin/usage/daily", tag: tagUsage, summary: "Get daily usage"},
+	{method: "GET", path: "/api/admin/usage/users", tag: tagUsage, summary: "Get usage per API key and end user"},
+	{method: "GET", path: "/api/admin/logs", tag: tagUsage, summary: "List request logs"},
+	{method: "DELETE", path: "/api/admin/logs", tag: tagUsage, summary: "Delete request logs"},
+	{method: "GET", path: "/api/admin/failures", tag: tagUsage, summary: "List upstream failure records"},
+	{method: "GET", path: "/api/admin/trace/{request_id}", tag: tagUsage, summary: "Trace one request's lifecycle"},
+
+	// System info
+	{method: "GET", path: "/api/admin/health", tag: tagSystem, summary: "Get gateway and database health"},
+	{method: "GET", path: "/api/admin/info", tag: tagSystem, summary: "Get version, uptime and quick stats"},
+	{method: "GET", path: "/api/admin/cache/metrics", tag: tagSystem, summary: "Get hit, miss and eviction counters for the in-memory caches"},
+	{method: "GET", path: "/api/admin/encryption/health", tag: tagSystem, summary: "Check the encryption key can decrypt stored credentials"},
+	{method: "GET", path: "/api/admin/openapi.json", tag: tagSystem, summary: "Get this OpenAPI document"},
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// ProviderStatus combines a provider's recent upstream health with credential checks.
+type ProviderStatus struct {
+	Provider string `json:"provider"`
+	provider.ProviderHealth
+	CredentialOK bool     `json:"credential_ok"`                 // Every route has a usable credential
+	Missing      []string `json:"missing_credentials,omitempty"` // Masked names that failed to resolve
+}
+
+// ProvidersStatus handles GET /api/admin/providers/status.
+// Reports, per provider referenced by the routing config, recent health and
+// whether the credentials its routes name exist and belong to that provider.
+func (h *Handlers) ProvidersStatus(w http.ResponseWriter, r *http.Request) {
+	routes := h.providerCredentials()
+
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := make([]ProviderStatus, 0, len(names))
+	for _, name := range names {
+		status := ProviderStatus{
+			Provider:       name,
+			ProviderHealth: h.Health.Snapshot(name),
+			CredentialOK:   true,
+		}
+		for _, credName := range routes[name] {
+			if !h.credentialUsable(name, credName) {
+				status.CredentialOK = false
+				status.Missing = append(status.Missing, maskName(credName))
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	shared.WriteAdminJSON(w, r, map[string]any{"providers": statuses}, http.StatusOK)
+}
+
+// providerCredentials maps each routed provider to the distinct credential
+// names its aliases (stored and tenant aliases included) and the default
+// routes use.
+func (h *Handlers) providerCredentials() map[string][]string {
+	routes := make(map[string][]string)
+	if h.Config == nil {
+		return routes
+	}
+
+	add := func(prov, credName string) {
+		for _, existing := range routes[prov] {
+			if existing == credName {
+				return
+			}
+		}
+		routes[prov] = append(routes[prov], credName)
+	}
+	for _, a := range h.routedAliases() {
+		add(a.Provider, a.CredentialName)
+	}
+	if d := h.Config.Default; d != nil {
+		add(d.Provider, d.CredentialName)
+	}
+	tenants := make([]string, 0, len(h.Config.Tenants))
+	for name := range h.Config.Tenants {
+		tenants = append(tenants, name)
+	}
+	sort.Strings(tenants)
+	for _, name := range tenants {
+		if d := h.Config.Tenants[name].Default; d != nil {
+			add(d.Provider, d.CredentialName)
+		}
+	}
+	endpoints := make([]string, 0, len(h.Config.EndpointDefaults))
+	for endpoint := range h.Config.EndpointDefaults {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		d := h.Config.EndpointDefaults[endpoint]
+		add(d.Provider, d.CredentialName)
+	}
+	return routes
+}
+
+// credentialUsable reports whether a named credential exists for the provider.
+func (h *Handlers) credentialUsable(prov, credName string) bool {
+	if credName == "" || h.Storage == nil {
+		return false
+	}
+	cred, err := h.Storage.GetCredentialByName(credName)
+	return err == nil && cred.Provider == prov
+}
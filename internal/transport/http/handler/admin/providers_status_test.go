@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// credentialStorage resolves credentials by name; other methods are unused.
+type credentialStorage struct {
+	storage.Storage
+	creds map[string]*storage.Credential
+}
+
+func (s *credentialStorage) GetCredentialByName(name string) (*storage.Credential, error) {
+	if c, ok := s.creds[name]; ok {
+		return c, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func TestProvidersStatus(t *testing.T) {
+	health := provider.NewHealthTracker()
+	for i := 0; i < 5; i++ {
+		health.Record("bedrock", &types.ProxyResult{StatusCode: http.StatusBadGateway}, errors.New("dial tcp: timeout"))
+	}
+	health.Record("openrouter", &types.ProxyResult{StatusCode: http.StatusOK}, nil)
+	health.Record("openrouter", &types.ProxyResult{StatusCode: http.StatusBadRequest}, nil) // client error, not a failure
+	health.Record("openrouter", &types.ProxyResult{StatusCode: http.StatusTooManyRequests, ErrorMessage: "slow down"}, nil)
+	health.Record("openrouter", &types.ProxyResult{StatusCode: http.StatusOK}, nil)
+
+	h := &Handlers{
+		Config: &config.Config{
+			Models: []config.ModelAlias{
+				{Slug: "gpt", Provider: "openrouter", CredentialName: "or-main"},
+				{Slug: "claude", Provider: "bedrock", CredentialName: "aws-prod"},
+				{Slug: "llama", Provider: "bedrock", CredentialName: "or-main"}, // wrong provider
+			},
+			Default: &config.DefaultRoute{Provider: "azure", CredentialName: "missing"},
+		},
+		Storage: &credentialStorage{creds: map[string]*storage.Credential{
+			"or-main":  {Name: "or-main", Provider: "openrouter"},
+			"aws-prod": {Name: "aws-prod", Provider: "bedrock"},
+		}},
+		Health: health,
+	}
+
+	rec := httptest.NewRecorder()
+	h.ProvidersStatus(rec, httptest.NewRequest(http.MethodGet, "/api/admin/providers/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+
+	var resp struct {
+		Providers []struct {
+			Provider     string  `json:"provider"`
+			State        string  `json:"state"`
+			Requests     int     `json:"recent_requests"`
+			SuccessRate  float64 `json:"success_rate"`
+			LastError    string  `json:"last_error"`
+			CredentialOK bool    `json:"credential_ok"`
+		} `json:"providers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	type want struct {
+		state        string
+		requests     int
+		successRate  float64
+		lastError    string
+		credentialOK bool
+	}
+	wants := map[string]want{
+		"azure":      {provider.HealthStateHealthy, 0, 1, "", false},
+		"bedrock":    {provider.HealthStateFailing, 5, 0, "dial tcp: timeout", false},
+		"openrouter": {provider.HealthStateDegraded, 4, 0.75, "slow down", true},
+	}
+	if len(resp.Providers) != len(wants) {
+		t.Fatalf("got %d providers, want %d", len(resp.Providers), len(wants))
+	}
+	for _, p := range resp.Providers {
+		w := wants[p.Provider]
+		got := want{p.State, p.Requests, p.SuccessRate, p.LastError, p.CredentialOK}
+		if got != w {
+			t.Errorf("%s = %+v, want %+v", p.Provider, got, w)
+		}
+	}
+}
+
+// stubProvider is a named provider that is never called.
+type stubProvider struct{ types.Provider }
+
+func TestProviderCredentials_RoutedAliases(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	if err := store.CreateAlias(&storage.ModelAlias{Slug: "gpt", Provider: "openrouter", Model: "openai/gpt-4o", CredentialName: "or-stored"}); err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+	cfg := &config.Config{
+		Models: []config.ModelAlias{{Slug: "gpt", Provider: "openrouter", CredentialName: "or-config"}},
+		Tenants: map[string]config.TenantRoutes{
+			"acme": {
+				Models:  []config.ModelAlias{{Slug: "claude", Provider: "bedrock", CredentialName: "acme-aws"}},
+				Default: &config.DefaultRoute{Provider: "openrouter", CredentialName: "acme-or"},
+			},
+		},
+	}
+	providers := map[string]types.Provider{"openrouter": stubProvider{}, "bedrock": stubProvider{}}
+	h := &Handlers{Config: cfg, Router: provider.NewRouter(providers, cfg, store)}
+
+	got := h.providerCredentials()
+	want := map[string][]string{"openrouter": {"or-stored", "acme-or"}, "bedrock": {"acme-aws"}}
+	if len(got) != len(want) {
+		t.Fatalf("providerCredentials = %v, want %v", got, want)
+	}
+	for prov, names := range want {
+		if strings.Join(got[prov], ",") != strings.Join(names, ",") {
+			t.Errorf("%s credentials = %q, want %q (stored alias replaces or-config)", prov, got[prov], names)
+		}
+	}
+}
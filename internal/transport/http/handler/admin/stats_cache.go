@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// statsCacheTTL bounds how stale dashboard usage stats may be.
+const statsCacheTTL = 10 * time.Second
+
+// newStatsCache creates the usage stats cache (nil disables caching).
+func newStatsCache(metrics bool) *ristretto.Cache[string, *storage.UsageStats] {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, *storage.UsageStats]{
+		NumCounters: 1e4,
+		MaxCost:     1e3,
+		BufferItems: 64,
+		Metrics:     metrics,
+	})
+	if err != nil {
+		return nil
+	}
+	return cache
+}
+
+// usageStats returns aggregate stats for the filter, serving repeat calls within
+// statsCacheTTL from cache to avoid re-running aggregate SQL on every dashboard poll.
+func (h *Handlers) usageStats(filter storage.StatsFilter) (*storage.UsageStats, error) {
+	key := statsCacheKey(filter)
+	if h.StatsCache != nil {
+		if stats, ok := h.StatsCache.Get(key); ok {
+			return stats, nil
+		}
+	}
+
+	stats, err := h.Storage.GetUsageStats(filter)
+	if err != nil {
+		return nil, err
+	}
+	if h.StatsCache != nil {
+		h.StatsCache.SetWithTTL(key, stats, 1, statsCacheTTL)
+	}
+	return stats, nil
+}
+
+// invalidateStatsCache drops all cached stats (call after deleting usage data).
+func (h *Handlers) invalidateStatsCache() {
+	if h.StatsCache != nil {
+		h.StatsCache.Clear()
+	}
+}
+
+// statsCacheKey builds a cache key covering every filter field.
+func statsCacheKey(f storage.StatsFilter) string {
+	day := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s", f.CredentialID, f.Model, f.Provider, day(f.StartDate), day(f.EndDate))
+}
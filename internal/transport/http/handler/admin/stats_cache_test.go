@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// countingStorage counts aggregate stats queries; other methods are unused.
+type countingStorage struct {
+	storage.Storage
+	statsCalls int
+}
+
+func (s *countingStorage) GetUsageStats(f storage.StatsFilter) (*storage.UsageStats, error) {
+	s.statsCalls++
+	return &storage.UsageStats{TotalRequests: 42}, nil
+}
+
+func TestGetUsageStats_CachedWithinTTL(t *testing.T) {
+	store := &countingStorage{}
+	h := &Handlers{Storage: store, StatsCache: newStatsCache(false)}
+
+	get := func(query string) {
+		rec := httptest.NewRecorder()
+		h.GetUsageStats(rec, httptest.NewRequest(http.MethodGet, "/api/admin/usage"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+		h.StatsCache.Wait() // Ristretto applies writes asynchronously
+	}
+
+	get("?model=gpt-4o")
+	get("?model=gpt-4o")
+	if store.statsCalls != 1 {
+		t.Errorf("identical call within TTL hit storage: %d queries, want 1", store.statsCalls)
+	}
+
+	get("?model=claude")
+	if store.statsCalls != 2 {
+		t.Errorf("different filter should miss the cache: %d queries, want 2", store.statsCalls)
+	}
+
+	h.invalidateStatsCache()
+	get("?model=gpt-4o")
+	if store.statsCalls != 3 {
+		t.Errorf("invalidated cache should query storage: %d queries, want 3", store.statsCalls)
+	}
+}
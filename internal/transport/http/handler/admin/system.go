@@ -23,7 +23,7 @@ func (h *Handlers) AdminHealth(w http.ResponseWriter, r *http.Request) {
 		dbStatus = "error: " + err.Error()
 	}
 
-	shared.WriteJSON(w, map[string]any{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"status":    status,
 		"database":  dbStatus,
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
@@ -35,10 +35,13 @@ func (h *Handlers) AdminInfo(w http.ResponseWriter, r *http.Request) {
 	uptime := time.Since(h.StartTime)
 
 	// Get quick stats
-	stats, _ := h.Storage.GetUsageStats(storage.StatsFilter{})
+	stats, err := h.usageStats(storage.StatsFilter{})
+	if err != nil {
+		stats = &storage.UsageStats{}
+	}
 	creds, _ := h.Storage.ListCredentials()
 
-	shared.WriteJSON(w, map[string]any{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"version":     version.Version,
 		"go_version":  runtime.Version(),
 		"uptime":      uptime.String(),
@@ -81,5 +84,5 @@ func (h *Handlers) ChangeAdminPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, map[string]string{"message": "password updated"}, http.StatusOK)
+	shared.WriteAdminJSON(w, r, map[string]string{"message": "password updated"}, http.StatusOK)
 }
@@ -0,0 +1,22 @@
+package admin
+
+import "github.com/mandalnilabja/goatway/internal/config"
+
+// TenantView is the read-only routing config of one tenant.
+type TenantView struct {
+	Default *AliasView  `json:"default"`
+	Aliases []AliasView `json:"aliases"`
+}
+
+// tenantViews converts the tenant routing configs with credential names
+// masked, or nil when no tenants are set.
+func tenantViews(tenants map[string]config.TenantRoutes) map[string]TenantView {
+	if len(tenants) == 0 {
+		return nil
+	}
+	views := make(map[string]TenantView, len(tenants))
+	for name, t := range tenants {
+		views[name] = TenantView{Default: defaultView(t.Default), Aliases: aliasViews(t.Models)}
+	}
+	return views
+}
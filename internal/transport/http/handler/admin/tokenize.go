@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// TokenizeRequest is the request body for counting tokens.
+// Exactly one of Text or Messages must be set; Tools only apply to Messages.
+type TokenizeRequest struct {
+	Model    string          `json:"model"` // Selects the encoding; unknown models use cl100k_base
+	Text     *string         `json:"text,omitempty"`
+	Messages []types.Message `json:"messages,omitempty"`
+	Tools    []types.Tool    `json:"tools,omitempty"`
+}
+
+// TokenizeResponse reports the gateway's token count for a request.
+type TokenizeResponse struct {
+	Model  string `json:"model"`
+	Tokens int    `json:"tokens"`
+}
+
+// Tokenize handles POST /api/admin/tokenize.
+// Counts are computed exactly as for prompt metering: text with CountTokens,
+// messages (plus tools) with CountRequest, including per-message overhead.
+func (h *Handlers) Tokenize(w http.ResponseWriter, r *http.Request) {
+	if h.Tokenizer == nil {
+		shared.WriteJSONError(w, "Tokenizer not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req TokenizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if (req.Text == nil) == (len(req.Messages) == 0) {
+		shared.WriteJSONError(w, "exactly one of text or messages is required", http.StatusBadRequest)
+		return
+	}
+
+	var tokens int
+	var err error
+	if req.Text != nil {
+		tokens, err = h.Tokenizer.CountTokens(*req.Text, req.Model)
+	} else {
+		tokens, err = h.Tokenizer.CountRequest(&types.ChatCompletionRequest{
+			Model:    req.Model,
+			Messages: req.Messages,
+			Tools:    req.Tools,
+		})
+	}
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to count tokens: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	shared.WriteAdminJSON(w, r, TokenizeResponse{Model: req.Model, Tokens: tokens}, http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestTokenize_MatchesTokenizer(t *testing.T) {
+	tok := tokenizer.New()
+	messages := []types.Message{
+		types.NewTextMessage(types.RoleSystem, "You are a helpful assistant."),
+		types.NewTextMessage(types.RoleUser, "How many tokens is this?"),
+	}
+	textWant, err := tok.CountTokens("Hello, tokenizer!", "gpt-4o")
+	if err != nil {
+		t.Skipf("tokenizer encodings unavailable: %v", err)
+	}
+	messagesWant, err := tok.CountRequest(&types.ChatCompletionRequest{Model: "gpt-4", Messages: messages})
+	if err != nil {
+		t.Fatalf("CountRequest: %v", err)
+	}
+	rawMessages, _ := json.Marshal(messages)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"text", `{"model":"gpt-4o","text":"Hello, tokenizer!"}`, textWant},
+		{"messages", `{"model":"gpt-4","messages":` + string(rawMessages) + `}`, messagesWant},
+	}
+
+	h := &Handlers{Tokenizer: tok}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Tokenize(rec, httptest.NewRequest(http.MethodPost, "/api/admin/tokenize", strings.NewReader(tt.body)))
+
+			var resp TokenizeResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+			if resp.Tokens != tt.want {
+				t.Errorf("tokens = %d, want %d from the tokenizer package", resp.Tokens, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenize_BadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"neither text nor messages", `{"model":"gpt-4o"}`},
+		{"both text and messages", `{"text":"hi","messages":[{"role":"user","content":"hi"}]}`},
+		{"malformed", `{"text":`},
+	}
+
+	h := &Handlers{Tokenizer: tokenizer.New()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Tokenize(rec, httptest.NewRequest(http.MethodPost, "/api/admin/tokenize", strings.NewReader(tt.body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+		})
+	}
+}
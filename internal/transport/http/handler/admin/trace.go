@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/handler/shared"
+)
+
+// RequestTrace is the recorded lifecycle of one request ID, assembled from
+// its request logs (including shadow mirrors) and upstream failure records.
+type RequestTrace struct {
+	RequestID        string   `json:"request_id"`
+	Model            string   `json:"model"`
+	Provider         string   `json:"provider"`
+	CredentialID     string   `json:"credential_id,omitempty"`
+	APIKeyID         string   `json:"api_key_id,omitempty"`
+	User             string   `json:"user,omitempty"`
+	StatusCode       int      `json:"status_code"`
+	UpstreamAttempts int      `json:"upstream_attempts"`
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	TotalTokens      int      `json:"total_tokens"`
+	CostUSD          *float64 `json:"cost_usd,omitempty"` // Omitted when the model has no [pricing] entry
+	DurationMs       int64    `json:"duration_ms"`
+	ErrorType        string   `json:"error_type,omitempty"`
+	ErrorMessage     string   `json:"error_message,omitempty"`
+
+	Timeline []TraceEvent             `json:"timeline"`
+	Logs     []*storage.RequestLog    `json:"logs"`
+	Failures []*storage.FailedRequest `json:"failures"`
+}
+
+// TraceEvent is one step of a request's lifecycle.
+type TraceEvent struct {
+	Time   time.Time `json:"time"`
+	Event  string    `json:"event"` // received, routed, first_byte, upstream_failure, completed, failed, shadow
+	Detail string    `json:"detail,omitempty"`
+}
+
+// GetRequestTrace handles GET /api/admin/trace/{request_id}.
+func (h *Handlers) GetRequestTrace(w http.ResponseWriter, r *http.Request) {
+	requestID := r.PathValue("request_id")
+
+	logs, err := h.Storage.GetRequestLogs(storage.LogFilter{RequestID: requestID})
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get request logs: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	failures, err := h.Storage.ListFailures(storage.FailureFilter{RequestID: requestID})
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get failures: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(logs) == 0 && len(failures) == 0 {
+		shared.WriteJSONError(w, "No records for request "+requestID, http.StatusNotFound)
+		return
+	}
+
+	shared.WriteAdminJSON(w, r, h.buildTrace(requestID, logs, failures), http.StatusOK)
+}
+
+// buildTrace summarizes the primary (non-shadow) log, or the newest failure
+// when the credential opted out of request logging, and orders every record
+// into a timeline.
+func (h *Handlers) buildTrace(requestID string, logs []*storage.RequestLog, failures []*storage.FailedRequest) *RequestTrace {
+	trace := &RequestTrace{
+		RequestID: requestID,
+		Logs:      append([]*storage.RequestLog{}, logs...),
+		Failures:  append([]*storage.FailedRequest{}, failures...),
+	}
+
+	var primary *storage.RequestLog
+	for _, log := range logs {
+		if !log.IsShadow {
+			primary = log
+			break
+		}
+	}
+
+	switch {
+	case primary != nil:
+		trace.Model, trace.Provider = primary.Model, primary.Provider
+		trace.CredentialID, trace.APIKeyID, trace.User = primary.CredentialID, primary.APIKeyID, primary.User
+		trace.StatusCode, trace.DurationMs = primary.StatusCode, primary.DurationMs
+		trace.PromptTokens, trace.CompletionTokens, trace.TotalTokens = primary.PromptTokens, primary.CompletionTokens, primary.TotalTokens
+		trace.ErrorType, trace.ErrorMessage = primary.ErrorType, primary.ErrorMessage
+		trace.UpstreamAttempts = primary.Attempts
+		if price, ok := h.modelPrice(primary.Model); ok {
+			cost := price.Cost(primary.PromptTokens, primary.CompletionTokens)
+			trace.CostUSD = &cost
+		}
+	case len(failures) > 0:
+		f := failures[0]
+		trace.Model, trace.Provider = f.Model, f.Provider
+		trace.CredentialID, trace.APIKeyID = f.CredentialID, f.APIKeyID
+		trace.StatusCode, trace.ErrorType, trace.ErrorMessage = f.StatusCode, f.ErrorType, f.ErrorMessage
+	}
+	for _, f := range failures {
+		trace.UpstreamAttempts = max(trace.UpstreamAttempts, f.Attempts)
+	}
+
+	trace.Timeline = traceTimeline(primary, logs, failures)
+	return trace
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestGetRequestTrace(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	done := time.Date(2026, 3, 10, 12, 0, 2, 0, time.UTC)
+	seed := []*storage.RequestLog{
+		{RequestID: "req-1", Model: "openai/gpt-4o", Provider: "openrouter", CredentialID: "c1", APIKeyID: "k1",
+			StatusCode: 502, ErrorType: "server_error", ErrorMessage: "bad gateway", PromptTokens: 1000, CompletionTokens: 500,
+			TotalTokens: 1500, DurationMs: 2000, TTFBMs: 1500, Attempts: 2, CreatedAt: done},
+		{RequestID: "req-1", Model: "claude", Provider: "bedrock", StatusCode: 200, TotalTokens: 30, IsShadow: true, CreatedAt: done.Add(time.Second)},
+		{RequestID: "req-2", Model: "other", Provider: "openrouter", StatusCode: 200, CreatedAt: done},
+	}
+	for _, log := range seed {
+		if err := store.LogRequest(log); err != nil {
+			t.Fatalf("LogRequest: %v", err)
+		}
+	}
+	failure := &storage.FailedRequest{RequestID: "req-1", Model: "openai/gpt-4o", Provider: "openrouter", StatusCode: 502,
+		ErrorType: "server_error", ErrorMessage: "bad gateway", Attempts: 1, CreatedAt: done}
+	if err := store.RecordFailure(failure); err != nil {
+		t.Fatalf("RecordFailure: %v", err)
+	}
+	cfg := &config.Config{Pricing: map[string]config.Price{"openai/gpt-4o": {Input: 2, Output: 10}}}
+	h := &Handlers{Config: cfg, Storage: store}
+
+	t.Run("unknown request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/trace/missing", nil)
+		req.SetPathValue("request_id", "missing")
+		rec := httptest.NewRecorder()
+		h.GetRequestTrace(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", rec.Code)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/trace/req-1", nil)
+	req.SetPathValue("request_id", "req-1")
+	rec := httptest.NewRecorder()
+	h.GetRequestTrace(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var trace RequestTrace
+	if err := json.Unmarshal(rec.Body.Bytes(), &trace); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if trace.Model != "openai/gpt-4o" || trace.StatusCode != 502 || trace.TotalTokens != 1500 || trace.UpstreamAttempts != 2 {
+		t.Errorf("summary = %+v, want the primary log's route, status and tokens", trace)
+	}
+	if trace.CostUSD == nil || *trace.CostUSD != 0.007 {
+		t.Errorf("cost = %v, want 0.007", trace.CostUSD)
+	}
+	if len(trace.Logs) != 2 || len(trace.Failures) != 1 {
+		t.Errorf("records = %d logs, %d failures; want 2 and 1", len(trace.Logs), len(trace.Failures))
+	}
+
+	wantEvents := []string{"received", "routed", "first_byte", "upstream_failure", "failed", "shadow"}
+	if len(trace.Timeline) != len(wantEvents) {
+		t.Fatalf("timeline = %+v, want events %v", trace.Timeline, wantEvents)
+	}
+	for i, want := range wantEvents {
+		if trace.Timeline[i].Event != want {
+			t.Errorf("event %d = %q, want %q", i, trace.Timeline[i].Event, want)
+		}
+	}
+	if received := trace.Timeline[0].Time; !received.Equal(done.Add(-2 * time.Second)) {
+		t.Errorf("received at %v, want the log time minus its duration", received)
+	}
+}
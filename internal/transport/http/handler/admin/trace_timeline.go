@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// traceTimeline orders a request's records into lifecycle events. Logs are
+// written when the response ends, so the receive time is derived from the
+// primary log's duration and the first byte from its TTFB.
+func traceTimeline(primary *storage.RequestLog, logs []*storage.RequestLog, failures []*storage.FailedRequest) []TraceEvent {
+	var events []TraceEvent
+	if primary != nil {
+		received := primary.CreatedAt.Add(-time.Duration(primary.DurationMs) * time.Millisecond)
+		events = append(events,
+			TraceEvent{Time: received, Event: "received", Detail: requestOrigin(primary)},
+			TraceEvent{Time: received, Event: "routed", Detail: routeDetail(primary.Provider, primary.Model, primary.CredentialID)},
+		)
+		if primary.TTFBMs > 0 {
+			events = append(events, TraceEvent{
+				Time:   received.Add(time.Duration(primary.TTFBMs) * time.Millisecond),
+				Event:  "first_byte",
+				Detail: fmt.Sprintf("after %dms", primary.TTFBMs),
+			})
+		}
+	}
+
+	for _, f := range failures {
+		events = append(events, TraceEvent{
+			Time:   f.CreatedAt,
+			Event:  "upstream_failure",
+			Detail: fmt.Sprintf("%s %d from %s after %d attempt(s): %s", f.ErrorType, f.StatusCode, f.Provider, f.Attempts, f.ErrorMessage),
+		})
+	}
+
+	for _, log := range logs {
+		switch {
+		case log.IsShadow:
+			events = append(events, TraceEvent{Time: log.CreatedAt, Event: "shadow",
+				Detail: fmt.Sprintf("status %d from %s, %d tokens", log.StatusCode, routeDetail(log.Provider, log.Model, ""), log.TotalTokens)})
+		case log != primary:
+			continue
+		case log.StatusCode >= 400 || log.ErrorType != "":
+			events = append(events, TraceEvent{Time: log.CreatedAt, Event: "failed",
+				Detail: fmt.Sprintf("status %d %s: %s", log.StatusCode, log.ErrorType, log.ErrorMessage)})
+		default:
+			events = append(events, TraceEvent{Time: log.CreatedAt, Event: "completed",
+				Detail: fmt.Sprintf("status %d, %d prompt + %d completion tokens", log.StatusCode, log.PromptTokens, log.CompletionTokens)})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
+
+// requestOrigin describes who sent a request.
+func requestOrigin(log *storage.RequestLog) string {
+	origin := "api key " + log.APIKeyID
+	if log.APIKeyID == "" {
+		origin = "no api key"
+	}
+	if log.User != "" {
+		origin += ", user " + log.User
+	}
+	if log.IsStreaming {
+		origin += ", streaming"
+	}
+	return origin
+}
+
+// routeDetail describes the upstream a request was sent to.
+func routeDetail(provider, model, credentialID string) string {
+	detail := provider + "/" + model
+	if credentialID != "" {
+		detail += " with credential " + credentialID
+	}
+	return detail
+}
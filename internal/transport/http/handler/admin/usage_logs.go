@@ -19,7 +19,7 @@ func (h *Handlers) GetRequestLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, map[string]any{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"logs":   logs,
 		"limit":  filter.Limit,
 		"offset": filter.Offset,
@@ -45,8 +45,9 @@ func (h *Handlers) DeleteRequestLogs(w http.ResponseWriter, r *http.Request) {
 		shared.WriteJSONError(w, "Failed to delete logs: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.invalidateStatsCache()
 
-	shared.WriteJSON(w, map[string]any{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"deleted_count": deleted,
 		"before_date":   beforeDate,
 	}, http.StatusOK)
@@ -62,6 +63,12 @@ func parseLogFilter(r *http.Request) storage.LogFilter {
 	if v := r.URL.Query().Get("credential_id"); v != "" {
 		filter.CredentialID = v
 	}
+	if v := r.URL.Query().Get("api_key_id"); v != "" {
+		filter.APIKeyID = v
+	}
+	if v := r.URL.Query().Get("user"); v != "" {
+		filter.User = v
+	}
 	if v := r.URL.Query().Get("model"); v != "" {
 		filter.Model = v
 	}
@@ -73,6 +80,9 @@ func parseLogFilter(r *http.Request) storage.LogFilter {
 			filter.StatusCode = &code
 		}
 	}
+	if v := r.URL.Query().Get("anomalous"); v != "" {
+		filter.Anomalous, _ = strconv.ParseBool(v)
+	}
 	if v := r.URL.Query().Get("limit"); v != "" {
 		if limit, err := strconv.Atoi(v); err == nil && limit > 0 {
 			filter.Limit = limit
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// logStorage returns fixed request logs; other methods are unused.
+type logStorage struct {
+	storage.Storage
+	logs []*storage.RequestLog
+}
+
+func (s *logStorage) GetRequestLogs(f storage.LogFilter) ([]*storage.RequestLog, error) {
+	return s.logs, nil
+}
+
+func TestGetRequestLogs_JSONEncoding(t *testing.T) {
+	store := &logStorage{logs: []*storage.RequestLog{
+		{ID: "log-1", Model: "m", ErrorMessage: "tools & <functions> rejected"},
+	}}
+	h := &Handlers{Storage: store}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantIndent bool
+	}{
+		{"compact by default", "", false},
+		{"pretty on request", "?pretty=true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.GetRequestLogs(rec, httptest.NewRequest(http.MethodGet, "/api/admin/logs"+tt.query, nil))
+
+			body := rec.Body.String()
+			if !strings.Contains(body, `"tools & <functions> rejected"`) {
+				t.Errorf("HTML characters escaped in log field: %s", body)
+			}
+			if got := strings.Contains(body, "\n  "); got != tt.wantIndent {
+				t.Errorf("indented = %v, want %v: %s", got, tt.wantIndent, body)
+			}
+		})
+	}
+}
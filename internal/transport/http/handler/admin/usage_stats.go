@@ -9,16 +9,17 @@ import (
 )
 
 // GetUsageStats handles GET /api/admin/usage.
+// Results are cached briefly per filter (see statsCacheTTL).
 func (h *Handlers) GetUsageStats(w http.ResponseWriter, r *http.Request) {
 	filter := parseStatsFilter(r)
 
-	stats, err := h.Storage.GetUsageStats(filter)
+	stats, err := h.usageStats(filter)
 	if err != nil {
 		shared.WriteJSONError(w, "Failed to get usage stats: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	shared.WriteJSON(w, stats, http.StatusOK)
+	shared.WriteAdminJSON(w, r, stats, http.StatusOK)
 }
 
 // GetDailyUsage handles GET /api/admin/usage/daily.
@@ -40,13 +41,27 @@ func (h *Handlers) GetDailyUsage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, map[string]any{
+	shared.WriteAdminJSON(w, r, map[string]any{
 		"daily_usage": usage,
 		"start_date":  startDate,
 		"end_date":    endDate,
 	}, http.StatusOK)
 }
 
+// GetUserUsage handles GET /api/admin/usage/users.
+// Usage is attributed per (API key, end user); api_key_id narrows it to one key.
+func (h *Handlers) GetUserUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := h.Storage.GetUserUsage(r.URL.Query().Get("api_key_id"), parseStatsFilter(r))
+	if err != nil {
+		shared.WriteJSONError(w, "Failed to get user usage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	shared.WriteAdminJSON(w, r, map[string]any{
+		"data": usage,
+	}, http.StatusOK)
+}
+
 // parseStatsFilter creates a StatsFilter from query parameters.
 func parseStatsFilter(r *http.Request) storage.StatsFilter {
 	filter := storage.StatsFilter{}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/config"
 	"github.com/mandalnilabja/goatway/internal/provider"
 	"github.com/mandalnilabja/goatway/internal/storage"
 	"github.com/mandalnilabja/goatway/internal/tokenizer"
@@ -12,6 +13,7 @@ import (
 	"github.com/mandalnilabja/goatway/internal/transport/http/handler/proxy"
 	"github.com/mandalnilabja/goatway/internal/transport/http/handler/webui"
 	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/ratelimit"
 )
 
 // Repo composes all domain-specific handlers.
@@ -23,13 +25,20 @@ type Repo struct {
 }
 
 // NewRepo creates a new instance of the composed handler repository.
-func NewRepo(cache *ristretto.Cache[string, any], prov provider.Provider, store storage.Storage, tok tokenizer.Tokenizer, apiKeyCache *ristretto.Cache[string, *auth.CachedAPIKey]) *Repo {
+func NewRepo(cfg *config.Config, cache *ristretto.Cache[string, any], prov provider.Provider, store storage.Storage, tok tokenizer.Tokenizer, apiKeyCache *ristretto.Cache[string, *auth.CachedAPIKey]) *Repo {
 	startTime := time.Now()
+	adminHandlers := admin.New(cfg, store, startTime, apiKeyCache)
+	adminHandlers.SetTokenizer(tok)
+	adminHandlers.SetResponseCache(cache)
+	proxyHandlers := proxy.New(cfg, prov, store, tok, cache)
+	adminHandlers.SetModelSource(proxyHandlers.ModelIDs)
+	infraHandlers := infra.New(cache, startTime)
+	infraHandlers.SetTokenizer(tok)
 	return &Repo{
-		Admin: admin.New(store, startTime, apiKeyCache),
+		Admin: adminHandlers,
 		WebUI: webui.New(store, nil), // SessionStore set later
-		Proxy: proxy.New(prov, store, tok, cache),
-		Infra: infra.New(cache, startTime),
+		Proxy: proxyHandlers,
+		Infra: infraHandlers,
 	}
 }
 
@@ -42,3 +51,23 @@ func (r *Repo) SetSessionStore(store *auth.SessionStore) {
 func (r *Repo) SetCredentialResolver(cr *provider.CredentialResolver) {
 	r.Admin.SetCredentialResolver(cr)
 }
+
+// SetHealthTracker sets the provider health tracker for the admin status endpoint.
+func (r *Repo) SetHealthTracker(t *provider.HealthTracker) {
+	r.Admin.SetHealthTracker(t)
+}
+
+// SetRouter sets the model router reloaded by the admin alias endpoints.
+func (r *Repo) SetRouter(router *provider.Router) {
+	r.Admin.SetRouter(router)
+}
+
+// SetUserLimiter sets the rate limiter used for per-end-user limits.
+func (r *Repo) SetUserLimiter(l ratelimit.RateLimiter) {
+	r.Proxy.UserLimiter = l
+}
+
+// Close finishes the handlers' background work; see proxy.Handlers.Close.
+func (r *Repo) Close() {
+	r.Proxy.Close()
+}
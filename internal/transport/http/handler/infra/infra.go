@@ -4,12 +4,14 @@ import (
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
 )
 
 // Handlers holds the dependencies for infrastructure HTTP handlers.
 type Handlers struct {
 	Cache     *ristretto.Cache[string, any]
 	StartTime time.Time
+	Tokenizer tokenizer.Tokenizer // Probed by Readiness (nil skips the check)
 }
 
 // New creates a new instance of infrastructure handlers.
@@ -19,3 +21,8 @@ func New(cache *ristretto.Cache[string, any], startTime time.Time) *Handlers {
 		StartTime: startTime,
 	}
 }
+
+// SetTokenizer sets the tokenizer probed by the readiness check.
+func (h *Handlers) SetTokenizer(tok tokenizer.Tokenizer) {
+	h.Tokenizer = tok
+}
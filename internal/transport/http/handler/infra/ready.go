@@ -0,0 +1,30 @@
+package infra
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
+)
+
+// Readiness handles GET /readyz. It reports 503 while a dependency the
+// gateway needs to serve requests is unusable, listing each check's result.
+func (h *Handlers) Readiness(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]string{}
+	status, code := "ready", http.StatusOK
+
+	if h.Tokenizer != nil {
+		checks["tokenizer"] = "ok"
+		if err := tokenizer.Probe(h.Tokenizer); err != nil {
+			checks["tokenizer"] = err.Error()
+			status, code = "not_ready", http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status": status,
+		"checks": checks,
+	})
+}
@@ -0,0 +1,64 @@
+package infra
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/tokenizer"
+)
+
+// probeTokenizer answers CountTokens with a fixed result; other methods are unused.
+type probeTokenizer struct {
+	tokenizer.Tokenizer
+	count int
+	err   error
+}
+
+func (p probeTokenizer) CountTokens(text, model string) (int, error) { return p.count, p.err }
+
+func TestReadiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		tok        tokenizer.Tokenizer
+		wantStatus int
+		wantCheck  string // Expected "tokenizer" check ("" = absent)
+	}{
+		{"tokenizer works", probeTokenizer{count: 1}, http.StatusOK, "ok"},
+		{"encoding data fails to load", probeTokenizer{err: errors.New("no encoding data")}, http.StatusServiceUnavailable, "tokenizer probe: no encoding data"},
+		{"no tokens counted", probeTokenizer{}, http.StatusServiceUnavailable, "tokenizer probe: counted no tokens"},
+		{"no tokenizer", nil, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(nil, time.Now())
+			if tt.tok != nil {
+				h.SetTokenizer(tt.tok)
+			}
+
+			rec := httptest.NewRecorder()
+			h.Readiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body struct {
+				Status string            `json:"status"`
+				Checks map[string]string `json:"checks"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Checks["tokenizer"] != tt.wantCheck {
+				t.Errorf("tokenizer check = %q, want %q", body.Checks["tokenizer"], tt.wantCheck)
+			}
+			if (body.Status == "ready") != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("status field = %q with code %d", body.Status, rec.Code)
+			}
+		})
+	}
+}
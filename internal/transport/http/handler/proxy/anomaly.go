@@ -0,0 +1,28 @@
+package proxy
+
+import (
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// anomalySlackPercent is how far a completion may run over max_tokens before
+// it is flagged; providers count a few tokens past the limit (stop sequences,
+// tokenizer differences), so only a clear overrun is suspicious.
+const anomalySlackPercent = 10
+
+// tokenAnomaly flags a successful result whose upstream-reported usage looks
+// like a billing error: a completion well above the max_tokens sent upstream,
+// or no prompt tokens billed although the prompt counted locally is non-empty.
+// Counts the gateway estimated itself are never flagged.
+func (h *Handlers) tokenAnomaly(opts *provider.ProxyOptions, result *provider.ProxyResult, promptTokens int) string {
+	if h.Config == nil || !h.Config.FlagTokenAnomalies || result.StatusCode >= 400 || result.Error != nil {
+		return ""
+	}
+	switch {
+	case opts.MaxTokens > 0 && result.CompletionTokens*100 > opts.MaxTokens*(100+anomalySlackPercent):
+		return storage.AnomalyCompletionOverLimit
+	case promptTokens > 0 && result.PromptTokens == 0 && result.CompletionTokens > 0:
+		return storage.AnomalyZeroPrompt
+	}
+	return ""
+}
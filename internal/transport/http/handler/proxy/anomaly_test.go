@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestLogChatRequest_FlagsTokenAnomalies(t *testing.T) {
+	tests := []struct {
+		name         string
+		disabled     bool
+		maxTokens    int
+		result       provider.ProxyResult
+		promptTokens int // Counted locally
+		want         string
+	}{
+		{"plausible usage", false, 100, provider.ProxyResult{PromptTokens: 10, CompletionTokens: 100}, 10, ""},
+		{"within slack", false, 100, provider.ProxyResult{PromptTokens: 10, CompletionTokens: 110}, 10, ""},
+		{"completion over max_tokens", false, 100, provider.ProxyResult{PromptTokens: 10, CompletionTokens: 500}, 10, storage.AnomalyCompletionOverLimit},
+		{"no max_tokens sent", false, 0, provider.ProxyResult{PromptTokens: 10, CompletionTokens: 500}, 10, ""},
+		{"zero prompt billed", false, 0, provider.ProxyResult{CompletionTokens: 20}, 10, storage.AnomalyZeroPrompt},
+		{"no upstream usage", false, 0, provider.ProxyResult{}, 10, ""},
+		{"failed request", false, 100, provider.ProxyResult{StatusCode: http.StatusBadGateway, CompletionTokens: 500}, 10, ""},
+		{"detection disabled", true, 100, provider.ProxyResult{PromptTokens: 10, CompletionTokens: 500}, 10, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			h := New(&config.Config{LogConfig: config.LogConfig{FlagTokenAnomalies: !tt.disabled}}, &captureProvider{}, store, nil, nil)
+			result := tt.result
+			result.Model = "m"
+			if result.StatusCode == 0 {
+				result.StatusCode = http.StatusOK
+			}
+
+			h.logChatRequest("req-1", &provider.ProxyOptions{MaxTokens: tt.maxTokens}, &result, tt.promptTokens)
+
+			logs, err := store.GetRequestLogs(storage.LogFilter{})
+			if err != nil || len(logs) != 1 {
+				t.Fatalf("GetRequestLogs = %d logs, %v; want 1", len(logs), err)
+			}
+			if logs[0].Anomaly != tt.want {
+				t.Errorf("anomaly = %q, want %q", logs[0].Anomaly, tt.want)
+			}
+			flagged, _ := store.GetRequestLogs(storage.LogFilter{Anomalous: true})
+			if (len(flagged) == 1) != (tt.want != "") {
+				t.Errorf("anomalous filter returned %d logs", len(flagged))
+			}
+		})
+	}
+}
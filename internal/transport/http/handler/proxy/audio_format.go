@@ -0,0 +1,72 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+)
+
+// defaultSpeechFormat is the response_format used when a request omits it.
+const defaultSpeechFormat = "mp3"
+
+// speechContentTypes maps each TTS response_format to the media type
+// browsers need to play it. Raw PCM (24 kHz, 16-bit little-endian) has no
+// registered type, so it is labeled audio/pcm.
+var speechContentTypes = map[string]string{
+	"mp3":  "audio/mpeg",
+	"opus": "audio/ogg",
+	"aac":  "audio/aac",
+	"flac": "audio/flac",
+	"wav":  "audio/wav",
+	"pcm":  "audio/pcm",
+}
+
+// speechFormatList names the accepted formats for error messages.
+const speechFormatList = "mp3, opus, aac, flac, wav, pcm"
+
+// speechContentType returns the media type for a response_format.
+// An empty format means the mp3 default; unknown formats return false.
+func speechContentType(format string) (string, bool) {
+	if format == "" {
+		format = defaultSpeechFormat
+	}
+	contentType, ok := speechContentTypes[format]
+	return contentType, ok
+}
+
+// contentTypeWriter sets Content-Type on successful non-SSE responses,
+// replacing generic upstream types such as application/octet-stream.
+// Error responses keep the upstream type so JSON errors stay readable.
+type contentTypeWriter struct {
+	http.ResponseWriter
+	contentType string
+	wroteHeader bool
+}
+
+func (w *contentTypeWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code < 400 && !strings.Contains(w.Header().Get("Content-Type"), "text/event-stream") {
+			w.Header().Set("Content-Type", w.contentType)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *contentTypeWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher so streamed audio is not buffered.
+func (w *contentTypeWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *contentTypeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
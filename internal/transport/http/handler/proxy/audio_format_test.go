@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// audioProvider answers like a TTS upstream with a generic or JSON content type.
+type audioProvider struct {
+	captureProvider
+	status int
+}
+
+func (p *audioProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	p.body = []byte("proxied")
+	if p.status >= 400 {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.WriteHeader(p.status)
+	_, _ = w.Write([]byte("audio"))
+	return &types.ProxyResult{StatusCode: p.status}, nil
+}
+
+func TestTextToSpeech_ContentType(t *testing.T) {
+	tests := []struct {
+		format     string
+		status     int
+		wantStatus int
+		wantType   string
+	}{
+		{"", http.StatusOK, http.StatusOK, "audio/mpeg"},
+		{"mp3", http.StatusOK, http.StatusOK, "audio/mpeg"},
+		{"opus", http.StatusOK, http.StatusOK, "audio/ogg"},
+		{"aac", http.StatusOK, http.StatusOK, "audio/aac"},
+		{"flac", http.StatusOK, http.StatusOK, "audio/flac"},
+		{"wav", http.StatusOK, http.StatusOK, "audio/wav"},
+		{"pcm", http.StatusOK, http.StatusOK, "audio/pcm"},
+		{"wav", http.StatusBadRequest, http.StatusBadRequest, "application/json"},
+		{"ogg", http.StatusOK, http.StatusBadRequest, "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+http.StatusText(tt.status), func(t *testing.T) {
+			prov := &audioProvider{status: tt.status}
+			h := New(nil, prov, nil, nil, nil)
+			body := `{"model":"tts-1","input":"hi","voice":"alloy","response_format":"` + tt.format + `"}`
+			rec := httptest.NewRecorder()
+			h.TextToSpeech(rec, httptest.NewRequest(http.MethodPost, "/v1/audio/speech", strings.NewReader(body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantType) {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if rejected := tt.wantStatus != tt.status; rejected == (prov.body != nil) {
+				t.Errorf("proxied = %v, want %v", prov.body != nil, !rejected)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,21 +17,22 @@ func (h *Handlers) Transcription(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Audio transcription uses multipart/form-data
-	// Parse up to 32MB of file data
-	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to parse multipart form"))
+	// Buffer the body so it can be replayed to the provider
+	body, err := bufferMultipart(w, r)
+	if err != nil {
+		writeMultipartError(w, err)
 		return
 	}
 
 	// Get model from form
-	model := r.FormValue("model")
+	model := strings.TrimSpace(r.FormValue("model"))
 	if model == "" {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
 		return
 	}
 
 	// Verify file is present
-	_, _, err := r.FormFile("file")
+	_, _, err = r.FormFile("file")
 	if err != nil {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("audio file is required"))
 		return
@@ -41,14 +43,14 @@ func (h *Handlers) Transcription(w http.ResponseWriter, r *http.Request) {
 		RequestID:   requestID,
 		Model:       model,
 		IsStreaming: false,
-		Body:        nil, // Multipart form is passed through r directly
+		Body:        body, // Buffered multipart body, replayable on retry
 	}
 
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	h.background(func() { h.logSimpleRequest(requestID, opts, model, result, startTime) })
 }
 
 // Translation handles POST /v1/audio/translations requests.
@@ -58,20 +60,22 @@ func (h *Handlers) Translation(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Audio translation uses multipart/form-data
-	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to parse multipart form"))
+	// Buffer the body so it can be replayed to the provider
+	body, err := bufferMultipart(w, r)
+	if err != nil {
+		writeMultipartError(w, err)
 		return
 	}
 
 	// Get model from form
-	model := r.FormValue("model")
+	model := strings.TrimSpace(r.FormValue("model"))
 	if model == "" {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
 		return
 	}
 
 	// Verify file is present
-	_, _, err := r.FormFile("file")
+	_, _, err = r.FormFile("file")
 	if err != nil {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("audio file is required"))
 		return
@@ -82,12 +86,12 @@ func (h *Handlers) Translation(w http.ResponseWriter, r *http.Request) {
 		RequestID:   requestID,
 		Model:       model,
 		IsStreaming: false,
-		Body:        nil, // Multipart form is passed through r directly
+		Body:        body, // Buffered multipart body, replayable on retry
 	}
 
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	h.background(func() { h.logSimpleRequest(requestID, opts, model, result, startTime) })
 }
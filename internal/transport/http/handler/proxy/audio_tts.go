@@ -2,9 +2,8 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,22 +18,20 @@ func (h *Handlers) TextToSpeech(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request
 	var req types.AudioSpeechRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("invalid request format"))
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
 
 	// Validate required fields
-	if req.Model == "" {
+	if req.Model = strings.TrimSpace(req.Model); req.Model == "" {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
 		return
 	}
@@ -46,6 +43,11 @@ func (h *Handlers) TextToSpeech(w http.ResponseWriter, r *http.Request) {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("voice is required"))
 		return
 	}
+	contentType, ok := speechContentType(req.ResponseFormat)
+	if !ok {
+		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("response_format must be one of: "+speechFormatList))
+		return
+	}
 
 	// Build proxy options (credential resolved by Router)
 	opts := &provider.ProxyOptions{
@@ -55,9 +57,11 @@ func (h *Handlers) TextToSpeech(w http.ResponseWriter, r *http.Request) {
 		Body:        bytes.NewReader(bodyBytes),
 	}
 
-	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	// Proxy the request, labeling the audio with the requested format's media type
+	result := h.proxyRequest(&contentTypeWriter{ResponseWriter: w, contentType: contentType}, r, opts)
 
-	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, req.Model, result, startTime)
+	// Log asynchronously; metering counts the input tokens, so it runs there too
+	h.background(func() {
+		h.logMeteredRequest(requestID, opts, req.Model, result, startTime, h.speechMeter(req.Input, req.Model))
+	})
 }
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// defaultMaxBodyBytes caps JSON request bodies when no limit is configured.
+const defaultMaxBodyBytes = 32 << 20
+
+// readBody buffers a JSON request body up to the configured limit. A declared
+// Content-Length over the limit is rejected before any byte is read, and
+// MaxBytesReader stops chunked bodies that exceed it while reading.
+func (h *Handlers) readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	limit := h.maxBodyBytes()
+	if r.ContentLength > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	return body, nil
+}
+
+// maxBodyBytes returns the configured body limit, or the default.
+func (h *Handlers) maxBodyBytes() int64 {
+	if h.Config != nil && h.Config.MaxRequestBodyBytes > 0 {
+		return h.Config.MaxRequestBodyBytes
+	}
+	return defaultMaxBodyBytes
+}
+
+// writeBodyError reports a readBody failure to the client.
+func writeBodyError(w http.ResponseWriter, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		types.WriteError(w, http.StatusRequestEntityTooLarge, types.ErrInvalidRequest("request body too large"))
+		return
+	}
+	types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+}
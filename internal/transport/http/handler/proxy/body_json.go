@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// jsonContextBytes is how much of the body is quoted on each side of a syntax error.
+const jsonContextBytes = 16
+
+// parseBody decodes a JSON request body into v. On failure it writes a 400
+// that locates the problem (byte offset, line and column, and the field for
+// type mismatches) and returns false.
+func parseBody(w http.ResponseWriter, body []byte, v any) bool {
+	err := json.Unmarshal(body, v)
+	if err == nil {
+		return true
+	}
+
+	message, param := describeJSONError(body, err)
+	apiErr := types.ErrInvalidRequest(message)
+	if param != "" {
+		apiErr = types.NewAPIErrorWithParam(message, types.ErrorTypeInvalidRequest, param)
+	}
+	types.WriteError(w, http.StatusBadRequest, apiErr)
+	return false
+}
+
+// describeJSONError explains a decode error for the client and names the
+// offending field when there is one.
+func describeJSONError(body []byte, err error) (message, param string) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case len(bytes.TrimSpace(body)) == 0:
+		return "request body is empty; expected a JSON object", ""
+	case errors.As(err, &syntaxErr):
+		// Offset counts the offending byte, so it sits at Offset-1
+		line, col := lineColumn(body, syntaxErr.Offset-1)
+		return fmt.Sprintf("invalid JSON at byte %d (line %d, column %d): %s, near %q",
+			syntaxErr.Offset, line, col, syntaxErr.Error(), jsonContext(body, syntaxErr.Offset)), ""
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("request body must be a JSON object, got %s", typeErr.Value), ""
+		}
+		return fmt.Sprintf("invalid value for %q ending at byte %d: expected %s, got %s",
+			typeErr.Field, typeErr.Offset, typeErr.Type, typeErr.Value), typeErr.Field
+	}
+	return "invalid request format: " + err.Error(), ""
+}
+
+// lineColumn returns the 1-based line and column of the byte at index.
+func lineColumn(body []byte, index int64) (line, col int) {
+	prefix := body[:min(max(index, 0), int64(len(body)))]
+	line = bytes.Count(prefix, []byte("\n")) + 1
+	col = len(prefix) - bytes.LastIndexByte(prefix, '\n')
+	return line, col
+}
+
+// jsonContext returns the body around offset for quoting in an error message.
+func jsonContext(body []byte, offset int64) string {
+	start := max(offset-jsonContextBytes, 0)
+	end := min(offset+jsonContextBytes, int64(len(body)))
+	return string(body[start:end])
+}
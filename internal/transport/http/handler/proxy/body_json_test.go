@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestParseBody_LocatesMalformedJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantParts []string // Substrings the error message must contain
+		wantParam string
+	}{
+		{
+			name:      "trailing comma",
+			body:      "{\n  \"model\": \"m\",\n  \"messages\": [],\n}",
+			wantParts: []string{"byte 37", "line 4, column 1", `near "`, `[],\n}`},
+		},
+		{
+			name:      "unquoted key",
+			body:      `{"model":"m",messages:[]}`,
+			wantParts: []string{"byte 14", "line 1, column 14", "invalid character 'm'"},
+		},
+		{
+			name:      "truncated body",
+			body:      `{"model":"m","messages":[`,
+			wantParts: []string{"unexpected end of JSON input"},
+		},
+		{
+			name:      "wrong field type",
+			body:      `{"model":"m","messages":[],"temperature":"hot"}`,
+			wantParts: []string{`invalid value for "temperature" ending at byte 46`, "expected float64, got string"},
+			wantParam: "temperature",
+		},
+		{
+			name:      "not an object",
+			body:      `["m"]`,
+			wantParts: []string{"must be a JSON object, got array"},
+		},
+		{
+			name:      "empty body",
+			body:      "  ",
+			wantParts: []string{"request body is empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(nil, &captureProvider{}, nil, nil, nil)
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", rec.Code)
+			}
+			var apiErr types.APIError
+			if err := json.Unmarshal(rec.Body.Bytes(), &apiErr); err != nil {
+				t.Fatalf("error body is not an API error: %s", rec.Body.String())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(apiErr.Error.Message, part) {
+					t.Errorf("message %q does not contain %q", apiErr.Error.Message, part)
+				}
+			}
+			param := ""
+			if apiErr.Error.Param != nil {
+				param = *apiErr.Error.Param
+			}
+			if param != tt.wantParam {
+				t.Errorf("param = %q, want %q", param, tt.wantParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+// countingReader records how many bytes were read from it.
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestChatCompletions_BodySizeLimit(t *testing.T) {
+	const limit = 1024
+	small := `{"model":"m","messages":[{"role":"user","content":"hi"}]}`
+	large := `{"model":"m","messages":[{"role":"user","content":"` + strings.Repeat("a", 4*limit) + `"}]}`
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64 // -1 simulates a chunked request
+		wantStatus    int
+		wantNoRead    bool // rejected before reading the body
+	}{
+		{"within limit", small, int64(len(small)), http.StatusOK, false},
+		{"declared too large", large, int64(len(large)), http.StatusRequestEntityTooLarge, true},
+		{"chunked over limit", large, -1, http.StatusRequestEntityTooLarge, false},
+		{"chunked within limit", small, -1, http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			h := New(&config.Config{MaxRequestBodyBytes: limit}, prov, nil, nil, nil)
+
+			body := &countingReader{r: strings.NewReader(tt.body)}
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", body)
+			req.ContentLength = tt.contentLength
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantNoRead && body.read != 0 {
+				t.Errorf("read %d bytes before rejecting", body.read)
+			}
+			if tt.wantStatus == http.StatusRequestEntityTooLarge {
+				if body.read > limit+1 {
+					t.Errorf("read %d bytes, want at most limit+1", body.read)
+				}
+				if prov.body != nil {
+					t.Error("oversized request was proxied")
+				}
+				if !strings.Contains(rec.Body.String(), "request body too large") {
+					t.Errorf("body = %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -2,14 +2,12 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mandalnilabja/goatway/internal/provider"
-	"github.com/mandalnilabja/goatway/internal/storage"
 	"github.com/mandalnilabja/goatway/internal/types"
 )
 
@@ -22,43 +20,73 @@ func (h *Handlers) ChatCompletions(w http.ResponseWriter, r *http.Request) {
 	requestID := uuid.New().String()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request to extract model and messages
 	var req types.ChatCompletionRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		http.Error(w, "Invalid request format", http.StatusBadRequest)
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
+	types.MarkStreaming(r.Context(), req.Stream)
 
-	// Start token counting in background goroutine (non-blocking)
-	// This allows the proxy request to start immediately without waiting for token counting
-	tokensChan := make(chan int, 1)
-	go func() {
-		defer close(tokensChan)
-		if h.Tokenizer != nil {
-			if tokens, err := h.Tokenizer.CountRequest(&req); err == nil {
-				tokensChan <- tokens
-			}
-		}
-	}()
+	// Fill a missing model from the gateway default, else reject it before the Router
+	if req.Model = strings.TrimSpace(req.Model); req.Model == "" && h.Config != nil {
+		req.Model = h.Config.DefaultChatModel
+	}
+	if req.Model == "" {
+		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
+		return
+	}
+
+	// Reject out-of-range top_logprobs before anything is forwarded
+	if err := validateTopLogprobs(w, &req); err != nil {
+		return
+	}
+
+	// Optionally clamp sampling parameters that some providers reject
+	if h.Config != nil && h.Config.ClampSamplingParams {
+		bodyBytes = clampSamplingParams(bodyBytes, requestID)
+	}
+
+	// Enforce the alias's output token ceiling (clamp or reject)
+	if bodyBytes, err = h.enforceMaxTokens(w, r, &req, bodyBytes, requestID); err != nil {
+		return
+	}
+
+	// Reject requests whose worst-case cost exceeds the alias's ceiling
+	if err := h.enforceCostCeiling(w, r, &req, bodyBytes, requestID); err != nil {
+		return
+	}
 
 	// Build proxy options (credential resolved by Router)
+	maxTokens := h.sentMaxTokens(r, &req)
 	opts := &provider.ProxyOptions{
-		RequestID:    requestID,
-		PromptTokens: 0, // Will be populated from upstream response or background count
-		Model:        req.Model,
-		IsStreaming:  req.Stream,
-		Body:         bytes.NewReader(bodyBytes),
+		RequestID:     requestID,
+		PromptTokens:  0, // Will be populated from upstream response or background count
+		Model:         req.Model,
+		IsStreaming:   req.Stream,
+		Seeded:        req.Seed != nil,
+		MaxTokens:     maxTokens,
+		Body:          bytes.NewReader(bodyBytes),
+		LogBody:       h.loggedBody(bodyBytes),
+		AllowFallback: h.fallbackLimits(r, &req, bodyBytes, maxTokens),
 	}
 
+	// Attribute to the calling key's end user and enforce its per-user limit
+	if !h.attributeUser(w, r, opts, req.User) {
+		return
+	}
+
+	// Queue token counting on the bounded worker pool (non-blocking)
+	// This allows the proxy request to start immediately without waiting for token counting
+	tokensChan := h.countPrompt(&req)
+
 	// Proxy the request immediately - don't wait for token counting
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Collect token count with timeout (100ms max wait)
 	// Token counting may already be done, or we give it a short grace period
@@ -73,52 +101,11 @@ func (h *Handlers) ChatCompletions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log the request asynchronously (credential ID from opts set by Router)
-	go h.logChatRequest(requestID, opts, result, promptTokens)
-}
-
-// logChatRequest logs the proxy request to storage asynchronously.
-func (h *Handlers) logChatRequest(requestID string, opts *provider.ProxyOptions, result *provider.ProxyResult, promptTokens int) {
-	if h.Storage == nil || result == nil {
-		return
-	}
-
-	// Get credential ID from opts (set by Router)
-	credentialID := ""
-	if opts.Credential != nil {
-		credentialID = opts.Credential.ID
-	}
-
-	// Use upstream token counts if available, otherwise use pre-calculated
-	prompt := result.PromptTokens
-	if prompt == 0 {
-		prompt = promptTokens
-	}
-	completion := result.CompletionTokens
-	total := result.TotalTokens
-	if total == 0 {
-		total = prompt + completion
-	}
+	h.background(func() { h.logChatRequest(requestID, opts, result, promptTokens) })
 
-	// Create request log entry
-	log := &storage.RequestLog{
-		ID:               uuid.New().String(),
-		RequestID:        requestID,
-		CredentialID:     credentialID,
-		Model:            result.Model,
-		Provider:         h.Provider.Name(),
-		PromptTokens:     prompt,
-		CompletionTokens: completion,
-		TotalTokens:      total,
-		IsStreaming:      result.IsStreaming,
-		StatusCode:       result.StatusCode,
-		ErrorMessage:     result.ErrorMessage,
-		DurationMs:       result.Duration.Milliseconds(),
-		CreatedAt:        time.Now(),
+	// Mirror non-streaming requests to the shadow model, if configured
+	if h.Config != nil && h.Config.Shadow != nil && !req.Stream {
+		shadow := shadowRequest(r)
+		h.background(func() { h.runShadow(shadow, requestID, bodyBytes, promptTokens) })
 	}
-
-	// Log to storage (ignore errors in async context)
-	_ = h.Storage.LogRequest(log)
-
-	// Update daily usage aggregates
-	h.updateDailyUsage(credentialID, result, prompt, completion, total)
 }
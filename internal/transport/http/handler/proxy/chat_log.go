@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// logChatRequest logs the proxy request to storage asynchronously.
+func (h *Handlers) logChatRequest(requestID string, opts *provider.ProxyOptions, result *provider.ProxyResult, promptTokens int) {
+	if h.Storage == nil || result == nil {
+		return
+	}
+
+	log := h.chatRequestLog(requestID, opts, result, promptTokens)
+	logLatency(requestID, result)
+
+	// Upstream failures are recorded in full regardless of the logging opt-out
+	h.recordFailure(log, opts)
+
+	// Log to storage unless the credential opted out (transient failures are retried in the background)
+	if logsRequests(opts.Credential) {
+		h.logRequest(log)
+	}
+
+	// Update daily usage aggregates
+	h.updateDailyUsage(log.CredentialID, result, log.PromptTokens, log.CompletionTokens, log.TotalTokens)
+}
+
+// chatRequestLog builds the request log entry for a chat completion result.
+func (h *Handlers) chatRequestLog(requestID string, opts *provider.ProxyOptions, result *provider.ProxyResult, promptTokens int) *storage.RequestLog {
+	// Get credential ID from opts (set by Router)
+	credentialID := ""
+	if opts.Credential != nil {
+		credentialID = opts.Credential.ID
+	}
+
+	// Use upstream token counts if available, otherwise use pre-calculated
+	prompt := result.PromptTokens
+	if prompt == 0 {
+		prompt = promptTokens
+	}
+	completion := result.CompletionTokens
+	if completion == 0 {
+		completion = h.countCompletion(result)
+	}
+	total := result.TotalTokens
+	if total == 0 {
+		total = prompt + completion
+	}
+
+	return &storage.RequestLog{
+		ID:               uuid.New().String(),
+		RequestID:        requestID,
+		CredentialID:     credentialID,
+		APIKeyID:         opts.APIKeyID,
+		User:             opts.User,
+		Model:            result.Model,
+		Provider:         h.Provider.Name(),
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      total,
+		IsStreaming:      result.IsStreaming,
+		StatusCode:       result.StatusCode,
+		Attempts:         result.Attempts,
+		ErrorMessage:     result.ErrorMessage,
+		ErrorType:        errorType(result),
+		DurationMs:       result.Duration.Milliseconds(),
+		TTFBMs:           result.TTFB.Milliseconds(),
+		ToolCalls:        result.ToolCalls,
+		Anomaly:          h.tokenAnomaly(opts, result, promptTokens),
+		CreatedAt:        time.Now(),
+		RequestBody:      string(opts.LogBody),
+	}
+}
+
+// loggedBody returns the request body to store with the log, or nil when
+// request body logging is off.
+func (h *Handlers) loggedBody(body []byte) []byte {
+	if h.Config == nil || !h.Config.LogRequestBodies {
+		return nil
+	}
+	return body
+}
+
+// truncateBody cuts a stored request body to LogBodyMaxBytes, backing off to
+// a rune boundary so the stored text stays valid UTF-8.
+func (h *Handlers) truncateBody(body string) string {
+	if h.Config == nil || h.Config.LogBodyMaxBytes <= 0 || len(body) <= h.Config.LogBodyMaxBytes {
+		return body
+	}
+	n := h.Config.LogBodyMaxBytes
+	for n > 0 && !utf8.RuneStart(body[n]) {
+		n--
+	}
+	return body[:n]
+}
+
+// logLatency emits the latency breakdown so slow requests can be attributed
+// to the gateway (resolution) or the model (TTFB and upstream time).
+func logLatency(requestID string, result *provider.ProxyResult) {
+	slog.Debug("request latency",
+		"request_id", requestID,
+		"model", result.Model,
+		"gateway_overhead_ms", result.GatewayOverhead.Milliseconds(),
+		"ttfb_ms", result.TTFB.Milliseconds(),
+		"upstream_ms", result.UpstreamDuration.Milliseconds(),
+	)
+}
@@ -0,0 +1,26 @@
+package proxy
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestTruncateBody(t *testing.T) {
+	h := &Handlers{Config: &config.Config{LogConfig: config.LogConfig{LogBodyMaxBytes: 8}}}
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"a":1}`, `{"a":1}`},
+		{`{"a":"bcdefgh"}`, `{"a":"bc`},
+		{`{"a":"héé"}`, `{"a":"h`}, // Cut backs off to the start of the split é
+	}
+	for _, tt := range tests {
+		got := h.truncateBody(tt.body)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncateBody(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// samplingRanges lists the sampling parameters clamped when ClampSamplingParams is enabled.
+var samplingRanges = []struct {
+	name     string
+	min, max float64
+}{
+	{"temperature", 0, 2},
+	{"top_p", 0, 1},
+}
+
+// clampSamplingParams clamps out-of-range sampling parameters in a JSON request body.
+// Only the offending fields are replaced; other fields keep their original bytes.
+// The original body is returned unchanged when nothing needs clamping.
+func clampSamplingParams(body []byte, requestID string) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+
+	changed := false
+	for _, r := range samplingRanges {
+		raw, ok := fields[r.name]
+		if !ok {
+			continue
+		}
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			continue // null or invalid; leave for upstream validation
+		}
+
+		clamped := min(max(value, r.min), r.max)
+		if clamped == value {
+			continue
+		}
+		fields[r.name], _ = json.Marshal(clamped)
+		changed = true
+		slog.Info("clamped sampling parameter",
+			"request_id", requestID, "param", r.name, "from", value, "to", clamped)
+	}
+
+	if !changed {
+		return body
+	}
+	rewritten, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestClampSamplingParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantTemp any
+		wantTopP any
+	}{
+		{"temperature above range", `{"model":"m","temperature":3.5}`, 2.0, nil},
+		{"temperature below range", `{"model":"m","temperature":-1}`, 0.0, nil},
+		{"top_p above range", `{"model":"m","top_p":1.5}`, nil, 1.0},
+		{"both out of range", `{"model":"m","temperature":9,"top_p":-0.2}`, 2.0, 0.0},
+		{"in range untouched", `{"model":"m","temperature":0.7,"top_p":0.9}`, 0.7, 0.9},
+		{"null untouched", `{"model":"m","temperature":null}`, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := clampSamplingParams([]byte(tt.body), "req-1")
+
+			var got map[string]any
+			if err := json.Unmarshal(out, &got); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if got["temperature"] != tt.wantTemp {
+				t.Errorf("temperature = %v, want %v", got["temperature"], tt.wantTemp)
+			}
+			if got["top_p"] != tt.wantTopP {
+				t.Errorf("top_p = %v, want %v", got["top_p"], tt.wantTopP)
+			}
+			if got["model"] != "m" {
+				t.Errorf("model = %v, want m", got["model"])
+			}
+		})
+	}
+}
+
+func TestClampSamplingParams_InRangeBodyIsUnchanged(t *testing.T) {
+	body := []byte(`{"top_p":0.5, "model":"m", "temperature":1}`)
+	if out := clampSamplingParams(body, "req-1"); !bytes.Equal(out, body) {
+		t.Errorf("body rewritten: %s", out)
+	}
+}
+
+// captureProvider records the body and model forwarded by the handler.
+type captureProvider struct {
+	body  []byte
+	model string
+}
+
+func (p *captureProvider) Name() string                                                { return "capture" }
+func (p *captureProvider) BaseURL() string                                             { return "" }
+func (p *captureProvider) PrepareRequest(ctx context.Context, req *http.Request) error { return nil }
+func (p *captureProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	p.body, _ = io.ReadAll(opts.Body)
+	p.model = opts.Model
+	w.WriteHeader(http.StatusOK)
+	return &types.ProxyResult{StatusCode: http.StatusOK}, nil
+}
+
+func TestChatCompletions_ClampSamplingParams(t *testing.T) {
+	body := `{"model":"m","messages":[{"role":"user","content":"hi"}],"temperature":5}`
+
+	tests := []struct {
+		name     string
+		clamp    bool
+		wantTemp float64
+	}{
+		{"enabled", true, 2},
+		{"disabled", false, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			h := New(&config.Config{ClampSamplingParams: tt.clamp}, prov, nil, nil, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+			h.ChatCompletions(httptest.NewRecorder(), req)
+
+			var sent struct {
+				Temperature float64 `json:"temperature"`
+			}
+			if err := json.Unmarshal(prov.body, &sent); err != nil {
+				t.Fatalf("forwarded body: %v", err)
+			}
+			if sent.Temperature != tt.wantTemp {
+				t.Errorf("temperature = %v, want %v", sent.Temperature, tt.wantTemp)
+			}
+		})
+	}
+}
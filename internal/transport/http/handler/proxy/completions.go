@@ -2,9 +2,8 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,22 +19,21 @@ func (h *Handlers) LegacyCompletion(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request
 	var req types.CompletionRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("invalid request format"))
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
+	types.MarkStreaming(r.Context(), req.Stream)
 
 	// Validate required fields
-	if req.Model == "" {
+	if req.Model = strings.TrimSpace(req.Model); req.Model == "" {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
 		return
 	}
@@ -44,6 +42,11 @@ func (h *Handlers) LegacyCompletion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optionally clamp sampling parameters that some providers reject
+	if h.Config != nil && h.Config.ClampSamplingParams {
+		bodyBytes = clampSamplingParams(bodyBytes, requestID)
+	}
+
 	// Build proxy options (credential resolved by Router)
 	opts := &provider.ProxyOptions{
 		RequestID:   requestID,
@@ -52,11 +55,16 @@ func (h *Handlers) LegacyCompletion(w http.ResponseWriter, r *http.Request) {
 		Body:        bytes.NewReader(bodyBytes),
 	}
 
+	// Attribute to the calling key's end user and enforce its per-user limit
+	if !h.attributeUser(w, r, opts, req.User) {
+		return
+	}
+
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logCompletionRequest(requestID, opts, result, startTime)
+	h.background(func() { h.logCompletionRequest(requestID, opts, result, startTime) })
 }
 
 // logCompletionRequest logs a completion request to storage.
@@ -84,6 +92,8 @@ func (h *Handlers) logCompletionRequest(requestID string, opts *provider.ProxyOp
 		ID:               uuid.New().String(),
 		RequestID:        requestID,
 		CredentialID:     credentialID,
+		APIKeyID:         opts.APIKeyID,
+		User:             opts.User,
 		Model:            result.Model,
 		Provider:         h.Provider.Name(),
 		PromptTokens:     prompt,
@@ -91,12 +101,18 @@ func (h *Handlers) logCompletionRequest(requestID string, opts *provider.ProxyOp
 		TotalTokens:      total,
 		IsStreaming:      result.IsStreaming,
 		StatusCode:       result.StatusCode,
+		Attempts:         result.Attempts,
 		ErrorMessage:     result.ErrorMessage,
+		ErrorType:        errorType(result),
 		DurationMs:       duration.Milliseconds(),
+		TTFBMs:           result.TTFB.Milliseconds(),
 		CreatedAt:        time.Now(),
 	}
 
-	_ = h.Storage.LogRequest(log)
+	h.recordFailure(log, opts)
+	if logsRequests(opts.Credential) {
+		h.logRequest(log)
+	}
 
 	// Update daily usage
 	h.updateDailyUsage(credentialID, result, prompt, completion, total)
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// enforceCostCeiling rejects a chat request with 400 when its worst-case cost
+// exceeds the alias's max_cost_usd, or cannot be bounded because it sets no
+// output limit. Aliases without a ceiling or a price are not checked, so no
+// prompt tokens are counted for them.
+func (h *Handlers) enforceCostCeiling(w http.ResponseWriter, r *http.Request, req *types.ChatCompletionRequest, body []byte, requestID string) error {
+	alias := h.alias(r, req.Model)
+	if alias == nil {
+		return nil
+	}
+	err := h.costCeilingError(alias, outputLimit(req, alias), func() int { return h.countPromptNow(req, body) })
+	if err == nil {
+		return nil
+	}
+
+	slog.Info("rejected request over cost ceiling", "request_id", requestID, "model", req.Model, "error", err)
+	types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest(err.Error()))
+	return err
+}
+
+// costCeilingError returns why a request billed for up to outputTokens (0 when
+// unbounded) may cost more than alias's max_cost_usd, or nil. promptTokens is
+// only called for a priced alias with a ceiling.
+func (h *Handlers) costCeilingError(alias *config.ModelAlias, outputTokens int, promptTokens func() int) error {
+	if alias.MaxCostUSD <= 0 || h.Config == nil {
+		return nil
+	}
+	price, ok := config.LookupPrice(h.Config.Pricing, alias.Slug, alias.Model)
+	if !ok {
+		return nil
+	}
+	if outputTokens <= 0 {
+		return fmt.Errorf("max_tokens is required for model %s, whose cost limit of $%.4f cannot be checked against an unbounded completion",
+			alias.Slug, alias.MaxCostUSD)
+	}
+	if cost := price.Cost(promptTokens(), outputTokens); cost > alias.MaxCostUSD {
+		return fmt.Errorf("estimated worst-case cost $%.4f exceeds the limit of $%.4f for model %s; lower max_tokens or shorten the prompt",
+			cost, alias.MaxCostUSD, alias.Slug)
+	}
+	return nil
+}
+
+// outputLimit returns the most output tokens the request can be billed for:
+// its max_tokens, capped by the alias's max_output_tokens. With neither set
+// the output is unbounded and 0 is returned.
+func outputLimit(req *types.ChatCompletionRequest, alias *config.ModelAlias) int {
+	requested := req.GetMaxTokens()
+	if ceiling := alias.MaxOutputTokens; ceiling > 0 && (requested <= 0 || requested > ceiling) {
+		return ceiling
+	}
+	return max(requested, 0)
+}
+
+// countPromptNow counts prompt tokens before proxying, falling back to
+// ~4 bytes per token of the raw body when the tokenizer is unavailable.
+func (h *Handlers) countPromptNow(req *types.ChatCompletionRequest, body []byte) int {
+	if tokens, ok := <-h.countPrompt(req); ok {
+		return tokens
+	}
+	return (len(body) + 3) / 4
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+var testPricing = map[string]config.Price{
+	"openai/gpt-4o": {Input: 2.5, Output: 10},
+	"cheap":         {Input: 0.1, Output: 0.4},
+}
+
+func TestWorstCaseCost(t *testing.T) {
+	maxTokens := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		alias     config.ModelAlias
+		maxTokens *int
+		prompt    int
+		wantPrice bool
+		want      float64
+	}{
+		{"priced by upstream model", config.ModelAlias{Slug: "gpt4", Model: "openai/gpt-4o"}, maxTokens(1000), 2000, true, 0.015},
+		{"slug price wins", config.ModelAlias{Slug: "cheap", Model: "openai/gpt-4o"}, maxTokens(1000), 2000, true, 0.0006},
+		{"alias ceiling caps output", config.ModelAlias{Slug: "gpt4", Model: "openai/gpt-4o", MaxOutputTokens: 100}, maxTokens(1000), 0, true, 0.001},
+		{"alias ceiling when unset", config.ModelAlias{Slug: "gpt4", Model: "openai/gpt-4o", MaxOutputTokens: 100}, nil, 1000, true, 0.0035},
+		{"unbounded output prices prompt only", config.ModelAlias{Slug: "gpt4", Model: "openai/gpt-4o"}, nil, 1000, true, 0.0025}, // Rejected by the guard
+		{"unpriced model", config.ModelAlias{Slug: "x", Model: "other/model"}, maxTokens(1000), 1000, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, ok := config.LookupPrice(testPricing, tt.alias.Slug, tt.alias.Model)
+			if ok != tt.wantPrice {
+				t.Fatalf("lookupPrice found = %v, want %v", ok, tt.wantPrice)
+			}
+			req := &types.ChatCompletionRequest{MaxTokens: tt.maxTokens}
+			got := price.Cost(tt.prompt, outputLimit(req, &tt.alias))
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("worst-case cost = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletions_CostCeiling(t *testing.T) {
+	cfg := &config.Config{
+		Models:  []config.ModelAlias{{Slug: "gpt4", Model: "openai/gpt-4o", MaxCostUSD: 0.01}},
+		Pricing: testPricing,
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantError  string // Substring of the 400 error
+	}{
+		{"under ceiling", `{"model":"gpt4","max_tokens":500,"messages":[{"role":"user","content":"hi"}]}`, http.StatusOK, ""},
+		{"over ceiling", `{"model":"gpt4","max_tokens":2000,"messages":[{"role":"user","content":"hi"}]}`, http.StatusBadRequest, "worst-case cost"},
+		{"unbounded output", `{"model":"gpt4","messages":[{"role":"user","content":"hi"}]}`, http.StatusBadRequest, "max_tokens is required"},
+		{"no ceiling on other models", `{"model":"cheap","max_tokens":2000000,"messages":[{"role":"user","content":"hi"}]}`, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			h := New(cfg, prov, nil, nil, nil)
+
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if forwarded := prov.model != ""; forwarded != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("forwarded upstream = %v", forwarded)
+			}
+			if tt.wantStatus == http.StatusBadRequest && !strings.Contains(rec.Body.String(), tt.wantError) {
+				t.Errorf("error body = %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package proxy
+
+import (
+	"log/slog"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// countQueuePerWorker bounds how many counting jobs may wait per worker.
+// When the queue is full the job is skipped and upstream usage is used instead.
+const countQueuePerWorker = 64
+
+// defaultCountWorkers is used when the config does not set a pool size.
+const defaultCountWorkers = 8
+
+// countJob is a queued token count whose result is delivered on out.
+type countJob struct {
+	count func() (int, error)
+	out   chan int
+}
+
+// countPool runs token counting on a fixed number of worker goroutines so
+// request bursts cannot spawn unbounded counters.
+type countPool struct {
+	jobs    chan countJob
+	mu      sync.RWMutex // Guards closing jobs against concurrent submits
+	closed  bool
+	workers sync.WaitGroup
+	dropped atomic.Uint64 // Jobs skipped because the queue was full or closed
+}
+
+// newCountPool starts workers goroutines draining a bounded job queue.
+func newCountPool(workers int) *countPool {
+	if workers <= 0 {
+		workers = defaultCountWorkers
+	}
+	p := &countPool{jobs: make(chan countJob, workers*countQueuePerWorker)}
+	p.workers.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+// stop closes the queue and waits for the workers to finish queued jobs.
+// Later submits are dropped.
+func (p *countPool) stop() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+	p.workers.Wait()
+}
+
+// work runs queued jobs until the queue is closed.
+func (p *countPool) work() {
+	defer p.workers.Done()
+	for job := range p.jobs {
+		if tokens, err := job.count(); err == nil {
+			job.out <- tokens
+		}
+		close(job.out)
+	}
+}
+
+// submit queues count without blocking the caller. The returned channel
+// yields the count (if it succeeded) and is then closed; it is closed
+// immediately when the queue is full or stopped.
+func (p *countPool) submit(count func() (int, error)) <-chan int {
+	out := make(chan int, 1)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.closed {
+		select {
+		case p.jobs <- countJob{count: count, out: out}:
+			return out
+		default:
+		}
+	}
+	close(out)
+	p.drop()
+	return out
+}
+
+// drop counts a skipped job, logging at powers of two so a sustained
+// overload is visible without a line per request.
+func (p *countPool) drop() {
+	if n := p.dropped.Add(1); bits.OnesCount64(n) == 1 {
+		slog.Warn("token count queue full; using upstream usage", "dropped", n)
+	}
+}
@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCountPool_CapsConcurrentCounters(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		jobs    int
+	}{
+		{"single worker", 1, 5},
+		{"pool smaller than burst", 3, 20},
+		{"pool larger than burst", 8, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newCountPool(tt.workers)
+			release := make(chan struct{})
+			var running, peak atomic.Int32
+
+			outs := make([]<-chan int, tt.jobs)
+			for i := range outs {
+				outs[i] = pool.submit(func() (int, error) {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					<-release
+					running.Add(-1)
+					return 7, nil
+				})
+			}
+
+			// Let every available worker pick up a job before releasing them
+			want := int32(min(tt.workers, tt.jobs))
+			deadline := time.Now().Add(2 * time.Second)
+			for running.Load() < want && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(20 * time.Millisecond)
+			if got := running.Load(); got != want {
+				t.Errorf("running counters = %d, want %d", got, want)
+			}
+			close(release)
+
+			var wg sync.WaitGroup
+			for _, out := range outs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if tokens := <-out; tokens != 7 {
+						t.Errorf("tokens = %d, want 7", tokens)
+					}
+				}()
+			}
+			wg.Wait()
+			if got := peak.Load(); got > int32(tt.workers) {
+				t.Errorf("peak concurrent counters = %d, exceeds pool size %d", got, tt.workers)
+			}
+		})
+	}
+}
+
+func TestCountPool_SkipsWhenQueueFull(t *testing.T) {
+	pool := &countPool{jobs: make(chan countJob)} // no workers, no queue capacity
+
+	out := pool.submit(func() (int, error) { return 1, nil })
+	select {
+	case tokens, ok := <-out:
+		if ok {
+			t.Errorf("expected closed channel, got %d", tokens)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("submit blocked on a full queue")
+	}
+	if got := pool.dropped.Load(); got != 1 {
+		t.Errorf("dropped = %d, want 1", got)
+	}
+}
+
+func TestCountPool_StopFinishesQueuedJobs(t *testing.T) {
+	pool := newCountPool(1)
+	release := make(chan struct{})
+	first := pool.submit(func() (int, error) { <-release; return 1, nil })
+	queued := pool.submit(func() (int, error) { return 2, nil })
+
+	stopped := make(chan struct{})
+	go func() {
+		pool.stop()
+		close(stopped)
+	}()
+	close(release)
+	<-stopped
+
+	if got := <-first; got != 1 {
+		t.Errorf("running job = %d, want 1", got)
+	}
+	if got := <-queued; got != 2 {
+		t.Errorf("queued job = %d, want 2 (stop must drain the queue)", got)
+	}
+	if _, ok := <-pool.submit(func() (int, error) { return 3, nil }); ok {
+		t.Error("submit after stop ran the job")
+	}
+	if got := pool.dropped.Load(); got != 1 {
+		t.Errorf("dropped = %d, want 1", got)
+	}
+	pool.stop() // Stopping twice is harmless
+}
@@ -2,9 +2,8 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,22 +19,20 @@ func (h *Handlers) Embeddings(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request to extract model
 	var req types.EmbeddingsRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("invalid request format"))
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
 
 	// Validate required fields
-	if req.Model == "" {
+	if req.Model = strings.TrimSpace(req.Model); req.Model == "" {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("model is required"))
 		return
 	}
@@ -52,11 +49,16 @@ func (h *Handlers) Embeddings(w http.ResponseWriter, r *http.Request) {
 		Body:        bytes.NewReader(bodyBytes),
 	}
 
+	// Attribute to the calling key's end user and enforce its per-user limit
+	if !h.attributeUser(w, r, opts, req.User) {
+		return
+	}
+
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log the request asynchronously
-	go h.logEmbeddingsRequest(requestID, opts, req.Model, result, startTime)
+	h.background(func() { h.logEmbeddingsRequest(requestID, opts, req.Model, result, startTime) })
 }
 
 // logEmbeddingsRequest logs an embeddings request to storage.
@@ -76,18 +78,25 @@ func (h *Handlers) logEmbeddingsRequest(requestID string, opts *provider.ProxyOp
 		ID:           uuid.New().String(),
 		RequestID:    requestID,
 		CredentialID: credentialID,
+		APIKeyID:     opts.APIKeyID,
+		User:         opts.User,
 		Model:        model,
 		Provider:     h.Provider.Name(),
 		PromptTokens: result.PromptTokens,
 		TotalTokens:  result.TotalTokens,
 		IsStreaming:  false,
 		StatusCode:   result.StatusCode,
+		Attempts:     result.Attempts,
 		ErrorMessage: result.ErrorMessage,
+		ErrorType:    errorType(result),
 		DurationMs:   duration.Milliseconds(),
 		CreatedAt:    time.Now(),
 	}
 
-	_ = h.Storage.LogRequest(log)
+	h.recordFailure(log, opts)
+	if logsRequests(opts.Credential) {
+		h.logRequest(log)
+	}
 
 	// Update daily usage
 	h.updateDailyUsage(credentialID, result, result.PromptTokens, 0, result.TotalTokens)
@@ -0,0 +1,35 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// recordFailure persists a failure record when log describes an upstream
+// 5xx or timeout. It ignores the credential's logging opt-out because the
+// record is kept for analysis and replay rather than usage history.
+func (h *Handlers) recordFailure(log *storage.RequestLog, opts *provider.ProxyOptions) {
+	if log.StatusCode < http.StatusInternalServerError && log.ErrorType != types.ErrorClassTimeout {
+		return
+	}
+
+	failure := &storage.FailedRequest{
+		RequestID:    log.RequestID,
+		Model:        log.Model,
+		Provider:     log.Provider,
+		CredentialID: log.CredentialID,
+		APIKeyID:     log.APIKeyID,
+		StatusCode:   log.StatusCode,
+		ErrorType:    log.ErrorType,
+		ErrorMessage: log.ErrorMessage,
+		Attempts:     log.Attempts,
+		CreatedAt:    log.CreatedAt,
+	}
+	if opts.Credential != nil {
+		failure.Provider = opts.Credential.Provider // The router's own name says nothing about the upstream
+	}
+	h.persist("record failure", func() error { return h.Storage.RecordFailure(failure) })
+}
@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestLogChatRequest_RecordsUpstreamFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *provider.ProxyResult
+		wantType  string // empty when no failure is recorded
+		wantCount int
+	}{
+		{"bad gateway", &provider.ProxyResult{StatusCode: http.StatusBadGateway, ErrorMessage: "upstream down"}, types.ErrorClassServer, 1},
+		{"gateway timeout", &provider.ProxyResult{StatusCode: http.StatusGatewayTimeout}, types.ErrorClassTimeout, 1},
+		{"stream timeout", &provider.ProxyResult{StatusCode: http.StatusOK, ErrorType: types.ErrorClassTimeout}, types.ErrorClassTimeout, 1},
+		{"client error", &provider.ProxyResult{StatusCode: http.StatusBadRequest}, "", 0},
+		{"success", &provider.ProxyResult{StatusCode: http.StatusOK}, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			h := New(nil, &captureProvider{}, store, nil, nil)
+			tt.result.Model, tt.result.Attempts = "m", 2
+			opts := &provider.ProxyOptions{
+				APIKeyID:   "key-1",
+				Credential: &storage.Credential{ID: "cred-1", Provider: "openrouter", LogRequests: false},
+			}
+
+			h.logChatRequest("req-1", opts, tt.result, 0)
+
+			failures, err := store.ListFailures(storage.FailureFilter{})
+			if err != nil || len(failures) != tt.wantCount {
+				t.Fatalf("ListFailures = %d records, %v; want %d", len(failures), err, tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			got := failures[0]
+			if got.RequestID != "req-1" || got.Model != "m" || got.Provider != "openrouter" ||
+				got.CredentialID != "cred-1" || got.APIKeyID != "key-1" || got.Attempts != 2 {
+				t.Errorf("failure = %+v", got)
+			}
+			if got.StatusCode != tt.result.StatusCode || got.ErrorType != tt.wantType {
+				t.Errorf("status %d type %q, want %d %q", got.StatusCode, got.ErrorType, tt.result.StatusCode, tt.wantType)
+			}
+			if logs, _ := store.GetRequestLogs(storage.LogFilter{}); len(logs) != 0 {
+				t.Errorf("opted-out credential wrote %d request logs", len(logs))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// fallbackLimits returns the check the router runs before falling back to
+// another alias. enforceMaxTokens and enforceCostCeiling only saw the alias
+// the client named, so a fallback is skipped when the body as sent (with
+// sentMaxTokens output tokens) exceeds its max_output_tokens or max_cost_usd.
+// Prompt tokens are only counted when a fallback has a priced cost ceiling.
+func (h *Handlers) fallbackLimits(r *http.Request, req *types.ChatCompletionRequest, body []byte, sentMaxTokens int) func(slug string) error {
+	return func(slug string) error {
+		alias := h.alias(r, slug)
+		if alias == nil {
+			return nil
+		}
+		output := sentMaxTokens
+		if ceiling := alias.MaxOutputTokens; ceiling > 0 {
+			if output > ceiling {
+				return fmt.Errorf("max tokens %d exceeds the limit of %d for model %s", output, ceiling, slug)
+			}
+			if output <= 0 {
+				output = ceiling
+			}
+		}
+		return h.costCeilingError(alias, output, func() int { return h.countPromptNow(req, body) })
+	}
+}
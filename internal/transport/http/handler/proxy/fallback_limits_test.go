@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestFallbackLimits(t *testing.T) {
+	h := &Handlers{Config: &config.Config{
+		Models: []config.ModelAlias{
+			{Slug: "small", Model: "openai/gpt-4o", MaxOutputTokens: 100},
+			{Slug: "capped", Model: "openai/gpt-4o", MaxCostUSD: 0.01},
+			{Slug: "open", Model: "openai/gpt-4o"},
+		},
+		Pricing: testPricing,
+	}}
+	body := []byte(`{"model":"primary","messages":[{"role":"user","content":"` + strings.Repeat("x", 4000) + `"}]}`)
+	req := &types.ChatCompletionRequest{Model: "primary"}
+
+	tests := []struct {
+		name      string
+		slug      string
+		maxTokens int // Output limit sent upstream
+		wantErr   bool
+	}{
+		{"unaliased slug", "other", 5000, false},
+		{"no limits", "open", 5000, false},
+		{"under output ceiling", "small", 100, false},
+		{"over output ceiling", "small", 500, true},
+		{"under cost ceiling", "capped", 100, false},
+		{"over cost ceiling", "capped", 5000, true},
+		{"unbounded output under cost ceiling", "capped", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := h.fallbackLimits(httptest.NewRequest("POST", "/v1/chat/completions", nil), req, body, tt.maxTokens)
+			if err := check(tt.slug); (err != nil) != tt.wantErr {
+				t.Errorf("check(%q) = %v, wantErr %v", tt.slug, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,13 +17,15 @@ func (h *Handlers) ImageEdit(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Image edit uses multipart/form-data
-	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to parse multipart form"))
+	// Buffer the body so it can be replayed to the provider
+	body, err := bufferMultipart(w, r)
+	if err != nil {
+		writeMultipartError(w, err)
 		return
 	}
 
 	// Verify image file is present
-	_, _, err := r.FormFile("image")
+	_, _, err = r.FormFile("image")
 	if err != nil {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("image file is required"))
 		return
@@ -36,7 +39,7 @@ func (h *Handlers) ImageEdit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get model (optional)
-	model := r.FormValue("model")
+	model := strings.TrimSpace(r.FormValue("model"))
 	if model == "" {
 		model = "dall-e-2"
 	}
@@ -46,14 +49,15 @@ func (h *Handlers) ImageEdit(w http.ResponseWriter, r *http.Request) {
 		RequestID:   requestID,
 		Model:       model,
 		IsStreaming: false,
-		Body:        nil, // Multipart form is passed through r directly
+		Body:        body, // Buffered multipart body, replayable on retry
 	}
 
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	meter := formImageMeter(r.FormValue("n"))
+	h.background(func() { h.logMeteredRequest(requestID, opts, model, result, startTime, meter) })
 }
 
 // ImageVariation handles POST /v1/images/variations requests.
@@ -63,20 +67,22 @@ func (h *Handlers) ImageVariation(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Image variation uses multipart/form-data
-	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to parse multipart form"))
+	// Buffer the body so it can be replayed to the provider
+	body, err := bufferMultipart(w, r)
+	if err != nil {
+		writeMultipartError(w, err)
 		return
 	}
 
 	// Verify image file is present
-	_, _, err := r.FormFile("image")
+	_, _, err = r.FormFile("image")
 	if err != nil {
 		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("image file is required"))
 		return
 	}
 
 	// Get model (optional)
-	model := r.FormValue("model")
+	model := strings.TrimSpace(r.FormValue("model"))
 	if model == "" {
 		model = "dall-e-2"
 	}
@@ -86,12 +92,13 @@ func (h *Handlers) ImageVariation(w http.ResponseWriter, r *http.Request) {
 		RequestID:   requestID,
 		Model:       model,
 		IsStreaming: false,
-		Body:        nil, // Multipart form is passed through r directly
+		Body:        body, // Buffered multipart body, replayable on retry
 	}
 
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	meter := formImageMeter(r.FormValue("n"))
+	h.background(func() { h.logMeteredRequest(requestID, opts, model, result, startTime, meter) })
 }
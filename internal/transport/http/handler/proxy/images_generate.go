@@ -2,9 +2,8 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,17 +18,15 @@ func (h *Handlers) ImageGeneration(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request
 	var req types.ImageGenerationRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("invalid request format"))
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
 
@@ -40,7 +37,7 @@ func (h *Handlers) ImageGeneration(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Default model if not specified
-	model := req.Model
+	model := strings.TrimSpace(req.Model)
 	if model == "" {
 		model = "dall-e-2"
 	}
@@ -53,9 +50,15 @@ func (h *Handlers) ImageGeneration(w http.ResponseWriter, r *http.Request) {
 		Body:        bytes.NewReader(bodyBytes),
 	}
 
+	// Attribute to the calling key's end user and enforce its per-user limit
+	if !h.attributeUser(w, r, opts, req.User) {
+		return
+	}
+
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
 	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	meter := imageMeter(req.N)
+	h.background(func() { h.logMeteredRequest(requestID, opts, model, result, startTime, meter) })
 }
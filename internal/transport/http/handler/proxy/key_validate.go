@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// keyValidationResponse describes the calling API key.
+// Remaining budget is omitted because keys carry no spend budget yet.
+type keyValidationResponse struct {
+	Valid     bool       `json:"valid"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	KeyPrefix string     `json:"key_prefix"`
+	Scopes    []string   `json:"scopes"`
+	RateLimit int        `json:"rate_limit"`      // Requests per minute (0 = unlimited)
+	UserLimit int        `json:"user_rate_limit"` // Requests per minute per end user (0 = unlimited)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ValidateKey handles GET /v1/key/validate.
+// APIKeyAuth has already rejected invalid, inactive and expired keys, so this
+// only reports the key from context. Nothing is proxied or logged.
+func (h *Handlers) ValidateKey(w http.ResponseWriter, r *http.Request) {
+	key := auth.GetAPIKey(r.Context())
+	if key == nil {
+		types.WriteError(w, http.StatusUnauthorized, types.ErrAuthentication("API key required"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(keyValidationResponse{
+		Valid:     true,
+		ID:        key.ID,
+		Name:      key.Name,
+		KeyPrefix: key.KeyPrefix,
+		Scopes:    key.Scopes,
+		RateLimit: key.RateLimit,
+		UserLimit: key.UserLimit,
+		ExpiresAt: key.ExpiresAt,
+	})
+}
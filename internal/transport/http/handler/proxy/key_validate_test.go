@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+)
+
+func TestValidateKey(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-time.Hour)
+	raw := map[string]string{}
+	for id, expires := range map[string]*time.Time{"valid": &future, "expired": &past} {
+		key, _ := storage.GenerateAPIKey()
+		hash, _ := storage.HashPassword(key, nil)
+		err := store.CreateAPIKey(&storage.ClientAPIKey{
+			ID:        id,
+			Name:      id + " key",
+			KeyHash:   hash,
+			KeyPrefix: storage.ExtractKeyPrefix(key),
+			Scopes:    []string{"proxy"},
+			RateLimit: 30,
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			ExpiresAt: expires,
+		})
+		if err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		raw[id] = key
+	}
+
+	h := New(nil, nil, store, nil, nil)
+	handler := auth.APIKeyAuth(store, nil)(http.HandlerFunc(h.ValidateKey))
+
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+	}{
+		{"valid key", raw["valid"], http.StatusOK},
+		{"expired key", raw["expired"], http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/key/validate", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp keyValidationResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !resp.Valid || resp.ID != "valid" || resp.RateLimit != 30 ||
+				len(resp.Scopes) != 1 || resp.Scopes[0] != "proxy" {
+				t.Errorf("response = %+v", resp)
+			}
+			if resp.ExpiresAt == nil || !resp.ExpiresAt.Equal(future) {
+				t.Errorf("expires_at = %v, want %v", resp.ExpiresAt, future)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package proxy
+
+// background runs fn on its own goroutine, tracked so Close can wait for it.
+// Handlers use it for work that outlives the response, such as logging.
+func (h *Handlers) background(fn func()) {
+	h.inflight.Add(1)
+	go func() {
+		defer h.inflight.Done()
+		fn()
+	}()
+}
+
+// Close waits for background work started by requests to finish, stops the
+// token counting workers and makes a last attempt at the buffered storage
+// writes. Call it after the server has stopped accepting requests and before
+// the storage is closed.
+func (h *Handlers) Close() {
+	h.inflight.Wait()
+	h.counter.stop()
+	h.flushPending(true)
+}
@@ -0,0 +1,42 @@
+package proxy
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestClose_WaitsForBackgroundWorkAndFlushes(t *testing.T) {
+	store := &flakyStorage{failures: map[string]int{"r1": 1}, err: sqliteBusyError(t)}
+	h := New(nil, &captureProvider{}, store, nil, nil)
+	h.logRetryBackoff = time.Hour // Only Close retries the buffered write
+
+	h.logRequest(&storage.RequestLog{RequestID: "r1"})
+	release := make(chan struct{})
+	h.background(func() {
+		<-release
+		h.logRequest(&storage.RequestLog{RequestID: "r2"})
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		h.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Close returned before background work finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	<-closed
+
+	if got := store.written(); !slices.Equal(got, []string{"r2", "r1"}) {
+		t.Errorf("written = %v, want r2 then the buffered r1", got)
+	}
+	if len(h.pending) != 0 {
+		t.Errorf("pending after Close = %d, want 0", len(h.pending))
+	}
+}
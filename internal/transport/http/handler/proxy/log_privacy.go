@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// hashedLogPrefix marks a field value replaced by its keyed hash.
+const hashedLogPrefix = "hmac:"
+
+// applyLogPrivacy minimizes a request log before it is written: fields listed
+// in LogHashFields are replaced by a keyed hash (so per-user usage still
+// groups correctly) and fields in LogOmitFields are cleared. Omission wins
+// when a field is listed in both. A stored request body gets the same
+// treatment.
+func (h *Handlers) applyLogPrivacy(log *storage.RequestLog) {
+	h.minimizeFields(func(name string) *string {
+		switch name {
+		case config.LogFieldModel:
+			return &log.Model
+		case config.LogFieldUser:
+			return &log.User
+		}
+		return nil
+	})
+	if log.RequestBody != "" && h.Config != nil && len(h.Config.LogHashFields)+len(h.Config.LogOmitFields) > 0 {
+		log.RequestBody = h.minimizeBody(log.RequestBody)
+	}
+}
+
+// minimizeBody applies the privacy settings to the top-level model and user
+// fields of a request body. A body that isn't a JSON object is dropped, since
+// its fields can't be minimized.
+func (h *Handlers) minimizeBody(body string) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return ""
+	}
+	values := make(map[string]*string)
+	for _, name := range []string{config.LogFieldModel, config.LogFieldUser} {
+		var v string
+		if raw, ok := fields[name]; ok && json.Unmarshal(raw, &v) == nil {
+			values[name] = &v
+		}
+	}
+	h.minimizeFields(func(name string) *string { return values[name] })
+	for name, v := range values {
+		if *v == "" {
+			delete(fields, name)
+			continue
+		}
+		fields[name], _ = json.Marshal(*v)
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// applyUsagePrivacy minimizes a daily usage increment the same way, so the
+// aggregates don't keep a field the request logs leave out.
+func (h *Handlers) applyUsagePrivacy(usage *storage.DailyUsage) {
+	h.minimizeFields(func(name string) *string {
+		if name == config.LogFieldModel {
+			return &usage.Model
+		}
+		return nil
+	})
+}
+
+// minimizeFields hashes, then omits, the configured fields that field
+// resolves to a pointer (nil for fields the record doesn't carry).
+func (h *Handlers) minimizeFields(field func(name string) *string) {
+	if h.Config == nil {
+		return
+	}
+	for _, name := range h.Config.LogHashFields {
+		if f := field(normalizeLogField(name)); f != nil && *f != "" {
+			*f = hashLogValue(h.Config.LogHashKey, *f)
+		}
+	}
+	for _, name := range h.Config.LogOmitFields {
+		if f := field(normalizeLogField(name)); f != nil {
+			*f = ""
+		}
+	}
+}
+
+// normalizeLogField folds a configured field name to its canonical form.
+func normalizeLogField(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// hashLogValue returns a truncated HMAC-SHA256 of value under key, prefixed
+// so hashed rows are recognizable. Without the key, low-entropy values such
+// as emails can't be recovered by hashing guesses.
+func hashLogValue(key, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hashedLogPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestApplyLogPrivacy(t *testing.T) {
+	const key = "log-hash-key"
+	userHash := hashLogValue(key, "alice@example.com")
+
+	tests := []struct {
+		name      string
+		omit      []string
+		hash      []string
+		wantModel string
+		wantUser  string
+	}{
+		{
+			name:      "disabled keeps fields",
+			wantModel: "openai/gpt-4o",
+			wantUser:  "alice@example.com",
+		},
+		{
+			name:      "hash user",
+			hash:      []string{"user"},
+			wantModel: "openai/gpt-4o",
+			wantUser:  userHash,
+		},
+		{
+			name:      "omit model and user",
+			omit:      []string{"model", " User "},
+			wantModel: "",
+			wantUser:  "",
+		},
+		{
+			name:      "omit wins over hash",
+			omit:      []string{"user"},
+			hash:      []string{"user", "model"},
+			wantModel: hashLogValue(key, "openai/gpt-4o"),
+			wantUser:  "",
+		},
+		{
+			name:      "unknown fields ignored",
+			omit:      []string{"prompt"},
+			wantModel: "openai/gpt-4o",
+			wantUser:  "alice@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handlers{Config: &config.Config{LogConfig: config.LogConfig{LogOmitFields: tt.omit, LogHashFields: tt.hash, LogHashKey: key}}}
+			log := &storage.RequestLog{Model: "openai/gpt-4o", User: "alice@example.com", PromptTokens: 12}
+
+			h.applyLogPrivacy(log)
+
+			if log.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", log.Model, tt.wantModel)
+			}
+			if log.User != tt.wantUser {
+				t.Errorf("User = %q, want %q", log.User, tt.wantUser)
+			}
+			if log.PromptTokens != 12 {
+				t.Errorf("PromptTokens = %d, token counts must be kept", log.PromptTokens)
+			}
+		})
+	}
+
+	if !strings.HasPrefix(userHash, hashedLogPrefix) || strings.Contains(userHash, "alice") {
+		t.Errorf("hash %q leaks the raw value or lacks prefix", userHash)
+	}
+	if hashLogValue(key, "alice@example.com") != userHash {
+		t.Error("hash is not stable across calls")
+	}
+	if hashLogValue("other-key", "alice@example.com") == userHash {
+		t.Error("hash does not depend on the key")
+	}
+}
+
+func TestApplyUsagePrivacy(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.Config
+		wantModel string
+	}{
+		{"disabled keeps model", config.Config{}, "openai/gpt-4o"},
+		{"hash model", config.Config{LogConfig: config.LogConfig{LogHashFields: []string{"model"}, LogHashKey: "k"}}, hashLogValue("k", "openai/gpt-4o")},
+		{"omit model", config.Config{LogConfig: config.LogConfig{LogOmitFields: []string{"model"}}}, ""},
+		{"user fields don't apply", config.Config{LogConfig: config.LogConfig{LogOmitFields: []string{"user"}}}, "openai/gpt-4o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handlers{Config: &tt.cfg}
+			usage := &storage.DailyUsage{Model: "openai/gpt-4o", RequestCount: 1}
+
+			h.applyUsagePrivacy(usage)
+
+			if usage.Model != tt.wantModel || usage.RequestCount != 1 {
+				t.Errorf("usage = %+v, want model %q and counts kept", usage, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestApplyLogPrivacy_RequestBody(t *testing.T) {
+	const body = `{"model":"gpt-4o","user":"alice@example.com","messages":[{"role":"user","content":"hi"}]}`
+	tests := []struct {
+		name string
+		cfg  config.Config
+		body string
+		want string
+	}{
+		{"disabled keeps body", config.Config{}, body, body},
+		{
+			"omit user, hash model",
+			config.Config{LogConfig: config.LogConfig{LogOmitFields: []string{"user"}, LogHashFields: []string{"model"}, LogHashKey: "k"}},
+			body,
+			`{"messages":[{"role":"user","content":"hi"}],"model":"` + hashLogValue("k", "gpt-4o") + `"}`,
+		},
+		{"invalid JSON dropped", config.Config{LogConfig: config.LogConfig{LogOmitFields: []string{"user"}}}, `{"user":"alice`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handlers{Config: &tt.cfg}
+			log := &storage.RequestLog{RequestBody: tt.body}
+
+			h.applyLogPrivacy(log)
+
+			if log.RequestBody != tt.want {
+				t.Errorf("RequestBody = %s, want %s", log.RequestBody, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestLogChatRequest_SamplesDetailedLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     int
+		wantLogs int // 20 successes sampled + 3 failures always kept
+	}{
+		{"disabled", 0, 23},
+		{"every request", 1, 23},
+		{"one in five", 5, 4 + 3},
+		{"rate above volume", 100, 1 + 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			h := New(&config.Config{LogConfig: config.LogConfig{LogSampleRate: tt.rate}}, &captureProvider{}, store, nil, nil)
+			opts := &provider.ProxyOptions{}
+
+			for i := range 23 {
+				status := http.StatusOK
+				if i >= 20 {
+					status = http.StatusBadGateway
+				}
+				result := &provider.ProxyResult{Model: "m", StatusCode: status, PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}
+				h.logChatRequest("req", opts, result, 0)
+			}
+
+			logs, err := store.GetRequestLogs(storage.LogFilter{Limit: 100})
+			if err != nil || len(logs) != tt.wantLogs {
+				t.Fatalf("stored %d request logs, %v; want %d", len(logs), err, tt.wantLogs)
+			}
+			failed := 0
+			for _, l := range logs {
+				if l.StatusCode >= 400 {
+					failed++
+				}
+			}
+			if failed != 3 {
+				t.Errorf("stored %d failed logs, want all 3", failed)
+			}
+
+			// Aggregates stay exact whatever the sample rate
+			today := time.Now().Format("2006-01-02")
+			daily, err := store.GetDailyUsage(today, today)
+			if err != nil || len(daily) != 1 {
+				t.Fatalf("GetDailyUsage = %d rows, %v; want 1", len(daily), err)
+			}
+			if u := daily[0]; u.RequestCount != 23 || u.ErrorCount != 3 || u.TotalTokens != 23*5 {
+				t.Errorf("daily usage = %d requests, %d errors, %d tokens; want 23, 3, %d",
+					u.RequestCount, u.ErrorCount, u.TotalTokens, 23*5)
+			}
+		})
+	}
+}
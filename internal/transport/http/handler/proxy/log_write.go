@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// logWriteAttempts is how many times a busy storage write is tried before it is dropped.
+const logWriteAttempts = 3
+
+// maxPendingWrites bounds buffered writes awaiting retry; the oldest is dropped when full.
+const maxPendingWrites = 256
+
+// defaultLogRetryBackoff is the delay before a buffered write is retried,
+// doubled after each further failure.
+const defaultLogRetryBackoff = 20 * time.Millisecond
+
+// pendingWrite is a transiently failed storage write kept for a later retry.
+type pendingWrite struct {
+	op    string
+	write func() error
+	tries int       // Attempts made so far
+	due   time.Time // Earliest time of the next attempt
+}
+
+// logRequest persists a request log, retrying it later if storage is busy.
+// Privacy settings are applied first so raw values never reach storage.
+func (h *Handlers) logRequest(log *storage.RequestLog) {
+	if !h.sampleLog(log) {
+		return
+	}
+	h.applyLogPrivacy(log)
+	log.RequestBody = h.truncateBody(log.RequestBody)
+	h.persist("log request", func() error { return h.Storage.LogRequest(log) })
+}
+
+// logsRequests reports whether detailed request logs are written for cred.
+// Credentials that opt out still count toward the daily usage aggregates.
+func logsRequests(cred *storage.Credential) bool {
+	return cred == nil || cred.LogRequests
+}
+
+// sampleLog reports whether a request log is kept under LogSampleRate:
+// every failed or anomalous request and one in N successful ones.
+func (h *Handlers) sampleLog(log *storage.RequestLog) bool {
+	if h.Config == nil || h.Config.LogSampleRate <= 1 || log.StatusCode >= 400 || log.ErrorType != "" || log.Anomaly != "" {
+		return true
+	}
+	return h.logSeq.Add(1)%uint64(h.Config.LogSampleRate) == 1
+}
+
+// recordDailyUsage persists a daily usage increment. Increments are not
+// idempotent, so a failed one is dropped rather than retried. Privacy
+// settings apply here too.
+func (h *Handlers) recordDailyUsage(usage *storage.DailyUsage) {
+	h.applyUsagePrivacy(usage)
+	if err := h.Storage.UpdateDailyUsage(usage); err != nil {
+		slog.Warn("storage write failed, dropping", "op", "update daily usage", "error", err)
+		return
+	}
+	h.flushPending(false)
+}
+
+// persist runs write once. A write that fails because storage is busy is
+// buffered and retried, after its backoff, once a later write succeeds, so
+// logs survive short bursts of lock contention without blocking the caller.
+func (h *Handlers) persist(op string, write func() error) {
+	err := write()
+	switch {
+	case err == nil:
+		h.flushPending(false)
+	case storage.IsBusy(err):
+		slog.Warn("storage write failed, buffering for retry", "op", op, "error", err)
+		h.bufferWrite(pendingWrite{op: op, write: write, tries: 1, due: time.Now().Add(h.logRetryBackoff)})
+	default:
+		slog.Warn("storage write failed, dropping", "op", op, "error", err)
+	}
+}
+
+// bufferWrite queues a failed write, evicting the oldest when the buffer is full.
+func (h *Handlers) bufferWrite(pw pendingWrite) {
+	h.pendingMu.Lock()
+	defer h.pendingMu.Unlock()
+	if len(h.pending) >= maxPendingWrites {
+		slog.Warn("storage retry buffer full, dropping oldest write", "op", h.pending[0].op)
+		h.pending = h.pending[1:]
+	}
+	h.pending = append(h.pending, pw)
+}
+
+// flushPending retries the buffered writes that are due, or all of them when
+// force is set. Writes still busy are re-buffered with a doubled backoff until
+// they have been tried logWriteAttempts times.
+func (h *Handlers) flushPending(force bool) {
+	h.pendingMu.Lock()
+	pending := h.pending
+	h.pending = nil
+	h.pendingMu.Unlock()
+
+	now := time.Now()
+	for _, pw := range pending {
+		if !force && now.Before(pw.due) {
+			h.bufferWrite(pw)
+			continue
+		}
+		err := pw.write()
+		switch {
+		case err == nil:
+		case storage.IsBusy(err) && pw.tries+1 < logWriteAttempts && !force:
+			pw.tries++
+			pw.due = now.Add(h.logRetryBackoff << (pw.tries - 1))
+			h.bufferWrite(pw)
+		default:
+			slog.Warn("storage write failed, dropping", "op", pw.op, "error", err)
+		}
+	}
+}
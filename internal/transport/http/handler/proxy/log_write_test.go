@@ -0,0 +1,203 @@
+package proxy
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// flakyStorage fails the LogRequest and UpdateDailyUsage calls named in
+// failures (by request ID, or "usage") with err, as many times as listed.
+type flakyStorage struct {
+	storage.Storage
+	mu       sync.Mutex
+	failures map[string]int
+	err      error
+	logs     []*storage.RequestLog
+	usage    int // UpdateDailyUsage calls
+}
+
+func (s *flakyStorage) fail(key string) bool {
+	if s.failures[key] > 0 {
+		s.failures[key]--
+		return true
+	}
+	return false
+}
+
+func (s *flakyStorage) LogRequest(log *storage.RequestLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail(log.RequestID) {
+		return s.err
+	}
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *flakyStorage) UpdateDailyUsage(*storage.DailyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage++
+	if s.fail("usage") {
+		return s.err
+	}
+	return nil
+}
+
+func (s *flakyStorage) written() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, l := range s.logs {
+		ids = append(ids, l.RequestID)
+	}
+	return ids
+}
+
+// sqliteBusyError returns the error SQLite reports for a write to a database
+// another connection holds a write lock on.
+func sqliteBusyError(t *testing.T) error {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "busy.db")
+	open := func() *sql.DB {
+		db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(0)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	holder, writer := open(), open()
+	if _, err := holder.Exec(`CREATE TABLE t (x INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := holder.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(`INSERT INTO t VALUES (1)`); err != nil {
+		t.Fatal(err)
+	}
+	_, err = writer.Exec(`INSERT INTO t VALUES (2)`)
+	if !storage.IsBusy(err) {
+		t.Fatalf("expected a busy error, got %v", err)
+	}
+	return err
+}
+
+func TestLogRequest_RetriesBusyWrites(t *testing.T) {
+	busy := sqliteBusyError(t)
+
+	tests := []struct {
+		name        string
+		failures    int // Failed attempts to write r1
+		err         error
+		backoff     time.Duration
+		requests    []string // Logged after r1, in order
+		wantPending int
+		wantWritten []string
+	}{
+		{"buffered until the next write", 1, busy, 0, []string{"r2"}, 0, []string{"r2", "r1"}},
+		{"not retried before its backoff", 1, busy, time.Hour, []string{"r2"}, 1, []string{"r2"}},
+		{"dropped after the last attempt", logWriteAttempts, busy, 0, []string{"r2", "r3"}, 0, []string{"r2", "r3"}},
+		{"look-alike message is not busy", 1, errors.New("database is locked (5) (SQLITE_BUSY)"), 0, []string{"r2"}, 0, []string{"r2"}},
+		{"permanent error is dropped", 1, errors.New("no such table"), 0, []string{"r2"}, 0, []string{"r2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &flakyStorage{failures: map[string]int{"r1": tt.failures}, err: tt.err}
+			h := New(nil, &captureProvider{}, store, nil, nil)
+			h.logRetryBackoff = tt.backoff
+
+			start := time.Now()
+			h.logRequest(&storage.RequestLog{RequestID: "r1"})
+			for _, id := range tt.requests {
+				h.logRequest(&storage.RequestLog{RequestID: id})
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("logging blocked for %v", elapsed)
+			}
+			if got := store.written(); !slices.Equal(got, tt.wantWritten) {
+				t.Errorf("written = %v, want %v", got, tt.wantWritten)
+			}
+			if len(h.pending) != tt.wantPending {
+				t.Errorf("pending = %d, want %d", len(h.pending), tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestRecordDailyUsage_NotRetried(t *testing.T) {
+	store := &flakyStorage{failures: map[string]int{"usage": 1}, err: sqliteBusyError(t)}
+	h := New(nil, &captureProvider{}, store, nil, nil)
+	h.logRetryBackoff = 0
+
+	h.recordDailyUsage(&storage.DailyUsage{Model: "m", RequestCount: 1})
+	h.logRequest(&storage.RequestLog{RequestID: "r1"})
+
+	if store.usage != 1 || len(h.pending) != 0 {
+		t.Errorf("usage writes = %d, pending = %d; want one attempt and nothing buffered", store.usage, len(h.pending))
+	}
+}
+
+func TestBufferWrite_DropsOldestWhenFull(t *testing.T) {
+	h := &Handlers{}
+	for i := 0; i < maxPendingWrites+5; i++ {
+		h.bufferWrite(pendingWrite{op: "log request", write: func() error { return nil }})
+	}
+	if len(h.pending) != maxPendingWrites {
+		t.Errorf("pending = %d, want %d", len(h.pending), maxPendingWrites)
+	}
+}
+
+func TestLogRequests_CredentialOptOut(t *testing.T) {
+	result := &types.ProxyResult{Model: "m", StatusCode: http.StatusOK, PromptTokens: 5, CompletionTokens: 3, TotalTokens: 8}
+
+	tests := []struct {
+		name     string
+		cred     *storage.Credential
+		wantLogs int
+	}{
+		{"credential logs requests", &storage.Credential{ID: "cred-1", LogRequests: true}, 1},
+		{"credential opted out", &storage.Credential{ID: "cred-1", LogRequests: false}, 0},
+		{"no credential", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, logger := range []struct {
+				name string
+				call func(h *Handlers, opts *types.ProxyOptions)
+			}{
+				{"chat", func(h *Handlers, opts *types.ProxyOptions) { h.logChatRequest("req-1", opts, result, 5) }},
+				{"simple", func(h *Handlers, opts *types.ProxyOptions) {
+					h.logSimpleRequest("req-1", opts, "m", result, time.Now())
+				}},
+			} {
+				store := &usageCapture{}
+				h := New(nil, &captureProvider{}, store, nil, nil)
+
+				logger.call(h, &types.ProxyOptions{Credential: tt.cred})
+
+				if len(store.logs) != tt.wantLogs {
+					t.Errorf("%s: wrote %d request logs, want %d", logger.name, len(store.logs), tt.wantLogs)
+				}
+				if len(store.usage) != 1 {
+					t.Errorf("%s: wrote %d daily usage updates, want 1", logger.name, len(store.usage))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// maxTopLogprobs is the largest top_logprobs value the OpenAI API accepts.
+const maxTopLogprobs = 20
+
+// validateTopLogprobs rejects a chat request whose top_logprobs falls outside
+// 0-20 with a 400, so it never reaches (and is never billed by) the upstream.
+func validateTopLogprobs(w http.ResponseWriter, req *types.ChatCompletionRequest) error {
+	if req.TopLogprobs == nil || (*req.TopLogprobs >= 0 && *req.TopLogprobs <= maxTopLogprobs) {
+		return nil
+	}
+	err := fmt.Errorf("top_logprobs must be between 0 and %d, got %d", maxTopLogprobs, *req.TopLogprobs)
+	types.WriteError(w, http.StatusBadRequest, types.NewAPIErrorWithParam(err.Error(), types.ErrorTypeInvalidRequest, "top_logprobs"))
+	return err
+}
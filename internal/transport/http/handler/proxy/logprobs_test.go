@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChatCompletions_TopLogprobsRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		extra      string
+		wantStatus int
+	}{
+		{"unset", ``, http.StatusOK},
+		{"zero", `,"logprobs":true,"top_logprobs":0`, http.StatusOK},
+		{"maximum", `,"logprobs":true,"top_logprobs":20`, http.StatusOK},
+		{"negative", `,"logprobs":true,"top_logprobs":-1`, http.StatusBadRequest},
+		{"above maximum", `,"logprobs":true,"top_logprobs":21`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			h := New(nil, prov, nil, nil, nil)
+
+			body := `{"model":"m","messages":[{"role":"user","content":"hi"}]` + tt.extra + `}`
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			if !strings.Contains(rec.Body.String(), `"param":"top_logprobs"`) {
+				t.Errorf("body = %s, want top_logprobs param", rec.Body.String())
+			}
+			if prov.body != nil {
+				t.Error("request was proxied")
+			}
+		})
+	}
+}
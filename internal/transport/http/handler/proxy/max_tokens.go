@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// maxTokenFields lists the request fields that carry an output token limit.
+var maxTokenFields = []string{"max_tokens", "max_completion_tokens"}
+
+// enforceMaxTokens applies the alias's max_output_tokens ceiling to a chat request.
+// Depending on the configured policy the limit fields are clamped to the ceiling,
+// or a 400 is written and an error returned. The body is unchanged when under the ceiling.
+func (h *Handlers) enforceMaxTokens(w http.ResponseWriter, r *http.Request, req *types.ChatCompletionRequest, body []byte, requestID string) ([]byte, error) {
+	ceiling := h.maxOutputTokens(r, req.Model)
+	requested := req.GetMaxTokens()
+	if ceiling <= 0 || requested <= ceiling {
+		return body, nil
+	}
+
+	if h.Config.MaxTokensPolicy == config.MaxTokensPolicyReject {
+		param := "max_tokens"
+		if req.MaxCompletionTokens != nil {
+			param = "max_completion_tokens"
+		}
+		err := fmt.Errorf("max tokens %d exceeds the limit of %d for model %s", requested, ceiling, req.Model)
+		types.WriteError(w, http.StatusBadRequest, types.NewAPIErrorWithParam(err.Error(), types.ErrorTypeInvalidRequest, param))
+		return nil, err
+	}
+
+	slog.Info("clamped max tokens",
+		"request_id", requestID, "model", req.Model, "from", requested, "to", ceiling)
+	return clampMaxTokens(body, ceiling), nil
+}
+
+// sentMaxTokens returns the output token limit the upstream receives once
+// enforceMaxTokens has clamped the request (0 when it sets none).
+func (h *Handlers) sentMaxTokens(r *http.Request, req *types.ChatCompletionRequest) int {
+	requested, ceiling := req.GetMaxTokens(), h.maxOutputTokens(r, req.Model)
+	if ceiling > 0 && requested > ceiling {
+		return ceiling
+	}
+	return requested
+}
+
+// maxOutputTokens returns the configured ceiling for a model slug (0 if none).
+func (h *Handlers) maxOutputTokens(r *http.Request, slug string) int {
+	if alias := h.alias(r, slug); alias != nil {
+		return alias.MaxOutputTokens
+	}
+	return 0
+}
+
+// aliasLookup is implemented by providers that route by alias (the Router),
+// so limits come from the alias that actually serves the request.
+type aliasLookup interface {
+	Alias(req *http.Request, slug string) *config.ModelAlias
+}
+
+// alias returns the alias a model slug routes to, or nil. Without an alias
+// routing provider the config aliases are used; requests bound to a tenant
+// (see types.Tenant) then look the slug up in its aliases only.
+func (h *Handlers) alias(r *http.Request, slug string) *config.ModelAlias {
+	if lookup, ok := h.Provider.(aliasLookup); ok {
+		return lookup.Alias(r, slug)
+	}
+	if h.Config == nil {
+		return nil
+	}
+	aliases := h.Config.Models
+	if tenant := types.Tenant(r.Context()); tenant != "" {
+		aliases = h.Config.Tenants[tenant].Models
+	}
+	for i := range aliases {
+		if aliases[i].Slug == slug {
+			return &aliases[i]
+		}
+	}
+	return nil
+}
+
+// clampMaxTokens lowers every output token limit field above ceiling.
+// Only the offending fields are replaced; other fields keep their original bytes.
+func clampMaxTokens(body []byte, ceiling int) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+
+	for _, name := range maxTokenFields {
+		var value int
+		if raw, ok := fields[name]; ok && json.Unmarshal(raw, &value) == nil && value > ceiling {
+			fields[name], _ = json.Marshal(ceiling)
+		}
+	}
+
+	rewritten, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
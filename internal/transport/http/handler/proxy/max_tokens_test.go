@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestChatCompletions_MaxOutputTokens(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		tenant     string
+		body       string
+		wantStatus int
+		wantSent   map[string]int // nil when the request must not be proxied
+	}{
+		{
+			name:       "clamps max_tokens above ceiling",
+			policy:     config.MaxTokensPolicyClamp,
+			body:       `{"model":"small","messages":[],"max_tokens":9000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 4096},
+		},
+		{
+			name:       "clamps both limit fields",
+			policy:     config.MaxTokensPolicyClamp,
+			body:       `{"model":"small","messages":[],"max_tokens":100,"max_completion_tokens":8000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 100, "max_completion_tokens": 4096},
+		},
+		{
+			name:       "under ceiling passes through",
+			policy:     config.MaxTokensPolicyReject,
+			body:       `{"model":"small","messages":[],"max_tokens":1000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 1000},
+		},
+		{
+			name:       "unconfigured alias passes through",
+			policy:     config.MaxTokensPolicyReject,
+			body:       `{"model":"big","messages":[],"max_tokens":100000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 100000},
+		},
+		{
+			name:       "tenant ignores gateway ceiling",
+			policy:     config.MaxTokensPolicyClamp,
+			tenant:     "acme",
+			body:       `{"model":"small","messages":[],"max_tokens":9000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 9000},
+		},
+		{
+			name:       "tenant alias ceiling",
+			policy:     config.MaxTokensPolicyClamp,
+			tenant:     "acme",
+			body:       `{"model":"big","messages":[],"max_tokens":9000}`,
+			wantStatus: http.StatusOK,
+			wantSent:   map[string]int{"max_tokens": 2048},
+		},
+		{
+			name:       "rejects above ceiling",
+			policy:     config.MaxTokensPolicyReject,
+			body:       `{"model":"small","messages":[],"max_completion_tokens":9000}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			cfg := &config.Config{
+				MaxTokensPolicy: tt.policy,
+				Models: []config.ModelAlias{
+					{Slug: "small", Model: "vendor/small", MaxOutputTokens: 4096},
+					{Slug: "big", Model: "vendor/big"},
+				},
+				Tenants: map[string]config.TenantRoutes{
+					"acme": {Models: []config.ModelAlias{{Slug: "big", Model: "vendor/big", MaxOutputTokens: 2048}}},
+				},
+			}
+			h := New(cfg, prov, nil, nil, nil)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(tt.body))
+			if tt.tenant != "" {
+				req = req.WithContext(types.WithTenant(req.Context(), tt.tenant))
+			}
+			h.ChatCompletions(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantSent == nil {
+				if prov.body != nil {
+					t.Error("rejected request was proxied")
+				}
+				if !strings.Contains(rec.Body.String(), `"param":"max_completion_tokens"`) {
+					t.Errorf("error body = %s", rec.Body.String())
+				}
+				return
+			}
+
+			var sent map[string]any
+			if err := json.Unmarshal(prov.body, &sent); err != nil {
+				t.Fatalf("forwarded body: %v", err)
+			}
+			for field, want := range tt.wantSent {
+				if got, _ := sent[field].(float64); int(got) != want {
+					t.Errorf("%s = %v, want %d", field, sent[field], want)
+				}
+			}
+		})
+	}
+}
+
+// aliasProvider answers alias lookups like the Router does.
+type aliasProvider struct {
+	captureProvider
+	aliases map[string]config.ModelAlias
+}
+
+func (p *aliasProvider) Alias(_ *http.Request, slug string) *config.ModelAlias {
+	if a, ok := p.aliases[slug]; ok {
+		return &a
+	}
+	return nil
+}
+
+func TestMaxOutputTokens_UsesRoutedAlias(t *testing.T) {
+	// The config alias is overridden by the routed one, which has no ceiling
+	cfg := &config.Config{Models: []config.ModelAlias{{Slug: "gpt4", MaxOutputTokens: 100}}}
+	h := New(cfg, &aliasProvider{aliases: map[string]config.ModelAlias{
+		"gpt4":   {Slug: "gpt4"},
+		"claude": {Slug: "claude", MaxOutputTokens: 50},
+	}}, storage.NewMemoryStorage(), nil, nil)
+	r := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+
+	if got := h.maxOutputTokens(r, "gpt4"); got != 0 {
+		t.Errorf("gpt4 ceiling = %d, want 0 from the routed alias", got)
+	}
+	if got := h.maxOutputTokens(r, "claude"); got != 50 {
+		t.Errorf("claude ceiling = %d, want 50", got)
+	}
+}
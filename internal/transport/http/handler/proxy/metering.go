@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// usageMeter carries approximate accounting for endpoints whose upstream
+// responses report no token usage (audio, images and moderations).
+type usageMeter struct {
+	PromptTokens    int
+	AudioCharacters int
+	Images          int
+}
+
+// speechMeter accounts a TTS request by its input: characters (how TTS is
+// billed) and tokens, falling back to ~4 characters per token without a tokenizer.
+func (h *Handlers) speechMeter(input, model string) usageMeter {
+	return usageMeter{PromptTokens: h.inputTokens(input, model), AudioCharacters: utf8.RuneCountInString(input)}
+}
+
+// moderationMeter accounts a moderation request by the tokens of its inputs.
+func (h *Handlers) moderationMeter(inputs []string, model string) usageMeter {
+	tokens := 0
+	for _, input := range inputs {
+		tokens += h.inputTokens(input, model)
+	}
+	return usageMeter{PromptTokens: tokens}
+}
+
+// inputTokens counts the tokens of input text, falling back to ~4 characters
+// per token without a tokenizer.
+func (h *Handlers) inputTokens(input, model string) int {
+	if h.Tokenizer != nil {
+		if n, err := h.Tokenizer.CountTokens(input, model); err == nil {
+			return n
+		}
+	}
+	return (utf8.RuneCountInString(input) + 3) / 4
+}
+
+// imageMeter accounts an image request by the number of images requested.
+func imageMeter(n *int) usageMeter {
+	if n == nil || *n < 1 {
+		return usageMeter{Images: 1} // OpenAI default
+	}
+	return usageMeter{Images: *n}
+}
+
+// formImageMeter accounts a multipart image request from its "n" form value.
+func formImageMeter(value string) usageMeter {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return imageMeter(nil)
+	}
+	return imageMeter(&n)
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// usageCapture records request logs and daily usage; other methods are unused.
+type usageCapture struct {
+	logCapture
+	usage []*storage.DailyUsage
+}
+
+func (s *usageCapture) UpdateDailyUsage(usage *storage.DailyUsage) error {
+	s.usage = append(s.usage, usage)
+	return nil
+}
+
+func TestLogMeteredRequest_TextToSpeech(t *testing.T) {
+	input := "Hello, wörld"
+
+	tests := []struct {
+		name       string
+		tokenizer  bool
+		status     int
+		wantTokens int
+		wantChars  int
+	}{
+		{"tokenizer count", true, http.StatusOK, len(input), 12},
+		{"character estimate", false, http.StatusOK, 3, 12},
+		{"failed request not metered", true, http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &usageCapture{}
+			h := New(nil, &captureProvider{}, store, nil, nil)
+			if tt.tokenizer {
+				h.Tokenizer = charTokenizer{}
+			}
+
+			result := &types.ProxyResult{Model: "tts-1", StatusCode: tt.status}
+			h.logMeteredRequest("req-1", &types.ProxyOptions{}, "tts-1", result, time.Now(), h.speechMeter(input, "tts-1"))
+
+			if len(store.logs) != 1 || len(store.usage) != 1 {
+				t.Fatalf("got %d logs, %d usage rows", len(store.logs), len(store.usage))
+			}
+			if got := store.logs[0].PromptTokens; got != tt.wantTokens {
+				t.Errorf("logged prompt tokens = %d, want %d", got, tt.wantTokens)
+			}
+			u := store.usage[0]
+			if u.PromptTokens != tt.wantTokens || u.TotalTokens != tt.wantTokens || u.AudioCharacters != tt.wantChars {
+				t.Errorf("usage = %+v", u)
+			}
+		})
+	}
+}
+
+func TestImageMeter(t *testing.T) {
+	four := 4
+	tests := []struct {
+		name string
+		got  usageMeter
+		want int
+	}{
+		{"json n", imageMeter(&four), 4},
+		{"json default", imageMeter(nil), 1},
+		{"form n", formImageMeter("3"), 3},
+		{"form invalid", formImageMeter("x"), 1},
+	}
+	for _, tt := range tests {
+		if tt.got.Images != tt.want {
+			t.Errorf("%s: images = %d, want %d", tt.name, tt.got.Images, tt.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package proxy
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
 
 	"github.com/mandalnilabja/goatway/internal/types"
@@ -36,14 +35,13 @@ func (h *Handlers) ListModels(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.fetchModels(r, apiKey, openRouterModelsURL)
+	list, stale, err := h.loadModels(r, apiKey)
 	if err != nil {
-		types.WriteError(w, http.StatusBadGateway, types.ErrServer("upstream error: "+err.Error()))
+		types.WriteError(w, modelsFetchStatus(err), types.ErrServer("upstream error: "+err.Error()))
 		return
 	}
-	defer resp.Body.Close()
 
-	h.forwardModelsResponse(w, resp)
+	h.forwardModelsResponse(w, list, stale)
 }
 
 // GetModel proxies GET /v1/models/{model} to OpenRouter.
@@ -62,29 +60,25 @@ func (h *Handlers) GetModel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// OpenRouter doesn't have a single model endpoint, so fetch all and filter
-	resp, err := h.fetchModels(r, apiKey, openRouterModelsURL)
+	list, stale, err := h.loadModels(r, apiKey)
 	if err != nil {
-		types.WriteError(w, http.StatusBadGateway, types.ErrServer("upstream error: "+err.Error()))
+		types.WriteError(w, modelsFetchStatus(err), types.ErrServer("upstream error: "+err.Error()))
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		h.forwardModelsResponse(w, resp)
+	if list.status != http.StatusOK {
+		h.forwardModelsResponse(w, list, stale)
 		return
 	}
 
-	h.findAndReturnModel(w, resp.Body, modelID)
+	if stale {
+		w.Header().Set(StaleHeader, "true")
+	}
+	h.findAndReturnModel(w, list.body, modelID)
 }
 
 // findAndReturnModel parses the models list and returns a specific model.
-func (h *Handlers) findAndReturnModel(w http.ResponseWriter, body io.Reader, modelID string) {
-	data, err := io.ReadAll(body)
-	if err != nil {
-		types.WriteError(w, http.StatusBadGateway, types.ErrServer("failed to read upstream response"))
-		return
-	}
-
+func (h *Handlers) findAndReturnModel(w http.ResponseWriter, data []byte, modelID string) {
 	var modelsList modelsListResponse
 	if err := json.Unmarshal(data, &modelsList); err != nil {
 		types.WriteError(w, http.StatusBadGateway, types.ErrServer("failed to parse models response"))
@@ -102,27 +96,17 @@ func (h *Handlers) findAndReturnModel(w http.ResponseWriter, body io.Reader, mod
 	types.WriteError(w, http.StatusNotFound, types.ErrInvalidRequest("model '"+modelID+"' not found"))
 }
 
-// fetchModels makes a request to the upstream models endpoint.
-func (h *Handlers) fetchModels(r *http.Request, apiKey, url string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	return client.Do(req)
-}
-
 // forwardModelsResponse forwards the upstream response to the client.
-func (h *Handlers) forwardModelsResponse(w http.ResponseWriter, resp *http.Response) {
-	for k, v := range resp.Header {
+func (h *Handlers) forwardModelsResponse(w http.ResponseWriter, list *modelsList, stale bool) {
+	for k, v := range list.header {
 		w.Header()[k] = v
 	}
-	w.WriteHeader(resp.StatusCode)
-	_, _ = io.Copy(w, resp.Body)
+	w.Header().Del("Content-Length")
+	if stale {
+		w.Header().Set(StaleHeader, "true")
+	}
+	w.WriteHeader(list.status)
+	_, _ = w.Write(list.body)
 }
 
 // modelsListResponse represents the OpenAI models list response.
@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// modelsCacheKey holds the last successful upstream models list, served
+// stale when a later fetch fails.
+const modelsCacheKey = "models:openrouter"
+
+// StaleHeader marks a response served from cache after the upstream failed.
+const StaleHeader = "X-Goatway-Stale"
+
+// ErrNoModelsCredential is returned when no openrouter credential is stored
+// to fetch the models list with.
+var ErrNoModelsCredential = errors.New("no credential configured for openrouter")
+
+// errModelsTooLarge is returned when the models list exceeds the size cap.
+var errModelsTooLarge = errors.New("models response exceeds size limit")
+
+// modelsList is an upstream models response read in full.
+type modelsList struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// loadModels fetches the upstream models list. On failure the last good
+// list is returned with stale set; without one the fetch error is returned.
+func (h *Handlers) loadModels(r *http.Request, apiKey string) (list *modelsList, stale bool, err error) {
+	list, err = h.fetchModels(r, apiKey)
+	if err == nil && list.status == http.StatusOK && h.Cache != nil {
+		h.Cache.Set(modelsCacheKey, list, int64(len(list.body)))
+		h.Cache.Wait()
+	}
+	if err == nil || h.Cache == nil {
+		return list, false, err
+	}
+	if cached, ok := h.Cache.Get(modelsCacheKey); ok {
+		slog.Warn("models fetch failed; serving cached list", "error", err)
+		return cached.(*modelsList), true, nil
+	}
+	return nil, false, err
+}
+
+// ModelIDs returns the IDs in the upstream models list, fetched like
+// /v1/models (with its timeout, size cap and stale fallback) using any
+// openrouter credential.
+func (h *Handlers) ModelIDs(r *http.Request) ([]string, error) {
+	apiKey := h.getOpenRouterAPIKey()
+	if apiKey == "" {
+		return nil, ErrNoModelsCredential
+	}
+	list, _, err := h.loadModels(r, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if list.status != http.StatusOK {
+		return nil, fmt.Errorf("models upstream returned status %d", list.status)
+	}
+
+	var models modelsListResponse
+	if err := json.Unmarshal(list.body, &models); err != nil {
+		return nil, fmt.Errorf("failed to parse models response: %w", err)
+	}
+	ids := make([]string, 0, len(models.Data))
+	for _, m := range models.Data {
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+// fetchModels requests the upstream models endpoint, bounded by the
+// configured timeout and response size.
+func (h *Handlers) fetchModels(r *http.Request, apiKey string) (*modelsList, error) {
+	ctx := r.Context()
+	if h.Config != nil && h.Config.ModelsFetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Config.ModelsFetchTimeout)
+		defer cancel()
+	}
+
+	url := h.modelsURL
+	if url == "" {
+		url = openRouterModelsURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.modelsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	var limit int64
+	if h.Config != nil {
+		limit = h.Config.ModelsResponseLimit
+	}
+	if limit > 0 {
+		reader = io.LimitReader(resp.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w (%d bytes)", errModelsTooLarge, limit)
+	}
+	return &modelsList{status: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+// modelsFetchStatus maps a fetch error to the status returned to the client.
+func modelsFetchStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+const modelsBody = `{"object":"list","data":[{"id":"openai/gpt-4o","object":"model"}]}`
+
+// newModelsHandlers returns handlers whose models fetch hits upstreamURL
+// with an openrouter credential configured.
+func newModelsHandlers(t *testing.T, upstreamURL string, cfg *config.Config) *Handlers {
+	t.Helper()
+	store := storage.NewMemoryStorage()
+	if err := store.CreateCredential(&storage.Credential{Provider: "openrouter", Name: "or", Data: []byte(`{"api_key":"k"}`)}); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{NumCounters: 100, MaxCost: 1 << 20, BufferItems: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cache.Close)
+	h := New(cfg, nil, store, nil, cache)
+	h.modelsURL = upstreamURL
+	return h
+}
+
+func TestListModels_FetchLimits(t *testing.T) {
+	var slow atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = w.Write([]byte(modelsBody))
+	}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name       string
+		limit      int64
+		warm       bool // A successful fetch precedes the slow one
+		wantStatus int
+		wantStale  bool
+	}{
+		{"timeout without cached list", 0, false, http.StatusGatewayTimeout, false},
+		{"timeout serves cached list", 0, true, http.StatusOK, true},
+		{"response over size cap", 16, false, http.StatusBadGateway, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{UpstreamConfig: config.UpstreamConfig{ModelsFetchTimeout: 50 * time.Millisecond, ModelsResponseLimit: tt.limit}}
+			h := newModelsHandlers(t, upstream.URL, cfg)
+
+			slow.Store(false)
+			if tt.warm {
+				h.ListModels(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+			}
+			slow.Store(tt.limit == 0)
+
+			rec := httptest.NewRecorder()
+			start := time.Now()
+			h.ListModels(rec, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("request took %v, timeout not applied", elapsed)
+			}
+			if stale := rec.Header().Get(StaleHeader) == "true"; stale != tt.wantStale {
+				t.Errorf("stale header = %v, want %v", stale, tt.wantStale)
+			}
+			if tt.wantStale && !strings.Contains(rec.Body.String(), "openai/gpt-4o") {
+				t.Errorf("stale body = %s, want cached list", rec.Body)
+			}
+		})
+	}
+}
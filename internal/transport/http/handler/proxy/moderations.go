@@ -2,9 +2,8 @@ package proxy
 
 import (
 	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,17 +18,15 @@ func (h *Handlers) Moderation(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
 	// Read and buffer the request body
-	bodyBytes, err := io.ReadAll(r.Body)
+	bodyBytes, err := h.readBody(w, r)
 	if err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to read request body"))
+		writeBodyError(w, err)
 		return
 	}
-	r.Body.Close()
 
 	// Parse request
 	var req types.ModerationRequest
-	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("invalid request format"))
+	if !parseBody(w, bodyBytes, &req) {
 		return
 	}
 
@@ -40,7 +37,7 @@ func (h *Handlers) Moderation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Default model if not specified
-	model := req.Model
+	model := strings.TrimSpace(req.Model)
 	if model == "" {
 		model = "omni-moderation-latest"
 	}
@@ -54,8 +51,11 @@ func (h *Handlers) Moderation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Proxy the request
-	result, _ := h.Provider.ProxyRequest(r.Context(), w, r, opts)
+	result := h.proxyRequest(w, r, opts)
 
-	// Log asynchronously
-	go h.logSimpleRequest(requestID, opts, model, result, startTime)
+	// Log asynchronously, metering the input tokens (moderation responses carry no usage);
+	// the meter tokenizes the input, so it runs off the request path too
+	h.background(func() {
+		h.logMeteredRequest(requestID, opts, model, result, startTime, h.moderationMeter(req.Input.Values, model))
+	})
 }
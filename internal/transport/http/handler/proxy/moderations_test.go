@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// moderationProvider answers every request with a moderation result flagging the input.
+type moderationProvider struct {
+	captureProvider
+}
+
+func (p *moderationProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	p.body, _ = io.ReadAll(opts.Body)
+	p.model = opts.Model
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(types.ModerationResponse{
+		ID:    "modr-1",
+		Model: opts.Model,
+		Results: []types.ModerationResult{{
+			Flagged:    true,
+			Categories: types.ModerationCategories{Harassment: true},
+		}},
+	})
+	return &types.ProxyResult{Model: opts.Model, StatusCode: http.StatusOK}, nil
+}
+
+func TestModeration(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantModel  string
+		wantTokens int // Metered input tokens (charTokenizer counts bytes)
+	}{
+		{"string input", `{"model":"text-moderation-latest","input":"you are awful"}`, http.StatusOK, "text-moderation-latest", 13},
+		{"array input uses default model", `{"input":["hello","you are awful"]}`, http.StatusOK, "omni-moderation-latest", 5 + 13},
+		{"missing input", `{"model":"omni-moderation-latest"}`, http.StatusBadRequest, "", 0},
+		{"invalid json", `{`, http.StatusBadRequest, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			prov := &moderationProvider{}
+			h := New(nil, prov, store, charTokenizer{}, nil)
+
+			rec := httptest.NewRecorder()
+			h.Moderation(rec, httptest.NewRequest(http.MethodPost, "/v1/moderations", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if prov.model != tt.wantModel {
+				t.Errorf("proxied model = %q, want %q", prov.model, tt.wantModel)
+			}
+			var resp types.ModerationResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || len(resp.Results) != 1 || !resp.Results[0].Flagged {
+				t.Fatalf("response = %s, %v; want one flagged result", rec.Body.String(), err)
+			}
+
+			// Usage is logged asynchronously
+			today := time.Now().Format("2006-01-02")
+			deadline := time.Now().Add(time.Second)
+			for {
+				daily, _ := store.GetDailyUsage(today, today)
+				if len(daily) == 1 {
+					if u := daily[0]; u.Model != tt.wantModel || u.RequestCount != 1 || u.PromptTokens != tt.wantTokens {
+						t.Errorf("daily usage = %+v, want 1 request of %d prompt tokens for %s", u, tt.wantTokens, tt.wantModel)
+					}
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("daily usage was not recorded")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
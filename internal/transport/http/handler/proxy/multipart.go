@@ -0,0 +1,40 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// maxMultipartBytes caps buffered multipart uploads (audio and image files).
+const maxMultipartBytes = 32 << 20
+
+// bufferMultipart reads the multipart body once (up to maxMultipartBytes), parses
+// the form from the buffered bytes and returns a replayable reader for the provider.
+// Unlike streaming r.Body, the reader can be rewound for retries and fallbacks.
+func bufferMultipart(w http.ResponseWriter, r *http.Request) (*bytes.Reader, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMultipartBytes))
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := r.ParseMultipartForm(maxMultipartBytes); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(body), nil
+}
+
+// writeMultipartError reports a bufferMultipart failure to the client.
+func writeMultipartError(w http.ResponseWriter, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		types.WriteError(w, http.StatusRequestEntityTooLarge, types.ErrInvalidRequest("request body too large"))
+		return
+	}
+	types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("failed to parse multipart form"))
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// retryingProvider reads the body, rewinds it and reads it again like a retry would.
+type retryingProvider struct {
+	attempts [][]byte
+}
+
+func (p *retryingProvider) Name() string                                                { return "retrying" }
+func (p *retryingProvider) BaseURL() string                                             { return "" }
+func (p *retryingProvider) PrepareRequest(ctx context.Context, req *http.Request) error { return nil }
+func (p *retryingProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	for i := 0; i < 2; i++ {
+		if err := opts.ResetBody(); err != nil {
+			return nil, err
+		}
+		body, _ := io.ReadAll(opts.Body)
+		p.attempts = append(p.attempts, body)
+	}
+	w.WriteHeader(http.StatusOK)
+	return &types.ProxyResult{StatusCode: http.StatusOK}, nil
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, fileField string, size int) (*http.Request, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		_ = mw.WriteField(k, v)
+	}
+	fw, err := mw.CreateFormFile(fileField, "upload.bin")
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	_, _ = fw.Write(bytes.Repeat([]byte("a"), size))
+	_ = mw.Close()
+
+	raw := buf.Bytes()
+	req := httptest.NewRequest(http.MethodPost, "/v1/audio/transcriptions", bytes.NewReader(raw))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req, raw
+}
+
+func TestTranscription_RetryResendsSameBody(t *testing.T) {
+	prov := &retryingProvider{}
+	h := New(nil, prov, nil, nil, nil)
+
+	req, raw := newMultipartRequest(t, map[string]string{"model": "whisper-1"}, "file", 1024)
+	rec := httptest.NewRecorder()
+	h.Transcription(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if len(prov.attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(prov.attempts))
+	}
+	for i, body := range prov.attempts {
+		if !bytes.Equal(body, raw) {
+			t.Errorf("attempt %d sent %d bytes, want the original %d bytes", i+1, len(body), len(raw))
+		}
+	}
+}
+
+func TestImageEdit_BodyTooLarge(t *testing.T) {
+	h := New(nil, &retryingProvider{}, nil, nil, nil)
+
+	req, _ := newMultipartRequest(t, map[string]string{"prompt": "p"}, "image", maxMultipartBytes+1)
+	rec := httptest.NewRecorder()
+	h.ImageEdit(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "too large") {
+		t.Errorf("body = %s", rec.Body.String())
+	}
+}
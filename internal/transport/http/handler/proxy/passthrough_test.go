@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+)
+
+func TestChatCompletions_PassesThroughNewerFields(t *testing.T) {
+	// Fields the gateway does not model; each must arrive byte-for-byte
+	passthrough := map[string]string{
+		"parallel_tool_calls": `false`,
+		"service_tier":        `"flex"`,
+		"store":               `true`,
+		"metadata":            `{"trace":"abc","nested":{"n":1}}`,
+		"reasoning_effort":    `"high"`,
+		"seed":                `9007199254740993`, // 2^53+1; a float64 round trip changes it,
+		"max_tokens":          `50`,
+	}
+	var body strings.Builder
+	body.WriteString(`{"model":"gpt4","messages":[{"role":"user","content":"hi"}],"temperature":3`)
+	for name, raw := range passthrough {
+		body.WriteString(`,"` + name + `":` + raw)
+	}
+	body.WriteString(`}`)
+
+	var got []byte
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"openai/gpt-4o","choices":[]}`))
+	}))
+	defer upstream.Close()
+
+	data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+	prov := &credentialProvider{
+		Provider: openrouter.NewWithBaseURL(upstream.URL),
+		cred:     &storage.Credential{Provider: "openrouter", Data: data},
+	}
+	// Clamping and the output ceiling both rewrite the body before the provider does
+	cfg := &config.Config{
+		ClampSamplingParams: true,
+		Models:              []config.ModelAlias{{Slug: "gpt4", Model: "openai/gpt-4o", MaxOutputTokens: 100}},
+	}
+	h := New(cfg, prov, nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body.String())))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(got, &fields); err != nil {
+		t.Fatalf("upstream body is not JSON: %v (%s)", err, got)
+	}
+	for name, want := range passthrough {
+		if !bytes.Equal(fields[name], []byte(want)) {
+			t.Errorf("%s = %s, want %s", name, fields[name], want)
+		}
+	}
+	if string(fields["temperature"]) != "2" {
+		t.Errorf("temperature = %s, want clamped 2", fields["temperature"])
+	}
+}
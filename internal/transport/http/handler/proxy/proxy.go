@@ -1,101 +1,60 @@
 package proxy
 
 import (
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
-	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/config"
 	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/provider/upstream"
 	"github.com/mandalnilabja/goatway/internal/storage"
 	"github.com/mandalnilabja/goatway/internal/tokenizer"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/ratelimit"
 )
 
 // Handlers holds the dependencies for proxy HTTP handlers.
 type Handlers struct {
+	Config    *config.Config
 	Provider  provider.Provider
 	Storage   storage.Storage
 	Tokenizer tokenizer.Tokenizer
 	Cache     *ristretto.Cache[string, any]
-}
 
-// New creates a new instance of proxy handlers.
-func New(prov provider.Provider, store storage.Storage, tok tokenizer.Tokenizer, cache *ristretto.Cache[string, any]) *Handlers {
-	return &Handlers{
-		Provider:  prov,
-		Storage:   store,
-		Tokenizer: tok,
-		Cache:     cache,
-	}
-}
+	// UserLimiter enforces per-end-user rate limits (nil disables them)
+	UserLimiter ratelimit.RateLimiter
 
-// updateDailyUsage updates the daily usage aggregate for a request.
-func (h *Handlers) updateDailyUsage(credentialID string, result *provider.ProxyResult, prompt, completion, total int) {
-	today := time.Now().Format("2006-01-02")
+	modelsURL    string       // Upstream models endpoint; empty uses OpenRouter's
+	modelsClient *http.Client // Upstream client for the models fetch, pooled and host-restricted like the providers'
 
-	errorCount := 0
-	if result.StatusCode >= 400 {
-		errorCount = 1
-	}
-
-	usage := &storage.DailyUsage{
-		Date:             today,
-		CredentialID:     credentialID,
-		Model:            result.Model,
-		RequestCount:     1,
-		PromptTokens:     prompt,
-		CompletionTokens: completion,
-		TotalTokens:      total,
-		ErrorCount:       errorCount,
-	}
+	counter *countPool // Bounded token counting workers, stopped by Close
 
-	_ = h.Storage.UpdateDailyUsage(usage)
-}
+	pendingMu       sync.Mutex
+	pending         []pendingWrite // Request log writes awaiting retry while storage is busy
+	logRetryBackoff time.Duration  // Delay before a buffered write is retried, doubled per failure
 
-// logRequestBase creates a base request log entry.
-func (h *Handlers) logRequestBase(requestID, credentialID, model string, result *provider.ProxyResult, startTime time.Time) *storage.RequestLog {
-	duration := time.Since(startTime)
+	logSeq atomic.Uint64 // Successful request logs seen, for LogSampleRate
 
-	return &storage.RequestLog{
-		ID:           uuid.New().String(),
-		RequestID:    requestID,
-		CredentialID: credentialID,
-		Model:        model,
-		Provider:     h.Provider.Name(),
-		IsStreaming:  false,
-		StatusCode:   result.StatusCode,
-		ErrorMessage: result.ErrorMessage,
-		DurationMs:   duration.Milliseconds(),
-		CreatedAt:    time.Now(),
-	}
+	inflight sync.WaitGroup // Background work started by requests (see background)
 }
 
-// logSimpleRequest logs a simple request (no token counts) to storage.
-func (h *Handlers) logSimpleRequest(requestID string, opts *provider.ProxyOptions, model string, result *provider.ProxyResult, startTime time.Time) {
-	if h.Storage == nil || result == nil {
-		return
-	}
-
-	credentialID := ""
-	if opts.Credential != nil {
-		credentialID = opts.Credential.ID
-	}
-
-	log := h.logRequestBase(requestID, credentialID, model, result, startTime)
-	_ = h.Storage.LogRequest(log)
-
-	// Update daily usage
-	errorCount := 0
-	if result.StatusCode >= 400 {
-		errorCount = 1
+// New creates a new instance of proxy handlers.
+func New(cfg *config.Config, prov provider.Provider, store storage.Storage, tok tokenizer.Tokenizer, cache *ristretto.Cache[string, any]) *Handlers {
+	pool, workers := upstream.DefaultPool(), 0
+	if cfg != nil {
+		pool, workers = provider.UpstreamPool(cfg), cfg.TokenCountWorkers
 	}
+	return &Handlers{
+		Config:       cfg,
+		Provider:     prov,
+		Storage:      store,
+		Tokenizer:    tok,
+		Cache:        cache,
+		modelsClient: upstream.NewClient(pool),
+		counter:      newCountPool(workers),
 
-	usage := &storage.DailyUsage{
-		Date:         time.Now().Format("2006-01-02"),
-		CredentialID: credentialID,
-		Model:        model,
-		RequestCount: 1,
-		ErrorCount:   errorCount,
+		logRetryBackoff: defaultLogRetryBackoff,
 	}
-
-	_ = h.Storage.UpdateDailyUsage(usage)
 }
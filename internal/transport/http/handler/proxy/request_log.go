@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// updateDailyUsage updates the daily usage aggregate for a request.
+func (h *Handlers) updateDailyUsage(credentialID string, result *provider.ProxyResult, prompt, completion, total int) {
+	today := time.Now().Format("2006-01-02")
+
+	errorCount := 0
+	if result.StatusCode >= 400 {
+		errorCount = 1
+	}
+
+	usage := &storage.DailyUsage{
+		Date:             today,
+		CredentialID:     credentialID,
+		Model:            result.Model,
+		RequestCount:     1,
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      total,
+		ErrorCount:       errorCount,
+		ToolCalls:        result.ToolCalls,
+	}
+	if result.ToolCalls > 0 {
+		usage.ToolCallRequests = 1
+	}
+
+	h.recordDailyUsage(usage)
+}
+
+// logRequestBase creates a base request log entry.
+func (h *Handlers) logRequestBase(requestID, credentialID, model string, result *provider.ProxyResult, startTime time.Time) *storage.RequestLog {
+	duration := time.Since(startTime)
+
+	return &storage.RequestLog{
+		ID:           uuid.New().String(),
+		RequestID:    requestID,
+		CredentialID: credentialID,
+		Model:        model,
+		Provider:     h.Provider.Name(),
+		IsStreaming:  false,
+		StatusCode:   result.StatusCode,
+		Attempts:     result.Attempts,
+		ErrorMessage: result.ErrorMessage,
+		ErrorType:    errorType(result),
+		DurationMs:   duration.Milliseconds(),
+		CreatedAt:    time.Now(),
+	}
+}
+
+// logSimpleRequest logs a simple request (no token counts) to storage.
+func (h *Handlers) logSimpleRequest(requestID string, opts *provider.ProxyOptions, model string, result *provider.ProxyResult, startTime time.Time) {
+	h.logMeteredRequest(requestID, opts, model, result, startTime, usageMeter{})
+}
+
+// logMeteredRequest logs a request without upstream token usage, recording the
+// approximate accounting in meter when the request succeeded.
+func (h *Handlers) logMeteredRequest(requestID string, opts *provider.ProxyOptions, model string, result *provider.ProxyResult, startTime time.Time, meter usageMeter) {
+	if h.Storage == nil || result == nil {
+		return
+	}
+
+	credentialID := ""
+	if opts.Credential != nil {
+		credentialID = opts.Credential.ID
+	}
+
+	errorCount := 0
+	if result.StatusCode >= 400 {
+		errorCount = 1
+		meter = usageMeter{} // Failed calls are not billed
+	}
+
+	log := h.logRequestBase(requestID, credentialID, model, result, startTime)
+	log.APIKeyID, log.User = opts.APIKeyID, opts.User
+	log.PromptTokens = meter.PromptTokens
+	log.TotalTokens = meter.PromptTokens
+	h.recordFailure(log, opts)
+	if logsRequests(opts.Credential) {
+		h.logRequest(log)
+	}
+
+	usage := &storage.DailyUsage{
+		Date:            time.Now().Format("2006-01-02"),
+		CredentialID:    credentialID,
+		Model:           model,
+		RequestCount:    1,
+		PromptTokens:    meter.PromptTokens,
+		TotalTokens:     meter.PromptTokens,
+		ErrorCount:      errorCount,
+		AudioCharacters: meter.AudioCharacters,
+		ImageCount:      meter.Images,
+	}
+
+	h.recordDailyUsage(usage)
+}
+
+// errorType returns the result's error category, classifying router and
+// handler failures (which never reach a provider) by status code.
+func errorType(result *provider.ProxyResult) string {
+	if result.ErrorType != "" || result.StatusCode < 400 {
+		return result.ErrorType
+	}
+	return types.ClassifyUpstreamError(result.StatusCode, result.ErrorMessage)
+}
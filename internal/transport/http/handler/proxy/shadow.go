@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// shadowTimeout bounds a shadow request, which outlives the client request.
+const shadowTimeout = 2 * time.Minute
+
+// shadowRequest clones the client request for a shadow call. The clone is
+// detached from client cancellation so the shadow completes independently,
+// and drops the client's provider hint and admin credential override, which
+// apply to the primary model only; the shadow uses its own route's credential.
+func shadowRequest(r *http.Request) *http.Request {
+	ctx := types.WithCredentialOverride(context.WithoutCancel(r.Context()), "")
+	clone := r.Clone(ctx)
+	clone.Header.Del(provider.ProviderHintHeader)
+	return clone
+}
+
+// runShadow sends a copy of a chat request to the configured shadow model.
+// The response is discarded; latency and tokens are logged as a shadow request
+// and never counted in daily usage.
+func (h *Handlers) runShadow(r *http.Request, requestID string, body []byte, promptTokens int) {
+	ctx, cancel := context.WithTimeout(r.Context(), shadowTimeout)
+	defer cancel()
+
+	opts := &provider.ProxyOptions{
+		RequestID: requestID,
+		Model:     h.Config.Shadow.Model,
+		Body:      bytes.NewReader(body),
+	}
+
+	result, _ := h.Provider.ProxyRequest(ctx, &discardWriter{header: make(http.Header)}, r.WithContext(ctx), opts)
+	if h.Storage == nil || result == nil || !logsRequests(opts.Credential) {
+		return
+	}
+
+	log := h.chatRequestLog(requestID, opts, result, promptTokens)
+	log.IsShadow = true
+	h.logRequest(log)
+}
+
+// discardWriter is an http.ResponseWriter that drops everything written to it.
+type discardWriter struct {
+	header http.Header
+}
+
+func (d *discardWriter) Header() http.Header         { return d.header }
+func (d *discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (d *discardWriter) WriteHeader(int)             {}
+func (d *discardWriter) Flush()                      {}
@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// modelEchoProvider answers with the requested model and reports each call.
+type modelEchoProvider struct {
+	calls chan string
+}
+
+func (p *modelEchoProvider) Name() string                                                { return "echo" }
+func (p *modelEchoProvider) BaseURL() string                                             { return "" }
+func (p *modelEchoProvider) PrepareRequest(ctx context.Context, req *http.Request) error { return nil }
+func (p *modelEchoProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	w.Header().Set("X-Model", opts.Model)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(opts.Model))
+	p.calls <- opts.Model
+	return &types.ProxyResult{Model: opts.Model, StatusCode: http.StatusOK, PromptTokens: 3, CompletionTokens: 4}, nil
+}
+
+// syncLogCapture is a goroutine-safe logCapture for asynchronous shadow logs.
+type syncLogCapture struct {
+	storage.Storage
+	mu   sync.Mutex
+	logs []*storage.RequestLog
+}
+
+func (s *syncLogCapture) LogRequest(log *storage.RequestLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, log)
+	return nil
+}
+func (s *syncLogCapture) UpdateDailyUsage(usage *storage.DailyUsage) error { return nil }
+
+func TestChatCompletions_Shadow(t *testing.T) {
+	tests := []struct {
+		name       string
+		stream     bool
+		wantShadow bool
+	}{
+		{"non-streaming request is mirrored", false, true},
+		{"streaming request is not mirrored", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &modelEchoProvider{calls: make(chan string, 2)}
+			store := &syncLogCapture{}
+			cfg := &config.Config{Shadow: &config.ShadowRoute{Model: "shadow-model"}}
+			h := New(cfg, prov, store, nil, nil)
+
+			body := `{"model":"primary","stream":` + strconv.FormatBool(tt.stream) + `,"messages":[{"role":"user","content":"hi"}]}`
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+
+			if got := <-prov.calls; got != "primary" {
+				t.Fatalf("first call model = %q, want primary", got)
+			}
+			if rec.Body.String() != "primary" || rec.Header().Get("X-Model") != "primary" {
+				t.Errorf("primary response altered: body=%q header=%q", rec.Body.String(), rec.Header().Get("X-Model"))
+			}
+
+			select {
+			case got := <-prov.calls:
+				if !tt.wantShadow {
+					t.Fatalf("unexpected shadow call to %q", got)
+				}
+				if got != "shadow-model" {
+					t.Errorf("shadow model = %q, want shadow-model", got)
+				}
+			case <-time.After(500 * time.Millisecond):
+				if tt.wantShadow {
+					t.Fatal("shadow call did not fire")
+				}
+				return
+			}
+
+			// The shadow call is logged separately once it returns.
+			deadline := time.Now().Add(time.Second)
+			for time.Now().Before(deadline) {
+				store.mu.Lock()
+				for _, log := range store.logs {
+					if log.IsShadow {
+						store.mu.Unlock()
+						if log.Model != "shadow-model" || log.TotalTokens != 7 {
+							t.Errorf("shadow log = %+v", log)
+						}
+						return
+					}
+				}
+				store.mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+			}
+			t.Error("shadow request was not logged")
+		})
+	}
+}
+
+func TestShadowRequest_DropsPrimaryOnlyPins(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set(provider.ProviderHintHeader, "openrouter")
+	req = req.WithContext(types.WithCredentialOverride(req.Context(), "cred-123"))
+
+	clone := shadowRequest(req)
+	if got := types.CredentialOverride(clone.Context()); got != "" {
+		t.Errorf("shadow credential override = %q, want none", got)
+	}
+	if got := clone.Header.Get(provider.ProviderHintHeader); got != "" {
+		t.Errorf("shadow provider hint = %q, want none", got)
+	}
+	if got := types.CredentialOverride(req.Context()); got != "cred-123" {
+		t.Errorf("primary credential override = %q, want cred-123", got)
+	}
+}
@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+)
+
+// TimeoutHeader lets a client set a shorter deadline for one request, in
+// seconds (fractions allowed). It is capped by MAX_REQUEST_TIMEOUT.
+const TimeoutHeader = "X-Goatway-Timeout"
+
+// requestTimeout returns the deadline the client asked for with TimeoutHeader,
+// capped by the configured maximum. Missing, malformed or non-positive values
+// return 0, leaving the request without a gateway deadline.
+func (h *Handlers) requestTimeout(r *http.Request) time.Duration {
+	value := strings.TrimSpace(r.Header.Get(TimeoutHeader))
+	if value == "" {
+		return 0
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	timeout := time.Duration(seconds * float64(time.Second))
+	if h.Config != nil && h.Config.MaxRequestTimeout > 0 && timeout > h.Config.MaxRequestTimeout {
+		timeout = h.Config.MaxRequestTimeout
+	}
+	return timeout
+}
+
+// proxyRequest sends the request upstream under the client's deadline, if any.
+// An expired deadline surfaces as 504 from the provider.
+func (h *Handlers) proxyRequest(w http.ResponseWriter, r *http.Request, opts *provider.ProxyOptions) *provider.ProxyResult {
+	ctx := r.Context()
+	if timeout := h.requestTimeout(r); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+
+	result, _ := h.Provider.ProxyRequest(ctx, w, r, opts)
+	return result
+}
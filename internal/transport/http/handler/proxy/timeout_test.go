@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+	"github.com/mandalnilabja/goatway/internal/provider/openrouter"
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/storage/models"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// credentialProvider sets the credential the Router would have resolved.
+type credentialProvider struct {
+	types.Provider
+	cred *storage.Credential
+}
+
+func (p *credentialProvider) ProxyRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opts *types.ProxyOptions) (*types.ProxyResult, error) {
+	opts.Credential = p.cred
+	return p.Provider.ProxyRequest(ctx, w, req, opts)
+}
+
+func TestEmbeddings_ClientTimeout(t *testing.T) {
+	// The upstream never answers; release unblocks it so Close can return
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	data, _ := json.Marshal(models.APIKeyCredential{APIKey: "sk-test"})
+	prov := &credentialProvider{
+		Provider: openrouter.NewWithBaseURL(upstream.URL),
+		cred:     &storage.Credential{Provider: "openrouter", Data: data},
+	}
+	h := New(&config.Config{MaxRequestTimeout: time.Minute}, prov, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/embeddings", strings.NewReader(`{"model":"m","input":"hi"}`))
+	req.Header.Set(TimeoutHeader, "0.05")
+	rec := httptest.NewRecorder()
+
+	start := time.Now()
+	h.Embeddings(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504", rec.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v; client timeout was not applied", elapsed)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		max    time.Duration
+		want   time.Duration
+	}{
+		{"absent", "", time.Minute, 0},
+		{"whole seconds", "5", time.Minute, 5 * time.Second},
+		{"fractional seconds", "2.5", time.Minute, 2500 * time.Millisecond},
+		{"above cap is clamped", "3600", time.Minute, time.Minute},
+		{"no cap", "3600", 0, time.Hour},
+		{"malformed", "soon", time.Minute, 0},
+		{"negative", "-1", time.Minute, 0},
+		{"zero", "0", time.Minute, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(&config.Config{MaxRequestTimeout: tt.max}, &captureProvider{}, nil, nil, nil)
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			if tt.header != "" {
+				req.Header.Set(TimeoutHeader, tt.header)
+			}
+			if got := h.requestTimeout(req); got != tt.want {
+				t.Errorf("requestTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
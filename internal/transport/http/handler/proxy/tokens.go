@@ -0,0 +1,32 @@
+package proxy
+
+import (
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// countCompletion estimates completion tokens from streamed output (content and
+// reconstructed tool calls) when the upstream did not report usage.
+func (h *Handlers) countCompletion(result *provider.ProxyResult) int {
+	if h.Tokenizer == nil || (result.CompletionText == "" && len(result.CompletionToolCalls) == 0) {
+		return 0
+	}
+	tokens, err := h.Tokenizer.CountCompletion(result.CompletionText, result.CompletionToolCalls, result.Model)
+	if err != nil {
+		return 0
+	}
+	return tokens
+}
+
+// countPrompt queues prompt token counting for req on the shared worker pool.
+// The returned channel yields the count when available and is then closed.
+func (h *Handlers) countPrompt(req *types.ChatCompletionRequest) <-chan int {
+	if h.Tokenizer == nil {
+		out := make(chan int)
+		close(out)
+		return out
+	}
+	return h.counter.submit(func() (int, error) {
+		return h.Tokenizer.CountRequest(req)
+	})
+}
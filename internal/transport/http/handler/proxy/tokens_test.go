@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// charTokenizer counts one token per byte so results are deterministic offline.
+type charTokenizer struct{}
+
+func (charTokenizer) CountTokens(text, model string) (int, error) { return len(text), nil }
+func (charTokenizer) CountMessages(msgs []types.Message, model string) (int, error) {
+	return 0, nil
+}
+func (charTokenizer) CountRequest(req *types.ChatCompletionRequest) (int, error) { return 0, nil }
+func (charTokenizer) CountCompletion(content string, calls []types.ToolCall, model string) (int, error) {
+	total := len(content)
+	for _, c := range calls {
+		total += len(c.Function.Name) + len(c.Function.Arguments)
+	}
+	return total, nil
+}
+
+// logCapture records request logs; other storage methods are unused.
+type logCapture struct {
+	storage.Storage
+	logs []*storage.RequestLog
+}
+
+func (s *logCapture) LogRequest(log *storage.RequestLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+func (s *logCapture) UpdateDailyUsage(usage *storage.DailyUsage) error { return nil }
+
+func TestLogChatRequest_CountsStreamedToolCalls(t *testing.T) {
+	store := &logCapture{}
+	h := New(nil, &captureProvider{}, store, charTokenizer{}, nil)
+
+	result := &types.ProxyResult{
+		Model:       "m",
+		StatusCode:  200,
+		IsStreaming: true,
+		CompletionToolCalls: []types.ToolCall{
+			{ID: "call_1", Function: types.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+		},
+	}
+	h.logChatRequest("req-1", &types.ProxyOptions{}, result, 10)
+
+	if len(store.logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(store.logs))
+	}
+	want := len("get_weather") + len(`{"city":"Paris"}`)
+	if got := store.logs[0].CompletionTokens; got != want {
+		t.Errorf("completion tokens = %d, want %d", got, want)
+	}
+	if got := store.logs[0].TotalTokens; got != 10+want {
+		t.Errorf("total tokens = %d, want %d", got, 10+want)
+	}
+}
@@ -0,0 +1,43 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestLogChatRequest_CountsToolCalls(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	h := New(nil, &captureProvider{}, store, nil, nil)
+	opts := &provider.ProxyOptions{}
+
+	for i, calls := range []int{2, 0, 1} {
+		result := &provider.ProxyResult{Model: "m", StatusCode: http.StatusOK, ToolCalls: calls}
+		h.logChatRequest(string(rune('a'+i)), opts, result, 1)
+	}
+
+	logs, err := store.GetRequestLogs(storage.LogFilter{})
+	if err != nil || len(logs) != 3 {
+		t.Fatalf("GetRequestLogs = %d logs, %v; want 3", len(logs), err)
+	}
+	total := 0
+	for _, l := range logs {
+		total += l.ToolCalls
+	}
+	if total != 3 {
+		t.Errorf("logged tool calls = %d, want 3", total)
+	}
+
+	stats, err := store.GetUsageStats(storage.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetUsageStats: %v", err)
+	}
+	if stats.TotalToolCallRequests != 2 || stats.TotalToolCalls != 3 {
+		t.Errorf("stats = %d tool call requests, %d tool calls; want 2, 3", stats.TotalToolCallRequests, stats.TotalToolCalls)
+	}
+	if ms := stats.ModelBreakdown["m"]; ms == nil || ms.ToolCallRequests != 2 || ms.ToolCalls != 3 {
+		t.Errorf("model breakdown = %+v", ms)
+	}
+}
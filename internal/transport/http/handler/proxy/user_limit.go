@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/provider"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// attributeUser records the calling API key and the request's "user" field on
+// opts, then enforces the key's per-user rate limit. When the user is over its
+// limit a 429 is written and false is returned.
+func (h *Handlers) attributeUser(w http.ResponseWriter, r *http.Request, opts *provider.ProxyOptions, user string) bool {
+	key := auth.GetAPIKey(r.Context())
+	if key == nil {
+		return true
+	}
+	opts.APIKeyID = key.ID
+	opts.User = strings.TrimSpace(user)
+
+	if h.UserLimiter == nil || key.UserLimit <= 0 || opts.User == "" {
+		return true
+	}
+	if h.UserLimiter.Allow(userLimitKey(key.ID, opts.User), key.UserLimit) {
+		return true
+	}
+
+	w.Header().Set("Retry-After", "60")
+	types.WriteError(w, http.StatusTooManyRequests, types.ErrRateLimit("rate limit exceeded for user"))
+	return false
+}
+
+// userLimitKey namespaces an end user's bucket under its API key so the same
+// user value sent through different keys is limited independently.
+func userLimitKey(apiKeyID, user string) string {
+	return apiKeyID + ":user:" + user
+}
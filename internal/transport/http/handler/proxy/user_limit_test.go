@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
+	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/ratelimit"
+)
+
+func TestChatCompletions_PerUserRateLimit(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	raw := map[string]string{}
+	for id, userLimit := range map[string]int{"limited": 2, "open": 0} {
+		key, _ := storage.GenerateAPIKey()
+		hash, _ := storage.HashPassword(key, nil)
+		err := store.CreateAPIKey(&storage.ClientAPIKey{
+			ID:        id,
+			Name:      id,
+			KeyHash:   hash,
+			KeyPrefix: storage.ExtractKeyPrefix(key),
+			Scopes:    []string{"proxy"},
+			UserLimit: userLimit,
+			IsActive:  true,
+		})
+		if err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		raw[id] = key
+	}
+
+	h := New(nil, &captureProvider{}, store, nil, nil)
+	h.UserLimiter = ratelimit.New()
+	handler := auth.APIKeyAuth(store, nil)(http.HandlerFunc(h.ChatCompletions))
+
+	steps := []struct {
+		name       string
+		key        string
+		user       string
+		wantStatus int
+	}{
+		{"alice first", "limited", "alice", http.StatusOK},
+		{"alice second", "limited", "alice", http.StatusOK},
+		{"alice over limit", "limited", "alice", http.StatusTooManyRequests},
+		{"bob has own bucket", "limited", "bob", http.StatusOK},
+		{"no user is not limited", "limited", "", http.StatusOK},
+		{"no user again", "limited", "", http.StatusOK},
+		{"no user third", "limited", "", http.StatusOK},
+		{"alice on unlimited key", "open", "alice", http.StatusOK},
+		{"alice on unlimited key again", "open", "alice", http.StatusOK},
+		{"alice on unlimited key third", "open", "alice", http.StatusOK},
+	}
+
+	for _, step := range steps {
+		body := `{"model":"m","messages":[{"role":"user","content":"hi"}]`
+		if step.user != "" {
+			body += `,"user":"` + step.user + `"`
+		}
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body+"}"))
+		req.Header.Set("Authorization", "Bearer "+raw[step.key])
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != step.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", step.name, rec.Code, step.wantStatus, rec.Body.String())
+		}
+	}
+
+	// Successful requests are attributed to (key, user); rejected ones never reach the log
+	want := map[string]int{"limited/alice": 2, "limited/bob": 1, "open/alice": 3}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		usage, err := store.GetUserUsage("", storage.StatsFilter{})
+		if err != nil {
+			t.Fatalf("GetUserUsage: %v", err)
+		}
+		got := map[string]int{}
+		for _, u := range usage {
+			got[u.APIKeyID+"/"+u.User] = u.RequestCount
+		}
+		matched := len(got) == len(want)
+		for k, n := range want {
+			matched = matched && got[k] == n
+		}
+		if matched {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("user usage = %v, want %v", got, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/config"
+)
+
+func TestHandlers_RejectEmptyModel(t *testing.T) {
+	prov := &captureProvider{}
+	h := New(nil, prov, nil, nil, nil)
+
+	handlers := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string // %s is replaced with the model value
+	}{
+		{"chat", h.ChatCompletions, `{"model":%s,"messages":[{"role":"user","content":"hi"}]}`},
+		{"completions", h.LegacyCompletion, `{"model":%s,"prompt":"hi"}`},
+		{"embeddings", h.Embeddings, `{"model":%s,"input":"hi"}`},
+		{"speech", h.TextToSpeech, `{"model":%s,"input":"hi","voice":"alloy"}`},
+	}
+	models := []struct {
+		name  string
+		value string
+	}{
+		{"empty", `""`},
+		{"whitespace", `"  \t "`},
+		{"missing", `null`},
+	}
+
+	for _, hh := range handlers {
+		for _, m := range models {
+			t.Run(hh.name+"/"+m.name, func(t *testing.T) {
+				prov.body = nil
+				body := strings.Replace(hh.body, "%s", m.value, 1)
+				rec := httptest.NewRecorder()
+				hh.handler(rec, httptest.NewRequest(http.MethodPost, "/v1/x", strings.NewReader(body)))
+
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want 400", rec.Code)
+				}
+				if !strings.Contains(rec.Body.String(), "model is required") {
+					t.Errorf("body = %s", rec.Body.String())
+				}
+				if prov.body != nil {
+					t.Error("request was proxied")
+				}
+			})
+		}
+	}
+}
+
+func TestChatCompletions_DefaultModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		defaultTo  string
+		model      string
+		wantStatus int
+		wantModel  string
+	}{
+		{"empty model filled", "gpt4", `""`, http.StatusOK, "gpt4"},
+		{"missing model filled", "gpt4", `null`, http.StatusOK, "gpt4"},
+		{"explicit model kept", "gpt4", `"m"`, http.StatusOK, "m"},
+		{"no default rejects", "", `""`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &captureProvider{}
+			h := New(&config.Config{DefaultChatModel: tt.defaultTo}, prov, nil, nil, nil)
+
+			body := `{"model":` + tt.model + `,"messages":[{"role":"user","content":"hi"}]}`
+			rec := httptest.NewRecorder()
+			h.ChatCompletions(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if prov.model != tt.wantModel {
+				t.Errorf("proxied model = %q, want %q", prov.model, tt.wantModel)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteJSON writes a JSON response with the given status code.
+// HTML characters are not escaped, so fields such as logged prompts keep
+// their literal '<', '>' and '&'.
+func WriteJSON(w http.ResponseWriter, data any, status int) {
+	writeJSON(w, data, status, false)
+}
+
+// WriteAdminJSON writes a JSON response like WriteJSON, indenting it when the
+// request carries ?pretty=true.
+func WriteAdminJSON(w http.ResponseWriter, r *http.Request, data any, status int) {
+	writeJSON(w, data, status, r.URL.Query().Get("pretty") == "true")
+}
+
+// WriteJSONError writes a JSON error response.
+func WriteJSONError(w http.ResponseWriter, message string, status int) {
+	WriteJSON(w, map[string]any{
+		"error": map[string]any{
+			"message": message,
+			"code":    status,
+		},
+	}, status)
+}
+
+func writeJSON(w http.ResponseWriter, data any, status int, pretty bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	_ = enc.Encode(data)
+}
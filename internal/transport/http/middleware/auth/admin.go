@@ -2,31 +2,65 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/storage"
 )
 
-// AdminAuth middleware protects admin routes using session-based authentication only.
-// No Bearer token fallback - admin access requires login via web UI.
-func AdminAuth(sessions *SessionStore) func(http.Handler) http.Handler {
+// AdminScope is the API key scope that grants access to admin routes.
+const AdminScope = "admin"
+
+// AdminAuth middleware protects admin routes. A request is authorized by any of:
+//   - a valid web UI session cookie
+//   - "Authorization: Bearer <admin password>"
+//   - "Authorization: Bearer gw_..." for an active API key with the admin scope
+func AdminAuth(sessions *SessionStore, store storage.Storage, cache *ristretto.Cache[string, *CachedAPIKey]) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Session management required
-			if sessions == nil {
-				writeUnauthorized(w, "session management not configured")
+			// 1. Session cookie (web UI)
+			if cookie, err := r.Cookie("goatway_session"); err == nil && cookie.Value != "" && sessions != nil {
+				if sessions.Get(cookie.Value) != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			auth := r.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "Bearer ") || store == nil {
+				writeUnauthorized(w, "session or admin credentials required")
 				return
 			}
+			token := strings.TrimPrefix(auth, "Bearer ")
 
-			// Check for valid session cookie
-			cookie, err := r.Cookie("goatway_session")
-			if err != nil || cookie.Value == "" {
-				writeUnauthorized(w, "session required")
+			// 2. Admin-scoped API key
+			if strings.HasPrefix(token, storage.KeyPrefix()) {
+				key := resolveAPIKey(store, cache, token)
+				if key == nil {
+					writeUnauthorized(w, "invalid or expired API key")
+					return
+				}
+				if !key.HasScope(AdminScope) {
+					writeForbidden(w, "API key lacks admin scope")
+					return
+				}
+				warnExpiryGrace(w, key)
+				ctx := context.WithValue(r.Context(), APIKeyContextKey{}, key)
+				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 
-			session := sessions.Get(cookie.Value)
-			if session == nil {
-				writeUnauthorized(w, "invalid or expired session")
+			// 3. Admin password
+			hash, err := store.GetAdminPasswordHash()
+			if err != nil || hash == "" {
+				writeUnauthorized(w, "invalid admin credentials")
+				return
+			}
+			if valid, _ := storage.VerifyPassword(token, hash); !valid {
+				writeUnauthorized(w, "invalid admin credentials")
 				return
 			}
 
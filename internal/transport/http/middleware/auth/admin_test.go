@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// newAdminTestStore creates a storage with an admin password and one key per scope.
+func newAdminTestStore(t *testing.T) (storage.Storage, map[string]string) {
+	t.Helper()
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	hash, _ := storage.HashPassword("admin-password", nil)
+	if err := store.SetAdminPasswordHash(hash); err != nil {
+		t.Fatalf("SetAdminPasswordHash: %v", err)
+	}
+
+	keys := map[string]string{}
+	for _, scope := range []string{"admin", "proxy"} {
+		raw, _ := storage.GenerateAPIKey()
+		keyHash, _ := storage.HashPassword(raw, nil)
+		err := store.CreateAPIKey(&storage.ClientAPIKey{
+			ID:        scope + "-key",
+			Name:      scope,
+			KeyHash:   keyHash,
+			KeyPrefix: storage.ExtractKeyPrefix(raw),
+			Scopes:    []string{scope},
+			IsActive:  true,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		keys[scope] = raw
+	}
+	return store, keys
+}
+
+func TestAdminAuth(t *testing.T) {
+	store, keys := newAdminTestStore(t)
+	sessions := NewSessionStore(time.Hour)
+	session := sessions.Create()
+
+	handler := AdminAuth(sessions, store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		bearer     string
+		cookie     string
+		wantStatus int
+	}{
+		{"web session", "", session.ID, http.StatusOK},
+		{"admin password", "admin-password", "", http.StatusOK},
+		{"admin-scoped API key", keys["admin"], "", http.StatusOK},
+		{"proxy-scoped API key is forbidden", keys["proxy"], "", http.StatusForbidden},
+		{"wrong password", "wrong-password", "", http.StatusUnauthorized},
+		{"expired session", "", "unknown-session", http.StatusUnauthorized},
+		{"no credentials", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/info", nil)
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "goatway_session", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
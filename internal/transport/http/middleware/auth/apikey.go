@@ -8,6 +8,7 @@ import (
 
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
 )
 
 // APIKeyContextKey is the context key for authenticated API key.
@@ -20,7 +21,7 @@ type CachedAPIKey struct {
 }
 
 // APIKeyAuth middleware authenticates requests using Goatway API keys.
-// Only keys starting with "gw_" are accepted; all other keys are rejected.
+// Only keys starting with the configured prefix ("gw_" by default) are accepted.
 func APIKeyAuth(store storage.Storage, cache *ristretto.Cache[string, *CachedAPIKey]) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -32,65 +33,52 @@ func APIKeyAuth(store storage.Storage, cache *ristretto.Cache[string, *CachedAPI
 			}
 			apiKey := strings.TrimPrefix(auth, "Bearer ")
 
-			// Reject non-goatway keys (all clients must use gw_* keys)
-			if !strings.HasPrefix(apiKey, storage.APIKeyPrefix) {
-				writeUnauthorized(w, "only Goatway API keys (gw_*) are accepted")
+			// Reject non-goatway keys (all clients must use the configured prefix, gw_* by default)
+			if !strings.HasPrefix(apiKey, storage.KeyPrefix()) {
+				writeUnauthorized(w, "only Goatway API keys ("+storage.KeyPrefix()+"*) are accepted")
 				return
 			}
 
-			// 2. Check cache first
-			prefix := storage.ExtractKeyPrefix(apiKey)
-			cacheKey := "apikey:" + prefix
-
-			if cache != nil {
-				if cached, found := cache.Get(cacheKey); found {
-					if time.Now().Before(cached.ValidUntil) {
-						valid, _ := storage.VerifyPassword(apiKey, cached.Key.KeyHash)
-						if valid && cached.Key.IsActive && !cached.Key.IsExpired() {
-							ctx := context.WithValue(r.Context(), APIKeyContextKey{}, cached.Key)
-							next.ServeHTTP(w, r.WithContext(ctx))
-							return
-						}
-					}
-				}
-			}
-
-			// 3. Lookup in database by prefix
-			keys, err := store.GetAPIKeyByPrefix(prefix)
-			if err != nil || len(keys) == 0 {
-				writeUnauthorized(w, "invalid API key")
+			// 2. Resolve against cache, then database
+			validKey := resolveAPIKey(store, cache, apiKey)
+			if validKey == nil {
+				writeUnauthorized(w, "invalid or expired API key")
 				return
 			}
+			warnExpiryGrace(w, validKey)
 
-			// 4. Verify hash against all matching keys
-			var validKey *storage.ClientAPIKey
-			for _, k := range keys {
-				valid, _ := storage.VerifyPassword(apiKey, k.KeyHash)
-				if valid {
-					validKey = k
-					break
-				}
+			// 3. Add to context with the key's tenant, honoring an admin credential override
+			ctx := context.WithValue(r.Context(), APIKeyContextKey{}, validKey)
+			ctx, ok := withCredentialOverride(w, r, ctx, validKey)
+			if ok {
+				ctx, ok = withTenant(w, r, ctx, validKey)
 			}
-
-			if validKey == nil || !validKey.IsActive || validKey.IsExpired() {
-				writeUnauthorized(w, "invalid or expired API key")
+			if !ok {
 				return
 			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-			// 5. Cache valid key for 5 minutes
-			if cache != nil {
-				cache.Set(cacheKey, &CachedAPIKey{
-					Key:        validKey,
-					ValidUntil: time.Now().Add(5 * time.Minute),
-				}, 1)
+// OptionalAPIKeyAuth authenticates requests that send an Authorization header
+// exactly like APIKeyAuth, but lets requests without one through anonymously.
+// Used when client authentication is disabled for trusted (e.g. localhost) setups.
+// Anonymous requests cannot select a tenant, since tenants are bound to keys.
+func OptionalAPIKeyAuth(store storage.Storage, cache *ristretto.Cache[string, *CachedAPIKey]) func(http.Handler) http.Handler {
+	required := APIKeyAuth(store, cache)
+	return func(next http.Handler) http.Handler {
+		authenticated := required(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				if r.Header.Get(types.TenantHeader) != "" {
+					writeUnauthorized(w, "API key required to select a tenant")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
 			}
-
-			// 6. Update last used timestamp (async)
-			go func() { _ = store.UpdateAPIKeyLastUsed(validKey.ID) }()
-
-			// 7. Add to context and proceed
-			ctx := context.WithValue(r.Context(), APIKeyContextKey{}, validKey)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			authenticated.ServeHTTP(w, r)
 		})
 	}
 }
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+// resolveAPIKey verifies a gw_ key using the cache, falling back to storage.
+// Returns nil if the key is unknown, inactive or expired.
+func resolveAPIKey(store storage.Storage, cache *ristretto.Cache[string, *CachedAPIKey], apiKey string) *storage.ClientAPIKey {
+	prefix := storage.ExtractKeyPrefix(apiKey)
+	cacheKey := "apikey:" + prefix
+
+	if cache != nil {
+		if cached, found := cache.Get(cacheKey); found && time.Now().Before(cached.ValidUntil) {
+			valid, _ := storage.VerifyPassword(apiKey, cached.Key.KeyHash)
+			if valid && cached.Key.IsActive && !cached.Key.IsExpired() {
+				return cached.Key
+			}
+		}
+	}
+
+	// Lookup in database by prefix and verify hash against all matching keys
+	keys, err := store.GetAPIKeyByPrefix(prefix)
+	if err != nil {
+		return nil
+	}
+	var validKey *storage.ClientAPIKey
+	for _, k := range keys {
+		if valid, _ := storage.VerifyPassword(apiKey, k.KeyHash); valid {
+			validKey = k
+			break
+		}
+	}
+	if validKey == nil || !validKey.IsActive || validKey.IsExpired() {
+		return nil
+	}
+
+	// Cache valid key for 5 minutes
+	if cache != nil {
+		cache.Set(cacheKey, &CachedAPIKey{
+			Key:        validKey,
+			ValidUntil: time.Now().Add(5 * time.Minute),
+		}, 1)
+	}
+
+	// Update last used timestamp (async)
+	go func() { _ = store.UpdateAPIKeyLastUsed(validKey.ID) }()
+
+	return validKey
+}
+
+// warnExpiryGrace adds a Warning header when the key is only accepted because
+// of the expiry grace window, so clients notice before they are cut off.
+func warnExpiryGrace(w http.ResponseWriter, key *storage.ClientAPIKey) {
+	if key.InExpiryGrace() {
+		w.Header().Set("Warning", `299 goatway "API key expired at `+key.ExpiresAt.UTC().Format(time.RFC3339)+`; rotate it before the grace period ends"`)
+	}
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+)
+
+func TestAPIKeyAuth_CustomKeyScheme(t *testing.T) {
+	storage.SetKeyScheme("acme_", 48)
+	t.Cleanup(func() { storage.SetKeyScheme(storage.APIKeyPrefix, storage.APIKeyLength) })
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	// Create a key the same way the admin handler does
+	raw, _ := storage.GenerateAPIKey()
+	hash, _ := storage.HashPassword(raw, nil)
+	err = store.CreateAPIKey(&storage.ClientAPIKey{
+		ID:        "key-1",
+		Name:      "branded",
+		KeyHash:   hash,
+		KeyPrefix: storage.ExtractKeyPrefix(raw),
+		Scopes:    []string{"proxy"},
+		IsActive:  true,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	handler := APIKeyAuth(store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetAPIKey(r.Context()) == nil {
+			t.Error("expected API key in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+	}{
+		{"custom prefixed key authenticates", raw, http.StatusOK},
+		{"default gw_ prefix rejected", "gw_" + raw[len("acme_"):], http.StatusUnauthorized},
+		{"unknown custom key rejected", "acme_doesnotexist0000000000000000000000000000000000", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_ExpiryGrace(t *testing.T) {
+	storage.SetKeyExpiryGrace(30 * time.Minute)
+	t.Cleanup(func() { storage.SetKeyExpiryGrace(0) })
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "test.db"), storage.Options{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	handler := APIKeyAuth(store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name        string
+		expiresIn   time.Duration
+		wantStatus  int
+		wantWarning bool
+	}{
+		{"not yet expired", time.Hour, http.StatusOK, false},
+		{"expired within grace", -10 * time.Minute, http.StatusOK, true},
+		{"expired beyond grace", -2 * time.Hour, http.StatusUnauthorized, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := storage.GenerateAPIKey()
+			hash, _ := storage.HashPassword(raw, nil)
+			expiresAt := time.Now().Add(tt.expiresIn)
+			err := store.CreateAPIKey(&storage.ClientAPIKey{
+				ID:        "key-" + string(rune('a'+i)),
+				Name:      tt.name,
+				KeyHash:   hash,
+				KeyPrefix: storage.ExtractKeyPrefix(raw),
+				Scopes:    []string{"proxy"},
+				IsActive:  true,
+				CreatedAt: time.Now(),
+				ExpiresAt: &expiresAt,
+			})
+			if err != nil {
+				t.Fatalf("CreateAPIKey: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set("Authorization", "Bearer "+raw)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Warning") != ""; got != tt.wantWarning {
+				t.Errorf("Warning header present = %v, want %v (%q)", got, tt.wantWarning, rec.Header().Get("Warning"))
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// CredentialOverrideHeader lets admin-scoped keys force a stored credential for
+// one request, bypassing the alias's credential (useful for testing a credential).
+const CredentialOverrideHeader = "X-Goatway-Credential-Id"
+
+// withCredentialOverride adds the override header to the context for admin keys.
+// For any other key it writes a 403 and returns false.
+func withCredentialOverride(w http.ResponseWriter, r *http.Request, ctx context.Context, key *storage.ClientAPIKey) (context.Context, bool) {
+	id := strings.TrimSpace(r.Header.Get(CredentialOverrideHeader))
+	if id == "" {
+		return ctx, true
+	}
+	if !key.HasScope(AdminScope) {
+		writeForbidden(w, CredentialOverrideHeader+" requires an admin-scoped API key")
+		return ctx, false
+	}
+	return types.WithCredentialOverride(ctx, id), true
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestAPIKeyAuth_CredentialOverride(t *testing.T) {
+	store, keys := newAdminTestStore(t)
+
+	var gotOverride string
+	handler := APIKeyAuth(store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOverride = types.CredentialOverride(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name         string
+		key          string
+		header       string
+		wantStatus   int
+		wantOverride string
+	}{
+		{"admin key override honored", keys["admin"], "cred-123", http.StatusOK, "cred-123"},
+		{"proxy key override rejected", keys["proxy"], "cred-123", http.StatusForbidden, ""},
+		{"proxy key without header", keys["proxy"], "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOverride = ""
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+			if tt.header != "" {
+				req.Header.Set(CredentialOverrideHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if gotOverride != tt.wantOverride {
+				t.Errorf("override = %q, want %q", gotOverride, tt.wantOverride)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// withTenant adds the key's bound tenant to the context. A tenant header is
+// only accepted when it names that same tenant; otherwise it writes a 403 and
+// returns false, so a key can never reach another tenant's routes.
+func withTenant(w http.ResponseWriter, r *http.Request, ctx context.Context, key *storage.ClientAPIKey) (context.Context, bool) {
+	if name := strings.TrimSpace(r.Header.Get(types.TenantHeader)); name != "" && name != key.Tenant {
+		writeForbidden(w, "API key is not bound to tenant "+name)
+		return ctx, false
+	}
+	if key.Tenant == "" {
+		return ctx, true
+	}
+	return types.WithTenant(ctx, key.Tenant), true
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/storage"
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestAPIKeyAuth_Tenant(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	keys := map[string]string{}
+	for _, tenant := range []string{"", "a", "b"} {
+		raw, _ := storage.GenerateAPIKey()
+		keyHash, _ := storage.HashPassword(raw, nil)
+		err := store.CreateAPIKey(&storage.ClientAPIKey{
+			Name: "key-" + tenant, KeyHash: keyHash, KeyPrefix: storage.ExtractKeyPrefix(raw),
+			Scopes: []string{"proxy"}, Tenant: tenant, IsActive: true,
+		})
+		if err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		keys[tenant] = raw
+	}
+
+	var gotTenant string
+	handler := APIKeyAuth(store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTenant = types.Tenant(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		key        string
+		header     string
+		wantStatus int
+		wantTenant string
+	}{
+		{"bound key uses its tenant", keys["a"], "", http.StatusOK, "a"},
+		{"bound key with matching header", keys["a"], "a", http.StatusOK, "a"},
+		{"bound key cannot reach another tenant", keys["a"], "b", http.StatusForbidden, ""},
+		{"unbound key cannot select a tenant", keys[""], "a", http.StatusForbidden, ""},
+		{"unbound key uses gateway routes", keys[""], "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTenant = ""
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+			if tt.header != "" {
+				req.Header.Set(types.TenantHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if gotTenant != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", gotTenant, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestOptionalAPIKeyAuth_AnonymousTenantRejected(t *testing.T) {
+	handler := OptionalAPIKeyAuth(storage.NewMemoryStorage(), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set(types.TenantHeader, "a")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
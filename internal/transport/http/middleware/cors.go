@@ -1,19 +1,78 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
 
-// CORS adds Cross-Origin Resource Sharing headers for the Web UI.
+// proxyAllowHeaders are the request headers browser SDKs may send to the proxy API.
+const proxyAllowHeaders = "Content-Type, Authorization, X-Request-ID, X-Goatway-Credential-Id, X-Goatway-Model-Provider, X-Goatway-Tenant, X-Goatway-Timeout"
+
+// CORS adds permissive CORS headers for the public proxy API so browser SDKs can call it.
 func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
-
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
+	return CORSWith()(next)
+}
+
+// CORSWith is CORS that also allows extraHeaders (e.g. a custom request ID header).
+func CORSWith(extraHeaders ...string) func(http.Handler) http.Handler {
+	allow := allowHeaders(proxyAllowHeaders, extraHeaders)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", allow)
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		next.ServeHTTP(w, r)
-	})
+// AdminCORS returns a restrictive CORS middleware for admin routes.
+// Only origins in allowedOrigins receive CORS headers; all others are left to
+// the browser's same-origin policy. extraHeaders are allowed on top of the defaults.
+func AdminCORS(allowedOrigins []string, extraHeaders ...string) func(http.Handler) http.Handler {
+	allow := allowHeaders("Content-Type, Authorization, X-Request-ID", extraHeaders)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin != "" && slices.Contains(allowedOrigins, origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", allow)
+			}
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Preflight is the terminal handler for OPTIONS routes; wrap it with a CORS middleware.
+var Preflight = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+})
+
+// allowHeaders appends the non-empty extra headers missing from base, a
+// comma-separated header list.
+func allowHeaders(base string, extra []string) string {
+	names := strings.Split(base, ", ")
+	for _, h := range extra {
+		if h = strings.TrimSpace(h); h != "" && !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, h) }) {
+			names = append(names, h)
+		}
+	}
+	return strings.Join(names, ", ")
 }
@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -68,6 +70,76 @@ func TestRequestID(t *testing.T) {
 	}
 }
 
+func TestRequestIDWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    RequestIDOptions
+		inbound map[string]string
+		wantHdr string
+		wantID  string // exact ID expected (empty = generated)
+		wantLen int    // length of a generated ID
+	}{
+		{
+			name:    "custom header is read and echoed",
+			opts:    RequestIDOptions{Header: "X-Trace-ID"},
+			inbound: map[string]string{"X-Trace-ID": "trace-123"},
+			wantHdr: "X-Trace-ID",
+			wantID:  "trace-123",
+		},
+		{
+			name:    "correlation ID honored when header absent",
+			opts:    RequestIDOptions{Header: "X-Trace-ID"},
+			inbound: map[string]string{CorrelationIDHeader: "corr-456"},
+			wantHdr: "X-Trace-ID",
+			wantID:  "corr-456",
+		},
+		{
+			name:    "configured header wins over correlation ID",
+			inbound: map[string]string{RequestIDHeader: "req-1", CorrelationIDHeader: "corr-1"},
+			wantHdr: RequestIDHeader,
+			wantID:  "req-1",
+		},
+		{
+			name:    "generates hex by default",
+			wantHdr: RequestIDHeader,
+			wantLen: 16,
+		},
+		{
+			name:    "generates uuid when configured",
+			opts:    RequestIDOptions{Format: RequestIDFormatUUID},
+			wantHdr: RequestIDHeader,
+			wantLen: 36,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedID string
+			handler := RequestIDWith(tt.opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedID = GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			for k, v := range tt.inbound {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			respID := rec.Header().Get(tt.wantHdr)
+			if respID != capturedID {
+				t.Errorf("response %s = %q, context ID = %q", tt.wantHdr, respID, capturedID)
+			}
+			if tt.wantID != "" && respID != tt.wantID {
+				t.Errorf("expected ID %q, got %q", tt.wantID, respID)
+			}
+			if tt.wantLen != 0 && len(respID) != tt.wantLen {
+				t.Errorf("expected generated ID of length %d, got %q", tt.wantLen, respID)
+			}
+		})
+	}
+}
+
 func TestCORS(t *testing.T) {
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -97,6 +169,39 @@ func TestCORS(t *testing.T) {
 	})
 }
 
+func TestCORSWith_RequestIDHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		extra  string
+		want   string
+		hidden string
+	}{
+		{"custom header is allowed", "X-Trace-Id", "X-Trace-Id", ""},
+		{"default header is not repeated", "x-request-id", "X-Request-ID", "x-request-id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, h := range map[string]http.Handler{
+				"proxy": CORSWith(tt.extra)(Preflight),
+				"admin": AdminCORS([]string{"https://admin.example.com"}, tt.extra)(Preflight),
+			} {
+				req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+				req.Header.Set("Origin", "https://admin.example.com")
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				allow := strings.Split(rec.Header().Get("Access-Control-Allow-Headers"), ", ")
+				if !slices.Contains(allow, tt.want) {
+					t.Errorf("%s: Access-Control-Allow-Headers = %v, want %s", name, allow, tt.want)
+				}
+				if tt.hidden != "" && slices.Contains(allow, tt.hidden) {
+					t.Errorf("%s: Access-Control-Allow-Headers = %v repeats %s", name, allow, tt.hidden)
+				}
+			}
+		})
+	}
+}
+
 func TestRequestLogger(t *testing.T) {
 	// Use a discard handler to avoid test output noise
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -141,3 +246,42 @@ func TestGetRequestID_NoContext(t *testing.T) {
 		t.Errorf("expected empty ID, got %q", id)
 	}
 }
+
+func TestAdminCORS(t *testing.T) {
+	handler := AdminCORS([]string{"https://admin.example.com"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name        string
+		origin      string
+		wantOrigin  string
+		wantCreds   string
+		wantVaryHdr string
+	}{
+		{"allowed origin is echoed", "https://admin.example.com", "https://admin.example.com", "true", "Origin"},
+		{"unknown origin gets no CORS headers", "https://evil.example.com", "", "", "Origin"},
+		{"same-origin request without Origin header", "", "", "", "Origin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/info", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCreds {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCreds)
+			}
+			if got := rec.Header().Get("Vary"); got != tt.wantVaryHdr {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVaryHdr)
+			}
+		})
+	}
+}
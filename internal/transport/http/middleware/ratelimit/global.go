@@ -0,0 +1,26 @@
+package ratelimit
+
+import (
+	"net/http"
+	"time"
+)
+
+// Global returns middleware capping the whole server at rps requests per
+// second, whatever key they carry, answering 503 beyond it. It is blunt
+// overload protection tracked per process in one token bucket holding at most
+// one second's worth, so bursts stay close to the rate; rps <= 0 disables it.
+func Global(rps int) func(http.Handler) http.Handler {
+	if rps <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	b := &bucket{tokens: float64(rps), lastFill: time.Now()}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !b.take(float64(rps), float64(rps)) {
+				writeLimitError(w, http.StatusServiceUnavailable, "1", "server request rate limit exceeded", "server_error")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
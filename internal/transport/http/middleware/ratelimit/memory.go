@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// bucketIdleTTL is how long a bucket may go unused before it is evicted. Limits
+// are per minute, so a bucket idle this long has refilled completely and
+// dropping it loses nothing; eviction bounds memory when clients vary the key
+// (e.g. per-end-user limits keyed by the request's "user").
+const bucketIdleTTL = time.Minute
+
+// bucket represents a token bucket for rate limiting.
+type bucket struct {
+	tokens   float64
+	lastFill time.Time
+	mu       sync.Mutex
+}
+
+// Limiter is the in-memory RateLimiter; limits are tracked per process.
+type Limiter struct {
+	buckets   sync.Map     // map[keyID]*bucket
+	lastSweep atomic.Int64 // Unix nanoseconds of the last idle-bucket sweep
+}
+
+// New creates a new rate limiter.
+func New() *Limiter {
+	l := &Limiter{}
+	l.lastSweep.Store(time.Now().UnixNano())
+	return l
+}
+
+// Allow checks if a request is allowed under the rate limit.
+// Returns true if allowed, false if rate limited.
+func (l *Limiter) Allow(keyID string, rateLimit int) bool {
+	if rateLimit <= 0 {
+		return true // 0 = unlimited
+	}
+
+	l.sweepIfDue(time.Now())
+
+	// Get or create bucket for this key
+	val, _ := l.buckets.LoadOrStore(keyID, &bucket{
+		tokens:   float64(rateLimit),
+		lastFill: time.Now(),
+	})
+	b := val.(*bucket)
+	return b.take(float64(rateLimit), float64(rateLimit)/60.0)
+}
+
+// take refills the bucket at perSecond tokens per second, capped at capacity,
+// and takes a token if one is available.
+func (b *bucket) take(capacity, perSecond float64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Refill tokens based on elapsed time
+	now := time.Now()
+	b.tokens = min(b.tokens+now.Sub(b.lastFill).Seconds()*perSecond, capacity)
+	b.lastFill = now
+
+	// Check if we have tokens available
+	if b.tokens >= 1.0 {
+		b.tokens--
+		return true
+	}
+	return false
+}
+
+// sweepIfDue evicts idle buckets at most once per bucketIdleTTL, on whichever
+// request first notices a sweep is due.
+func (l *Limiter) sweepIfDue(now time.Time) {
+	last := l.lastSweep.Load()
+	if now.UnixNano()-last < int64(bucketIdleTTL) || !l.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	l.sweep(now)
+}
+
+// sweep deletes buckets not used since bucketIdleTTL before now.
+func (l *Limiter) sweep(now time.Time) {
+	l.buckets.Range(func(key, val any) bool {
+		b := val.(*bucket)
+		b.mu.Lock()
+		if now.Sub(b.lastFill) >= bucketIdleTTL {
+			l.buckets.Delete(key)
+		}
+		b.mu.Unlock()
+		return true
+	})
+}
@@ -1,70 +1,44 @@
-// Package ratelimit provides rate limiting middleware using token bucket algorithm.
+// Package ratelimit provides rate limiting middleware with pluggable token bucket backends.
 package ratelimit
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
-	"sync"
-	"time"
 
 	"github.com/mandalnilabja/goatway/internal/transport/http/middleware/auth"
 )
 
-// bucket represents a token bucket for rate limiting.
-type bucket struct {
-	tokens   float64
-	lastFill time.Time
-	mu       sync.Mutex
+// RateLimiter decides whether a request fits within a per-minute limit for a key.
+// Implementations must treat a limit <= 0 as unlimited. Backends that can fail
+// (e.g. Redis) allow the request on error rather than blocking traffic.
+// Concurrency caps (max_concurrent) are not rate limits and stay per process.
+type RateLimiter interface {
+	Allow(key string, limit int) bool
 }
 
-// Limiter tracks rate limits per API key.
-type Limiter struct {
-	buckets sync.Map // map[keyID]*bucket
-}
-
-// New creates a new rate limiter.
-func New() *Limiter {
-	return &Limiter{}
-}
-
-// Allow checks if a request is allowed under the rate limit.
-// Returns true if allowed, false if rate limited.
-func (l *Limiter) Allow(keyID string, rateLimit int) bool {
-	if rateLimit <= 0 {
-		return true // 0 = unlimited
-	}
-
-	// Get or create bucket for this key
-	val, _ := l.buckets.LoadOrStore(keyID, &bucket{
-		tokens:   float64(rateLimit),
-		lastFill: time.Now(),
-	})
-	b := val.(*bucket)
-
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	// Refill tokens based on elapsed time
-	now := time.Now()
-	elapsed := now.Sub(b.lastFill).Seconds()
-	refillRate := float64(rateLimit) / 60.0 // tokens per second
-	b.tokens += elapsed * refillRate
-	if b.tokens > float64(rateLimit) {
-		b.tokens = float64(rateLimit) // cap at max capacity
-	}
-	b.lastFill = now
+// Backend names accepted by Open.
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
 
-	// Check if we have tokens available
-	if b.tokens >= 1.0 {
-		b.tokens--
-		return true
+// Open creates the rate limiter for the configured backend.
+// The memory backend is per-process; the Redis backend enforces limits across instances.
+func Open(backend, redisURL string) (RateLimiter, error) {
+	switch backend {
+	case "", BackendMemory:
+		return New(), nil
+	case BackendRedis:
+		return NewRedis(redisURL)
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", backend)
 	}
-	return false
 }
 
 // Middleware returns an HTTP middleware that enforces rate limits.
 // Must be used after APIKeyAuth middleware (needs key in context).
-func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
+func Middleware(limiter RateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			key := auth.GetAPIKey(r.Context())
@@ -86,13 +60,19 @@ func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
 
 // writeTooManyRequests writes a JSON 429 response.
 func writeTooManyRequests(w http.ResponseWriter) {
+	writeLimitError(w, http.StatusTooManyRequests, "60", "rate limit exceeded", "rate_limit_error")
+}
+
+// writeLimitError writes a JSON error response asking the client to retry
+// after retryAfter seconds.
+func writeLimitError(w http.ResponseWriter, status int, retryAfter, message, errType string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Retry-After", "60")
-	w.WriteHeader(http.StatusTooManyRequests)
+	w.Header().Set("Retry-After", retryAfter)
+	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
 		"error": map[string]string{
-			"message": "rate limit exceeded",
-			"type":    "rate_limit_error",
+			"message": message,
+			"type":    errType,
 		},
 	})
 }
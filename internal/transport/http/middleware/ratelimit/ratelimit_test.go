@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Compile-time interface conformance for every backend.
+var (
+	_ RateLimiter = (*Limiter)(nil)
+	_ RateLimiter = (*RedisLimiter)(nil)
+)
+
+// testConformance checks the behavior every RateLimiter backend must share.
+// keyPrefix isolates runs against shared backends.
+func testConformance(t *testing.T, l RateLimiter, keyPrefix string) {
+	t.Helper()
+
+	t.Run("zero limit is unlimited", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			if !l.Allow(keyPrefix+"unlimited", 0) {
+				t.Fatalf("request %d denied with limit 0", i)
+			}
+		}
+	})
+
+	t.Run("allows up to limit then denies", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if !l.Allow(keyPrefix+"limited", 3) {
+				t.Fatalf("request %d denied within limit", i)
+			}
+		}
+		if l.Allow(keyPrefix+"limited", 3) {
+			t.Error("request over limit allowed")
+		}
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		if !l.Allow(keyPrefix+"a", 1) || !l.Allow(keyPrefix+"b", 1) {
+			t.Error("first request per key denied")
+		}
+		if l.Allow(keyPrefix+"a", 1) {
+			t.Error("second request for key a allowed")
+		}
+	})
+}
+
+func TestMemoryLimiter_Conformance(t *testing.T) {
+	testConformance(t, New(), "")
+}
+
+// TestRedisLimiter_Conformance runs against a real server when
+// GOATWAY_TEST_REDIS_URL is set (e.g. redis://localhost:6379/15).
+func TestRedisLimiter_Conformance(t *testing.T) {
+	url := os.Getenv("GOATWAY_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("GOATWAY_TEST_REDIS_URL not set")
+	}
+	l, err := NewRedis(url)
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+	testConformance(t, l, "test-"+strconv.FormatInt(time.Now().UnixNano(), 10)+":")
+}
+
+func TestMemoryLimiter_Refill(t *testing.T) {
+	l := New()
+	if !l.Allow("k", 60) {
+		t.Fatal("first request denied")
+	}
+
+	// Drain the bucket, then age it by one second (one token at 60/min)
+	val, _ := l.buckets.Load("k")
+	b := val.(*bucket)
+	b.mu.Lock()
+	b.tokens = 0
+	b.lastFill = time.Now().Add(-time.Second)
+	b.mu.Unlock()
+
+	if !l.Allow("k", 60) {
+		t.Error("request denied after refill")
+	}
+	if l.Allow("k", 60) {
+		t.Error("request allowed with empty bucket")
+	}
+}
+
+func TestMemoryLimiter_EvictsIdleBuckets(t *testing.T) {
+	l := New()
+	l.Allow("idle", 60)
+	l.Allow("busy", 60)
+
+	// Age one bucket past the idle TTL and make a sweep due
+	val, _ := l.buckets.Load("idle")
+	b := val.(*bucket)
+	b.mu.Lock()
+	b.lastFill = time.Now().Add(-2 * bucketIdleTTL)
+	b.mu.Unlock()
+	l.lastSweep.Store(time.Now().Add(-2 * bucketIdleTTL).UnixNano())
+
+	l.Allow("busy", 60)
+	if _, ok := l.buckets.Load("idle"); ok {
+		t.Error("idle bucket was not evicted")
+	}
+	if _, ok := l.buckets.Load("busy"); !ok {
+		t.Error("active bucket was evicted")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		backend, url string
+		wantErr      bool
+	}{
+		{"", "", false},
+		{BackendMemory, "", false},
+		{BackendRedis, "redis://:secret@localhost:6379/2", false},
+		{BackendRedis, "", true},
+		{BackendRedis, "http://localhost:6379", true},
+		{"memcached", "", true},
+	}
+	for _, tt := range tests {
+		if _, err := Open(tt.backend, tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("Open(%q, %q) error = %v, wantErr %v", tt.backend, tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// tokenBucketScript implements the same per-minute token bucket as Limiter
+// atomically in Redis. KEYS[1] = bucket, ARGV[1] = capacity. The time comes
+// from the Redis server so instances with skewed clocks share one timeline;
+// replicate_commands lets older servers write after reading TIME.
+const tokenBucketScript = `
+if redis.replicate_commands then redis.replicate_commands() end
+local capacity = tonumber(ARGV[1])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / 60000)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], 60000)
+return allowed
+`
+
+// RedisLimiter is a RateLimiter shared by every instance using the same Redis.
+type RedisLimiter struct {
+	client     *redisClient
+	prefix     string
+	scriptSHA  string        // SHA1 of tokenBucketScript, for EVALSHA
+	failedOpen atomic.Uint64 // Requests allowed because Redis failed
+}
+
+// NewRedis creates a Redis-backed limiter from a
+// redis://[[user]:password@]host:port[/db] URL. Connections are dialed on use.
+func NewRedis(redisURL string) (*RedisLimiter, error) {
+	client, err := newRedisClient(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum([]byte(tokenBucketScript))
+	return &RedisLimiter{client: client, prefix: "goatway:ratelimit:", scriptSHA: hex.EncodeToString(sum[:])}, nil
+}
+
+// Allow checks the shared bucket for key. Redis failures allow the request.
+func (l *RedisLimiter) Allow(key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	args := []string{"1", l.prefix + key, strconv.Itoa(limit)}
+	reply, err := l.client.do(append([]string{"EVALSHA", l.scriptSHA}, args...)...)
+	var redisErr redisError
+	if errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+		// First use on this server (or after SCRIPT FLUSH): EVAL also caches the script
+		reply, err = l.client.do(append([]string{"EVAL", tokenBucketScript}, args...)...)
+	}
+	if err != nil {
+		l.failOpen(err)
+		return true
+	}
+	allowed, _ := reply.(int64)
+	return allowed == 1
+}
+
+// failOpen counts a request allowed because Redis failed, logging at powers
+// of two so an outage is visible without a line per request.
+func (l *RedisLimiter) failOpen(err error) {
+	if n := l.failedOpen.Add(1); bits.OnesCount64(n) == 1 {
+		slog.Warn("rate limit backend unavailable, allowing requests", "error", err, "allowed_unchecked", n)
+	}
+}
@@ -0,0 +1,200 @@
+package ratelimit
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeRedis accepts connections, records each command and answers with the
+// reply for it. conns counts accepted connections.
+func fakeRedis(t *testing.T, reply func(cmd []string) string) (addr string, commands chan []string, conns *atomic.Int32) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	commands = make(chan []string, 16)
+	conns = &atomic.Int32{}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conns.Add(1)
+			go serveFakeRedis(conn, reply, commands)
+		}
+	}()
+	return ln.Addr().String(), commands, conns
+}
+
+func serveFakeRedis(conn net.Conn, reply func(cmd []string) string, commands chan<- []string) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil || !strings.HasPrefix(line, "*") {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			arg, _ := readReply(rd) // Arguments are bulk strings
+			s, _ := arg.(string)
+			args = append(args, s)
+		}
+		commands <- args
+		_, _ = conn.Write([]byte(reply(args)))
+	}
+}
+
+func always(reply string) func([]string) string {
+	return func([]string) string { return reply }
+}
+
+func TestRedisLimiter_Wire(t *testing.T) {
+	addr, commands, conns := fakeRedis(t, always(":0\r\n"))
+	l, err := NewRedis("redis://" + addr)
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+
+	for range 2 {
+		if l.Allow("key-1", 10) {
+			t.Error("expected denial when script returns 0")
+		}
+		cmd := <-commands
+		if len(cmd) != 5 || cmd[0] != "EVALSHA" || cmd[1] != l.scriptSHA || cmd[2] != "1" || cmd[3] != "goatway:ratelimit:key-1" || cmd[4] != "10" {
+			t.Errorf("command = %q", cmd)
+		}
+	}
+	if n := conns.Load(); n != 1 {
+		t.Errorf("dialed %d connections for sequential commands, want 1 reused", n)
+	}
+}
+
+func TestRedisLimiter_LoadsScriptOnNoScript(t *testing.T) {
+	addr, commands, _ := fakeRedis(t, func(cmd []string) string {
+		if cmd[0] == "EVALSHA" {
+			return "-NOSCRIPT No matching script. Please use EVAL.\r\n"
+		}
+		return ":1\r\n"
+	})
+	l, _ := NewRedis("redis://" + addr)
+
+	if !l.Allow("key-1", 10) {
+		t.Error("expected the EVAL fallback's answer")
+	}
+	if first, second := <-commands, <-commands; first[0] != "EVALSHA" || second[0] != "EVAL" || second[1] != tokenBucketScript {
+		t.Errorf("commands = %q then %q, want EVALSHA then EVAL with the script", first[0], second[0])
+	}
+	if l.failedOpen.Load() != 0 {
+		t.Error("NOSCRIPT counted as a backend failure")
+	}
+}
+
+func TestRedisLimiter_Auth(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{"password only", "redis://:secret@%s", []string{"AUTH", "secret"}},
+		{"ACL user", "redis://limiter:secret@%s", []string{"AUTH", "limiter", "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, commands, _ := fakeRedis(t, func(cmd []string) string {
+				if cmd[0] == "AUTH" {
+					return "+OK\r\n"
+				}
+				return ":1\r\n"
+			})
+			l, _ := NewRedis(strings.Replace(tt.url, "%s", addr, 1))
+			l.Allow("key-1", 10)
+			if got := <-commands; strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("first command = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedisLimiter_ConcurrentCommands(t *testing.T) {
+	// Each reply waits until two commands are in flight, which a client
+	// serializing commands on one connection never reaches
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	var stalled atomic.Bool
+	addr, _, conns := fakeRedis(t, func([]string) string {
+		arrived.Done()
+		done := make(chan struct{})
+		go func() { arrived.Wait(); close(done) }()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			stalled.Store(true)
+		}
+		return ":1\r\n"
+	})
+	l, _ := NewRedis("redis://" + addr)
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Allow("key-1", 10)
+		}()
+	}
+	wg.Wait()
+	if stalled.Load() || conns.Load() != 2 {
+		t.Errorf("stalled = %v, connections = %d; want both commands in flight on two connections", stalled.Load(), conns.Load())
+	}
+}
+
+func TestRedisLimiter_FailsOpen(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close() // Nothing listening: every dial fails
+
+	l, _ := NewRedis("redis://" + addr)
+	for range 3 {
+		if !l.Allow("key-1", 1) {
+			t.Error("expected request allowed when Redis is unreachable")
+		}
+	}
+	if n := l.failedOpen.Load(); n != 3 {
+		t.Errorf("failed open = %d, want 3", n)
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{"+OK\r\n", "OK", false},
+		{":42\r\n", int64(42), false},
+		{"$5\r\nhello\r\n", "hello", false},
+		{"$-1\r\n", nil, false},
+		{"-ERR wrong\r\n", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := readReply(bufio.NewReader(strings.NewReader(tt.raw)))
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("readReply(%q) = %v, %v; want %v (err %v)", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisTimeout bounds dialing and each command round trip.
+const redisTimeout = 2 * time.Second
+
+// redisMaxIdle is how many idle connections are kept for reuse. Busier
+// moments dial extra connections, closed again once idle ones are full.
+const redisMaxIdle = 16
+
+// redisClient is a minimal RESP2 client. Commands run concurrently, each on
+// a pooled connection; a connection that hits an I/O error is discarded.
+type redisClient struct {
+	addr     string
+	username string // Redis 6 ACL user (empty authenticates as default)
+	password string
+	db       int
+
+	idle chan *redisConn
+}
+
+// redisConn is one connection with its reply reader.
+type redisConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+// newRedisClient parses a redis://[[user]:password@]host:port[/db] URL.
+func newRedisClient(rawURL string) (*redisClient, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("invalid redis URL %q", rawURL)
+	}
+	c := &redisClient{addr: u.Host, idle: make(chan *redisConn, redisMaxIdle)}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		if c.db, err = strconv.Atoi(path); err != nil {
+			return nil, fmt.Errorf("invalid redis database %q", path)
+		}
+	}
+	return c, nil
+}
+
+// do sends a command and returns its reply (string, int64 or nil).
+func (c *redisClient) do(args ...string) (any, error) {
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.roundTrip(args)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		_ = conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+	return reply, err
+}
+
+// get returns an idle connection, or dials a new one.
+func (c *redisClient) get() (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+		return c.dial()
+	}
+}
+
+// put returns conn to the idle pool, closing it when the pool is full.
+func (c *redisClient) put(conn *redisConn) {
+	select {
+	case c.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+// dial connects to the server and applies AUTH and SELECT.
+func (c *redisClient) dial() (*redisConn, error) {
+	nc, err := net.DialTimeout("tcp", c.addr, redisTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: nc, rd: bufio.NewReader(nc)}
+
+	var setup [][]string
+	switch {
+	case c.username != "":
+		setup = append(setup, []string{"AUTH", c.username, c.password})
+	case c.password != "":
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	for _, cmd := range setup {
+		if _, err := conn.roundTrip(cmd); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (conn *redisConn) roundTrip(args []string) (any, error) {
+	_ = conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := conn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+	return readReply(conn.rd)
+}
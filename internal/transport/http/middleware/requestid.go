@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"net/http"
+
+	"github.com/google/uuid"
 )
 
 // contextKey is a custom type to avoid context key collisions.
@@ -13,9 +15,24 @@ type contextKey string
 // RequestIDKey is the context key for the request ID.
 const RequestIDKey contextKey = "request_id"
 
-// RequestIDHeader is the HTTP header for request ID.
+// RequestIDHeader is the default HTTP header for request ID.
 const RequestIDHeader = "X-Request-ID"
 
+// CorrelationIDHeader is accepted as an inbound request ID when the configured header is absent.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// Request ID formats for generated IDs.
+const (
+	RequestIDFormatHex  = "hex"  // 16 hex characters (default)
+	RequestIDFormatUUID = "uuid" // RFC 4122 version 4 UUID
+)
+
+// RequestIDOptions configures the request ID middleware.
+type RequestIDOptions struct {
+	Header string // Header read from the request and echoed on the response (default X-Request-ID)
+	Format string // Format of generated IDs: "hex" (default) or "uuid"
+}
+
 // GetRequestID retrieves the request ID from the context.
 func GetRequestID(ctx context.Context) string {
 	if id, ok := ctx.Value(RequestIDKey).(string); ok {
@@ -24,22 +41,43 @@ func GetRequestID(ctx context.Context) string {
 	return ""
 }
 
-// RequestID adds a unique request ID to each request.
+// RequestID adds a unique request ID to each request using the default header and format.
 func RequestID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Check for existing request ID in header
-		requestID := r.Header.Get(RequestIDHeader)
-		if requestID == "" {
-			requestID = generateRequestID()
-		}
-
-		// Add to response header
-		w.Header().Set(RequestIDHeader, requestID)
-
-		// Add to context
-		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+	return RequestIDWith(RequestIDOptions{})(next)
+}
+
+// RequestIDWith adds a request ID to each request. An inbound ID in the
+// configured header, or else X-Correlation-ID, is reused so traces line up
+// with upstream systems; otherwise a new ID is generated.
+func RequestIDWith(opts RequestIDOptions) func(http.Handler) http.Handler {
+	header := opts.Header
+	if header == "" {
+		header = RequestIDHeader
+	}
+	generate := generateRequestID
+	if opts.Format == RequestIDFormatUUID {
+		generate = uuid.NewString
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Check for existing request ID in header
+			requestID := r.Header.Get(header)
+			if requestID == "" {
+				requestID = r.Header.Get(CorrelationIDHeader)
+			}
+			if requestID == "" {
+				requestID = generate()
+			}
+
+			// Add to response header
+			w.Header().Set(header, requestID)
+
+			// Add to context
+			ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
 
 // generateRequestID creates a short random ID for request tracing.
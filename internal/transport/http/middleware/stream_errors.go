@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+// Error formats for requests that asked for a streamed response.
+const (
+	StreamErrorFormatSSE  = "sse"  // One SSE error frame followed by [DONE] (default)
+	StreamErrorFormatJSON = "json" // The JSON error body sent to non-streaming requests
+)
+
+// StreamErrors answers errors to "stream": true requests in SSE form, so
+// clients reading an event stream see the error instead of failing to parse a
+// JSON body. Handlers report the parsed flag with types.MarkStreaming; errors
+// written before that (auth, rate limits) peek at the JSON body instead. The
+// status code is kept. With StreamErrorFormatJSON errors pass through as-is.
+func StreamErrors(format string) func(http.Handler) http.Handler {
+	if format == StreamErrorFormatJSON {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, marker := types.WithStreamMarker(r.Context())
+			r = r.WithContext(ctx)
+			sw := &sseErrorWriter{ResponseWriter: w, r: r, marker: marker}
+			next.ServeHTTP(sw, r)
+			sw.finish()
+		})
+	}
+}
+
+// sseErrorWriter holds back an error response to a streaming request and
+// rewrites it as an SSE error frame once the handler returns.
+type sseErrorWriter struct {
+	http.ResponseWriter
+	r         *http.Request
+	marker    *types.StreamMarker
+	wrote     bool
+	status    int          // Held-back error status (0 = passing through)
+	errorBody bytes.Buffer // Held-back error body
+}
+
+func (sw *sseErrorWriter) WriteHeader(code int) {
+	if sw.wrote {
+		return
+	}
+	sw.wrote = true
+	contentType := sw.Header().Get("Content-Type")
+	if code >= http.StatusBadRequest && !strings.HasPrefix(contentType, "text/event-stream") && sw.streaming() {
+		sw.status = code
+		return
+	}
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+func (sw *sseErrorWriter) Write(b []byte) (int, error) {
+	if !sw.wrote {
+		sw.WriteHeader(http.StatusOK)
+	}
+	if sw.status != 0 {
+		return sw.errorBody.Write(b)
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher for streaming support.
+func (sw *sseErrorWriter) Flush() {
+	if sw.status != 0 {
+		return
+	}
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (sw *sseErrorWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// finish writes a held-back error as one SSE error frame and [DONE].
+func (sw *sseErrorWriter) finish() {
+	if sw.status == 0 {
+		return
+	}
+	var apiErr types.APIError
+	if json.Unmarshal(sw.errorBody.Bytes(), &apiErr) != nil || apiErr.Error.Message == "" {
+		apiErr = *types.NewAPIError(strings.TrimSpace(sw.errorBody.String()), errorTypeForStatus(sw.status))
+	}
+
+	header := sw.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Del("Content-Length")
+	sw.ResponseWriter.WriteHeader(sw.status)
+	_, _ = sw.ResponseWriter.Write(types.FormatSSEError(&apiErr))
+	_, _ = sw.ResponseWriter.Write([]byte(types.SSEDone))
+}
+
+// errorTypeForStatus picks the OpenAI error type for a plain-text error.
+func errorTypeForStatus(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return types.ErrorTypeAuthentication
+	case status == http.StatusForbidden:
+		return types.ErrorTypePermission
+	case status == http.StatusNotFound:
+		return types.ErrorTypeNotFound
+	case status == http.StatusTooManyRequests:
+		return types.ErrorTypeRateLimit
+	case status < http.StatusInternalServerError:
+		return types.ErrorTypeInvalidRequest
+	default:
+		return types.ErrorTypeServer
+	}
+}
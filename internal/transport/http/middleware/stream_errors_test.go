@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mandalnilabja/goatway/internal/types"
+)
+
+func TestStreamErrors(t *testing.T) {
+	streamBody := `{"model":"m","messages":[],"stream":true}`
+
+	tests := []struct {
+		name     string
+		format   string
+		body     string
+		handler  http.HandlerFunc
+		wantSSE  bool
+		wantBody string // Substring expected in the response
+	}{
+		{
+			name: "error before parsing peeks the body",
+			body: streamBody,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.WriteError(w, http.StatusUnauthorized, types.ErrAuthentication("invalid API key"))
+			},
+			wantSSE:  true,
+			wantBody: `data: {"error":{"message":"invalid API key","type":"authentication_error"}}`,
+		},
+		{
+			name: "stream flag in an oversized body before it is read",
+			body: `{"stream":true,"messages":[{"content":"` + strings.Repeat("x", 2*streamPeekBytes) + `"}]}`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.WriteError(w, http.StatusTooManyRequests, types.ErrRateLimit("slow down"))
+			},
+			wantSSE:  true,
+			wantBody: `"type":"rate_limit_error"`,
+		},
+		{
+			name: "stream flag past the peeked prefix keeps JSON",
+			body: `{"messages":[{"content":"` + strings.Repeat("x", 2*streamPeekBytes) + `"}],"stream":true}`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.WriteError(w, http.StatusUnauthorized, types.ErrAuthentication("invalid API key"))
+			},
+			wantBody: `{"error":{"message":"invalid API key","type":"authentication_error"}}`,
+		},
+		{
+			name: "plain text error from marked handler",
+			body: streamBody,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.MarkStreaming(r.Context(), true)
+				http.Error(w, "Model not found: m", http.StatusBadRequest)
+			},
+			wantSSE:  true,
+			wantBody: `data: {"error":{"message":"Model not found: m","type":"invalid_request_error"}}`,
+		},
+		{
+			name: "non-streaming request keeps JSON",
+			body: `{"model":"m","messages":[]}`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.WriteError(w, http.StatusUnauthorized, types.ErrAuthentication("invalid API key"))
+			},
+			wantBody: `{"error":{"message":"invalid API key","type":"authentication_error"}}`,
+		},
+		{
+			name: "handler marking overrides the body",
+			body: streamBody,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.MarkStreaming(r.Context(), false)
+				types.WriteError(w, http.StatusBadRequest, types.ErrInvalidRequest("bad"))
+			},
+			wantBody: `{"error":{"message":"bad","type":"invalid_request_error"}}`,
+		},
+		{
+			name: "successful stream passes through",
+			body: streamBody,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.MarkStreaming(r.Context(), true)
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte(types.SSEDone))
+			},
+			wantSSE:  true,
+			wantBody: types.SSEDone,
+		},
+		{
+			name:   "json format disables rewriting",
+			format: StreamErrorFormatJSON,
+			body:   streamBody,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				types.WriteError(w, http.StatusUnauthorized, types.ErrAuthentication("invalid API key"))
+			},
+			wantBody: `{"error":{"message":"invalid API key","type":"authentication_error"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			StreamErrors(tt.format)(tt.handler).ServeHTTP(rec, req)
+
+			isSSE := strings.HasPrefix(rec.Header().Get("Content-Type"), "text/event-stream")
+			if isSSE != tt.wantSSE {
+				t.Errorf("Content-Type = %q, want SSE %v", rec.Header().Get("Content-Type"), tt.wantSSE)
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if tt.wantSSE && rec.Code >= http.StatusBadRequest && !strings.HasSuffix(body, "\n\n"+types.SSEDone) {
+				t.Errorf("SSE error not followed by [DONE]: %q", body)
+			}
+		})
+	}
+}
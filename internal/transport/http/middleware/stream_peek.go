@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// streamPeekBytes bounds how much of an unread body is scanned for its stream
+// flag when an error is answered before any handler has parsed it. A flag
+// past this prefix is not seen and the error stays JSON.
+const streamPeekBytes = 4 << 10
+
+// streaming reports whether the request asked for a streamed response.
+func (sw *sseErrorWriter) streaming() bool {
+	if sw.marker.Known {
+		return sw.marker.Streaming
+	}
+	if !strings.Contains(sw.r.Header.Get("Content-Type"), "json") || sw.r.Body == nil {
+		return false
+	}
+	return peekStream(io.LimitReader(sw.r.Body, streamPeekBytes))
+}
+
+// peekStream scans the top-level keys of a JSON object for "stream": true,
+// stopping at the key or at the first error (including a truncated body).
+func peekStream(r io.Reader) bool {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if key == "stream" {
+			var stream bool
+			return dec.Decode(&stream) == nil && stream
+		}
+		var skip json.RawMessage
+		if dec.Decode(&skip) != nil {
+			return false
+		}
+	}
+	return false
+}
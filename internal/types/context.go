@@ -0,0 +1,62 @@
+package types
+
+import "context"
+
+// credentialOverrideKey carries an admin-selected credential ID through a request.
+type credentialOverrideKey struct{}
+
+// WithCredentialOverride returns a context that pins the request to a stored credential.
+// Callers must have verified that the client is allowed to override credentials.
+func WithCredentialOverride(ctx context.Context, credentialID string) context.Context {
+	return context.WithValue(ctx, credentialOverrideKey{}, credentialID)
+}
+
+// CredentialOverride returns the pinned credential ID, or "" if none.
+func CredentialOverride(ctx context.Context) string {
+	id, _ := ctx.Value(credentialOverrideKey{}).(string)
+	return id
+}
+
+// TenantHeader names the tenant whose routing config serves a request. It
+// must match the tenant the client's API key is bound to (see WithTenant).
+const TenantHeader = "X-Goatway-Tenant"
+
+// tenantKey carries the tenant a request's API key is bound to.
+type tenantKey struct{}
+
+// WithTenant returns a context that routes the request within tenant.
+// Callers must have verified that the client's API key is bound to it.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant returns the tenant the request is bound to, or "" for the gateway's own routes.
+func Tenant(ctx context.Context) string {
+	name, _ := ctx.Value(tenantKey{}).(string)
+	return name
+}
+
+// streamMarkerKey carries a request's StreamMarker.
+type streamMarkerKey struct{}
+
+// StreamMarker records whether a request asked for a streamed response, as
+// reported by the handler that parsed its body.
+type StreamMarker struct {
+	Known     bool // A handler has parsed the body
+	Streaming bool // The body set "stream": true
+}
+
+// WithStreamMarker returns a context carrying an empty StreamMarker for
+// handlers to fill in with MarkStreaming.
+func WithStreamMarker(ctx context.Context) (context.Context, *StreamMarker) {
+	marker := &StreamMarker{}
+	return context.WithValue(ctx, streamMarkerKey{}, marker), marker
+}
+
+// MarkStreaming reports a parsed request's stream flag to the context's
+// StreamMarker, if any.
+func MarkStreaming(ctx context.Context, streaming bool) {
+	if marker, ok := ctx.Value(streamMarkerKey{}).(*StreamMarker); ok {
+		marker.Known, marker.Streaming = true, streaming
+	}
+}
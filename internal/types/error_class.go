@@ -0,0 +1,55 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Normalized error categories recorded on request logs for analytics.
+const (
+	ErrorClassAuth           = "auth"
+	ErrorClassRateLimit      = "rate_limit"
+	ErrorClassInvalidRequest = "invalid_request"
+	ErrorClassServer         = "server_error"
+	ErrorClassTimeout        = "timeout"
+)
+
+// ClassifyUpstreamError maps an upstream error status and message to a normalized category.
+// The message refines ambiguous statuses (e.g. a 400 reporting an exhausted quota).
+func ClassifyUpstreamError(status int, message string) string {
+	msg := strings.ToLower(message)
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorClassAuth
+	case status == http.StatusTooManyRequests || strings.Contains(msg, "rate limit") || strings.Contains(msg, "quota"):
+		return ErrorClassRateLimit
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout ||
+		strings.Contains(msg, "timed out") || strings.Contains(msg, "timeout"):
+		return ErrorClassTimeout
+	case status >= 400 && status < 500:
+		return ErrorClassInvalidRequest
+	default:
+		return ErrorClassServer
+	}
+}
+
+// ClassifyTransportError maps a failure to reach or read from the upstream to a category.
+func ClassifyTransportError(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorClassTimeout
+	}
+	return ErrorClassServer
+}
+
+// TransportErrorStatus is the status returned to the client when the upstream
+// round trip fails: 504 when a deadline or network timeout expired, else 502.
+func TransportErrorStatus(err error) int {
+	if ClassifyTransportError(err) == ErrorClassTimeout {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyUpstreamError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		message string
+		want    string
+	}{
+		{"invalid api key", 401, "Incorrect API key provided", ErrorClassAuth},
+		{"forbidden region", 403, "Country, region, or territory not supported", ErrorClassAuth},
+		{"rate limited", 429, "Rate limit reached for requests", ErrorClassRateLimit},
+		{"quota in 400 body", 400, "You exceeded your current quota", ErrorClassRateLimit},
+		{"bad parameter", 400, "Invalid value for 'temperature'", ErrorClassInvalidRequest},
+		{"unknown model", 404, "The model does not exist", ErrorClassInvalidRequest},
+		{"gateway timeout", 504, "", ErrorClassTimeout},
+		{"timeout in 500 body", 500, "Request timed out", ErrorClassTimeout},
+		{"overloaded", 503, "The engine is currently overloaded", ErrorClassServer},
+		{"internal error", 500, "Internal server error", ErrorClassServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyUpstreamError(tt.status, tt.message); got != tt.want {
+				t.Errorf("ClassifyUpstreamError(%d, %q) = %q, want %q", tt.status, tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyTransportError(t *testing.T) {
+	if got := ClassifyTransportError(fmt.Errorf("dial: %w", context.DeadlineExceeded)); got != ErrorClassTimeout {
+		t.Errorf("deadline exceeded = %q, want timeout", got)
+	}
+	if got := ClassifyTransportError(errors.New("connection refused")); got != ErrorClassServer {
+		t.Errorf("connection refused = %q, want server_error", got)
+	}
+}
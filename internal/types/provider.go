@@ -13,6 +13,9 @@ import (
 // ErrNoAPIKey is returned when no API key is configured for a request
 var ErrNoAPIKey = errors.New("no API key configured")
 
+// ErrBodyNotReplayable is returned when a request body cannot be rewound for a retry
+var ErrBodyNotReplayable = errors.New("request body is not replayable")
+
 // Provider defines the interface all LLM providers must implement
 type Provider interface {
 	// Name returns the provider identifier
@@ -49,25 +52,70 @@ type ProxyOptions struct {
 
 	// Body is the request body (already read, needs to be replayed)
 	Body io.Reader
-}
-
-// ProxyResult contains the result of a proxied request
-type ProxyResult struct {
-	// Model used for the request
-	Model string
-
-	// Token counts (from upstream or calculated)
-	PromptTokens     int
-	CompletionTokens int
-	TotalTokens      int
 
-	// Request metadata
-	StatusCode   int
-	FinishReason string
-	Duration     time.Duration
-	IsStreaming  bool
+	// APIKeyID and User attribute the request to a client API key and the
+	// end user named in its "user" field (used for logging only)
+	APIKeyID string
+	User     string
+
+	// LogBody is the client request body stored with the request log
+	// (nil unless request body logging is enabled)
+	LogBody []byte
+
+	// MaxTokens is the output token limit sent upstream (0 = none); logged
+	// completions far above it are flagged as anomalies
+	MaxTokens int
+
+	// IdleTimeout aborts a stream with an SSE error frame when the upstream
+	// sends no bytes for this long (0 disables)
+	IdleTimeout time.Duration
+
+	// FirstByteTimeout aborts the upstream call when its response headers do not
+	// arrive within this long (0 disables). The provider then returns
+	// upstream.ErrFirstByteTimeout without answering, so the router can fall back
+	FirstByteTimeout time.Duration
+
+	// AllowFallback, when set, is asked before the router falls back to another
+	// alias; an error skips that fallback. Handlers use it to re-apply limits
+	// they checked only against the alias the client named
+	AllowFallback func(slug string) error
+
+	// ParseLimit caps how many bytes of a pass-through JSON response are buffered
+	// to extract usage; larger responses are forwarded unparsed (0 disables the cap)
+	ParseLimit int64
+
+	// NormalizeSSE forwards only data frames of a stream, dropping comments,
+	// keep-alives and other lines strict OpenAI clients reject
+	NormalizeSSE bool
+
+	// StreamRequestID writes RequestID as a leading SSE comment on streams
+	// (see FormatSSERequestID) so clients can correlate them with logs
+	StreamRequestID bool
+
+	// ResponseModel, when set, replaces the model field of the response (or of
+	// each stream chunk) sent to the client with the alias it requested; results
+	// and logs keep the upstream model
+	ResponseModel string
+
+	// StripHeaders lists extra client headers never forwarded upstream,
+	// on top of the hop-by-hop headers that are always dropped
+	StripHeaders []string
+
+	// Seeded is set when the client sent a seed; the upstream system_fingerprint
+	// is then echoed in SystemFingerprintHeader (a trailer on streams)
+	Seeded bool
+}
 
-	// Error info (if any)
-	Error        error
-	ErrorMessage string
+// SystemFingerprintHeader carries the upstream system_fingerprint of a seeded
+// request so clients can audit whether outputs are reproducible.
+const SystemFingerprintHeader = "X-Goatway-System-Fingerprint"
+
+// ResetBody rewinds Body to the start so the request can be re-sent on retry or fallback.
+func (o *ProxyOptions) ResetBody() error {
+	seeker, ok := o.Body.(io.Seeker)
+	if !ok {
+		return ErrBodyNotReplayable
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err
 }
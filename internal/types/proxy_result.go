@@ -0,0 +1,51 @@
+package types
+
+import (
+	"net/http"
+	"time"
+)
+
+// ProxyResult contains the result of a proxied request
+type ProxyResult struct {
+	// Model used for the request
+	Model string
+
+	// Token counts (from upstream or calculated)
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+
+	// Streamed completion output, kept for counting tokens when upstream omits usage
+	CompletionText      string
+	CompletionToolCalls []ToolCall
+
+	// ToolCalls is the number of tool calls in the response, across choices
+	ToolCalls int
+
+	// Request metadata
+	StatusCode   int
+	FinishReason string
+	Duration     time.Duration
+	IsStreaming  bool
+
+	// Latency breakdown: TTFB is the time until the first response byte was
+	// written to the client, UpstreamDuration spans the upstream call until the
+	// body was fully relayed, and GatewayOverhead is model and credential
+	// resolution before the upstream call
+	TTFB             time.Duration
+	UpstreamDuration time.Duration
+	GatewayOverhead  time.Duration
+
+	// Attempts is the number of upstream calls made, counting fallbacks
+	// (0 when the request was answered before reaching an upstream)
+	Attempts int
+
+	// RateLimit is the upstream rate-limit state under normalized
+	// x-ratelimit-* names, re-emitted to the client (nil if none was sent)
+	RateLimit http.Header
+
+	// Error info (if any)
+	Error        error
+	ErrorMessage string
+	ErrorType    string // Normalized category, see ClassifyUpstreamError
+}
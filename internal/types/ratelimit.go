@@ -0,0 +1,53 @@
+package types
+
+import (
+	"net/http"
+	"strings"
+)
+
+// rateLimitAliases maps the upstream rate-limit header styles to the OpenAI
+// names re-emitted to clients: x-ratelimit-{limit,remaining,reset}-{requests,tokens}.
+// Reset values are passed through as sent (durations, timestamps or epoch ms).
+var rateLimitAliases = map[string]string{
+	// OpenAI and OpenRouter-compatible gateways (already normalized)
+	"X-Ratelimit-Limit-Requests":     "X-Ratelimit-Limit-Requests",
+	"X-Ratelimit-Remaining-Requests": "X-Ratelimit-Remaining-Requests",
+	"X-Ratelimit-Reset-Requests":     "X-Ratelimit-Reset-Requests",
+	"X-Ratelimit-Limit-Tokens":       "X-Ratelimit-Limit-Tokens",
+	"X-Ratelimit-Remaining-Tokens":   "X-Ratelimit-Remaining-Tokens",
+	"X-Ratelimit-Reset-Tokens":       "X-Ratelimit-Reset-Tokens",
+
+	// OpenRouter: a single request-based window
+	"X-Ratelimit-Limit":     "X-Ratelimit-Limit-Requests",
+	"X-Ratelimit-Remaining": "X-Ratelimit-Remaining-Requests",
+	"X-Ratelimit-Reset":     "X-Ratelimit-Reset-Requests",
+
+	// Anthropic-compatible upstreams
+	"Anthropic-Ratelimit-Requests-Limit":     "X-Ratelimit-Limit-Requests",
+	"Anthropic-Ratelimit-Requests-Remaining": "X-Ratelimit-Remaining-Requests",
+	"Anthropic-Ratelimit-Requests-Reset":     "X-Ratelimit-Reset-Requests",
+	"Anthropic-Ratelimit-Tokens-Limit":       "X-Ratelimit-Limit-Tokens",
+	"Anthropic-Ratelimit-Tokens-Remaining":   "X-Ratelimit-Remaining-Tokens",
+	"Anthropic-Ratelimit-Tokens-Reset":       "X-Ratelimit-Reset-Tokens",
+}
+
+// RateLimitHeaders extracts upstream rate-limit state under the normalized
+// OpenAI names. Returns nil when the upstream sent none.
+// Explicit per-window headers win over the single-window OpenRouter form.
+func RateLimitHeaders(upstream http.Header) http.Header {
+	var normalized http.Header
+	for name, values := range upstream {
+		alias, ok := rateLimitAliases[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if normalized == nil {
+			normalized = make(http.Header)
+		}
+		if normalized.Get(alias) != "" && name != alias {
+			continue
+		}
+		normalized.Set(alias, strings.TrimSpace(values[0]))
+	}
+	return normalized
+}
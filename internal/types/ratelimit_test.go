@@ -0,0 +1,64 @@
+package types
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream map[string]string
+		want     map[string]string // nil means no rate-limit state
+	}{
+		{
+			name:     "no rate-limit headers",
+			upstream: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			name:     "openai style passes through",
+			upstream: map[string]string{"x-ratelimit-remaining-requests": "9", "x-ratelimit-remaining-tokens": "4000", "x-ratelimit-reset-tokens": "6m0s"},
+			want:     map[string]string{"X-Ratelimit-Remaining-Requests": "9", "X-Ratelimit-Remaining-Tokens": "4000", "X-Ratelimit-Reset-Tokens": "6m0s"},
+		},
+		{
+			name:     "openrouter single window maps to requests",
+			upstream: map[string]string{"X-RateLimit-Limit": "200", "X-RateLimit-Remaining": "17"},
+			want:     map[string]string{"X-Ratelimit-Limit-Requests": "200", "X-Ratelimit-Remaining-Requests": "17"},
+		},
+		{
+			name:     "anthropic style",
+			upstream: map[string]string{"anthropic-ratelimit-tokens-remaining": "12000", "anthropic-ratelimit-requests-reset": "2025-01-01T00:00:00Z"},
+			want:     map[string]string{"X-Ratelimit-Remaining-Tokens": "12000", "X-Ratelimit-Reset-Requests": "2025-01-01T00:00:00Z"},
+		},
+		{
+			name:     "explicit per-window header wins",
+			upstream: map[string]string{"X-RateLimit-Remaining": "17", "x-ratelimit-remaining-requests": "5"},
+			want:     map[string]string{"X-Ratelimit-Remaining-Requests": "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := make(http.Header)
+			for k, v := range tt.upstream {
+				upstream.Set(k, v)
+			}
+
+			got := RateLimitHeaders(upstream)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("RateLimitHeaders = %v, want nil", got)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d headers %v, want %d", len(got), got, len(tt.want))
+			}
+			for k, v := range tt.want {
+				if got.Get(k) != v {
+					t.Errorf("%s = %q, want %q", k, got.Get(k), v)
+				}
+			}
+		})
+	}
+}
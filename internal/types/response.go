@@ -12,6 +12,15 @@ type ChatCompletionResponse struct {
 	ServiceTier       string   `json:"service_tier,omitempty"`
 }
 
+// ToolCallCount returns the number of tool calls across all choices.
+func (r *ChatCompletionResponse) ToolCallCount() int {
+	n := 0
+	for _, choice := range r.Choices {
+		n += len(choice.Message.ToolCalls)
+	}
+	return n
+}
+
 // Object constants
 const (
 	ObjectChatCompletion      = "chat.completion"
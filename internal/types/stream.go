@@ -1,5 +1,7 @@
 package types
 
+import "encoding/json"
+
 // ChatCompletionChunk represents a streaming chunk response.
 type ChatCompletionChunk struct {
 	ID                string        `json:"id"`
@@ -48,6 +50,18 @@ const SSEPrefix = "data: "
 // SSEDone is the final SSE message indicating stream end.
 const SSEDone = "data: [DONE]\n\n"
 
+// FormatSSEError formats an API error as an SSE data frame for mid-stream failures.
+func FormatSSEError(apiErr *APIError) []byte {
+	data, _ := json.Marshal(apiErr)
+	return FormatSSE(data)
+}
+
+// FormatSSERequestID formats the gateway request ID as a leading SSE comment.
+// Spec-compliant parsers skip comments, so clients that don't look for it are unaffected.
+func FormatSSERequestID(id string) []byte {
+	return []byte(": goatway-request-id=" + id + "\n\n")
+}
+
 // FormatSSE formats a chunk for Server-Sent Events transmission.
 func FormatSSE(data []byte) []byte {
 	result := make([]byte, 0, len(SSEPrefix)+len(data)+2)
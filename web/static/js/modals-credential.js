@@ -71,6 +71,7 @@ Modals.showCredentialForm = async function(editId = null) {
                             <option value="openai" ${credential.provider === 'openai' ? 'selected' : ''}>OpenAI</option>
                             <option value="anthropic" ${credential.provider === 'anthropic' ? 'selected' : ''}>Anthropic</option>
                             <option value="azure" ${credential.provider === 'azure' ? 'selected' : ''}>Azure OpenAI</option>
+                            <option value="groq" ${credential.provider === 'groq' ? 'selected' : ''}>Groq</option>
                         </select>
                     </div>
                     <div id="azure-fields" style="display: ${isAzure ? 'block' : 'none'}">
@@ -109,6 +110,8 @@ Modals.showCredentialForm = async function(editId = null) {
 
         try {
             if (editId) {
+                // Rejected with 409 if someone else edited it meanwhile
+                if (credential.version) data.version = credential.version;
                 await API.updateCredential(editId, data);
             } else {
                 await API.createCredential(data);